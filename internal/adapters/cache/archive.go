@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Export writes every readable cache entry to w as a gzip-compressed tar
+// archive, one directory per reel, and returns how many entries it wrote.
+func (c *FileCache) Export(ctx context.Context, w io.Writer) (int, error) {
+	entries, err := c.readCacheDirs()
+	if err != nil {
+		return 0, err
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+
+	exported := 0
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return exported, err
+		}
+		reelID := entry.Name()
+		if _, err := c.read(reelID); err != nil {
+			continue // Skip directories without readable metadata
+		}
+		if err := c.exportDir(tw, reelID); err != nil {
+			return exported, fmt.Errorf("failed to export %s: %w", reelID, err)
+		}
+		exported++
+	}
+
+	if err := tw.Close(); err != nil {
+		return exported, err
+	}
+	return exported, zw.Close()
+}
+
+// exportDir adds the files of reelID's cache directory to tw
+func (c *FileCache) exportDir(tw *tar.Writer, reelID string) error {
+	files, err := os.ReadDir(c.GetCacheDir(reelID))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if !file.Type().IsRegular() || strings.HasSuffix(file.Name(), ".tmp") {
+			continue // Skip temporary files left by interrupted writes
+		}
+		info, err := file.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(reelID, file.Name())
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(filepath.Join(c.GetCacheDir(reelID), file.Name()))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import reads an archive written by Export from r into the cache and
+// returns how many entries it imported. Archived entries replace cached ones
+// for the same reel, and their file paths are pointed at this cache. The
+// archive is extracted to a staging directory first, so an archive that
+// can't be read completely leaves the cache untouched.
+func (c *FileCache) Import(ctx context.Context, r io.Reader) (int, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("not a cache archive: %w", err)
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+
+	// Staging next to the cache keeps the final renames on one filesystem
+	// without showing half-imported entries to the cache's readers
+	if err := os.MkdirAll(filepath.Dir(c.baseDir), dirPerm); err != nil {
+		return 0, err
+	}
+	stagingDir, err := os.MkdirTemp(filepath.Dir(c.baseDir), ".cache-import-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(stagingDir)
+	staged := NewFileCache(stagingDir)
+
+	var reelIDs []string
+	seen := make(map[string]bool)
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read cache archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		reelID, name, ok := archiveEntryName(hdr.Name)
+		if !ok {
+			return 0, fmt.Errorf("invalid path in cache archive: %s", hdr.Name)
+		}
+		if !seen[reelID] {
+			if err := os.MkdirAll(staged.GetCacheDir(reelID), dirPerm); err != nil {
+				return 0, err
+			}
+			seen[reelID] = true
+			reelIDs = append(reelIDs, reelID)
+		}
+
+		if err := extractFile(tr, filepath.Join(staged.GetCacheDir(reelID), name)); err != nil {
+			return 0, fmt.Errorf("failed to import %s: %w", hdr.Name, err)
+		}
+	}
+
+	if err := os.MkdirAll(c.baseDir, dirPerm); err != nil {
+		return 0, err
+	}
+	// Cached entries are moved here while their replacements go in, so a
+	// failed swap can put them back
+	replacedDir, err := os.MkdirTemp(filepath.Dir(c.baseDir), ".cache-replaced-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(replacedDir)
+
+	imported := 0
+	for _, reelID := range reelIDs {
+		if _, err := staged.read(reelID); err != nil {
+			continue // Skip entries without readable metadata
+		}
+
+		// The archived entry replaces the cached one rather than merging
+		// with its files
+		dir, old := c.GetCacheDir(reelID), filepath.Join(replacedDir, reelID)
+		replaced := true
+		if err := os.Rename(dir, old); err != nil {
+			if !os.IsNotExist(err) {
+				return imported, err
+			}
+			replaced = false
+		}
+		restore := func() {
+			_ = os.RemoveAll(dir)
+			if replaced {
+				_ = os.Rename(old, dir)
+			}
+		}
+
+		if err := os.Rename(staged.GetCacheDir(reelID), dir); err != nil {
+			restore()
+			return imported, err
+		}
+		if !c.rebase(reelID) {
+			restore()
+			continue
+		}
+		imported++
+	}
+
+	// The size cap is applied once, so entries just imported aren't evicted
+	// to make room for the next
+	c.evict(ctx, "")
+	return imported, nil
+}
+
+// archiveEntryName splits an archived file's name into its reel ID and file
+// name, rejecting anything but a file directly inside a reel's directory
+func archiveEntryName(name string) (reelID, file string, ok bool) {
+	reelID, file, ok = strings.Cut(path.Clean(name), "/")
+	if !ok || reelID == "" || reelID == ".." || strings.Contains(reelID, `\`) || file == "" || strings.ContainsAny(file, `/\`) {
+		return "", "", false
+	}
+	return reelID, file, true
+}
+
+// extractFile writes the contents of r to destPath
+func extractFile(r io.Reader, destPath string) error {
+	out, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, filePerm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// rebase points an imported entry's file paths, which name files on the
+// machine it was exported from, at the same files in this cache. Paths to
+// files that weren't archived are cleared. It reports whether the entry was
+// readable. Callers apply the size cap.
+func (c *FileCache) rebase(reelID string) bool {
+	item, err := c.read(reelID)
+	if err != nil {
+		return false
+	}
+
+	local := func(p string) string {
+		name := fileName(p)
+		if name == "" {
+			return ""
+		}
+		localPath := filepath.Join(c.GetCacheDir(reelID), name)
+		if _, err := os.Stat(localPath); err != nil {
+			return ""
+		}
+		return localPath
+	}
+	item.AudioPath = local(item.AudioPath)
+	item.VideoPath = local(item.VideoPath)
+	item.ThumbnailPath = local(item.ThumbnailPath)
+	item.RawOutputPath = local(item.RawOutputPath)
+
+	return c.set(reelID, item) == nil
+}
+
+// fileName returns the last element of a cached file's path, which may have
+// been recorded on another machine, or "" when it names no file
+func fileName(p string) string {
+	// Split on both separators, since the path may come from Windows
+	name := p[strings.LastIndexAny(p, `/\`)+1:]
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
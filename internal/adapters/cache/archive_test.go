@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestFileCache_ExportImport(t *testing.T) {
+	src := NewFileCache(t.TempDir())
+	ctx := context.Background()
+
+	audio := filepath.Join(src.GetCacheDir("ABC123"), "audio.wav")
+	if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello"},
+		AudioPath:  audio,
+		VideoPath:  filepath.Join(src.GetCacheDir("ABC123"), "video.mp4"), // not on disk
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	if err := src.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+	// Directories without metadata aren't exported
+	if err := os.MkdirAll(src.GetCacheDir("partial"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var archive bytes.Buffer
+	exported, err := src.Export(ctx, &archive)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if exported != 1 {
+		t.Errorf("Export() = %d, want 1", exported)
+	}
+
+	dst := NewFileCache(t.TempDir())
+	if err := dst.Set(ctx, "ABC123", &ports.CachedItem{Reel: &domain.Reel{ID: "ABC123"}, ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	imported, err := dst.Import(ctx, &archive)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("Import() = %d, want 1", imported)
+	}
+
+	got, err := dst.Get(ctx, "ABC123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Transcript == nil || got.Transcript.Text != "hello" {
+		t.Errorf("imported transcript = %+v, want the archived one", got.Transcript)
+	}
+	if want := filepath.Join(dst.GetCacheDir("ABC123"), "audio.wav"); got.AudioPath != want {
+		t.Errorf("AudioPath = %s, want %s", got.AudioPath, want)
+	}
+	if data, err := os.ReadFile(got.AudioPath); err != nil || string(data) != "RIFF" {
+		t.Errorf("imported audio = %q, %v", data, err)
+	}
+	if got.VideoPath != "" {
+		t.Errorf("VideoPath = %s, want empty for a file that wasn't archived", got.VideoPath)
+	}
+	// The replaced entry is set aside only until the import finishes
+	if leftover, _ := filepath.Glob(filepath.Join(filepath.Dir(dst.baseDir), ".cache-replaced-*")); len(leftover) != 0 {
+		t.Errorf("replaced entries left behind: %v", leftover)
+	}
+}
+
+func TestFileCache_ImportTruncatedLeavesCache(t *testing.T) {
+	src := NewFileCache(t.TempDir())
+	ctx := context.Background()
+	if err := src.Set(ctx, "ABC123", &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "new"},
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A complete entry followed by a file cut short
+	var archive bytes.Buffer
+	zw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(zw)
+	if err := src.exportDir(tw, "ABC123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: "XYZ789/audio.wav", Mode: 0644, Size: 100, Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("R")); err != nil {
+		t.Fatal(err)
+	}
+	zw.Close()
+
+	parent := t.TempDir()
+	dst := NewFileCache(filepath.Join(parent, "cache"))
+	if err := dst.Set(ctx, "ABC123", &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "old"},
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dst.Import(ctx, &archive); err == nil {
+		t.Fatal("Import() expected an error for a truncated archive")
+	}
+	got, err := dst.Get(ctx, "ABC123")
+	if err != nil || got.Transcript == nil || got.Transcript.Text != "old" {
+		t.Errorf("cached entry = %+v, %v, want the one from before the import", got, err)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(parent, ".cache-import-*")); len(leftovers) > 0 {
+		t.Errorf("staging directories left behind: %v", leftovers)
+	}
+}
+
+func TestFileCache_ImportRejectsUnsafePaths(t *testing.T) {
+	for _, name := range []string{"../escape/meta.json.gz", "/abs/meta.json.gz", "meta.json.gz", "ABC123/sub/meta.json.gz"} {
+		var archive bytes.Buffer
+		zw := gzip.NewWriter(&archive)
+		tw := tar.NewWriter(zw)
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 1, Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte("x")); err != nil {
+			t.Fatal(err)
+		}
+		tw.Close()
+		zw.Close()
+
+		cache := NewFileCache(t.TempDir())
+		if _, err := cache.Import(context.Background(), &archive); err == nil {
+			t.Errorf("Import(%q) expected error", name)
+		}
+	}
+}
+
+func TestFileCache_ImportNotArchive(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+	if _, err := cache.Import(context.Background(), bytes.NewReader([]byte("not gzip"))); err == nil {
+		t.Error("Import() expected error for a file that isn't an archive")
+	}
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"os"
+)
+
+// cacheVersion is the entry format Set writes. When fields are added, bump it
+// and append a migration filling them in for older entries.
+const cacheVersion = 1
+
+// migrations[v] upgrades an entry from version v to v+1. Entries written
+// before versioning are version 0.
+var migrations = []func(entry *cacheEntry){
+	// 1: checksums of cached files
+	func(entry *cacheEntry) {
+		if entry.Checksums == nil {
+			entry.Checksums = checksumFiles(entry.AudioPath, entry.VideoPath, entry.ThumbnailPath, entry.RawOutputPath)
+		}
+	},
+}
+
+// migrateEntry upgrades entry to cacheVersion and reports whether it changed.
+// Entries written by newer versions are left alone.
+func migrateEntry(entry *cacheEntry) bool {
+	if entry.Version >= cacheVersion {
+		return false
+	}
+	for v := entry.Version; v < cacheVersion; v++ {
+		migrations[v](entry)
+	}
+	entry.Version = cacheVersion
+	return true
+}
+
+// Migrate upgrades every entry written in an older format and returns how
+// many it upgraded. Migrating doesn't count as a use.
+func (c *FileCache) Migrate(ctx context.Context) (int, error) {
+	dirs, err := c.readCacheDirs()
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return migrated, err
+		}
+		reelID := dir.Name()
+		entry, err := c.readEntry(reelID)
+		if err != nil {
+			continue // Skip directories without readable metadata
+		}
+		if !migrateEntry(entry) {
+			continue
+		}
+
+		used := c.lastUsed(reelID)
+		if err := c.writeEntry(reelID, entry); err != nil {
+			return migrated, err
+		}
+		_ = os.Chtimes(c.compressedMetaPath(reelID), used, used)
+		migrated++
+	}
+	return migrated, nil
+}
+
+// Migrate upgrades every entry written in an older format and returns how
+// many it upgraded
+func (c *RedisCache) Migrate(ctx context.Context) (int, error) {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, reelID := range reelIDs {
+		entry, err := c.readEntry(ctx, reelID)
+		if err != nil {
+			continue // Skip entries deleted since the scan, or unreadable
+		}
+		if !migrateEntry(entry) {
+			continue
+		}
+		if err := c.writeEntry(ctx, reelID, entry); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, nil
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCache_Migrate(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	// An entry written before versioning and checksums
+	dir := filepath.Join(tmpDir, "old123")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	audio := filepath.Join(dir, "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	legacy := `{"reel": {"id": "old123"}, "audio_path": "` + filepath.ToSlash(audio) + `", "expires_at": "` +
+		time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`
+	if err := os.WriteFile(filepath.Join(dir, metaName), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	used := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, metaName), used, used); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := cache.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if migrated != 1 {
+		t.Errorf("Migrate() = %d, want 1", migrated)
+	}
+
+	entry, err := cache.readEntry("old123")
+	if err != nil {
+		t.Fatalf("readEntry() error = %v", err)
+	}
+	if entry.Version != cacheVersion {
+		t.Errorf("migrated version = %d, want %d", entry.Version, cacheVersion)
+	}
+	if entry.Checksums["audio.wav"] == "" {
+		t.Error("migrated entry has no checksum for its audio")
+	}
+	if got := cache.lastUsed("old123"); !got.Equal(used) {
+		t.Errorf("lastUsed() = %v, want %v: migrating shouldn't count as a use", got, used)
+	}
+
+	// Up-to-date entries are left alone
+	if migrated, err := cache.Migrate(ctx); err != nil || migrated != 0 {
+		t.Errorf("second Migrate() = %d, %v, want 0, nil", migrated, err)
+	}
+}
+
+func TestMigrateEntry_Newer(t *testing.T) {
+	entry := &cacheEntry{Version: cacheVersion + 1}
+	if migrateEntry(entry) {
+		t.Error("migrateEntry() changed an entry written by a newer version")
+	}
+	if entry.Version != cacheVersion+1 {
+		t.Errorf("version = %d, want %d", entry.Version, cacheVersion+1)
+	}
+}
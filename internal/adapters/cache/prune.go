@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// pruneEntry deletes the files of entry that filter selects and clears their
+// paths. It returns how many files it deleted and their total size.
+func pruneEntry(entry *cacheEntry, filter ports.CachePruneFilter, now time.Time) (int, int64) {
+	if filter.OlderThan > 0 && now.Sub(entry.CreatedAt) < filter.OlderThan {
+		return 0, 0
+	}
+
+	paths := map[string]*string{
+		ports.CacheFileAudio:     &entry.AudioPath,
+		ports.CacheFileVideo:     &entry.VideoPath,
+		ports.CacheFileThumbnail: &entry.ThumbnailPath,
+		ports.CacheFileRaw:       &entry.RawOutputPath,
+	}
+
+	files, freed := 0, int64(0)
+	for _, kind := range filter.Kinds {
+		path := paths[kind]
+		if path == nil || *path == "" {
+			continue
+		}
+		info, err := os.Stat(*path)
+		if err != nil || info.Size() <= filter.LargerThan {
+			continue
+		}
+		if err := os.Remove(*path); err != nil {
+			continue // Pruning is best effort
+		}
+		delete(entry.Checksums, filepath.Base(*path))
+		*path = ""
+		files++
+		freed += info.Size()
+	}
+	return files, freed
+}
+
+// Prune deletes the cached files filter selects. Entries keep their
+// transcripts, and pruning doesn't count as a use.
+func (c *FileCache) Prune(ctx context.Context, filter ports.CachePruneFilter) (int, int64, error) {
+	dirs, err := c.readCacheDirs()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := time.Now()
+	files, freed := 0, int64(0)
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return files, freed, err
+		}
+		reelID := dir.Name()
+		entry, err := c.readEntry(reelID)
+		if err != nil {
+			continue // Skip directories without readable metadata
+		}
+
+		n, size := pruneEntry(entry, filter, now)
+		if n == 0 {
+			continue
+		}
+		files += n
+		freed += size
+
+		used := c.lastUsed(reelID)
+		if err := c.writeEntry(reelID, entry); err != nil {
+			return files, freed, err
+		}
+		_ = os.Chtimes(c.compressedMetaPath(reelID), used, used)
+	}
+	return files, freed, nil
+}
+
+// Prune deletes the cached files filter selects. Entries keep their
+// transcripts.
+func (c *RedisCache) Prune(ctx context.Context, filter ports.CachePruneFilter) (int, int64, error) {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := time.Now()
+	files, freed := 0, int64(0)
+	for _, reelID := range reelIDs {
+		entry, err := c.readEntry(ctx, reelID)
+		if err != nil {
+			continue // Skip entries deleted since the scan, or unreadable
+		}
+
+		n, size := pruneEntry(entry, filter, now)
+		if n == 0 {
+			continue
+		}
+		files += n
+		freed += size
+
+		if err := c.writeEntry(ctx, reelID, entry); err != nil {
+			return files, freed, err
+		}
+	}
+	return files, freed, nil
+}
+
+// expireMedia deletes entry's audio, video and thumbnail once they're past
+// their expiry, and reports whether it changed the entry
+func expireMedia(entry *cacheEntry, now time.Time) bool {
+	if entry.MediaExpiresAt.IsZero() || !now.After(entry.MediaExpiresAt) {
+		return false
+	}
+
+	changed := false
+	for _, path := range []*string{&entry.AudioPath, &entry.VideoPath, &entry.ThumbnailPath} {
+		if *path == "" {
+			continue
+		}
+		_ = os.Remove(*path) // Best effort; the path is dropped either way
+		delete(entry.Checksums, filepath.Base(*path))
+		*path = ""
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestFileCache_Prune(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+	ctx := context.Background()
+
+	// set caches reelID, created age ago, with a 10-byte audio file and a
+	// video of videoSize bytes
+	set := func(reelID string, age time.Duration, videoSize int) {
+		t.Helper()
+		dir := cache.GetCacheDir(reelID)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		audio, video := filepath.Join(dir, "audio.wav"), filepath.Join(dir, "video.mp4")
+		if err := os.WriteFile(audio, make([]byte, 10), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(video, make([]byte, videoSize), 0644); err != nil {
+			t.Fatal(err)
+		}
+		item := &ports.CachedItem{
+			Reel:       &domain.Reel{ID: reelID},
+			Transcript: &domain.Transcript{Text: "kept"},
+			AudioPath:  audio,
+			VideoPath:  video,
+			CreatedAt:  time.Now().Add(-age),
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}
+		if err := cache.Set(ctx, reelID, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	set("old-big", 40*24*time.Hour, 1000)
+	set("old-small", 40*24*time.Hour, 100)
+	set("new-big", time.Hour, 1000)
+
+	files, freed, err := cache.Prune(ctx, ports.CachePruneFilter{
+		Kinds:      []string{ports.CacheFileVideo},
+		OlderThan:  30 * 24 * time.Hour,
+		LargerThan: 500,
+	})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if files != 1 || freed != 1000 {
+		t.Errorf("Prune() = %d files, %d bytes; want 1, 1000", files, freed)
+	}
+
+	item, err := cache.Get(ctx, "old-big")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if item.VideoPath != "" || item.AudioPath == "" || item.Transcript == nil || item.Transcript.Text != "kept" {
+		t.Errorf("pruned item = %+v, want only the video gone", item)
+	}
+	if _, err := os.Stat(filepath.Join(cache.GetCacheDir("old-big"), "video.mp4")); !os.IsNotExist(err) {
+		t.Error("Prune() kept the video file")
+	}
+	for _, reelID := range []string{"old-small", "new-big"} {
+		if item, _ := cache.Get(ctx, reelID); item == nil || item.VideoPath == "" {
+			t.Errorf("Prune() touched %s", reelID)
+		}
+	}
+	if problems, _ := cache.Verify(ctx); len(problems) != 0 {
+		t.Errorf("Verify() after Prune() = %+v", problems)
+	}
+}
@@ -0,0 +1,270 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// fakeRedis serves the commands redisClient sends from memory
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeRedis{listener: l, password: password, values: make(map[string]string)}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRedis) url() string {
+	if s.password != "" {
+		return "redis://:" + s.password + "@" + s.listener.Addr().String() + "/2"
+	}
+	return "redis://" + s.listener.Addr().String()
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authed := s.password == ""
+	for {
+		reply, err := readRedisReply(r)
+		if err != nil {
+			return
+		}
+		parts, _ := reply.([]any)
+		args := make([]string, len(parts))
+		for i, p := range parts {
+			b, _ := p.([]byte)
+			args[i] = string(b)
+		}
+		if len(args) == 0 {
+			return
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, args[0])
+		cmd := strings.ToUpper(args[0])
+		switch {
+		case cmd == "AUTH":
+			authed = args[len(args)-1] == s.password
+			if authed {
+				fmt.Fprint(conn, "+OK\r\n")
+			} else {
+				fmt.Fprint(conn, "-WRONGPASS invalid password\r\n")
+			}
+		case !authed:
+			fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+		case cmd == "SELECT":
+			fmt.Fprint(conn, "+OK\r\n")
+		case cmd == "GET":
+			if v, ok := s.values[args[1]]; ok {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(v), v)
+			} else {
+				fmt.Fprint(conn, "$-1\r\n")
+			}
+		case cmd == "SET":
+			s.values[args[1]] = args[2]
+			fmt.Fprint(conn, "+OK\r\n")
+		case cmd == "DEL":
+			_, ok := s.values[args[1]]
+			delete(s.values, args[1])
+			if ok {
+				fmt.Fprint(conn, ":1\r\n")
+			} else {
+				fmt.Fprint(conn, ":0\r\n")
+			}
+		case cmd == "STRLEN":
+			fmt.Fprintf(conn, ":%d\r\n", len(s.values[args[1]]))
+		case cmd == "SCAN":
+			prefix := strings.ReplaceAll(strings.TrimSuffix(args[3], "*"), `\`, "")
+			var keys []string
+			for k := range s.values {
+				if strings.HasPrefix(k, prefix) {
+					keys = append(keys, k)
+				}
+			}
+			fmt.Fprintf(conn, "*2\r\n$1\r\n0\r\n*%d\r\n", len(keys))
+			for _, k := range keys {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(k), k)
+			}
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	c, err := NewRedisCache(server.url(), "", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, "ABC123"); !errors.Is(err, domain.ErrCacheMiss) {
+		t.Errorf("Get() error = %v, want ErrCacheMiss", err)
+	}
+
+	audio := filepath.Join(c.GetCacheDir("ABC123"), "audio.wav")
+	if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(audio, make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello"},
+		AudioPath:  audio,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	if err := c.Set(ctx, "ABC123", item); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	expired := &ports.CachedItem{Reel: &domain.Reel{ID: "OLD"}, ExpiresAt: time.Now().Add(-time.Hour)}
+	if err := c.Set(ctx, "OLD", expired); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.values["ig2insights:ABC123"]; !ok {
+		t.Error("entry not stored under the default prefix")
+	}
+
+	got, err := c.Get(ctx, "ABC123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Transcript.Text != "hello" || got.AudioPath != audio {
+		t.Errorf("Get() = %+v", got)
+	}
+	if _, err := c.Get(ctx, "OLD"); !errors.Is(err, domain.ErrCacheExpired) {
+		t.Errorf("Get() error = %v, want ErrCacheExpired", err)
+	}
+	if _, err := c.Peek(ctx, "OLD"); err != nil {
+		t.Errorf("Peek() error = %v", err)
+	}
+
+	stats, err := c.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.ItemCount != 2 || stats.TotalSize <= 100 {
+		t.Errorf("Stats() = %d, %d; want 2 entries over 100 bytes", stats.ItemCount, stats.TotalSize)
+	}
+	if stats.Audio.Count != 1 || stats.Transcripts.Count != 1 || len(stats.Largest) != 2 {
+		t.Errorf("Stats() = %+v, want one audio file and one transcript", stats)
+	}
+	if items, err := c.List(ctx); err != nil || len(items) != 2 {
+		t.Errorf("List() = %d items, %v", len(items), err)
+	}
+
+	if cleaned, err := c.CleanExpired(ctx); err != nil || cleaned != 1 {
+		t.Errorf("CleanExpired() = %d, %v; want 1", cleaned, err)
+	}
+	if err := c.Delete(ctx, "ABC123"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(c.GetCacheDir("ABC123")); !os.IsNotExist(err) {
+		t.Error("Delete() kept the media directory")
+	}
+	if stats, _ := c.Stats(ctx); stats.ItemCount != 0 {
+		t.Errorf("Stats() count = %d after deleting everything", stats.ItemCount)
+	}
+	if server.commands[0] != "AUTH" || server.commands[1] != "SELECT" {
+		t.Errorf("first commands = %v, want AUTH then SELECT", server.commands[:2])
+	}
+}
+
+func TestRedisCache_Clear(t *testing.T) {
+	server := newFakeRedis(t, "")
+	server.values["other:ABC123"] = "kept"
+	c, err := NewRedisCache(server.url(), "team:", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, id := range []string{"A", "B"} {
+		if err := c.Set(ctx, id, &ports.CachedItem{ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if len(server.values) != 1 || server.values["other:ABC123"] != "kept" {
+		t.Errorf("values after Clear() = %v, want only other prefixes", server.values)
+	}
+}
+
+func TestRedisClient_Reconnects(t *testing.T) {
+	server := newFakeRedis(t, "")
+	client, err := newRedisClient(server.url())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.do(ctx, "SET", "k", "v"); err != nil {
+		t.Fatal(err)
+	}
+	// The server drops idle connections
+	client.conn.Close()
+	value, err := client.get(ctx, "k")
+	if err != nil || string(value) != "v" {
+		t.Errorf("get() = %q, %v after the connection dropped", value, err)
+	}
+}
+
+func TestRedisClient_Errors(t *testing.T) {
+	server := newFakeRedis(t, "secret")
+	client, err := newRedisClient("redis://:wrong@" + server.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.do(context.Background(), "GET", "k"); err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("do() error = %v, want WRONGPASS", err)
+	}
+
+	for _, bad := range []string{"http://localhost", "redis://localhost/db", "http://:secret@localhost", "redis://:secret@local host:x"} {
+		_, err := newRedisClient(bad)
+		if err == nil {
+			t.Errorf("newRedisClient(%q) expected error", bad)
+		} else if strings.Contains(err.Error(), "secret") {
+			t.Errorf("newRedisClient(%q) error = %q, want the password left out", bad, err)
+		}
+	}
+}
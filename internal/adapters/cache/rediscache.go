@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// DefaultRedisPrefix is prepended to the keys RedisCache writes
+const DefaultRedisPrefix = "ig2insights:"
+
+// RedisCache implements ports.CacheStore with reel metadata and transcripts
+// in Redis and media files on local disk, so workers sharing a server and
+// its disk share one cache.
+type RedisCache struct {
+	client *redisClient
+	prefix string
+	files  *FileCache // where media is kept
+}
+
+// NewRedisCache creates a cache keeping entries in the Redis server at
+// redisURL, e.g. redis://localhost:6379/0, under keys starting with prefix,
+// and media in baseDir
+func NewRedisCache(redisURL, prefix, baseDir string) (*RedisCache, error) {
+	client, err := newRedisClient(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+	return &RedisCache{client: client, prefix: prefix, files: NewFileCache(baseDir)}, nil
+}
+
+func (c *RedisCache) key(reelID string) string {
+	return c.prefix + reelID
+}
+
+func (c *RedisCache) Get(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	entry, err := c.readEntry(ctx, reelID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(entry.ExpiresAt) {
+		return nil, domain.ErrCacheExpired
+	}
+	if expireMedia(entry, now) {
+		if err := c.writeEntry(ctx, reelID, entry); err != nil {
+			return nil, err
+		}
+	}
+	return entry.item(), nil
+}
+
+func (c *RedisCache) Peek(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	entry, err := c.readEntry(ctx, reelID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.item(), nil
+}
+
+// readEntry loads reelID's entry from Redis
+func (c *RedisCache) readEntry(ctx context.Context, reelID string) (*cacheEntry, error) {
+	data, err := c.client.get(ctx, c.key(reelID))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, domain.ErrCacheMiss
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// writeEntry saves reelID's entry to Redis
+func (c *RedisCache) writeEntry(ctx context.Context, reelID string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.do(ctx, "SET", c.key(reelID), string(data))
+	return err
+}
+
+func (c *RedisCache) Set(ctx context.Context, reelID string, item *ports.CachedItem) error {
+	if err := os.MkdirAll(c.GetCacheDir(reelID), dirPerm); err != nil {
+		return err
+	}
+
+	previous, _ := c.readEntry(ctx, reelID)
+	entry := newCacheEntry(item, previous)
+	return c.writeEntry(ctx, reelID, &entry)
+}
+
+func (c *RedisCache) Delete(ctx context.Context, reelID string) error {
+	if _, err := c.client.do(ctx, "DEL", c.key(reelID)); err != nil {
+		return err
+	}
+	return c.files.Delete(ctx, reelID)
+}
+
+func (c *RedisCache) CleanExpired(ctx context.Context) (int, error) {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	cleaned := 0
+	for _, reelID := range reelIDs {
+		// Get also deletes expired media of entries that haven't expired
+		_, err := c.Get(ctx, reelID)
+		if !errors.Is(err, domain.ErrCacheExpired) {
+			continue
+		}
+		if err := c.Delete(ctx, reelID); err == nil {
+			cleaned++
+		}
+	}
+	return cleaned, nil
+}
+
+func (c *RedisCache) Clear(ctx context.Context) error {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, reelID := range reelIDs {
+		if _, err := c.client.do(ctx, "DEL", c.key(reelID)); err != nil {
+			return err
+		}
+	}
+	return c.files.Clear(ctx)
+}
+
+func (c *RedisCache) GetCacheDir(reelID string) string {
+	return c.files.GetCacheDir(reelID)
+}
+
+// Stats counts the entries in Redis, and sizes them by their metadata and
+// local media
+func (c *RedisCache) Stats(ctx context.Context) (*ports.CacheStats, error) {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ports.CacheStats{ItemCount: len(reelIDs)}
+	sizes := make([]ports.CacheItemSize, 0, len(reelIDs))
+	for _, reelID := range reelIDs {
+		data, err := c.client.get(ctx, c.key(reelID))
+		if err != nil {
+			return nil, err
+		}
+		size := int64(len(data)) + c.files.dirSize(c.GetCacheDir(reelID))
+		stats.TotalSize += size
+		sizes = append(sizes, ports.CacheItemSize{ReelID: reelID, Size: size})
+
+		var entry cacheEntry
+		if err := json.Unmarshal(data, &entry); err == nil {
+			addEntryStats(stats, &entry, int64(len(data)))
+		}
+	}
+	setLargest(stats, sizes)
+
+	return stats, nil
+}
+
+func (c *RedisCache) List(ctx context.Context) ([]*ports.CachedItem, error) {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*ports.CachedItem, 0, len(reelIDs))
+	for _, reelID := range reelIDs {
+		item, err := c.Peek(ctx, reelID)
+		if err != nil {
+			continue // Skip entries deleted or unreadable since the scan
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// reelIDs returns the IDs of all entries under the cache's prefix
+func (c *RedisCache) reelIDs(ctx context.Context) ([]string, error) {
+	keys, err := c.client.scan(ctx, redisGlobEscape(c.prefix)+"*")
+	if err != nil {
+		return nil, err
+	}
+	reelIDs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		reelIDs = append(reelIDs, strings.TrimPrefix(key, c.prefix))
+	}
+	return reelIDs, nil
+}
+
+// redisGlobEscape escapes the characters SCAN's MATCH patterns treat
+// specially
+func redisGlobEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
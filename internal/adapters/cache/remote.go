@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// RemoteCache implements ports.CacheStore with a FileCache shared through an
+// S3-compatible bucket. Entries are written to both, and reels missing from
+// the local cache are fetched from the bucket, so a team transcribes each
+// reel once. Listing, stats, cleaning and clearing only touch the local
+// copy; the bucket's lifecycle rules decide how long shared entries live.
+type RemoteCache struct {
+	*FileCache
+	bucket *s3Bucket
+}
+
+// NewRemoteCache creates a cache kept in baseDir and shared through the
+// bucket opts describes
+func NewRemoteCache(baseDir string, opts S3Options) (*RemoteCache, error) {
+	bucket, err := newS3Bucket(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteCache{FileCache: NewFileCache(baseDir), bucket: bucket}, nil
+}
+
+// SetHTTPClient sets the client used for bucket requests, e.g. one that goes
+// through a proxy
+func (c *RemoteCache) SetHTTPClient(client *http.Client) {
+	c.bucket.client = client
+}
+
+// Get returns the local entry for reelID, fetching it from the bucket when
+// it's missing or expired locally. Bucket errors are treated as misses, so
+// an unreachable bucket only costs a transcription.
+func (c *RemoteCache) Get(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	item, err := c.FileCache.Get(ctx, reelID)
+	if !errors.Is(err, domain.ErrCacheMiss) && !errors.Is(err, domain.ErrCacheExpired) {
+		return item, err
+	}
+	if pullErr := c.pull(ctx, reelID); pullErr != nil {
+		return nil, err
+	}
+	return c.FileCache.Get(ctx, reelID)
+}
+
+// Set stores item locally, then uploads what the bucket doesn't hold yet
+func (c *RemoteCache) Set(ctx context.Context, reelID string, item *ports.CachedItem) error {
+	if err := c.FileCache.Set(ctx, reelID, item); err != nil {
+		return err
+	}
+	if err := c.push(ctx, reelID); err != nil {
+		return fmt.Errorf("failed to share %s: %w", reelID, err)
+	}
+	return nil
+}
+
+// Delete removes the entry for reelID locally and from the bucket
+func (c *RemoteCache) Delete(ctx context.Context, reelID string) error {
+	if err := c.FileCache.Delete(ctx, reelID); err != nil {
+		return err
+	}
+
+	entry, err := c.remoteEntry(ctx, reelID)
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, name := range entryFiles(entry) {
+		if err := c.bucket.delete(ctx, c.bucket.key(reelID, name)); err != nil {
+			return err
+		}
+	}
+	return c.bucket.delete(ctx, c.bucket.key(reelID, compressedMetaName))
+}
+
+// push uploads reelID's files, then its metadata, so readers never find
+// metadata whose files are still missing. Since every transcription writes
+// its entry, even a full cache hit, files the bucket's entry records with
+// the same checksum aren't uploaded again, and the metadata only when it
+// changed.
+func (c *RemoteCache) push(ctx context.Context, reelID string) error {
+	local, err := c.readEntry(reelID)
+	if err != nil {
+		return err
+	}
+	// An unreadable bucket entry is overwritten like a missing one
+	remote, err := c.remoteEntry(ctx, reelID)
+	if err != nil {
+		remote = nil
+	}
+
+	changed := remote == nil || !sameSharedEntry(local, remote)
+	for _, path := range []string{local.AudioPath, local.VideoPath, local.ThumbnailPath, local.RawOutputPath} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue // Skip files that were never downloaded
+		}
+		name := filepath.Base(path)
+		if remote != nil && local.Checksums[name] != "" && remote.Checksums[name] == local.Checksums[name] {
+			continue
+		}
+		if err := c.bucket.put(ctx, c.bucket.key(reelID, name), path); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return c.bucket.put(ctx, c.bucket.key(reelID, compressedMetaName), c.compressedMetaPath(reelID))
+}
+
+// sameSharedEntry reports whether the bucket's entry already holds what the
+// local one would share. Paths are compared by file name, since each
+// machine's point into its own cache. Each write slides the local expiry
+// forward, so the bucket's is only refreshed once it would expire before
+// half of the local entry's remaining time.
+func sameSharedEntry(local, remote *cacheEntry) bool {
+	now := time.Now()
+	if remote.ExpiresAt.Before(now.Add(local.ExpiresAt.Sub(now) / 2)) {
+		return false
+	}
+
+	shared := func(e *cacheEntry) []byte {
+		e2 := *e
+		e2.AudioPath, e2.VideoPath = fileName(e.AudioPath), fileName(e.VideoPath)
+		e2.ThumbnailPath, e2.RawOutputPath = fileName(e.ThumbnailPath), fileName(e.RawOutputPath)
+		e2.ExpiresAt, e2.MediaExpiresAt = time.Time{}, time.Time{}
+		data, _ := json.Marshal(e2)
+		return data
+	}
+	return bytes.Equal(shared(local), shared(remote))
+}
+
+// pull downloads reelID's entry and files from the bucket into the local
+// cache, replacing any local entry
+func (c *RemoteCache) pull(ctx context.Context, reelID string) error {
+	entry, err := c.remoteEntry(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		return domain.ErrCacheExpired
+	}
+
+	if err := c.FileCache.Delete(ctx, reelID); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.GetCacheDir(reelID), dirPerm); err != nil {
+		return err
+	}
+	for _, name := range append(entryFiles(entry), compressedMetaName) {
+		if err := c.download(ctx, reelID, name); err != nil && !errors.Is(err, errObjectNotFound) {
+			_ = c.FileCache.Delete(ctx, reelID)
+			return err
+		}
+	}
+
+	if !c.rebase(reelID) {
+		_ = c.FileCache.Delete(ctx, reelID)
+		return domain.ErrCacheMiss
+	}
+	c.evict(ctx, reelID)
+	return nil
+}
+
+// download copies the object holding one of reelID's files into the local
+// cache directory
+func (c *RemoteCache) download(ctx context.Context, reelID, name string) error {
+	body, err := c.bucket.get(ctx, c.bucket.key(reelID, name))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return extractFile(body, filepath.Join(c.GetCacheDir(reelID), name))
+}
+
+// remoteEntry returns the metadata stored in the bucket for reelID
+func (c *RemoteCache) remoteEntry(ctx context.Context, reelID string) (*cacheEntry, error) {
+	body, err := c.bucket.get(ctx, c.bucket.key(reelID, compressedMetaName))
+	if errors.Is(err, errObjectNotFound) {
+		return nil, domain.ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var entry cacheEntry
+	if err := json.NewDecoder(zr).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// entryFiles returns the names of the files entry refers to, which are kept
+// next to its metadata
+func entryFiles(entry *cacheEntry) []string {
+	var names []string
+	for _, path := range []string{entry.AudioPath, entry.VideoPath, entry.ThumbnailPath, entry.RawOutputPath} {
+		if name := fileName(path); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
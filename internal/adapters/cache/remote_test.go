@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// fakeS3 keeps objects in memory, by path
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	puts    []string // keys written, in order
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.objects[r.URL.Path] = data
+		s.puts = append(s.puts, r.URL.Path)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// takePuts returns the keys written since the last call
+func (s *fakeS3) takePuts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	puts := s.puts
+	s.puts = nil
+	return puts
+}
+
+func newTestRemoteCache(t *testing.T, server *httptest.Server) *RemoteCache {
+	t.Helper()
+	c, err := NewRemoteCache(t.TempDir(), S3Options{
+		Endpoint:        server.URL,
+		Bucket:          "team",
+		Prefix:          "ig",
+		PathStyle:       true,
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRemoteCache_SharesEntries(t *testing.T) {
+	s3 := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+	ctx := context.Background()
+
+	alice := newTestRemoteCache(t, server)
+	audio := filepath.Join(alice.GetCacheDir("ABC123"), "audio.wav")
+	if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello"},
+		AudioPath:  audio,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	if err := alice.Set(ctx, "ABC123", item); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	for _, key := range []string{"/team/ig/ABC123/audio.wav", "/team/ig/ABC123/meta.json.gz"} {
+		if _, ok := s3.objects[key]; !ok {
+			t.Errorf("bucket missing %s", key)
+		}
+	}
+
+	// Another machine gets the entry, with paths into its own cache
+	bob := newTestRemoteCache(t, server)
+	got, err := bob.Get(ctx, "ABC123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Transcript == nil || got.Transcript.Text != "hello" {
+		t.Errorf("shared transcript = %+v", got.Transcript)
+	}
+	if want := filepath.Join(bob.GetCacheDir("ABC123"), "audio.wav"); got.AudioPath != want {
+		t.Errorf("AudioPath = %s, want %s", got.AudioPath, want)
+	}
+	if data, err := os.ReadFile(got.AudioPath); err != nil || string(data) != "RIFF" {
+		t.Errorf("shared audio = %q, %v", data, err)
+	}
+
+	if _, err := bob.Get(ctx, "MISSING"); !errors.Is(err, domain.ErrCacheMiss) {
+		t.Errorf("Get() error = %v, want ErrCacheMiss", err)
+	}
+
+	if err := bob.Delete(ctx, "ABC123"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if len(s3.objects) != 0 {
+		t.Errorf("bucket still holds %d objects after Delete", len(s3.objects))
+	}
+}
+
+func TestRemoteCache_SetUploadsOnlyChanges(t *testing.T) {
+	s3 := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+	ctx := context.Background()
+
+	c := newTestRemoteCache(t, server)
+	audio := filepath.Join(c.GetCacheDir("ABC123"), "audio.wav")
+	if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello"},
+		AudioPath:  audio,
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	if err := c.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+	if puts := s3.takePuts(); len(puts) != 2 {
+		t.Fatalf("first Set uploaded %v, want the audio and metadata", puts)
+	}
+
+	// A cache hit writes the same entry with a slightly later expiry
+	item.ExpiresAt = item.ExpiresAt.Add(time.Minute)
+	if err := c.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+	if puts := s3.takePuts(); len(puts) != 0 {
+		t.Errorf("unchanged Set uploaded %v, want nothing", puts)
+	}
+
+	// A new transcript only needs new metadata
+	item.Transcript = &domain.Transcript{Text: "hello again"}
+	if err := c.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+	if puts := s3.takePuts(); len(puts) != 1 || puts[0] != "/team/ig/ABC123/meta.json.gz" {
+		t.Errorf("changed Set uploaded %v, want only the metadata", puts)
+	}
+}
+
+func TestRemoteCache_SkipsExpiredRemoteEntries(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{objects: make(map[string][]byte)})
+	defer server.Close()
+	ctx := context.Background()
+
+	alice := newTestRemoteCache(t, server)
+	item := &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "ABC123"},
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}
+	if err := alice.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+
+	bob := newTestRemoteCache(t, server)
+	if _, err := bob.Get(ctx, "ABC123"); !errors.Is(err, domain.ErrCacheMiss) {
+		t.Errorf("Get() error = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestRemoteCache_BucketErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	c := newTestRemoteCache(t, server)
+	item := &ports.CachedItem{Reel: &domain.Reel{ID: "ABC123"}, ExpiresAt: time.Now().Add(time.Hour)}
+	err := c.Set(ctx, "ABC123", item)
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Set() error = %v, want AccessDenied", err)
+	}
+	// The entry is still cached locally
+	if _, err := c.Get(ctx, "ABC123"); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+	// An unreachable bucket is a miss, not a failure
+	if _, err := c.Get(ctx, "OTHER"); !errors.Is(err, domain.ErrCacheMiss) {
+		t.Errorf("Get() error = %v, want ErrCacheMiss", err)
+	}
+}
@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+
+	// unsignedPayload skips hashing uploads, which S3 and MinIO accept when
+	// signing requests
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	amzDateLayout = "20060102T150405Z"
+)
+
+// errObjectNotFound is returned for keys that aren't in the bucket
+var errObjectNotFound = errors.New("object not found")
+
+// S3Options locates an S3-compatible bucket, such as AWS S3 or MinIO, and
+// the credentials to sign requests with
+type S3Options struct {
+	Endpoint        string // e.g. https://minio.example.com; empty for AWS
+	Region          string // defaults to us-east-1
+	Bucket          string
+	Prefix          string // prepended to every key, e.g. team/ig2insights
+	PathStyle       bool   // put the bucket in the path rather than the host name, as MinIO expects
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // for temporary credentials
+}
+
+// s3Bucket reads and writes objects with S3's REST API, signing requests
+// with AWS Signature Version 4
+type s3Bucket struct {
+	opts   S3Options
+	client *http.Client
+	now    func() time.Time
+}
+
+func newS3Bucket(opts S3Options) (*s3Bucket, error) {
+	if opts.Bucket == "" {
+		return nil, errors.New("S3 cache needs a bucket")
+	}
+	if opts.Region == "" {
+		opts.Region = defaultS3Region
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://s3." + opts.Region + ".amazonaws.com"
+	}
+	if _, err := url.Parse(opts.Endpoint); err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+	opts.Prefix = strings.Trim(opts.Prefix, "/")
+	return &s3Bucket{opts: opts, client: http.DefaultClient, now: time.Now}, nil
+}
+
+// key returns the object key of a file in reelID's cache directory
+func (b *s3Bucket) key(reelID, name string) string {
+	if b.opts.Prefix == "" {
+		return reelID + "/" + name
+	}
+	return b.opts.Prefix + "/" + reelID + "/" + name
+}
+
+// objectURL returns the URL of the object at key
+func (b *s3Bucket) objectURL(key string) *url.URL {
+	u, _ := url.Parse(b.opts.Endpoint) // validated by newS3Bucket
+	p := "/" + key
+	if b.opts.PathStyle {
+		p = "/" + b.opts.Bucket + p
+	} else {
+		u.Host = b.opts.Bucket + "." + u.Host
+	}
+	u.Path = p
+	u.RawPath = s3Escape(p)
+	return u
+}
+
+// get returns the contents of the object at key, or errObjectNotFound
+func (b *s3Bucket) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// put uploads the file at path to key
+func (b *s3Bucket) put(ctx context.Context, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.objectURL(key).String(), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// delete removes the object at key. Deleting a missing key succeeds.
+func (b *s3Bucket) delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, b.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do signs and sends req, turning error responses into errors
+func (b *s3Bucket) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if b.opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.opts.SessionToken)
+	}
+	b.sign(req, b.now())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errObjectNotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if code := s3ErrorCode(body); code != "" {
+		return nil, fmt.Errorf("S3 %s %s: HTTP %d %s", req.Method, req.URL.Path, resp.StatusCode, code)
+	}
+	return nil, fmt.Errorf("S3 %s %s: HTTP %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+// s3ErrorCode returns the <Code> of an S3 XML error document
+func s3ErrorCode(body []byte) string {
+	_, rest, ok := strings.Cut(string(body), "<Code>")
+	if !ok {
+		return ""
+	}
+	code, _, _ := strings.Cut(rest, "</Code>")
+	return code
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to req, covering
+// the host and every header already set. The payload hash is taken from
+// X-Amz-Content-Sha256.
+func (b *s3Bucket) sign(req *http.Request, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateLayout)
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := date + "/" + b.opts.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+b.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, b.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes everything in p but unreserved characters and
+// slashes, as Signature Version 4 expects of paths
+func s3Escape(p string) string {
+	var sb strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"os"
+	"sort"
+
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// largestItems is how many of the largest items Stats reports
+const largestItems = 10
+
+// addEntryStats counts the files entry refers to in stats. metaSize is the
+// size of its metadata, which holds the transcript.
+func addEntryStats(stats *ports.CacheStats, entry *cacheEntry, metaSize int64) {
+	if entry.Transcript != nil {
+		stats.Transcripts.Count++
+		stats.Transcripts.Size += metaSize
+		if info, err := os.Stat(entry.RawOutputPath); entry.RawOutputPath != "" && err == nil {
+			stats.Transcripts.Size += info.Size()
+		}
+	}
+	addFileUsage(&stats.Audio, entry.AudioPath)
+	addFileUsage(&stats.Video, entry.VideoPath)
+	addFileUsage(&stats.Thumbnails, entry.ThumbnailPath)
+}
+
+// addFileUsage counts the file at path in usage, if it exists
+func addFileUsage(usage *ports.CacheUsage, path string) {
+	if path == "" {
+		return
+	}
+	if info, err := os.Stat(path); err == nil {
+		usage.Count++
+		usage.Size += info.Size()
+	}
+}
+
+// setLargest records the largest of sizes in stats, largest first
+func setLargest(stats *ports.CacheStats, sizes []ports.CacheItemSize) {
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Size > sizes[j].Size })
+	if len(sizes) > largestItems {
+		sizes = sizes[:largestItems]
+	}
+	stats.Largest = sizes
+}
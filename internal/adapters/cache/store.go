@@ -1,11 +1,16 @@
 package cache
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/devbush/ig2insights/internal/domain"
@@ -16,11 +21,21 @@ const (
 	dirPerm  = 0755
 	filePerm = 0644
 	metaName = "meta.json"
+
+	// compressedMetaName is where entries are written; metaName is only read,
+	// for entries cached before compression
+	compressedMetaName = "meta.json.gz"
 )
 
+// errCorruptEntry is returned for metadata that can't be decoded, such as
+// files left truncated by a killed process
+var errCorruptEntry = errors.New("corrupt cache entry")
+
 // FileCache implements ports.CacheStore using the local filesystem.
 type FileCache struct {
-	baseDir string
+	baseDir       string
+	maxSize       int64
+	deleteCorrupt bool
 }
 
 // NewFileCache creates a new file-based cache store.
@@ -30,13 +45,30 @@ func NewFileCache(baseDir string) *FileCache {
 
 // cacheEntry is the on-disk representation of a cached item.
 type cacheEntry struct {
+	Version       int                `json:"version,omitempty"` // entry format, see cacheVersion
 	Reel          *domain.Reel       `json:"reel"`
 	Transcript    *domain.Transcript `json:"transcript"`
 	AudioPath     string             `json:"audio_path"`
 	VideoPath     string             `json:"video_path"`
 	ThumbnailPath string             `json:"thumbnail_path"`
+	RawOutputPath string             `json:"raw_output_path,omitempty"`
 	CreatedAt     time.Time          `json:"created_at"`
 	ExpiresAt     time.Time          `json:"expires_at"`
+	Checksums     map[string]string  `json:"checksums,omitempty"` // SHA-256 of each file, by file name
+
+	MediaExpiresAt time.Time `json:"media_expires_at,omitzero"`
+}
+
+// SetMaxSize caps the cache at maxBytes. Each Set then evicts the least
+// recently used reels until the cache fits again. Zero means no cap.
+func (c *FileCache) SetMaxSize(maxBytes int64) {
+	c.maxSize = maxBytes
+}
+
+// SetDeleteCorrupt makes Get delete entries whose metadata can't be decoded,
+// rather than leaving them for cache verify to report
+func (c *FileCache) SetDeleteCorrupt(deleteCorrupt bool) {
+	c.deleteCorrupt = deleteCorrupt
 }
 
 func (c *FileCache) GetCacheDir(reelID string) string {
@@ -47,8 +79,108 @@ func (c *FileCache) metaPath(reelID string) string {
 	return filepath.Join(c.GetCacheDir(reelID), metaName)
 }
 
+func (c *FileCache) compressedMetaPath(reelID string) string {
+	return filepath.Join(c.GetCacheDir(reelID), compressedMetaName)
+}
+
+// readMeta returns the entry's JSON, preferring the compressed file
+func (c *FileCache) readMeta(reelID string) ([]byte, error) {
+	f, err := os.Open(c.compressedMetaPath(reelID))
+	if os.IsNotExist(err) {
+		return os.ReadFile(c.metaPath(reelID))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCorruptEntry, err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errCorruptEntry, err)
+	}
+	return data, nil
+}
+
+// Get returns the entry for reelID. Entries whose metadata can't be decoded
+// are treated as misses, so they are processed and written again.
 func (c *FileCache) Get(ctx context.Context, reelID string) (*ports.CachedItem, error) {
-	data, err := os.ReadFile(c.metaPath(reelID))
+	entry, err := c.readEntry(reelID)
+	if errors.Is(err, errCorruptEntry) {
+		if c.deleteCorrupt {
+			_ = c.Delete(ctx, reelID)
+		}
+		return nil, domain.ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(entry.ExpiresAt) {
+		return nil, domain.ErrCacheExpired
+	}
+	if expireMedia(entry, now) {
+		if err := c.writeEntry(reelID, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	c.touch(reelID)
+	return entry.item(), nil
+}
+
+// Peek returns the entry for reelID whether or not it has expired, leaving
+// its last use untouched
+func (c *FileCache) Peek(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	return c.read(reelID)
+}
+
+// touch marks the entry as just used, for least-recently-used eviction. The
+// metadata file's modification time doubles as the last use.
+func (c *FileCache) touch(reelID string) {
+	now := time.Now()
+	if err := os.Chtimes(c.compressedMetaPath(reelID), now, now); os.IsNotExist(err) {
+		_ = os.Chtimes(c.metaPath(reelID), now, now)
+	}
+}
+
+// lastUsed returns when the entry was last written or read
+func (c *FileCache) lastUsed(reelID string) time.Time {
+	for _, path := range []string{c.compressedMetaPath(reelID), c.metaPath(reelID), c.GetCacheDir(reelID)} {
+		if info, err := os.Stat(path); err == nil {
+			return info.ModTime()
+		}
+	}
+	return time.Time{}
+}
+
+// hasMeta reports whether the entry's metadata has been written
+func (c *FileCache) hasMeta(reelID string) bool {
+	for _, path := range []string{c.compressedMetaPath(reelID), c.metaPath(reelID)} {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// read loads a cache entry without checking expiry
+func (c *FileCache) read(reelID string) (*ports.CachedItem, error) {
+	entry, err := c.readEntry(reelID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.item(), nil
+}
+
+// readEntry loads the on-disk representation of a cache entry
+func (c *FileCache) readEntry(reelID string) (*cacheEntry, error) {
+	data, err := c.readMeta(reelID)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, domain.ErrCacheMiss
@@ -58,45 +190,164 @@ func (c *FileCache) Get(ctx context.Context, reelID string) (*ports.CachedItem,
 
 	var entry cacheEntry
 	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", errCorruptEntry, err)
 	}
+	return &entry, nil
+}
 
-	if time.Now().After(entry.ExpiresAt) {
-		return nil, domain.ErrCacheExpired
+// newCacheEntry converts a cached item into its on-disk representation,
+// recording the checksums of files that previous, the entry it replaces or
+// nil, doesn't already have
+func newCacheEntry(item *ports.CachedItem, previous *cacheEntry) cacheEntry {
+	return cacheEntry{
+		Version:       cacheVersion,
+		Reel:          item.Reel,
+		Transcript:    item.Transcript,
+		AudioPath:     item.AudioPath,
+		VideoPath:     item.VideoPath,
+		ThumbnailPath: item.ThumbnailPath,
+		RawOutputPath: item.RawOutputPath,
+		CreatedAt:     item.CreatedAt,
+		ExpiresAt:     item.ExpiresAt,
+		Checksums:     entryChecksums(previous, item.AudioPath, item.VideoPath, item.ThumbnailPath, item.RawOutputPath),
+
+		MediaExpiresAt: item.MediaExpiresAt,
 	}
+}
 
+// item converts the entry into a cached item
+func (e *cacheEntry) item() *ports.CachedItem {
 	return &ports.CachedItem{
-		Reel:          entry.Reel,
-		Transcript:    entry.Transcript,
-		AudioPath:     entry.AudioPath,
-		VideoPath:     entry.VideoPath,
-		ThumbnailPath: entry.ThumbnailPath,
-		CreatedAt:     entry.CreatedAt,
-		ExpiresAt:     entry.ExpiresAt,
-	}, nil
+		Reel:          e.Reel,
+		Transcript:    e.Transcript,
+		AudioPath:     e.AudioPath,
+		VideoPath:     e.VideoPath,
+		ThumbnailPath: e.ThumbnailPath,
+		RawOutputPath: e.RawOutputPath,
+		CreatedAt:     e.CreatedAt,
+		ExpiresAt:     e.ExpiresAt,
+
+		MediaExpiresAt: e.MediaExpiresAt,
+	}
 }
 
 func (c *FileCache) Set(ctx context.Context, reelID string, item *ports.CachedItem) error {
+	if err := c.set(reelID, item); err != nil {
+		return err
+	}
+	c.evict(ctx, reelID)
+	return nil
+}
+
+// set stores item like Set, without evicting entries to fit the size cap
+func (c *FileCache) set(reelID string, item *ports.CachedItem) error {
 	if err := os.MkdirAll(c.GetCacheDir(reelID), dirPerm); err != nil {
 		return err
 	}
 
-	entry := cacheEntry{
-		Reel:          item.Reel,
-		Transcript:    item.Transcript,
-		AudioPath:     item.AudioPath,
-		VideoPath:     item.VideoPath,
-		ThumbnailPath: item.ThumbnailPath,
-		CreatedAt:     item.CreatedAt,
-		ExpiresAt:     item.ExpiresAt,
+	previous, _ := c.readEntry(reelID)
+	entry := newCacheEntry(item, previous)
+	return c.writeEntry(reelID, &entry)
+}
+
+// writeEntry saves the metadata of reelID's entry. It's written to a
+// temporary file that then replaces the old one, so a process killed midway
+// leaves the previous entry intact rather than a truncated one.
+func (c *FileCache) writeEntry(reelID string, entry *cacheEntry) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(entry); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
 	}
 
-	data, err := json.MarshalIndent(entry, "", "  ")
+	if err := writeFileAtomic(c.compressedMetaPath(reelID), buf.Bytes()); err != nil {
+		return err
+	}
+	// The compressed entry supersedes any uncompressed one
+	if err := os.Remove(c.metaPath(reelID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path, then renames
+// it over path
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
 		return err
 	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, filePerm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// evict deletes the least recently used entries, other than keep, until the
+// cache fits within its size cap. Eviction is best effort: entries that
+// can't be deleted are skipped. Directories without metadata are still being
+// written, so they count toward the size but are never evicted.
+func (c *FileCache) evict(ctx context.Context, keep string) {
+	if c.maxSize <= 0 {
+		return
+	}
+	entries, err := c.readCacheDirs()
+	if err != nil {
+		return
+	}
 
-	return os.WriteFile(c.metaPath(reelID), data, filePerm)
+	type usage struct {
+		reelID string
+		size   int64
+		used   time.Time
+	}
+	var total int64
+	usages := make([]usage, 0, len(entries))
+	for _, entry := range entries {
+		u := usage{
+			reelID: entry.Name(),
+			size:   c.dirSize(filepath.Join(c.baseDir, entry.Name())),
+			used:   c.lastUsed(entry.Name()),
+		}
+		total += u.size
+		if !c.hasMeta(u.reelID) {
+			continue
+		}
+		usages = append(usages, u)
+	}
+	if total <= c.maxSize {
+		return
+	}
+
+	sort.Slice(usages, func(i, j int) bool { return usages[i].used.Before(usages[j].used) })
+	for _, u := range usages {
+		if total <= c.maxSize {
+			return
+		}
+		if u.reelID == keep {
+			continue
+		}
+		if err := c.Delete(ctx, u.reelID); err == nil {
+			total -= u.size
+		}
+	}
 }
 
 func (c *FileCache) Delete(ctx context.Context, reelID string) error {
@@ -109,14 +360,28 @@ func (c *FileCache) CleanExpired(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
+	// Entries are read directly rather than through Get, so cleaning up
+	// doesn't count as a use for eviction
+	now := time.Now()
 	cleaned := 0
 	for _, entry := range entries {
 		reelID := entry.Name()
-		_, err := c.Get(ctx, reelID)
-		if errors.Is(err, domain.ErrCacheExpired) {
+		item, err := c.readEntry(reelID)
+		if err != nil {
+			continue // Skip directories without readable metadata
+		}
+		if now.After(item.ExpiresAt) {
 			if deleteErr := c.Delete(ctx, reelID); deleteErr == nil {
 				cleaned++
 			}
+			continue
+		}
+		if expireMedia(item, now) {
+			used := c.lastUsed(reelID)
+			if err := c.writeEntry(reelID, item); err != nil {
+				continue
+			}
+			_ = os.Chtimes(c.compressedMetaPath(reelID), used, used)
 		}
 	}
 
@@ -136,18 +401,55 @@ func (c *FileCache) Clear(ctx context.Context) error {
 	return nil
 }
 
-func (c *FileCache) Stats(ctx context.Context) (itemCount int, totalSize int64, err error) {
+func (c *FileCache) Stats(ctx context.Context) (*ports.CacheStats, error) {
 	entries, err := c.readCacheDirs()
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		itemCount++
-		totalSize += c.dirSize(filepath.Join(c.baseDir, entry.Name()))
+	stats := &ports.CacheStats{}
+	sizes := make([]ports.CacheItemSize, 0, len(entries))
+	for _, dir := range entries {
+		reelID := dir.Name()
+		size := c.dirSize(filepath.Join(c.baseDir, reelID))
+		stats.ItemCount++
+		stats.TotalSize += size
+		sizes = append(sizes, ports.CacheItemSize{ReelID: reelID, Size: size})
+
+		if entry, err := c.readEntry(reelID); err == nil {
+			addEntryStats(stats, entry, c.metaSize(reelID))
+		}
+	}
+	setLargest(stats, sizes)
+
+	return stats, nil
+}
+
+// metaSize returns the size of the entry's metadata file
+func (c *FileCache) metaSize(reelID string) int64 {
+	for _, path := range []string{c.compressedMetaPath(reelID), c.metaPath(reelID)} {
+		if info, err := os.Stat(path); err == nil {
+			return info.Size()
+		}
+	}
+	return 0
+}
+
+func (c *FileCache) List(ctx context.Context) ([]*ports.CachedItem, error) {
+	entries, err := c.readCacheDirs()
+	if err != nil {
+		return nil, err
 	}
 
-	return itemCount, totalSize, nil
+	items := make([]*ports.CachedItem, 0, len(entries))
+	for _, entry := range entries {
+		item, err := c.read(entry.Name())
+		if err != nil {
+			continue // Skip directories without readable metadata
+		}
+		items = append(items, item)
+	}
+	return items, nil
 }
 
 // readCacheDirs returns all directory entries in the cache base directory.
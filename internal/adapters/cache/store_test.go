@@ -2,6 +2,8 @@ package cache
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -57,6 +59,66 @@ func TestFileCache_GetMiss(t *testing.T) {
 	}
 }
 
+func TestFileCache_GetCorrupt(t *testing.T) {
+	for _, deleteCorrupt := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		cache := NewFileCache(tmpDir)
+		cache.SetDeleteCorrupt(deleteCorrupt)
+
+		ctx := context.Background()
+		item := &ports.CachedItem{
+			Reel:      &domain.Reel{ID: "test123"},
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(24 * time.Hour),
+		}
+		if err := cache.Set(ctx, "test123", item); err != nil {
+			t.Fatal(err)
+		}
+
+		// Truncate the metadata, as a process killed while writing it would
+		data, err := os.ReadFile(cache.compressedMetaPath("test123"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(cache.compressedMetaPath("test123"), data[:len(data)/2], 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := cache.Get(ctx, "test123"); err != domain.ErrCacheMiss {
+			t.Errorf("Get() error = %v, want ErrCacheMiss", err)
+		}
+		_, err = os.Stat(cache.GetCacheDir("test123"))
+		if deleted := os.IsNotExist(err); deleted != deleteCorrupt {
+			t.Errorf("with SetDeleteCorrupt(%v), entry deleted = %v", deleteCorrupt, deleted)
+		}
+	}
+}
+
+func TestFileCache_SetLeavesNoTempFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+
+	ctx := context.Background()
+	item := &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "test123"},
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	for i := 0; i < 2; i++ {
+		if err := cache.Set(ctx, "test123", item); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	files, err := os.ReadDir(cache.GetCacheDir("test123"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Name() != compressedMetaName {
+		t.Errorf("cache directory holds %v, want only %s", files, compressedMetaName)
+	}
+}
+
 func TestFileCache_GetExpired(t *testing.T) {
 	tmpDir := t.TempDir()
 	cache := NewFileCache(tmpDir)
@@ -76,6 +138,32 @@ func TestFileCache_GetExpired(t *testing.T) {
 	}
 }
 
+func TestFileCache_PeekExpired(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+
+	ctx := context.Background()
+	item := &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "expired123"},
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+		ExpiresAt: time.Now().Add(-24 * time.Hour),
+	}
+
+	_ = cache.Set(ctx, "expired123", item)
+
+	got, err := cache.Peek(ctx, "expired123")
+	if err != nil {
+		t.Fatalf("Peek() error = %v", err)
+	}
+	if got.Reel.ID != "expired123" {
+		t.Errorf("Peek() reel ID = %s, want expired123", got.Reel.ID)
+	}
+
+	if _, err := cache.Peek(ctx, "missing"); err != domain.ErrCacheMiss {
+		t.Errorf("Peek() error = %v, want ErrCacheMiss", err)
+	}
+}
+
 func TestFileCache_CleanExpired(t *testing.T) {
 	tmpDir := t.TempDir()
 	cache := NewFileCache(tmpDir)
@@ -100,3 +188,256 @@ func TestFileCache_CleanExpired(t *testing.T) {
 		t.Errorf("CleanExpired() = %d, want 1", cleaned)
 	}
 }
+
+func TestFileCache_CleanExpiredKeepsLastUse(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	item := &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "live"},
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := cache.Set(ctx, "live", item); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	used := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(filepath.Join(cache.GetCacheDir("live"), compressedMetaName), used, used); err != nil {
+		t.Fatal(err)
+	}
+
+	if cleaned, err := cache.CleanExpired(ctx); err != nil || cleaned != 0 {
+		t.Fatalf("CleanExpired() = %d, %v, want 0, nil", cleaned, err)
+	}
+	if got := cache.lastUsed("live"); !got.Equal(used) {
+		t.Errorf("lastUsed() = %v, want %v: cleaning shouldn't count as a use", got, used)
+	}
+}
+
+func TestFileCache_List(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+
+	ctx := context.Background()
+	_ = cache.Set(ctx, "fresh123", &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "fresh123"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	_ = cache.Set(ctx, "stale123", &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "stale123"},
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+	// A directory without metadata is skipped
+	_ = os.MkdirAll(filepath.Join(tmpDir, "partial"), 0755)
+
+	items, err := cache.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("List() returned %d items, want 2", len(items))
+	}
+}
+
+func TestFileCache_UncompressedEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	// An entry written before compression is still read
+	legacy := `{"reel": {"id": "old123"}, "transcript": {"text": "Hello"}, "expires_at": "` +
+		time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`
+	dir := filepath.Join(tmpDir, "old123")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, metaName), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := cache.Get(ctx, "old123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Transcript.Text != "Hello" {
+		t.Errorf("Get() transcript text = %s, want Hello", got.Transcript.Text)
+	}
+
+	// Rewriting it replaces the uncompressed file
+	if err := cache.Set(ctx, "old123", got); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, metaName)); !os.IsNotExist(err) {
+		t.Errorf("uncompressed %s should be removed, stat error = %v", metaName, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, compressedMetaName)); err != nil {
+		t.Errorf("expected %s: %v", compressedMetaName, err)
+	}
+	if got, err := cache.Get(ctx, "old123"); err != nil || got.Transcript.Text != "Hello" {
+		t.Errorf("Get() after rewrite = %v, %v", got, err)
+	}
+}
+
+func TestFileCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	// Three reels with 1000 bytes of audio each, used an hour apart
+	set := func(reelID string, used time.Time) {
+		t.Helper()
+		audio := filepath.Join(cache.GetCacheDir(reelID), "audio.wav")
+		if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(audio, make([]byte, 1000), 0644); err != nil {
+			t.Fatal(err)
+		}
+		item := &ports.CachedItem{AudioPath: audio, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+		if err := cache.Set(ctx, reelID, item); err != nil {
+			t.Fatalf("Set(%s) error = %v", reelID, err)
+		}
+		if err := os.Chtimes(filepath.Join(cache.GetCacheDir(reelID), compressedMetaName), used, used); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	set("oldest", now.Add(-3*time.Hour))
+	set("middle", now.Add(-2*time.Hour))
+	set("newest", now.Add(-time.Hour))
+
+	// Reading the oldest makes it the most recently used
+	if _, err := cache.Get(ctx, "oldest"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	cache.SetMaxSize(3000)
+	set("latest", now)
+
+	for reelID, want := range map[string]bool{"oldest": true, "middle": false, "newest": false, "latest": true} {
+		_, err := os.Stat(cache.GetCacheDir(reelID))
+		if kept := err == nil; kept != want {
+			t.Errorf("%s kept = %v, want %v", reelID, kept, want)
+		}
+	}
+	if stats, _ := cache.Stats(ctx); stats.TotalSize > 3000 {
+		t.Errorf("cache size = %d, want at most 3000", stats.TotalSize)
+	}
+}
+
+func TestFileCache_EvictSkipsEntriesInProgress(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	// A download still being written has files but no metadata yet
+	partial := filepath.Join(cache.GetCacheDir("partial"), "audio.wav")
+	if err := os.MkdirAll(filepath.Dir(partial), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial, make([]byte, 1000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(cache.GetCacheDir("partial"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	cache.SetMaxSize(500)
+	item := &ports.CachedItem{CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := cache.Set(ctx, "done", item); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if _, err := os.Stat(partial); err != nil {
+		t.Errorf("entry in progress was evicted: %v", err)
+	}
+}
+
+func TestFileCache_MediaExpiry(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	video := filepath.Join(cache.GetCacheDir("ABC123"), "video.mp4")
+	if err := os.MkdirAll(filepath.Dir(video), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(video, []byte("mp4"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item := &ports.CachedItem{
+		Reel:           &domain.Reel{ID: "ABC123"},
+		Transcript:     &domain.Transcript{Text: "kept"},
+		VideoPath:      video,
+		CreatedAt:      time.Now().Add(-4 * 24 * time.Hour),
+		ExpiresAt:      time.Now().Add(time.Hour),
+		MediaExpiresAt: time.Now().Add(-time.Hour),
+	}
+	if err := cache.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := cache.Get(ctx, "ABC123")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.VideoPath != "" || got.Transcript == nil {
+		t.Errorf("Get() = %+v, want the transcript without the expired video", got)
+	}
+	if _, err := os.Stat(video); !os.IsNotExist(err) {
+		t.Error("Get() kept the expired video file")
+	}
+	if peeked, _ := cache.Peek(ctx, "ABC123"); peeked == nil || peeked.VideoPath != "" {
+		t.Errorf("Peek() after Get() = %+v, want the expiry saved", peeked)
+	}
+}
+
+func TestFileCache_StatsBreakdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	cache := NewFileCache(tmpDir)
+	ctx := context.Background()
+
+	set := func(reelID string, videoSize int) {
+		dir := cache.GetCacheDir(reelID)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		item := &ports.CachedItem{
+			Reel:       &domain.Reel{ID: reelID},
+			Transcript: &domain.Transcript{Text: "hello"},
+			CreatedAt:  time.Now(),
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}
+		if videoSize > 0 {
+			item.VideoPath = filepath.Join(dir, "video.mp4")
+			if err := os.WriteFile(item.VideoPath, make([]byte, videoSize), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := cache.Set(ctx, reelID, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	set("small", 100)
+	set("large", 5000)
+	set("textonly", 0)
+
+	stats, err := cache.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.ItemCount != 3 || stats.Transcripts.Count != 3 {
+		t.Errorf("Stats() = %d items, %d transcripts; want 3, 3", stats.ItemCount, stats.Transcripts.Count)
+	}
+	if stats.Video.Count != 2 || stats.Video.Size != 5100 {
+		t.Errorf("Stats() video = %+v, want 2 files of 5100 bytes", stats.Video)
+	}
+	if stats.Audio.Count != 0 || stats.Thumbnails.Count != 0 {
+		t.Errorf("Stats() counted audio %+v and thumbnails %+v that aren't cached", stats.Audio, stats.Thumbnails)
+	}
+	if len(stats.Largest) != 3 || stats.Largest[0].ReelID != "large" || stats.Largest[2].ReelID != "textonly" {
+		t.Errorf("Stats() largest = %+v, want large first and textonly last", stats.Largest)
+	}
+}
@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// checksumFiles returns the SHA-256 of each of the files at paths that
+// exists, by file name
+func checksumFiles(paths ...string) map[string]string {
+	sums := make(map[string]string)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if sum, err := fileChecksum(path); err == nil {
+			sums[filepath.Base(path)] = sum
+		}
+	}
+	if len(sums) == 0 {
+		return nil
+	}
+	return sums
+}
+
+// entryChecksums returns the checksums to record for an entry with files at
+// paths. A file the previous entry already had at the same path keeps its
+// recorded checksum: it was taken when the file was written, so rewriting
+// the entry neither hashes large media again nor re-records a file that
+// has since been corrupted.
+func entryChecksums(previous *cacheEntry, paths ...string) map[string]string {
+	if previous == nil {
+		return checksumFiles(paths...)
+	}
+
+	sums := make(map[string]string)
+	var added []string
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if sum, ok := previous.Checksums[filepath.Base(path)]; ok && previous.hasPath(path) {
+			sums[filepath.Base(path)] = sum
+			continue
+		}
+		added = append(added, path)
+	}
+	for name, sum := range checksumFiles(added...) {
+		sums[name] = sum
+	}
+	if len(sums) == 0 {
+		return nil
+	}
+	return sums
+}
+
+// hasPath reports whether path is one of the entry's files
+func (e *cacheEntry) hasPath(path string) bool {
+	for _, p := range []string{e.AudioPath, e.VideoPath, e.ThumbnailPath, e.RawOutputPath} {
+		if p != "" && p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// fileChecksum returns the hex SHA-256 of the file at path
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyEntry checks the files of reelID's entry against their recorded
+// checksums. Entries cached before checksums were recorded are only checked
+// for empty files.
+func verifyEntry(reelID string, entry *cacheEntry) []ports.CacheProblem {
+	var problems []ports.CacheProblem
+	for _, path := range []string{entry.AudioPath, entry.VideoPath, entry.ThumbnailPath, entry.RawOutputPath} {
+		if path == "" {
+			continue
+		}
+		want, recorded := entry.Checksums[filepath.Base(path)]
+
+		info, err := os.Stat(path)
+		switch {
+		case os.IsNotExist(err):
+			if recorded {
+				problems = append(problems, ports.CacheProblem{ReelID: reelID, File: path, Reason: "missing"})
+			}
+			continue // Unrecorded files were never downloaded
+		case err != nil:
+			problems = append(problems, ports.CacheProblem{ReelID: reelID, File: path, Reason: err.Error()})
+			continue
+		case info.Size() == 0:
+			problems = append(problems, ports.CacheProblem{ReelID: reelID, File: path, Reason: "empty"})
+			continue
+		case !recorded:
+			continue
+		}
+
+		got, err := fileChecksum(path)
+		if err != nil {
+			problems = append(problems, ports.CacheProblem{ReelID: reelID, File: path, Reason: err.Error()})
+		} else if got != want {
+			problems = append(problems, ports.CacheProblem{ReelID: reelID, File: path, Reason: "checksum mismatch"})
+		}
+	}
+	return problems
+}
+
+// Verify checks every entry's metadata, and its files against the checksums
+// recorded when it was cached
+func (c *FileCache) Verify(ctx context.Context) ([]ports.CacheProblem, error) {
+	entries, err := c.readCacheDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []ports.CacheProblem
+	for _, dir := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		reelID := dir.Name()
+		entry, err := c.readEntry(reelID)
+		if err != nil {
+			problems = append(problems, ports.CacheProblem{
+				ReelID: reelID,
+				File:   c.compressedMetaPath(reelID),
+				Reason: fmt.Sprintf("unreadable metadata: %v", err),
+			})
+			continue
+		}
+		problems = append(problems, verifyEntry(reelID, entry)...)
+	}
+	return problems, nil
+}
+
+// Verify checks every entry's metadata, and its files against the checksums
+// recorded when it was cached
+func (c *RedisCache) Verify(ctx context.Context) ([]ports.CacheProblem, error) {
+	reelIDs, err := c.reelIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []ports.CacheProblem
+	for _, reelID := range reelIDs {
+		data, err := c.client.get(ctx, c.key(reelID))
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue // Deleted since the scan
+		}
+		var entry cacheEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			problems = append(problems, ports.CacheProblem{
+				ReelID: reelID,
+				File:   c.key(reelID),
+				Reason: fmt.Sprintf("unreadable metadata: %v", err),
+			})
+			continue
+		}
+		problems = append(problems, verifyEntry(reelID, &entry)...)
+	}
+	return problems, nil
+}
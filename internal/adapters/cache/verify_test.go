@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestFileCache_Verify(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+	ctx := context.Background()
+
+	// set caches reelID with an audio file and returns the audio's path
+	set := func(reelID string) string {
+		t.Helper()
+		audio := filepath.Join(cache.GetCacheDir(reelID), "audio.wav")
+		if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(audio, []byte("RIFF....WAVE"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		item := &ports.CachedItem{
+			Reel:      &domain.Reel{ID: reelID},
+			AudioPath: audio,
+			VideoPath: filepath.Join(cache.GetCacheDir(reelID), "video.mp4"), // never downloaded
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		if err := cache.Set(ctx, reelID, item); err != nil {
+			t.Fatal(err)
+		}
+		return audio
+	}
+
+	set("intact")
+	if err := os.WriteFile(set("truncated"), []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(set("missing")); err != nil {
+		t.Fatal(err)
+	}
+	set("unreadable")
+	if err := os.WriteFile(cache.compressedMetaPath("unreadable"), []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := cache.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, p := range problems {
+		got[p.ReelID] = p.Reason
+	}
+	if len(got) != 3 || got["truncated"] != "checksum mismatch" || got["missing"] != "missing" || !strings.HasPrefix(got["unreadable"], "unreadable metadata") {
+		t.Errorf("Verify() problems = %v", got)
+	}
+}
+
+func TestFileCache_VerifyWithoutChecksums(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+	ctx := context.Background()
+
+	// An entry from before checksums were recorded, with an empty audio file
+	audio := filepath.Join(cache.GetCacheDir("old"), "audio.wav")
+	if err := os.MkdirAll(filepath.Dir(audio), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(audio, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"reel":{"id":"old"},"audio_path":"` + filepath.ToSlash(audio) + `","expires_at":"2099-01-01T00:00:00Z"}`
+	if err := os.WriteFile(cache.metaPath("old"), []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := cache.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if len(problems) != 1 || problems[0].Reason != "empty" {
+		t.Errorf("Verify() = %+v, want the empty audio file", problems)
+	}
+}
+
+func TestFileCache_SetKeepsRecordedChecksums(t *testing.T) {
+	cache := NewFileCache(t.TempDir())
+	ctx := context.Background()
+
+	dir := cache.GetCacheDir("reel")
+	audio, video := filepath.Join(dir, "audio.wav"), filepath.Join(dir, "video.mp4")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(audio, []byte("RIFF....WAVE"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item := &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "reel"},
+		AudioPath: audio,
+		VideoPath: video, // not downloaded yet
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := cache.Set(ctx, "reel", item); err != nil {
+		t.Fatal(err)
+	}
+
+	// The audio is corrupted after caching, the video is downloaded, and a
+	// cache hit writes the entry again
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(video, []byte("mp4"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Set(ctx, "reel", item); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := cache.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if len(problems) != 1 || problems[0].File != audio || problems[0].Reason != "checksum mismatch" {
+		t.Errorf("Verify() = %+v, want a checksum mismatch for the audio", problems)
+	}
+
+	entry, err := cache.readEntry("reel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Checksums["video.mp4"] == "" {
+		t.Errorf("Checksums = %v, want the newly written video recorded", entry.Checksums)
+	}
+}
@@ -1,22 +1,36 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	"github.com/devbush/ig2insights/internal/domain"
 	"github.com/spf13/cobra"
 )
 
 var (
-	latestFlag int
-	topFlag    int
+	latestFlag   int
+	topFlag      int
+	fromFileFlag string
 )
 
 // NewAccountCmd creates the account subcommand
 // Note: Hidden because Instagram is blocking yt-dlp user page scraping
 func NewAccountCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:    "account <username|url>",
-		Short:  "Browse and transcribe reels from an account",
+		Use:   "account <username|url>",
+		Short: "Browse and transcribe reels from an account",
+		Long: `Browse and transcribe reels from an account.
+
+When Instagram blocks profile scraping, seed the account with a file of
+its reel URLs (one per line) using --from-file. Metadata is fetched for
+each reel so sorting and selection still work, and --latest or --top
+transcribe the newest or most viewed of them without the selection list.
+
+Example:
+  ig2insights account someuser --from-file urls.txt
+  ig2insights account someuser --from-file urls.txt --top 5`,
 		Args:   cobra.MaximumNArgs(1),
 		RunE:   runAccount,
 		Hidden: true, // Hidden until yt-dlp fixes Instagram user page scraping
@@ -24,6 +38,7 @@ func NewAccountCmd() *cobra.Command {
 
 	cmd.Flags().IntVar(&latestFlag, "latest", 0, "Transcribe N most recent reels")
 	cmd.Flags().IntVar(&topFlag, "top", 0, "Transcribe N most viewed reels")
+	cmd.Flags().StringVar(&fromFileFlag, "from-file", "", "Seed the account with a file of reel URLs/IDs (one per line)")
 
 	return cmd
 }
@@ -36,6 +51,33 @@ func runAccount(cmd *cobra.Command, args []string) error {
 	}
 
 	username := args[0]
+
+	if fromFileFlag != "" {
+		account, err := domain.ParseAccountInput(username)
+		if err != nil {
+			return err
+		}
+		sort, limit, err := seededPick(latestFlag, topFlag)
+		if err != nil {
+			return err
+		}
+
+		app, err := GetApp()
+		if err != nil {
+			return fmt.Errorf("failed to initialize: %w", err)
+		}
+
+		reelIDs, err := ParseInputFile(context.Background(), app.InputSvc, fromFileFlag)
+		if err != nil {
+			return fmt.Errorf("failed to read reel file: %w", err)
+		}
+		if len(reelIDs) == 0 {
+			return fmt.Errorf("no valid reel URLs or IDs found in %s", fromFileFlag)
+		}
+
+		return runAccountSeeded(account.Username, reelIDs, sort, limit)
+	}
+
 	fmt.Printf("Browsing account: %s\n", username)
 
 	if latestFlag > 0 {
@@ -49,3 +91,15 @@ func runAccount(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// seededPick returns the order --latest and --top rank seeded reels in and
+// how many of them to transcribe, 0 to browse them all instead
+func seededPick(latest, top int) (domain.SortOrder, int, error) {
+	switch {
+	case latest > 0 && top > 0:
+		return "", 0, errors.New("--latest and --top can't be combined")
+	case top > 0:
+		return domain.SortMostViewed, top, nil
+	}
+	return domain.SortLatest, latest, nil
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestSeededPick(t *testing.T) {
+	tests := []struct {
+		name        string
+		latest, top int
+		wantSort    domain.SortOrder
+		wantLimit   int
+		wantErr     bool
+	}{
+		{"browse", 0, 0, domain.SortLatest, 0, false},
+		{"latest", 3, 0, domain.SortLatest, 3, false},
+		{"top", 0, 5, domain.SortMostViewed, 5, false},
+		{"both", 3, 5, "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, limit, err := seededPick(tt.latest, tt.top)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("seededPick(%d, %d) error = %v, wantErr %v", tt.latest, tt.top, err, tt.wantErr)
+			}
+			if sort != tt.wantSort || limit != tt.wantLimit {
+				t.Errorf("seededPick(%d, %d) = %s, %d; want %s, %d", tt.latest, tt.top, sort, limit, tt.wantSort, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/spf13/cobra"
+)
+
+var (
+	accountsFileFlag        string
+	accountsLatestFlag      int
+	accountsConcurrencyFlag int
+	accountsIgnoreLimits    bool
+)
+
+// NewAccountsCmd creates the accounts command for multi-account monitoring
+// Note: Hidden for the same reason as account; it relies on profile scraping
+func NewAccountsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accounts [usernames...]",
+		Short: "Transcribe the latest reels from many accounts",
+		Long: `Fetch the newest reels for each of many accounts concurrently and
+transcribe the combined set as a batch.
+
+Accounts come from arguments and/or a file of usernames or profile URLs
+(one per line). Listing stops early if Instagram starts rate limiting.
+
+Example:
+  ig2insights accounts --file accounts.txt --latest 5
+  ig2insights accounts alice bob --latest 3 --dir ./monitoring`,
+		RunE:   runAccounts,
+		Hidden: true, // Hidden until yt-dlp fixes Instagram user page scraping
+	}
+
+	cmd.Flags().StringVarP(&accountsFileFlag, "file", "f", "", "File with usernames/profile URLs (one per line)")
+	cmd.Flags().IntVar(&accountsLatestFlag, "latest", 5, "Number of newest reels per account")
+	cmd.Flags().IntVar(&accountsConcurrencyFlag, "accounts-concurrency", 4, "Max accounts listed concurrently")
+	cmd.Flags().IntVarP(&batchConcurrency, "concurrency", "c", 10, "Max concurrent transcription workers (max 50)")
+	cmd.Flags().BoolVar(&accountsIgnoreLimits, "ignore-limits", false, "Start even if a recent rate limit suggests waiting")
+
+	return cmd
+}
+
+func runAccounts(cmd *cobra.Command, args []string) error {
+	usernames, err := CollectAccounts(args, accountsFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to collect accounts: %w", err)
+	}
+	if len(usernames) == 0 {
+		return fmt.Errorf("no valid usernames or profile URLs provided")
+	}
+	if accountsLatestFlag < 1 {
+		return fmt.Errorf("--latest must be at least 1")
+	}
+
+	app, err := GetApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	batchConcurrency = resolveConcurrency(cmd, app)
+
+	ctx := context.Background()
+
+	if !accountsIgnoreLimits {
+		if err := checkCooldown(ctx, app); err != nil {
+			return err
+		}
+	}
+
+	if !quietFlag {
+		fmt.Printf("Listing %d latest reels from %d accounts...\n", accountsLatestFlag, len(usernames))
+	}
+
+	listings := app.BrowseSvc.ListLatestForAccounts(ctx, usernames, accountsLatestFlag, accountsConcurrencyFlag)
+
+	reelIDs, failed := collectAccountReels(ctx, app, listings)
+
+	if len(reelIDs) == 0 {
+		return fmt.Errorf("no reels found across %d accounts", len(usernames))
+	}
+	if !quietFlag {
+		fmt.Println()
+	}
+
+	outputDir := dirFlag
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := processBatch(ctx, app, reelIDs, outputDir, nil, false); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d accounts could not be listed", failed, len(usernames))
+	}
+	return nil
+}
+
+// collectAccountReels reports each account's listing and returns the
+// distinct reel IDs found and how many accounts failed. Only the rate
+// limits Instagram actually returned are recorded; accounts skipped after
+// one never sent a request.
+func collectAccountReels(ctx context.Context, app *App, listings []application.AccountReels) ([]string, int) {
+	seen := make(map[string]bool)
+	var reelIDs []string
+	var failed int
+	for _, listing := range listings {
+		if listing.Err != nil {
+			recordRateLimit(ctx, app, listing.Err)
+			failed++
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", listing.Username, listing.Err)
+			continue
+		}
+		if !quietFlag {
+			fmt.Printf("✓ %s: %d reels\n", listing.Username, len(listing.Reels))
+		}
+		for _, reel := range listing.Reels {
+			if !seen[reel.ID] {
+				seen[reel.ID] = true
+				reelIDs = append(reelIDs, reel.ID)
+			}
+		}
+	}
+	return reelIDs, failed
+}
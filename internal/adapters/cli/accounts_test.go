@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/ratelimit"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestCollectAccountReels_RecordsOneRateLimit(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+	app.RateLimitSvc = application.NewRateLimitService(ratelimit.NewFileStore(filepath.Join(dir, "ratelimit.json")))
+	ctx := context.Background()
+
+	listings := []application.AccountReels{
+		{Username: "alice", Reels: []*domain.Reel{{ID: "AAA111"}}},
+		{Username: "bob", Err: domain.ErrRateLimited},
+	}
+	for _, username := range []string{"carol", "dave", "erin", "frank"} {
+		listings = append(listings, application.AccountReels{Username: username, Err: application.ErrSkippedAfterRateLimit})
+	}
+
+	reelIDs, failed := collectAccountReels(ctx, app, listings)
+	if len(reelIDs) != 1 || failed != 5 {
+		t.Errorf("collectAccountReels() = %v, %d failed; want 1 reel, 5 failed", reelIDs, failed)
+	}
+
+	status, err := app.RateLimitSvc.Status(ctx, instagramHost)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.RecentHits != 1 {
+		t.Errorf("RecentHits = %d, want 1", status.RecentHits)
+	}
+}
@@ -1,9 +1,19 @@
 package cli
 
 import (
-	"time"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
 
-	"github.com/devbush/ig2insights/internal/adapters/cache"
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/adapters/history"
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/adapters/ratelimit"
+	"github.com/devbush/ig2insights/internal/adapters/session"
+	"github.com/devbush/ig2insights/internal/adapters/share"
 	"github.com/devbush/ig2insights/internal/adapters/whisper"
 	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
 	"github.com/devbush/ig2insights/internal/application"
@@ -11,62 +21,366 @@ import (
 	"github.com/devbush/ig2insights/internal/ports"
 )
 
+// Downloader is the downloader surface the CLI depends on, satisfied by the
+// yt-dlp and gallery-dl adapters and the mock used by --mock
+type Downloader interface {
+	ports.VideoDownloader
+	ports.AccountFetcher
+	IsAvailable() bool
+	GetBinaryPath() string
+	Install(ctx context.Context, progress func(downloaded, total int64)) error
+	Update(ctx context.Context) error
+	IsFFmpegAvailable() bool
+	GetFFmpegPath() string
+	InstallFFmpeg(ctx context.Context, progress func(downloaded, total int64)) error
+	FFmpegInstructions() string
+	Version(ctx context.Context) string
+}
+
+// Transcriber is the whisper surface the CLI depends on
+type Transcriber interface {
+	ports.Transcriber
+	IsAvailable() bool
+	GetBinaryPath() string
+	InstallationInstructions() string
+	Install(ctx context.Context, progress func(downloaded, total int64)) error
+	Version(ctx context.Context) string
+	ModelHash(model string) (string, error)
+}
+
 // App holds all application dependencies
 type App struct {
 	Config      *config.Config
 	Cache       ports.CacheStore
-	Downloader  *ytdlp.Downloader
-	Transcriber *whisper.Transcriber
+	CacheLimit  int64 // cache size cap in bytes, from the cache profile when one is chosen; 0 for none
+	Downloader  Downloader
+	Transcriber Transcriber
+	Throttle    config.ThrottleProfile // active throttle profile, zero when none
+	Proxy       string                 // proxy requests go through, "" when none
+	Paths       AppPaths               // where the App keeps its state
 
 	TranscribeSvc *application.TranscribeService
 	BrowseSvc     *application.BrowseService
 	CacheSvc      *application.CacheService
+	CompareSvc    *application.CompareService
+	RateLimitSvc  *application.RateLimitService
+	InputSvc      *application.ReelInputService
+	HistorySvc    *application.HistoryService
+	DashboardSvc  *application.DashboardService
+	SessionSvc    *application.SessionService
 }
 
-// NewApp creates and wires up all dependencies
-func NewApp() (*App, error) {
-	// Ensure directories exist
-	if err := config.EnsureDirs(); err != nil {
-		return nil, err
+// AppOptions are the per-run settings an App is built with on top of its
+// config. The CLI fills them from flags; embedders set only what they need.
+type AppOptions struct {
+	ThrottleProfile string        // config throttle profile; "" uses the config's default
+	CacheProfile    string        // config cache profile; "" uses the config's default
+	Proxy           string        // replaces the config's proxy
+	Cookies         ytdlp.Cookies // replace the config's login cookies
+	AnyURL          bool          // accept URLs of any site yt-dlp supports
+	YtDlpArgs       []string      // added to every yt-dlp request after the config's ytdlp_args
+	Aria2c          bool          // download through aria2c even if the config doesn't ask to
+	Offline         bool          // serve reels from cache only, failing instead of using the network
+	Mock            *mock.Options // swaps in deterministic fakes with isolated state
+	Paths           AppPaths      // where the App keeps its state
+}
+
+// AppPaths are where an App keeps its state on disk. Empty fields use the
+// active config profile's, which depend on config.SetProfile and the XDG
+// environment variables.
+type AppPaths struct {
+	ConfigPath    string // config file the App's config was read from, named in messages
+	CacheDir      string // transcript cache when no cache profile is chosen
+	CachesDir     string // cache profiles that don't set a dir of their own
+	ModelsDir     string // whisper models
+	BinDir        string // downloaded yt-dlp, whisper, ffmpeg, gallery-dl and aria2c
+	SessionDir    string // stored Instagram login
+	HistoryPath   string // past transcription runs
+	RateLimitPath string // recent rate-limit responses
+	YtDlpConfig   string // yt-dlp config read when ytdlp_config is managed
+}
+
+// withDefaults fills the empty paths from the config package
+func (p AppPaths) withDefaults() AppPaths {
+	if p.ConfigPath == "" {
+		p.ConfigPath = config.ConfigPath()
+	}
+	if p.CacheDir == "" {
+		p.CacheDir = config.CacheDir()
+	}
+	if p.CachesDir == "" {
+		p.CachesDir = config.CacheProfilesDir()
 	}
+	if p.ModelsDir == "" {
+		p.ModelsDir = config.ModelsDir()
+	}
+	if p.SessionDir == "" {
+		p.SessionDir = config.SessionDir()
+	}
+	if p.HistoryPath == "" {
+		p.HistoryPath = config.HistoryPath()
+	}
+	if p.RateLimitPath == "" {
+		p.RateLimitPath = config.RateLimitStatePath()
+	}
+	if p.YtDlpConfig == "" {
+		p.YtDlpConfig = config.YtDlpConfigPath()
+	}
+	if p.BinDir == "" {
+		p.BinDir = config.BinDir()
+	}
+	return p
+}
+
+// ensureDirs creates the directories the App reads from before writing to
+func (p AppPaths) ensureDirs() error {
+	dirs := []string{filepath.Dir(p.ConfigPath), filepath.Dir(p.HistoryPath), p.ModelsDir, p.CacheDir, p.BinDir}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	return nil
+}
 
-	// Load config
+// appOptionsFromFlags returns the AppOptions the command-line flags ask for
+func appOptionsFromFlags() (AppOptions, error) {
+	ytdlpArgs, err := splitShellWords(ytdlpArgsFlag)
+	if err != nil {
+		return AppOptions{}, fmt.Errorf("invalid --ytdlp-args: %w", err)
+	}
+	opts := AppOptions{
+		ThrottleProfile: throttleProfileFlag,
+		CacheProfile:    cacheProfileFlag,
+		Proxy:           proxyFlag,
+		Cookies:         ytdlp.Cookies{File: cookiesFlag, FromBrowser: cookiesFromBrowserFlag},
+		AnyURL:          anyURLFlag,
+		YtDlpArgs:       ytdlpArgs,
+		Aria2c:          aria2cFlag,
+		Offline:         offlineFlag,
+	}
+	if mockFlag {
+		opts.Mock = &mock.Options{Delay: mockDelayFlag, FailureRate: mockFailRateFlag}
+	}
+	return opts, nil
+}
+
+// NewApp creates an App from config.yaml, the environment and the
+// command-line flags, and sets the locale the TUI formats numbers and dates
+// for
+func NewApp() (*App, error) {
 	cfg, err := config.LoadDefault()
 	if err != nil {
 		return nil, err
 	}
+	if cacheTTLFlag != "" {
+		// --cache-ttl replaces both the default and cache.ttl.transcript
+		cfg.Defaults.CacheTTL, cfg.Cache.TTL.Transcript = cacheTTLFlag, ""
+		if _, err := cfg.GetCacheTTL(); err != nil {
+			return nil, fmt.Errorf("invalid --cache-ttl: %s (use format like 24h, 7d, or never)", cacheTTLFlag)
+		}
+	}
+	locale, err := tui.ResolveLocale(cfg.Output.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("output.locale: %w", err)
+	}
+	tui.SetLocale(locale)
+	if _, err := audioFormat(cfg); err != nil {
+		return nil, err
+	}
+	if _, err := videoQuality(cfg); err != nil {
+		return nil, err
+	}
+	opts, err := appOptionsFromFlags()
+	if err != nil {
+		return nil, err
+	}
+	return NewAppWithConfig(cfg, opts)
+}
+
+// NewAppWithConfig creates and wires up all dependencies for cfg. It reads
+// no flags, and keeps its state where opts.Paths says, so Apps given
+// different paths can live side by side.
+func NewAppWithConfig(cfg *config.Config, opts AppOptions) (*App, error) {
+	paths := opts.Paths.withDefaults()
+	if opts.Mock == nil {
+		if err := paths.ensureDirs(); err != nil {
+			return nil, err
+		}
+	}
 
 	// Parse cache TTL
 	ttl, err := cfg.GetCacheTTL()
 	if err != nil {
-		ttl = 7 * 24 * time.Hour // Default
+		return nil, err
 	}
+	mediaTTL, err := cfg.GetCacheMediaTTL()
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, maxCacheSize, cacheProfile, err := cfg.GetCacheProfileIn(opts.CacheProfile, paths.CacheDir, paths.CachesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	throttle, _, err := cfg.GetThrottleProfile(opts.ThrottleProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := resolveProxy(cfg, opts.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	var client *http.Client
+	if proxy != "" {
+		client = proxyHTTPClient(proxy)
+	}
+
+	var (
+		sessionStore *session.FileStore
+		downloader   Downloader
+		transcriber  Transcriber
+		resolver     ports.LinkResolver
+		fallbacks    []application.TranscriberFallback
+	)
+	rateLimitPath := paths.RateLimitPath
+	historyPath := paths.HistoryPath
+
+	if opts.Mock != nil {
+		// Mock mode swaps in deterministic fakes and isolates their state.
+		// No real adapter is built, so nothing reads the login or probes
+		// for binaries.
+		sessionStore = session.NewFileStore(filepath.Join(mockDir(), "session"))
+		downloader = mock.NewDownloader(*opts.Mock)
+		transcriber = mock.NewTranscriber(*opts.Mock)
+		cacheDir = filepath.Join(mockDir(), "cache")
+		rateLimitPath = filepath.Join(mockDir(), "ratelimit.json")
+		historyPath = filepath.Join(mockDir(), "history.jsonl")
+	} else {
+		sessionStore = session.NewFileStore(paths.SessionDir)
+		cookies, err := loginCookies(cfg, opts.Cookies, sessionStore.CookiesPath())
+		if err != nil {
+			return nil, err
+		}
+		ytdlpConfigs, err := ytdlpConfigFiles(cfg, paths.YtDlpConfig)
+		if err != nil {
+			return nil, err
+		}
+		mirrors, err := cfg.GetMirrors()
+		if err != nil {
+			return nil, err
+		}
 
-	// Create adapters
-	cacheStore := cache.NewFileCache(config.CacheDir())
-	downloader := ytdlp.NewDownloader()
-	transcriber := whisper.NewTranscriber("")
+		// Create adapters
+		ytdlpDownloader := ytdlp.NewDownloader()
+		ytdlpDownloader.SetBinDir(paths.BinDir)
+		ytdlpDownloader.SetThrottle(ytdlpThrottle(throttle))
+		ytdlpDownloader.SetCookies(cookies)
+		ytdlpDownloader.SetConfigFiles(ytdlpConfigs)
+		ytdlpDownloader.SetExtraArgs(append(append([]string(nil), cfg.YtDlpArgs...), opts.YtDlpArgs...))
+		ytdlpDownloader.SetAria2c(cfg.Aria2c || opts.Aria2c)
+		ytdlpDownloader.SetMirror(mirrors.YtDlp)
+		whisperTranscriber := whisper.NewTranscriber(paths.ModelsDir)
+		whisperTranscriber.SetBinDir(paths.BinDir)
+		whisperTranscriber.SetModelMirror(mirrors.Models)
+		whisperTranscriber.SetBinaryMirror(mirrors.Whisper)
+		shareResolver := share.NewResolver()
+		if proxy != "" {
+			ytdlpDownloader.SetProxy(proxy, client)
+			whisperTranscriber.SetHTTPClient(client)
+			shareResolver.SetHTTPClient(client)
+		}
+		if fallbacks, err = transcriberFallbacks(cfg, whisperTranscriber, client); err != nil {
+			return nil, err
+		}
+		if downloader, err = selectDownloader(cfg, ytdlpDownloader, throttle, cookies, proxy, client); err != nil {
+			return nil, err
+		}
+		transcriber = whisperTranscriber
+		resolver = shareResolver
+	}
+
+	// Offline runs use the local copy of a shared bucket; Redis, usually on
+	// the local network, is still used. Cache profiles are never shared.
+	shared := opts.Mock == nil && !cacheProfile && (!opts.Offline || cfg.Cache.Redis.URL != "")
+	cacheStore, err := newCacheStore(cfg, cacheDir, maxCacheSize, shared, client)
+	if err != nil {
+		return nil, err
+	}
+	reelCache := cacheStore
+	if opts.Offline {
+		downloader = &offlineDownloader{Downloader: downloader}
+		transcriber = &offlineTranscriber{Transcriber: transcriber}
+		resolver = nil
+		fallbacks = offlineFallbacks(fallbacks)
+		reelCache = &offlineCache{CacheStore: cacheStore}
+	}
+	historyStore := history.NewFileStore(historyPath)
 
 	// Create services
-	transcribeSvc := application.NewTranscribeService(cacheStore, downloader, transcriber, ttl)
+	transcribeSvc := application.NewTranscribeService(reelCache, downloader, transcriber, ttl)
+	transcribeSvc.SetFallbacks(fallbacks)
+	transcribeSvc.SetMediaTTL(mediaTTL)
 	browseSvc := application.NewBrowseService(downloader)
 	cacheSvc := application.NewCacheService(cacheStore)
+	compareSvc := application.NewCompareService(reelCache, downloader, transcriber, ttl)
+	compareSvc.SetMediaTTL(mediaTTL)
+	rateLimitSvc := application.NewRateLimitService(ratelimit.NewFileStore(rateLimitPath))
+	inputSvc := application.NewReelInputService(resolver)
+	if opts.AnyURL {
+		registrar, ok := downloader.(ports.URLRegistrar)
+		if !ok {
+			return nil, fmt.Errorf("--any-url is not supported by the %s downloader", cfg.Downloader)
+		}
+		inputSvc.AllowAnyURL(registrar)
+	}
+	if registrar, ok := downloader.(ports.FileRegistrar); ok {
+		inputSvc.AllowLocalFiles(registrar)
+	}
+	if lister, ok := downloader.(ports.PlaylistLister); ok {
+		inputSvc.AllowPlaylists(lister)
+	}
+	historySvc := application.NewHistoryService(historyStore)
+	dashboardSvc := application.NewDashboardService(cacheStore, historyStore)
+	sessionSvc := application.NewSessionService(sessionStore, downloader)
 
 	return &App{
 		Config:        cfg,
-		Cache:         cacheStore,
+		Cache:         reelCache,
+		CacheLimit:    maxCacheSize,
 		Downloader:    downloader,
 		Transcriber:   transcriber,
+		Throttle:      throttle,
+		Proxy:         proxy,
+		Paths:         paths,
 		TranscribeSvc: transcribeSvc,
 		BrowseSvc:     browseSvc,
 		CacheSvc:      cacheSvc,
+		CompareSvc:    compareSvc,
+		RateLimitSvc:  rateLimitSvc,
+		InputSvc:      inputSvc,
+		HistorySvc:    historySvc,
+		DashboardSvc:  dashboardSvc,
+		SessionSvc:    sessionSvc,
 	}, nil
 }
 
-var globalApp *App
+// mockDir holds mock mode's cache, history and session
+func mockDir() string {
+	return filepath.Join(os.TempDir(), "ig2insights-mock")
+}
+
+var (
+	appMu     sync.Mutex
+	globalApp *App
+)
 
-// GetApp returns the global app instance, creating it if needed
+// GetApp returns the app the commands run against, creating it from
+// config.yaml and the flags on first use. It is safe for concurrent use.
 func GetApp() (*App, error) {
+	appMu.Lock()
+	defer appMu.Unlock()
 	if globalApp == nil {
 		app, err := NewApp()
 		if err != nil {
@@ -76,3 +390,13 @@ func GetApp() (*App, error) {
 	}
 	return globalApp, nil
 }
+
+// SetApp makes the commands run against app, e.g. one built by
+// NewAppWithConfig when embedding the CLI. A nil app makes the next GetApp
+// build a fresh one. The commands still read their options from the flag
+// variables, which every command in the process shares.
+func SetApp(app *App) {
+	appMu.Lock()
+	defer appMu.Unlock()
+	globalApp = app
+}
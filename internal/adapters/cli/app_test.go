@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+func TestNewAppWithConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.Proxy = "http://proxy.corp:3128"
+	opts := AppOptions{Mock: &mock.Options{}, Proxy: "socks5://127.0.0.1:1080"}
+
+	a, err := NewAppWithConfig(cfg, opts)
+	if err != nil {
+		t.Fatalf("NewAppWithConfig() error = %v", err)
+	}
+	b, err := NewAppWithConfig(config.DefaultConfig(), AppOptions{Mock: &mock.Options{}})
+	if err != nil {
+		t.Fatalf("NewAppWithConfig() error = %v", err)
+	}
+
+	if a.Config != cfg || a.Proxy != opts.Proxy {
+		t.Errorf("app config/proxy = %p/%q, want the injected ones", a.Config, a.Proxy)
+	}
+	if b.Proxy != "" || a.TranscribeSvc == b.TranscribeSvc {
+		t.Error("apps built separately should not share state")
+	}
+
+	if _, err := NewAppWithConfig(cfg, AppOptions{Mock: &mock.Options{}, Proxy: "ftp://proxy:21"}); err == nil {
+		t.Error("expected error for an invalid proxy")
+	}
+
+	badTTL := config.DefaultConfig()
+	badTTL.Defaults.CacheTTL = "forever"
+	if _, err := NewAppWithConfig(badTTL, AppOptions{Mock: &mock.Options{}}); err == nil {
+		t.Error("expected error for an invalid cache TTL")
+	}
+}
+
+func TestNewAppWithConfig_MockBuildsNoRealAdapters(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// Settings only the real adapters read don't stop mock mode
+	cfg := config.DefaultConfig()
+	cfg.Cookies.File = filepath.Join(t.TempDir(), "missing.txt")
+	cfg.YtDlpConfig = "managed"
+	app, err := NewAppWithConfig(cfg, AppOptions{Mock: &mock.Options{}})
+	if err != nil {
+		t.Fatalf("NewAppWithConfig() error = %v", err)
+	}
+	if _, ok := app.Downloader.(*mock.Downloader); !ok {
+		t.Errorf("downloader = %T, want the mock", app.Downloader)
+	}
+	if entries, _ := os.ReadDir(home); len(entries) != 0 {
+		t.Errorf("NewAppWithConfig() wrote %d entries to the home directory, want none", len(entries))
+	}
+}
+
+func TestNewAppWithConfig_CacheProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.Cache.MaxSize = "1GB"
+	cfg.Cache.Profiles = map[string]config.CacheProfile{"client": {Dir: t.TempDir(), MaxSize: "2GB"}}
+
+	app, err := NewAppWithConfig(cfg, AppOptions{CacheProfile: "client"})
+	if err != nil {
+		t.Fatalf("NewAppWithConfig() error = %v", err)
+	}
+	if got, want := app.Cache.GetCacheDir("ABC123"), filepath.Join(cfg.Cache.Profiles["client"].Dir, "ABC123"); got != want {
+		t.Errorf("cache dir = %s, want %s", got, want)
+	}
+	if app.CacheLimit != 2<<30 {
+		t.Errorf("cache limit = %d, want the profile's max_size", app.CacheLimit)
+	}
+
+	if _, err := NewAppWithConfig(cfg, AppOptions{CacheProfile: "personal"}); err == nil {
+		t.Error("expected error for an unknown cache profile")
+	}
+}
+
+func TestNewAppWithConfig_Paths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, v := range []string{"XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_DATA_HOME"} {
+		t.Setenv(v, "")
+	}
+
+	appPaths := func(dir string) AppPaths {
+		return AppPaths{
+			ConfigPath:    filepath.Join(dir, "config.yaml"),
+			CacheDir:      filepath.Join(dir, "cache"),
+			CachesDir:     filepath.Join(dir, "caches"),
+			ModelsDir:     filepath.Join(dir, "models"),
+			BinDir:        filepath.Join(dir, "bin"),
+			SessionDir:    filepath.Join(dir, "session"),
+			HistoryPath:   filepath.Join(dir, "history.jsonl"),
+			RateLimitPath: filepath.Join(dir, "ratelimits.json"),
+			YtDlpConfig:   filepath.Join(dir, "yt-dlp.conf"),
+		}
+	}
+	cfg := config.DefaultConfig()
+	cfg.Cache.Profiles = map[string]config.CacheProfile{"client": {}}
+
+	// Two Apps in one process keep their state apart
+	ctx := context.Background()
+	for _, paths := range []AppPaths{appPaths(t.TempDir()), appPaths(t.TempDir())} {
+		app, err := NewAppWithConfig(cfg, AppOptions{Paths: paths})
+		if err != nil {
+			t.Fatalf("NewAppWithConfig() error = %v", err)
+		}
+
+		if app.Paths != paths {
+			t.Errorf("app paths = %+v, want %+v", app.Paths, paths)
+		}
+		if got, want := app.Cache.GetCacheDir("ABC123"), filepath.Join(paths.CacheDir, "ABC123"); got != want {
+			t.Errorf("cache dir = %s, want %s", got, want)
+		}
+		if got, want := app.Downloader.(binDirer).BinDir(), paths.BinDir; got != want {
+			t.Errorf("downloader bin dir = %s, want %s", got, want)
+		}
+		if got, want := app.Transcriber.(binDirer).BinDir(), paths.BinDir; got != want {
+			t.Errorf("transcriber bin dir = %s, want %s", got, want)
+		}
+		if err := app.RateLimitSvc.Record(ctx, instagramHost); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(paths.RateLimitPath); err != nil {
+			t.Errorf("rate-limit state not at the given path: %v", err)
+		}
+
+		profileApp, err := NewAppWithConfig(cfg, AppOptions{Paths: paths, CacheProfile: "client"})
+		if err != nil {
+			t.Fatalf("NewAppWithConfig() error = %v", err)
+		}
+		if got, want := profileApp.Cache.GetCacheDir("ABC123"), filepath.Join(paths.CachesDir, "client", "ABC123"); got != want {
+			t.Errorf("cache profile dir = %s, want %s", got, want)
+		}
+	}
+
+	if entries, _ := os.ReadDir(home); len(entries) != 0 {
+		t.Errorf("NewAppWithConfig() wrote %d entries to the home directory, want none", len(entries))
+	}
+}
+
+// binDirer is implemented by adapters that install binaries
+type binDirer interface {
+	BinDir() string
+}
+
+func TestGetApp_Concurrent(t *testing.T) {
+	defer SetApp(nil)
+	app := newMockApp(t.TempDir())
+	SetApp(app)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := GetApp(); err != nil || got != app {
+				t.Errorf("GetApp() = %p, %v, want the app set", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// defaultAudioFormat is what --audio saves unless told otherwise: the WAV
+// whisper transcribes, copied as is
+const defaultAudioFormat = "wav"
+
+// audioEncoders maps each --audio-format to the ffmpeg options that encode
+// it. The format name doubles as the file extension.
+var audioEncoders = map[string][]string{
+	"mp3":  {"-c:a", "libmp3lame", "-q:a", "2"},
+	"m4a":  {"-c:a", "aac", "-b:a", "192k"},
+	"flac": {"-c:a", "flac"},
+}
+
+// audioFormats lists the --audio-format values, the default first
+var audioFormats = []string{defaultAudioFormat, "mp3", "m4a", "flac"}
+
+// audioFormat returns the --audio-format to save audio in, falling back to
+// the config default
+func audioFormat(cfg *config.Config) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(audioFormatFlag))
+	if format == "" {
+		format = strings.ToLower(strings.TrimSpace(cfg.Defaults.AudioFormat))
+	}
+	if format == "" {
+		return defaultAudioFormat, nil
+	}
+	if !slices.Contains(audioFormats, format) {
+		return "", fmt.Errorf("unknown audio format: %s (use %s)", format, strings.Join(audioFormats, ", "))
+	}
+	return format, nil
+}
+
+// saveAudio writes the downloaded WAV at src to path in format, converting
+// it with ffmpeg unless it's WAV. Returns the path written, which differs
+// from path when the user chose to rename.
+func saveAudio(ctx context.Context, ffmpegPath, format, src, path string) (string, error) {
+	target, write := outputTarget(path)
+	if !write {
+		return target, nil
+	}
+	return target, convertAudio(ctx, ffmpegPath, format, src, target)
+}
+
+// convertAudio encodes the WAV at src as format into dest
+func convertAudio(ctx context.Context, ffmpegPath, format, src, dest string) error {
+	encoder, ok := audioEncoders[format]
+	if !ok {
+		return copyFile(src, dest)
+	}
+	if ffmpegPath == "" {
+		return domain.ErrFFmpegNotFound
+	}
+
+	args := append([]string{"-y", "-loglevel", "error", "-i", src, "-vn"}, encoder...)
+	args = append(args, dest)
+	if out, err := exec.CommandContext(ctx, ffmpegPath, args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("failed to convert audio to %s: %s", format, msg)
+		}
+		return fmt.Errorf("failed to convert audio to %s: %w", format, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestAudioFormat(t *testing.T) {
+	oldFlag := audioFormatFlag
+	defer func() { audioFormatFlag = oldFlag }()
+
+	cfg := config.DefaultConfig()
+	tests := []struct {
+		flag, config string
+		want         string
+		wantErr      bool
+	}{
+		{"", "", "wav", false},
+		{"", "flac", "flac", false},
+		{"MP3", "flac", "mp3", false},
+		{"ogg", "", "", true},
+		{"", "aiff", "", true},
+	}
+	for _, tt := range tests {
+		audioFormatFlag = tt.flag
+		cfg.Defaults.AudioFormat = tt.config
+		got, err := audioFormat(cfg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("audioFormat(flag %q, config %q) = %q, %v; want %q", tt.flag, tt.config, got, err, tt.want)
+		}
+	}
+}
+
+func TestConvertAudio(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "audio.wav")
+	if err := os.WriteFile(src, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// WAV is copied without ffmpeg
+	dest := filepath.Join(dir, "out.wav")
+	if err := convertAudio(ctx, "", "wav", src, dest); err != nil {
+		t.Fatalf("convertAudio(wav) error = %v", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "RIFF" {
+		t.Errorf("copied audio = %q", data)
+	}
+
+	if err := convertAudio(ctx, "", "mp3", src, filepath.Join(dir, "out.mp3")); !errors.Is(err, domain.ErrFFmpegNotFound) {
+		t.Errorf("convertAudio(mp3) without ffmpeg error = %v, want ErrFFmpegNotFound", err)
+	}
+}
+
+func TestConvertAudio_FFmpeg(t *testing.T) {
+	ffmpeg, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		t.Skip("ffmpeg is not installed")
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "audio.wav")
+	if out, err := exec.Command(ffmpeg, "-loglevel", "error", "-f", "lavfi", "-i", "anullsrc=r=16000:cl=mono", "-t", "1", src).CombinedOutput(); err != nil {
+		t.Fatalf("failed to make test audio: %v: %s", err, out)
+	}
+
+	for format := range audioEncoders {
+		dest := filepath.Join(dir, "out."+format)
+		if err := convertAudio(context.Background(), ffmpeg, format, src, dest); err != nil {
+			t.Errorf("convertAudio(%s) error = %v", format, err)
+			continue
+		}
+		if fi, err := os.Stat(dest); err != nil || fi.Size() == 0 {
+			t.Errorf("convertAudio(%s) wrote nothing", format)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	authBrowserFlag string
+	authFileFlag    string
+)
+
+// NewAuthCmd creates the auth command
+func NewAuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage the Instagram login used for downloads",
+		Long: `Store an Instagram session so every download is made logged in.
+Instagram increasingly blocks anonymous requests, and private or
+login-gated reels always need a login.
+
+The session is kept under ~/.ig2insights/session and used whenever
+--cookies, --cookies-from-browser and the cookies config are not set.`,
+		RunE: runAuthStatus,
+	}
+
+	loginCmd := &cobra.Command{
+		Use:   "login",
+		Short: "Import a session from a browser or cookies file",
+		Example: `  ig2insights auth login --from-browser firefox
+  ig2insights auth login --file cookies.txt`,
+		Args: cobra.NoArgs,
+		RunE: runAuthLogin,
+	}
+	loginCmd.Flags().StringVar(&authBrowserFlag, "from-browser", "", "Browser to read the login from (e.g., firefox, chrome, chrome:Profile 1)")
+	loginCmd.Flags().StringVar(&authFileFlag, "file", "", "Netscape-format cookies file to import")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show and validate the stored session",
+		RunE:  runAuthStatus,
+	}
+
+	refreshCmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Re-import the session from where it was logged in",
+		RunE:  runAuthRefresh,
+	}
+
+	logoutCmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Delete the stored session",
+		RunE:  runAuthLogout,
+	}
+
+	cmd.AddCommand(loginCmd)
+	cmd.AddCommand(statusCmd)
+	cmd.AddCommand(refreshCmd)
+	cmd.AddCommand(logoutCmd)
+	return cmd
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) error {
+	if (authBrowserFlag == "") == (authFileFlag == "") {
+		return errors.New("pass either --from-browser or --file")
+	}
+
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	source := domain.SessionSource{Browser: authBrowserFlag, File: authFileFlag}
+	session, err := app.SessionSvc.Login(context.Background(), source)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Logged in%s from %s\n", describeUser(session), source)
+	return nil
+}
+
+func runAuthRefresh(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	session, err := app.SessionSvc.Refresh(context.Background())
+	if errors.Is(err, domain.ErrNoSession) {
+		return errors.New("not logged in; run 'ig2insights auth login' first")
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Session refreshed%s, expires %s\n", describeUser(session), describeExpiry(session, time.Now()))
+	return nil
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Instagram Session:")
+	fmt.Println()
+
+	status, err := app.SessionSvc.Status(context.Background())
+	if errors.Is(err, domain.ErrNoSession) {
+		fmt.Println("  Not logged in; downloads are anonymous")
+		fmt.Println("  Log in with: ig2insights auth login --from-browser firefox")
+		fmt.Println()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if status.Source != (domain.SessionSource{}) {
+		fmt.Printf("  Source:  %s\n", status.Source)
+	}
+	if status.Session != nil {
+		if status.Session.UserID != "" {
+			fmt.Printf("  User ID: %s\n", status.Session.UserID)
+		}
+		fmt.Printf("  Expires: %s\n", describeExpiry(status.Session, time.Now()))
+	}
+	if status.Err != nil {
+		fmt.Printf("  Status:  unusable (%v); run 'ig2insights auth refresh' or log in again\n", status.Err)
+	} else {
+		fmt.Println("  Status:  valid")
+	}
+	if cookiesFlag != "" || cookiesFromBrowserFlag != "" || app.Config.Cookies != (config.CookiesConfig{}) {
+		fmt.Println("  Note:    --cookies, --cookies-from-browser or the cookies config take precedence")
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	if err := app.SessionSvc.Logout(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Println("Instagram session deleted")
+	return nil
+}
+
+// describeUser renders " as user <id>" when the session knows the user
+func describeUser(s *domain.Session) string {
+	if s.UserID == "" {
+		return ""
+	}
+	return " as user " + s.UserID
+}
+
+// describeExpiry renders when the session expires relative to now
+func describeExpiry(s *domain.Session, now time.Time) string {
+	if s.ExpiresAt.IsZero() {
+		return "when the browser session ends"
+	}
+	if !now.Before(s.ExpiresAt) {
+		return s.ExpiresAt.Local().Format("2006-01-02") + " (expired)"
+	}
+	return fmt.Sprintf("%s (in %d days)", s.ExpiresAt.Local().Format("2006-01-02"), int(s.ExpiresAt.Sub(now).Hours()/24))
+}
@@ -16,9 +16,12 @@ import (
 )
 
 var (
-	batchFileFlag      string
-	batchNoSaveMedia   bool
-	batchConcurrency   int
+	batchFileFlag     string
+	batchNoSaveMedia  bool
+	batchConcurrency  int
+	batchIgnoreLimits bool
+	batchResumeFlag   bool
+	batchDryRunFlag   bool
 )
 
 // NewBatchCmd creates the batch command
@@ -29,52 +32,126 @@ func NewBatchCmd() *cobra.Command {
 		Long: `Batch process multiple Instagram Reels concurrently.
 
 Provide reel URLs or IDs as arguments and/or via a file with --file.
+A .csv file has a header row naming its columns: url, and optionally name,
+language and formats to override --name, --language and --format per reel.
 Each reel will be transcribed and saved to the output directory.
+YouTube playlist and channel URLs given as arguments are expanded into
+their Shorts.
 
 Example:
   ig2insights batch reel1 reel2 reel3
   ig2insights batch --file reels.txt
-  ig2insights batch reel1 --file more-reels.txt --concurrency 5`,
+  ig2insights batch reel1 --file more-reels.txt --concurrency 5
+  ig2insights batch --file reels.csv
+  ig2insights batch --resume --dir ./output
+  ig2insights batch status --dir ./output
+  ig2insights batch retry --dir ./output --model medium
+  ig2insights batch --file reels.txt --dry-run
+  ig2insights batch https://www.youtube.com/@creator/shorts`,
 		RunE: runBatch,
 	}
 
 	// Batch-specific flags
-	cmd.Flags().StringVarP(&batchFileFlag, "file", "f", "", "File with URLs/IDs (one per line)")
+	cmd.Flags().StringVarP(&batchFileFlag, "file", "f", "", "File with URLs/IDs (one per line), or a CSV file with per-reel options")
 	cmd.Flags().BoolVar(&batchNoSaveMedia, "no-save-media", false, "Don't save audio/video to cache after processing")
 	cmd.Flags().IntVarP(&batchConcurrency, "concurrency", "c", 10, "Max concurrent workers (max 50)")
+	cmd.Flags().BoolVar(&batchIgnoreLimits, "ignore-limits", false, "Start even if a recent rate limit suggests waiting")
+	cmd.Flags().BoolVar(&batchResumeFlag, "resume", false, "Continue an interrupted batch from its journal in the output directory")
+	cmd.Flags().BoolVar(&batchDryRunFlag, "dry-run", false, "List the reels and estimated transcription time without processing them")
+
+	cmd.AddCommand(newBatchRetryCmd())
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show which reels of the last batch are done, failed or still pending",
+		Long: `Read the batch journal in the output directory (--dir, default the current
+directory) and list its reels by state. Works while a batch is running or
+after it was interrupted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBatchStatus(cmd.Context(), os.Stdout, batchOutputDir())
+		},
+	})
 
 	return cmd
 }
 
+// batchOutputDir returns the directory batch writes outputs and its journal
+// to: --dir, or the current directory
+func batchOutputDir() string {
+	if dirFlag == "" {
+		return "."
+	}
+	return dirFlag
+}
+
 func runBatch(cmd *cobra.Command, args []string) error {
-	// Validate concurrency
-	if batchConcurrency < 1 {
-		batchConcurrency = 1
+	if err := validateEncoding(encodingFlag); err != nil {
+		return err
+	}
+	if _, err := parseFormats(formatFlag); err != nil {
+		return err
+	}
+	if templateFlag != "" {
+		if _, err := loadOutputTemplate(templateFlag); err != nil {
+			return err
+		}
+	}
+	if err := validateConflictFlags(); err != nil {
+		return err
+	}
+	if err := validateOutputTemplate(); err != nil {
+		return err
+	}
+	if clipboardFlag || clipboardOnlyFlag {
+		return fmt.Errorf("--clipboard is only supported for single reels")
+	}
+	if stdoutFlag {
+		return fmt.Errorf("--stdout is only supported for single reels")
 	}
-	if batchConcurrency > 50 {
-		batchConcurrency = 50
+
+	// Initialize app
+	app, err := GetApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	batchConcurrency = resolveConcurrency(cmd, app)
+
+	ctx := context.Background()
+
+	args, err = expandPlaylists(ctx, app, args)
+	if err != nil {
+		return err
 	}
 
 	// Collect all reel IDs from args and file
-	reelIDs, err := CollectInputs(args, batchFileFlag)
+	reelIDs, rows, err := collectBatchInputs(ctx, app.InputSvc, args, batchFileFlag)
 	if err != nil {
-		return fmt.Errorf("failed to collect inputs: %w", err)
+		return err
 	}
 
-	if len(reelIDs) == 0 {
+	if len(reelIDs) == 0 && !batchResumeFlag {
 		return fmt.Errorf("no valid reel URLs or IDs provided")
 	}
 
-	// Initialize app
-	app, err := GetApp()
-	if err != nil {
-		return fmt.Errorf("failed to initialize: %w", err)
+	outputDir := batchOutputDir()
+
+	if batchResumeFlag {
+		reelIDs, err = planResume(ctx, app, openBatchJournal(outputDir), reelIDs)
+		if err != nil {
+			return err
+		}
+		if len(reelIDs) == 0 {
+			if !quietFlag {
+				fmt.Println("Nothing to resume: every reel in the journal is done")
+			}
+			return nil
+		}
 	}
 
-	// Determine output directory
-	outputDir := dirFlag
-	if outputDir == "" {
-		outputDir = "." // Default to current directory for batch
+	if batchDryRunFlag {
+		est := batchEstimator(ctx, app)
+		printBatchPlan(estimateReels(ctx, app, est, reelIDs, true), est)
+		return nil
 	}
 
 	// Create output directory
@@ -82,15 +159,79 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	ctx := context.Background()
+	if !batchIgnoreLimits {
+		if err := checkCooldown(ctx, app); err != nil {
+			return err
+		}
+	}
 
 	// Process batch
-	return processBatch(ctx, app, reelIDs, outputDir)
+	return processBatch(ctx, app, reelIDs, outputDir, rows, batchResumeFlag)
 }
 
-func processBatch(ctx context.Context, app *App, reelIDs []string, outputDir string) error {
+// batchRun is what a batch looks up once and shares across its reels
+type batchRun struct {
+	journal    *batchJournal
+	provenance *runProvenance // nil unless --provenance is set
+	estimators *estimators
+}
+
+// newBatchRun opens the journal in outputDir and prepares the lookups a
+// batch shares
+func newBatchRun(ctx context.Context, app *App, outputDir string) *batchRun {
+	run := &batchRun{journal: openBatchJournal(outputDir), estimators: newEstimators(app)}
+	if provenanceFlag {
+		run.provenance = newRunProvenance(ctx, app)
+	}
+	return run
+}
+
+// processBatch transcribes reelIDs into outputDir. rows holds the options
+// CSV rows set for their reels, and may be nil. A resumed batch appends to
+// the journal in outputDir rather than starting a new one.
+func processBatch(ctx context.Context, app *App, reelIDs []string, outputDir string, rows map[string]batchRow, resume bool) error {
 	total := len(reelIDs)
+	startedAt := time.Now()
+
+	// batch, batch retry and accounts all start here, so each has its models
+	downloading := ""
+	if err := downloadModels(ctx, app, transcriptionModels(app.Config, modelFlag), func(m string, d, t int64) {
+		if quietFlag || t <= 0 {
+			return
+		}
+		if m != downloading {
+			downloading = m
+			fmt.Printf("Downloading model '%s'...\n", m)
+		}
+		fmt.Printf("\rProgress: %.1f%% (%s / %s)", float64(d)/float64(t)*100, tui.FormatSize(d), tui.FormatSize(t))
+	}); err != nil {
+		return err
+	}
+	if downloading != "" {
+		fmt.Println()
+	}
+
+	// A fresh run starts a new journal; a resumed one appends to it
+	run := newBatchRun(ctx, app, outputDir)
+	journal := run.journal
+	if !resume {
+		if err := journal.store.Reset(ctx); err != nil {
+			return fmt.Errorf("failed to reset batch journal: %w", err)
+		}
+	}
+	for _, id := range reelIDs {
+		journal.record(ctx, id, domain.JobQueued, "", "")
+	}
+
 	progress := tui.NewBatchProgress(total, quietFlag)
+	outputNames.reset()
+	if !quietFlag {
+		estimates := make(map[string]time.Duration, total)
+		for _, e := range estimateReels(ctx, app, run.estimators.forModel(ctx, modelFlag), reelIDs, false) {
+			estimates[e.ReelID] = e.Time
+		}
+		progress.SetEstimates(estimates)
+	}
 
 	// Results collection with mutex
 	var results []BatchResult
@@ -108,7 +249,7 @@ func processBatch(ctx context.Context, app *App, reelIDs []string, outputDir str
 			defer wg.Done()
 			defer func() { <-sem }() // Release semaphore
 
-			result := processOneReel(ctx, app, id, outputDir)
+			result := processOneReel(ctx, app, id, outputDir, rows[id], run)
 
 			// Thread-safe result collection
 			resultsMu.Lock()
@@ -124,6 +265,15 @@ func processBatch(ctx context.Context, app *App, reelIDs []string, outputDir str
 
 	// Print completion summary
 	progress.Complete()
+	if !quietFlag {
+		writeCacheMetrics(os.Stdout, batchCacheMetrics(results))
+	}
+
+	if provenanceFlag {
+		if _, err := writeManifest(outputDir, startedAt, results); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+	}
 
 	// Return error if any failed
 	failCount := countFailed(results)
@@ -134,57 +284,119 @@ func processBatch(ctx context.Context, app *App, reelIDs []string, outputDir str
 	return nil
 }
 
-func processOneReel(ctx context.Context, app *App, reelID string, outputDir string) BatchResult {
+// processOneReel transcribes reelID for the batch run
+func processOneReel(ctx context.Context, app *App, reelID string, outputDir string, row batchRow, run *batchRun) BatchResult {
+	journal := run.journal
 	start := time.Now()
+	var result *application.TranscribeResult
 
 	makeResult := func(success bool, errMsg string, cached bool) BatchResult {
-		return BatchResult{
+		if !success {
+			journal.record(ctx, reelID, domain.JobFailed, errMsg, "")
+		}
+		batchResult := BatchResult{
 			ReelID:   reelID,
 			Success:  success,
 			Error:    errMsg,
 			Duration: time.Since(start),
 			Cached:   cached,
 		}
+		if result != nil {
+			batchResult.AudioCached = result.AudioFromCache
+			batchResult.TimeSaved = result.TimeSaved
+		}
+		return batchResult
 	}
 
 	opts := application.TranscribeOptions{
 		Model:         modelFlag,
 		NoCache:       noCacheFlag,
 		Language:      languageFlag,
+		Prompt:        promptFlag,
 		SaveAudio:     audioFlag,
 		SaveVideo:     videoFlag,
 		SaveThumbnail: thumbnailFlag,
 	}
+	if row.Language != "" {
+		opts.Language = row.Language
+	}
+	opts.OnStage = func(state domain.JobState) {
+		journal.record(ctx, reelID, state, "", "")
+	}
+	if err := applyTranscribeDefaults(&opts, app.Config); err != nil {
+		return makeResult(false, err.Error(), false)
+	}
 
 	result, err := app.TranscribeSvc.Transcribe(ctx, reelID, opts)
+	recordRun(ctx, app, reelID, opts.Model, opts.Language, start, result, err)
 	if err != nil {
+		recordRateLimit(ctx, app, err)
 		return makeResult(false, err.Error(), false)
 	}
+	run.estimators.attachTimeSaved(ctx, result)
+
+	if run.provenance != nil {
+		result.Provenance = run.provenance.collect(result, start)
+	}
+
+	baseName := reelID
+	switch {
+	case row.Name != "":
+		baseName = outputNames.claim(row.Name, reelID)
+	case outputTemplateFlag != "" && result.Reel != nil:
+		baseName = outputNames.claim(templateBaseName(result.Reel), reelID)
+	}
+
+	formats := formatFlag
+	if row.Formats != "" {
+		formats = row.Formats
+	}
+	transcriptPaths, _, err := writeOutputFormats(result, app.Config, formats, outputDir, baseName)
+	if err != nil {
+		return makeResult(false, err.Error(), result.TranscriptFromCache)
+	}
 
-	transcriptContent, ext := formatTranscript(result.Transcript)
-	transcriptPath := filepath.Join(outputDir, reelID+"."+ext)
-	if err := os.WriteFile(transcriptPath, []byte(transcriptContent), 0644); err != nil {
-		return makeResult(false, fmt.Sprintf("failed to write transcript: %v", err), result.TranscriptFromCache)
+	if compareCaptionsFlag {
+		if _, err := writeCaptionComparison(ctx, app, reelID, result, outputDir, baseName); err != nil {
+			return makeResult(false, err.Error(), result.TranscriptFromCache)
+		}
+	}
+
+	if captionBundleFlag {
+		if _, err := writeCaptionBundle(result, app.Config, outputDir, baseName); err != nil {
+			return makeResult(false, err.Error(), result.TranscriptFromCache)
+		}
+	}
+
+	if commentsFlag > 0 {
+		if _, err := writeComments(ctx, app, reelID, outputDir, baseName); err != nil {
+			recordRateLimit(ctx, app, err)
+			return makeResult(false, err.Error(), result.TranscriptFromCache)
+		}
 	}
 
 	// Copy requested media files
+	if audioFlag && result.AudioPath != "" {
+		format, _ := audioFormat(app.Config)
+		if _, err := saveAudio(ctx, app.Downloader.GetFFmpegPath(), format, result.AudioPath, filepath.Join(outputDir, baseName+"."+format)); err != nil {
+			return makeResult(false, fmt.Sprintf("failed to save audio: %v", err), result.TranscriptFromCache)
+		}
+	}
 	mediaFiles := []struct {
 		enabled bool
 		srcPath string
 		dstName string
 		label   string
 	}{
-		{audioFlag, result.AudioPath, reelID + ".wav", "audio"},
-		{videoFlag, result.VideoPath, reelID + ".mp4", "video"},
-		{thumbnailFlag, result.ThumbnailPath, reelID + ".jpg", "thumbnail"},
+		{videoFlag, result.VideoPath, baseName + ".mp4", "video"},
+		{thumbnailFlag, result.ThumbnailPath, baseName + ".jpg", "thumbnail"},
 	}
 
 	for _, media := range mediaFiles {
 		if !media.enabled || media.srcPath == "" {
 			continue
 		}
-		dstPath := filepath.Join(outputDir, media.dstName)
-		if err := copyFile(media.srcPath, dstPath); err != nil {
+		if _, err := copyOutput(media.srcPath, filepath.Join(outputDir, media.dstName)); err != nil {
 			return makeResult(false, fmt.Sprintf("failed to copy %s: %v", media.label, err), result.TranscriptFromCache)
 		}
 	}
@@ -193,7 +405,11 @@ func processOneReel(ctx context.Context, app *App, reelID string, outputDir stri
 		cleanupCacheMedia(ctx, app, reelID, result)
 	}
 
-	return makeResult(true, "", result.TranscriptFromCache)
+	batchResult := makeResult(true, "", result.TranscriptFromCache)
+	batchResult.Output = transcriptPaths[0]
+	journal.record(ctx, reelID, domain.JobDone, "", batchResult.Output)
+	batchResult.Provenance = result.Provenance
+	return batchResult
 }
 
 // cleanupCacheMedia deletes audio/video/thumbnail from cache and updates cache entry
@@ -226,6 +442,7 @@ func cleanupCacheMedia(ctx context.Context, app *App, reelID string, result *app
 		AudioPath:     "", // Cleared
 		VideoPath:     "", // Cleared
 		ThumbnailPath: "", // Cleared
+		RawOutputPath: cached.RawOutputPath,
 		CreatedAt:     cached.CreatedAt,
 		ExpiresAt:     cached.ExpiresAt,
 	}
@@ -242,11 +459,3 @@ func countFailed(results []BatchResult) int {
 	}
 	return count
 }
-
-// formatTranscript returns the transcript content and file extension based on formatFlag
-func formatTranscript(transcript *domain.Transcript) (content, ext string) {
-	if formatFlag == "srt" {
-		return transcript.ToSRT(), "srt"
-	}
-	return transcript.ToText(), "txt"
-}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// batchRow is what a row of a CSV batch file sets for its reel. Empty
+// fields fall back to the flags.
+type batchRow struct {
+	Name     string // base filename
+	Language string
+	Formats  string // comma-separated, like --format
+}
+
+// csvColumns are the columns a CSV batch file may have; url is required
+var csvColumns = []string{"url", "name", "language", "formats"}
+
+// isCSVInput reports whether the batch file at path is read as CSV
+func isCSVInput(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// ParseInputCSVFile reads a CSV batch file. Its header names the columns:
+// url (a reel URL or ID) and optionally name, language and formats, which
+// override --name, --language and --format for that row's reel. Blank rows
+// are skipped; a reel listed twice keeps its first row.
+// Returns the reel IDs in order and the options of the rows that set any.
+func ParseInputCSVFile(ctx context.Context, inputs *application.ReelInputService, path string) ([]string, map[string]batchRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+	return parseInputCSV(ctx, inputs, file)
+}
+
+// parseInputCSV reads a CSV batch file from r as ParseInputCSVFile describes
+func parseInputCSV(ctx context.Context, inputs *application.ReelInputService, r io.Reader) ([]string, map[string]batchRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(csvColumns, name) {
+			return nil, nil, fmt.Errorf("unknown column %q (expected %s)", name, strings.Join(csvColumns, ", "))
+		}
+		if _, dup := columns[name]; dup {
+			return nil, nil, fmt.Errorf("column %q appears twice", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, nil, fmt.Errorf("missing url column")
+	}
+
+	var ids []string
+	rows := make(map[string]batchRow)
+	seen := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		input := field("url")
+		if input == "" {
+			if strings.TrimSpace(strings.Join(record, "")) == "" {
+				continue
+			}
+			return nil, nil, fmt.Errorf("line %d: missing url", line)
+		}
+		if _, ok := domain.ParseYouTubePlaylist(input); ok {
+			// A row's options are for one video
+			return nil, nil, fmt.Errorf("line %d: playlists aren't supported in CSV files; list %s in a plain --file or on the command line", line, input)
+		}
+		reel, err := inputs.Normalize(ctx, input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		row, err := parseBatchRow(field("name"), field("language"), field("formats"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if seen[reel.ID] {
+			continue
+		}
+		seen[reel.ID] = true
+		ids = append(ids, reel.ID)
+		if row != (batchRow{}) {
+			rows[reel.ID] = row
+		}
+	}
+	return ids, rows, nil
+}
+
+// parseBatchRow checks a CSV row's options
+func parseBatchRow(name, language, formats string) (batchRow, error) {
+	if name != "" {
+		sanitized := sanitizeFilename(name)
+		if sanitized == "" {
+			return batchRow{}, fmt.Errorf("invalid name %q", name)
+		}
+		name = sanitized
+	}
+	if language != "" && language != tui.AutoLanguage && domain.LanguageName(language) == language {
+		return batchRow{}, fmt.Errorf("unknown language %q", language)
+	}
+	if formats != "" {
+		if _, err := parseFormats(formats); err != nil {
+			return batchRow{}, err
+		}
+	}
+	return batchRow{Name: name, Language: language, Formats: formats}, nil
+}
+
+// collectBatchInputs collects batch's reels like CollectInputs, reading a
+// CSV file's reels after the args along with their rows' options. The CSV
+// IDs are already normalized, so they're merged as they are: local files and
+// --any-url URLs only get their IDs from the original input.
+func collectBatchInputs(ctx context.Context, inputs *application.ReelInputService, args []string, filePath string) ([]string, map[string]batchRow, error) {
+	if !isCSVInput(filePath) {
+		ids, err := CollectInputs(ctx, inputs, args, filePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to collect inputs: %w", err)
+		}
+		return ids, nil, nil
+	}
+
+	ids, err := CollectInputs(ctx, inputs, args, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to collect inputs: %w", err)
+	}
+	csvIDs, rows, err := ParseInputCSVFile(ctx, inputs, filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	for _, id := range csvIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, rows, nil
+}
@@ -2,24 +2,49 @@ package cli
 
 import (
 	"bufio"
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/devbush/ig2insights/internal/application"
 	"github.com/devbush/ig2insights/internal/domain"
 )
 
-// ParseInputFile reads a file containing URLs or IDs, one per line.
-// Blank lines and lines starting with # are ignored.
+// maxInputLineSize is the longest line ParseInputFile reads
+const maxInputLineSize = 4 << 20
+
+// stdinInput is the file named "-" reads, so URLs can be piped in. A
+// variable so tests can substitute a fake.
+var stdinInput io.Reader = os.Stdin
+
+// ParseInputFile reads a file containing URLs or IDs, one per line, or
+// standard input when path is "-".
+// Blank lines and lines starting with # are ignored. YouTube playlist and
+// channel URLs contribute their videos, and fail the read when they can't be
+// listed. Other lines that are not a URL or ID on their own, such as
+// Markdown notes or the entries of a browser's HTML bookmark export,
+// contribute the reel URLs embedded in them.
 // Returns a slice of reel IDs (extracted from URLs if needed).
-func ParseInputFile(path string) ([]string, error) {
+func ParseInputFile(ctx context.Context, inputs *application.ReelInputService, path string) ([]string, error) {
+	if path == "-" {
+		return parseInputs(ctx, inputs, stdinInput)
+	}
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
+	return parseInputs(ctx, inputs, file)
+}
 
+// parseInputs reads reel IDs from r as ParseInputFile describes
+func parseInputs(ctx context.Context, inputs *application.ReelInputService, r io.Reader) ([]string, error) {
 	var ids []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
+	// Bookmark exports inline favicons, making some lines very long
+	scanner.Buffer(make([]byte, 0, 64*1024), maxInputLineSize)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
@@ -28,14 +53,24 @@ func ParseInputFile(path string) ([]string, error) {
 			continue
 		}
 
-		// Parse the input to extract the reel ID
-		reel, err := domain.ParseReelInput(line)
-		if err != nil {
-			// Skip invalid lines
+		// Parse the input to extract the reel IDs
+		reels, err := inputs.Expand(ctx, line)
+		if err == nil {
+			for _, reel := range reels {
+				ids = append(ids, reel.ID)
+			}
 			continue
 		}
+		if _, ok := domain.ParseYouTubePlaylist(line); ok {
+			return nil, err
+		}
 
-		ids = append(ids, reel.ID)
+		// Otherwise look for links in the text, skipping non-reel pages
+		for _, u := range domain.ExtractInstagramURLs(line) {
+			if reel, err := inputs.Normalize(ctx, u); err == nil {
+				ids = append(ids, reel.ID)
+			}
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -46,15 +81,29 @@ func ParseInputFile(path string) ([]string, error) {
 }
 
 // CollectInputs combines CLI arguments and file input, deduplicating.
-// Args are processed first, then file entries.
+// Args are processed first, then file entries. An argument or file of "-"
+// reads standard input.
 // Returns a slice of unique reel IDs in order of first appearance.
-func CollectInputs(args []string, filePath string) ([]string, error) {
+func CollectInputs(ctx context.Context, inputs *application.ReelInputService, args []string, filePath string) ([]string, error) {
 	seen := make(map[string]bool)
 	var ids []string
 
-	// Process CLI args first
+	// Process CLI args first; "-" reads more from standard input in its place
 	for _, arg := range args {
-		reel, err := domain.ParseReelInput(arg)
+		if arg == "-" {
+			stdinIDs, err := ParseInputFile(ctx, inputs, "-")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read standard input: %w", err)
+			}
+			for _, id := range stdinIDs {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+			continue
+		}
+		reel, err := inputs.Normalize(ctx, arg)
 		if err != nil {
 			continue
 		}
@@ -66,7 +115,7 @@ func CollectInputs(args []string, filePath string) ([]string, error) {
 
 	// Process file if provided
 	if filePath != "" {
-		fileIDs, err := ParseInputFile(filePath)
+		fileIDs, err := ParseInputFile(ctx, inputs, filePath)
 		if err != nil {
 			return nil, err
 		}
@@ -80,3 +129,45 @@ func CollectInputs(args []string, filePath string) ([]string, error) {
 
 	return ids, nil
 }
+
+// CollectAccounts combines CLI arguments and an optional file of usernames or
+// profile URLs (one per line), deduplicating. Blank lines, comments, and
+// invalid entries are skipped.
+func CollectAccounts(args []string, filePath string) ([]string, error) {
+	inputs := append([]string{}, args...)
+
+	if filePath != "" {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			inputs = append(inputs, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, input := range inputs {
+		account, err := domain.ParseAccountInput(input)
+		if err != nil {
+			continue
+		}
+		if !seen[account.Username] {
+			seen[account.Username] = true
+			usernames = append(usernames, account.Username)
+		}
+	}
+
+	return usernames, nil
+}
@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
 )
 
 func TestParseInputFile(t *testing.T) {
@@ -24,7 +30,7 @@ XYZ999
 			t.Fatalf("failed to create test file: %v", err)
 		}
 
-		ids, err := ParseInputFile(filePath)
+		ids, err := ParseInputFile(context.Background(), application.NewReelInputService(nil), filePath)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -41,8 +47,35 @@ XYZ999
 		}
 	})
 
+	t.Run("extracts reel links from Markdown and HTML bookmarks", func(t *testing.T) {
+		content := `# Hooks to study
+
+- Great opener: [this one](https://www.instagram.com/reel/MD1234/?igsh=abc).
+- Compare with <https://instagram.com/p/MD5678/> and the creator's profile https://www.instagram.com/someone/
+
+<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><A HREF="https://www.instagram.com/reel/BM1234/?utm_source=ig&amp;igsh=x" ADD_DATE="1700000000">Reel</A>
+    <DT><A HREF="https://example.com/reel/NOTIG1/">Elsewhere</A>
+</DL><p>
+`
+		filePath := filepath.Join(t.TempDir(), "notes.md")
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		ids, err := ParseInputFile(context.Background(), application.NewReelInputService(nil), filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{"MD1234", "MD5678", "BM1234"}
+		if strings.Join(ids, ",") != strings.Join(expected, ",") {
+			t.Errorf("ids = %v, want %v", ids, expected)
+		}
+	})
+
 	t.Run("returns error for nonexistent file", func(t *testing.T) {
-		_, err := ParseInputFile("/nonexistent/path/file.txt")
+		_, err := ParseInputFile(context.Background(), application.NewReelInputService(nil), "/nonexistent/path/file.txt")
 		if err == nil {
 			t.Error("expected error for nonexistent file, got nil")
 		}
@@ -65,7 +98,7 @@ GHI789
 		// Args include ABC123 which is also in file (should be deduplicated)
 		args := []string{"ABC123", "https://instagram.com/p/NEW001/"}
 
-		ids, err := CollectInputs(args, filePath)
+		ids, err := CollectInputs(context.Background(), application.NewReelInputService(nil), args, filePath)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -83,10 +116,31 @@ GHI789
 		}
 	})
 
+	t.Run("reads standard input for -", func(t *testing.T) {
+		old := stdinInput
+		defer func() { stdinInput = old }()
+		stdinInput = strings.NewReader("# from grep\nhttps://www.instagram.com/reel/DEF456/\nABC123\n")
+
+		args := []string{"ABC123", "-", "GHI789"}
+		ids, err := CollectInputs(context.Background(), application.NewReelInputService(nil), args, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := strings.Join(ids, ","); got != "ABC123,DEF456,GHI789" {
+			t.Errorf("CollectInputs() = %s, want stdin's IDs in place of -", got)
+		}
+
+		stdinInput = strings.NewReader("JKL012\n")
+		ids, err = CollectInputs(context.Background(), application.NewReelInputService(nil), nil, "-")
+		if err != nil || strings.Join(ids, ",") != "JKL012" {
+			t.Errorf("CollectInputs(--file -) = %v, %v; want JKL012", ids, err)
+		}
+	})
+
 	t.Run("works with args only when filePath is empty", func(t *testing.T) {
 		args := []string{"ABC123", "https://www.instagram.com/reel/DEF456/"}
 
-		ids, err := CollectInputs(args, "")
+		ids, err := CollectInputs(context.Background(), application.NewReelInputService(nil), args, "")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -103,3 +157,106 @@ GHI789
 		}
 	})
 }
+
+func TestParseInputCSV(t *testing.T) {
+	inputs := application.NewReelInputService(nil)
+
+	t.Run("reads per-row options", func(t *testing.T) {
+		content := "URL, Name, Language, Formats\n" +
+			"https://www.instagram.com/reel/ABC123/,Interview: part 1,fr,\"srt,text\"\n" +
+			"\n" +
+			"DEF456,,,\n" +
+			"ABC123,duplicate,en,json\n"
+
+		ids, rows, err := parseInputCSV(context.Background(), inputs, strings.NewReader(content))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Join(ids, ",") != "ABC123,DEF456" {
+			t.Errorf("ids = %v, want [ABC123 DEF456]", ids)
+		}
+		want := batchRow{Name: "Interview_ part 1", Language: "fr", Formats: "srt,text"}
+		if rows["ABC123"] != want {
+			t.Errorf("rows[ABC123] = %+v, want %+v", rows["ABC123"], want)
+		}
+		if _, ok := rows["DEF456"]; ok {
+			t.Errorf("rows[DEF456] set for a row without options")
+		}
+	})
+
+	for _, tt := range []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown column", "url,lang\nABC123,fr\n", `unknown column "lang"`},
+		{"missing url column", "name\nreport\n", "missing url column"},
+		{"missing url", "url,name\n,report\n", "line 2: missing url"},
+		{"unknown format", "url,formats\nABC123,xyz\n", "line 2:"},
+		{"unknown language", "url,language\nABC123,klingon\n", `line 2: unknown language "klingon"`},
+		{"playlist", "url\nhttps://www.youtube.com/@creator\n", "line 2: playlists aren't supported in CSV files"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseInputCSV(context.Background(), inputs, strings.NewReader(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCollectBatchInputs_CSV(t *testing.T) {
+	dir := t.TempDir()
+	audio := filepath.Join(dir, "talk.mp3")
+	if err := os.WriteFile(audio, []byte("audio"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	csvPath := filepath.Join(dir, "reels.csv")
+	content := "url,name,language\n" + audio + ",talk,de\nDEF456,,\n"
+	if err := os.WriteFile(csvPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := application.NewReelInputService(nil)
+	inputs.AllowLocalFiles(ytdlp.NewDownloader())
+	ids, rows, err := collectBatchInputs(context.Background(), inputs, []string{"ABC123", "DEF456"}, csvPath)
+	if err != nil {
+		t.Fatalf("collectBatchInputs() error = %v", err)
+	}
+
+	if len(ids) != 3 || ids[0] != "ABC123" || ids[1] != "DEF456" || !domain.IsLocalFileID(ids[2]) {
+		t.Fatalf("ids = %v, want ABC123, DEF456 and the local file", ids)
+	}
+	if want := (batchRow{Name: "talk", Language: "de"}); rows[ids[2]] != want {
+		t.Errorf("local file row = %+v, want %+v", rows[ids[2]], want)
+	}
+}
+
+func TestCollectAccounts(t *testing.T) {
+	content := `# competitors
+https://www.instagram.com/alice/
+@bob
+
+not a valid username!
+alice
+`
+	filePath := filepath.Join(t.TempDir(), "accounts.txt")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	usernames, err := CollectAccounts([]string{"carol"}, filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"carol", "alice", "bob"}
+	if len(usernames) != len(expected) {
+		t.Fatalf("expected %d usernames, got %d: %v", len(expected), len(usernames), usernames)
+	}
+	for i, u := range usernames {
+		if u != expected[i] {
+			t.Errorf("expected username[%d] = %q, got %q", i, expected[i], u)
+		}
+	}
+}
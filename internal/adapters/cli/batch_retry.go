@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/devbush/ig2insights/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// newBatchRetryCmd creates the batch retry subcommand
+func newBatchRetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry [reel-urls-or-ids...]",
+		Short: "Process again only the reels that failed in the last batch",
+		Long: `Process again the reels the batch journal in the output directory (--dir,
+default the current directory) records as failed. Reels that succeeded are
+left alone. Change what made them fail with the usual flags:
+
+  ig2insights batch retry --dir ./output --model medium
+  ig2insights batch retry --download-backoff 30s --concurrency 2
+
+The journal only keeps reel IDs. Local files and --any-url URLs can't be
+fetched again from their IDs, and CSV rows' options aren't kept, so pass the
+batch's inputs again to retry those:
+
+  ig2insights batch retry --file reels.csv`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runBatchRetry,
+	}
+
+	cmd.Flags().StringVarP(&batchFileFlag, "file", "f", "", "The batch's file with URLs/IDs, or its CSV file, to retry local files, URLs and row options")
+	cmd.Flags().IntVarP(&batchConcurrency, "concurrency", "c", 10, "Max concurrent workers (max 50)")
+	cmd.Flags().BoolVar(&batchNoSaveMedia, "no-save-media", false, "Don't save audio/video to cache after processing")
+	cmd.Flags().BoolVar(&batchIgnoreLimits, "ignore-limits", false, "Start even if a recent rate limit suggests waiting")
+
+	return cmd
+}
+
+func runBatchRetry(cmd *cobra.Command, args []string) error {
+	if _, err := parseFormats(formatFlag); err != nil {
+		return err
+	}
+
+	app, err := GetApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	batchConcurrency = resolveConcurrency(cmd, app)
+
+	ctx := context.Background()
+	outputDir := batchOutputDir()
+	failed, err := failedReels(ctx, openBatchJournal(outputDir))
+	if err != nil {
+		return err
+	}
+	given, rows, err := collectBatchInputs(ctx, app.InputSvc, args, batchFileFlag)
+	if err != nil {
+		return err
+	}
+	reelIDs, missing := retryReels(failed, given)
+	for _, id := range missing {
+		fmt.Fprintf(os.Stderr, "Warning: skipping %s: pass its file or URL, or the batch's --file, to retry it\n", id)
+	}
+	if len(reelIDs) == 0 && len(missing) > 0 {
+		return fmt.Errorf("none of the %d failed reels can be retried without their inputs", len(missing))
+	}
+	if len(reelIDs) == 0 {
+		if !quietFlag {
+			fmt.Println("Nothing to retry: no reel in the journal failed")
+		}
+		return nil
+	}
+	if !quietFlag {
+		fmt.Printf("Retrying %d failed reels\n", len(reelIDs))
+	}
+
+	if !batchIgnoreLimits {
+		if err := checkCooldown(ctx, app); err != nil {
+			return err
+		}
+	}
+
+	// Retries are appended to the journal, like a resumed batch, so the
+	// reels that succeeded before stay recorded
+	return processBatch(ctx, app, reelIDs, outputDir, rows, true)
+}
+
+// retryReels splits the failed reels into those a retry can process and
+// those it can't: local files and generic URLs are only known through their
+// original input, so they're retried only when given again
+func retryReels(failed, given []string) (retry, missing []string) {
+	for _, id := range failed {
+		if (domain.IsLocalFileID(id) || domain.IsGenericID(id)) && !slices.Contains(given, id) {
+			missing = append(missing, id)
+			continue
+		}
+		retry = append(retry, id)
+	}
+	return retry, missing
+}
@@ -1,6 +1,10 @@
 package cli
 
-import "time"
+import (
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
 
 // BatchResult represents the result of processing a single reel in a batch
 type BatchResult struct {
@@ -9,6 +13,12 @@ type BatchResult struct {
 	Error    string
 	Duration time.Duration
 	Cached   bool // true if transcript was from cache
+
+	AudioCached bool          // true if audio was from cache
+	TimeSaved   time.Duration // estimated time the cache hits saved
+
+	Output     string             // written transcript path
+	Provenance *domain.Provenance // set when --provenance is enabled
 }
 
 // BatchSummary aggregates results from a batch run
@@ -29,3 +39,14 @@ func (s *BatchSummary) FailedResults() []BatchResult {
 	}
 	return failed
 }
+
+// batchCacheMetrics counts how the cache served the reels that succeeded
+func batchCacheMetrics(results []BatchResult) domain.CacheMetrics {
+	var m domain.CacheMetrics
+	for _, r := range results {
+		if r.Success {
+			m.Add(r.Cached, r.AudioCached, r.TimeSaved)
+		}
+	}
+	return m
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// benchTimeout bounds each host's test download
+	benchTimeout = 20 * time.Second
+
+	// slowThroughput is the speed below which a connection counts as slow
+	slowThroughput = 256 * tui.KB
+
+	benchUserAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+)
+
+// benchTarget is a host bench-network downloads a sample from
+type benchTarget struct {
+	Name  string
+	URL   string
+	Bytes int64 // requested with a Range header; hosts that ignore it are cut off here
+}
+
+// benchTargets are the hosts ig2insights downloads from: reels from
+// Instagram, whisper models from Hugging Face, binaries from GitHub
+var benchTargets = []benchTarget{
+	{"Instagram", "https://www.instagram.com/", 256 * tui.KB},
+	{"Hugging Face", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.bin", 4 * tui.MB},
+	{"GitHub", "https://github.com/yt-dlp/yt-dlp/releases/latest/download/yt-dlp", 2 * tui.MB},
+}
+
+// mirroredBenchTargets returns benchTargets with the configured mirrors
+// tested in place of the hosts they replace
+func mirroredBenchTargets(mirrors config.MirrorsConfig) []benchTarget {
+	targets := append([]benchTarget(nil), benchTargets...)
+	for i, target := range targets {
+		switch {
+		case target.Name == "Hugging Face" && mirrors.Models != "":
+			targets[i] = benchTarget{"Model mirror", strings.TrimSuffix(mirrors.Models, "/") + "/ggml-tiny.bin", target.Bytes}
+		case target.Name == "GitHub" && mirrors.YtDlp != "":
+			targets[i] = benchTarget{"yt-dlp mirror", strings.TrimSuffix(mirrors.YtDlp, "/") + "/yt-dlp", target.Bytes}
+		}
+	}
+	return targets
+}
+
+// benchResult is the outcome of one test download
+type benchResult struct {
+	Target   benchTarget
+	Status   int           // HTTP status, zero when the request failed
+	Bytes    int64         // body bytes read
+	Latency  time.Duration // time to response headers
+	Duration time.Duration // time to read the body
+	Err      error
+}
+
+// throughput returns the body download speed in bytes per second
+func (r benchResult) throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Bytes) / r.Duration.Seconds()
+}
+
+// ok reports whether the host served the sample
+func (r benchResult) ok() bool {
+	return r.Err == nil && r.Status >= 200 && r.Status < 300
+}
+
+// NewBenchNetworkCmd creates the bench-network command
+func NewBenchNetworkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "bench-network",
+		Short: "Measure download speed from Instagram, Hugging Face and GitHub",
+		Long: `Download a small sample from each host ig2insights depends on and report
+latency and throughput. Use it to tell Instagram blocking requests apart
+from a slow connection before filing a bug report. Requests go through the
+configured proxy.`,
+		Args: cobra.NoArgs,
+		RunE: runBenchNetwork,
+	}
+}
+
+func runBenchNetwork(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	client := http.DefaultClient
+	proxy := app.Proxy
+	if proxy != "" {
+		client = proxyHTTPClient(proxy)
+	}
+
+	fmt.Println()
+	fmt.Println("Network Benchmark:")
+	if proxy != "" {
+		fmt.Printf("  (via proxy %s)\n", displayProxy(proxy))
+	}
+	fmt.Println()
+
+	targets := mirroredBenchTargets(app.Config.Mirrors)
+	results := make([]benchResult, 0, len(targets))
+	for _, target := range targets {
+		r := benchDownload(cmd.Context(), client, target)
+		results = append(results, r)
+		fmt.Println("  " + formatBenchResult(r))
+	}
+
+	fmt.Println()
+	fmt.Println("  " + diagnoseBench(results))
+	fmt.Println()
+	return nil
+}
+
+// benchDownload fetches up to target.Bytes from target.URL, timing the
+// response headers and the body separately
+func benchDownload(ctx context.Context, client *http.Client, target benchTarget) benchResult {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, benchTimeout)
+	defer cancel()
+
+	result := benchResult{Target: target}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL, nil)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	req.Header.Set("User-Agent", benchUserAgent)
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", target.Bytes-1))
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	result.Latency = time.Since(start)
+	result.Status = resp.StatusCode
+
+	bodyStart := time.Now()
+	result.Bytes, result.Err = io.Copy(io.Discard, io.LimitReader(resp.Body, target.Bytes))
+	result.Duration = time.Since(bodyStart)
+	return result
+}
+
+// formatBenchResult renders one host's line of the report
+func formatBenchResult(r benchResult) string {
+	switch {
+	case r.Status == 0:
+		return fmt.Sprintf("%-13s failed: %v", r.Target.Name, r.Err)
+	case !r.ok():
+		return fmt.Sprintf("%-13s HTTP %d after %s", r.Target.Name, r.Status, r.Latency.Round(time.Millisecond))
+	}
+	line := fmt.Sprintf("%-13s %4d ms latency  %10s/s  (%s)",
+		r.Target.Name, r.Latency.Milliseconds(), tui.FormatSize(int64(r.throughput())), tui.FormatSize(r.Bytes))
+	if r.Err != nil {
+		line += fmt.Sprintf("  interrupted: %v", r.Err)
+	}
+	return line
+}
+
+// diagnoseBench sums up what the results say about the connection
+func diagnoseBench(results []benchResult) string {
+	var instagram *benchResult
+	othersOK, othersFailed, slow := 0, 0, 0
+	for i := range results {
+		r := &results[i]
+		if r.Target.Name == "Instagram" {
+			instagram = r
+			continue
+		}
+		if !r.ok() {
+			othersFailed++
+			continue
+		}
+		othersOK++
+		if r.Bytes >= r.Target.Bytes/2 && r.throughput() < slowThroughput {
+			slow++
+		}
+	}
+
+	switch {
+	case othersOK == 0 && othersFailed > 0 && (instagram == nil || !instagram.ok()):
+		return "No host could be reached: check your connection or proxy."
+	case instagram != nil && (instagram.Status == http.StatusTooManyRequests || instagram.Status == http.StatusForbidden):
+		return fmt.Sprintf("Instagram answered HTTP %d while other hosts work: Instagram is blocking or rate-limiting you. Wait, log in (auth login) or use a throttle profile.", instagram.Status)
+	case instagram != nil && !instagram.ok() && othersOK > 0:
+		return "Instagram could not be reached while other hosts work: Instagram or your network is blocking it."
+	case slow > 0:
+		return fmt.Sprintf("Your connection is slow (under %s/s): downloads will take a while, but nothing is being blocked.", tui.FormatSize(slowThroughput))
+	case othersFailed > 0:
+		return "Instagram is reachable, but model or binary downloads fail: installs and model downloads may not work."
+	}
+	return "All hosts are reachable at normal speed."
+}
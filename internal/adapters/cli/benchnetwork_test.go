@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+func TestBenchDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "bytes=0-1023" {
+			t.Errorf("Range = %q", r.Header.Get("Range"))
+		}
+		// Ignore the range, as some hosts do
+		w.Write(make([]byte, 4096))
+	}))
+	defer server.Close()
+
+	r := benchDownload(context.Background(), server.Client(), benchTarget{Name: "Test", URL: server.URL, Bytes: 1024})
+	if !r.ok() || r.Bytes != 1024 {
+		t.Errorf("result = %+v, want 1024 bytes read", r)
+	}
+	if !strings.Contains(formatBenchResult(r), "ms latency") {
+		t.Errorf("formatBenchResult() = %q", formatBenchResult(r))
+	}
+}
+
+func TestDiagnoseBench(t *testing.T) {
+	instagram := func(status int) benchResult {
+		return benchResult{Target: benchTarget{Name: "Instagram", Bytes: 1000}, Status: status, Bytes: 1000, Duration: time.Millisecond}
+	}
+	fast := benchResult{Target: benchTarget{Name: "GitHub", Bytes: 1000}, Status: 206, Bytes: 1000, Duration: time.Millisecond}
+	slow := benchResult{Target: benchTarget{Name: "GitHub", Bytes: 1000}, Status: 200, Bytes: 1000, Duration: time.Second}
+	down := benchResult{Target: benchTarget{Name: "GitHub"}, Err: errors.New("dial tcp: no route to host")}
+
+	tests := []struct {
+		name    string
+		results []benchResult
+		want    string
+	}{
+		{"healthy", []benchResult{instagram(200), fast}, "normal speed"},
+		{"blocked", []benchResult{instagram(429), fast}, "blocking or rate-limiting"},
+		{"unreachable instagram", []benchResult{{Target: benchTarget{Name: "Instagram"}, Err: errors.New("timeout")}, fast}, "Instagram could not be reached"},
+		{"slow", []benchResult{instagram(200), slow}, "connection is slow"},
+		{"offline", []benchResult{{Target: benchTarget{Name: "Instagram"}, Err: errors.New("timeout")}, down}, "No host could be reached"},
+	}
+	for _, tt := range tests {
+		if got := diagnoseBench(tt.results); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: diagnoseBench() = %q, want it to mention %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMirroredBenchTargets(t *testing.T) {
+	targets := mirroredBenchTargets(config.MirrorsConfig{Models: "https://mirror.example.com/models/"})
+	if len(targets) != len(benchTargets) {
+		t.Fatalf("mirroredBenchTargets() = %d targets, want %d", len(targets), len(benchTargets))
+	}
+	for i, target := range targets {
+		switch benchTargets[i].Name {
+		case "Hugging Face":
+			if target.URL != "https://mirror.example.com/models/ggml-tiny.bin" {
+				t.Errorf("model target URL = %s, want the mirror", target.URL)
+			}
+		default:
+			if target != benchTargets[i] {
+				t.Errorf("target %s changed without a mirror: %+v", benchTargets[i].Name, target)
+			}
+		}
+	}
+}
@@ -2,14 +2,37 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
 	"github.com/spf13/cobra"
 )
 
+// transcriptPreviewLen is how many characters of the transcript cache show
+// prints
+const transcriptPreviewLen = 300
+
 var clearAllFlag bool
 
+var verifyPurgeFlag bool
+
+var (
+	pruneOlderThanFlag  string
+	pruneLargerThanFlag string
+	pruneTypeFlag       []string
+)
+
+var warmFileFlag string
+
 // NewCacheCmd creates the cache subcommand
 func NewCacheCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -25,7 +48,109 @@ func NewCacheCmd() *cobra.Command {
 	}
 	clearCmd.Flags().BoolVar(&clearAllFlag, "all", false, "Clear all cache entries")
 
+	showCmd := &cobra.Command{
+		Use:   "show <reel-url|reel-id>",
+		Short: "Show a cached entry",
+		Long: `Print everything cached for a reel: its metadata, the start of the
+transcript, the cached files with their sizes, and when the entry was
+created and expires. Expired entries are shown too, and showing an entry
+doesn't count as a use.
+
+Example:
+  ig2insights cache show ABC123`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCacheShow,
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export <file.tar.gz>",
+		Short: "Export the cache to an archive",
+		Long: `Write every cached reel, with its transcript and media, to a
+gzip-compressed tar archive, to back the cache up or move it to another
+machine with cache import.
+
+Example:
+  ig2insights cache export cache.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCacheExport,
+	}
+
+	importCmd := &cobra.Command{
+		Use:   "import <file.tar.gz>",
+		Short: "Import a cache archive",
+		Long: `Read an archive written by cache export into the cache. Archived
+reels replace cached entries for the same reels; other entries are kept.
+
+Example:
+  ig2insights cache import cache.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCacheImport,
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check cached files for damage",
+		Long: `Check every cached file against the checksum recorded when it was
+cached, to find files truncated or corrupted by killed downloads or disk
+trouble. Damaged entries can then be purged, so the next run downloads and
+transcribes those reels again.
+
+Entries cached before checksums were recorded are only checked for
+unreadable metadata and empty files.`,
+		Args: cobra.NoArgs,
+		RunE: runCacheVerify,
+	}
+	verifyCmd.Flags().BoolVar(&verifyPurgeFlag, "purge", false, "Purge damaged entries without asking")
+
 	cmd.AddCommand(clearCmd)
+	cmd.AddCommand(showCmd)
+	cmd.AddCommand(exportCmd)
+	cmd.AddCommand(importCmd)
+	cmd.AddCommand(verifyCmd)
+
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete cached media, keeping transcripts",
+		Long: `Delete cached audio, video and thumbnails to reclaim space. Transcripts
+and metadata are kept, so cached reels are still transcribed instantly;
+media is downloaded again only when it's asked for.
+
+Example:
+  ig2insights cache prune --older-than 30d --larger-than 100MB --type video`,
+		Args: cobra.NoArgs,
+		RunE: runCachePrune,
+	}
+	pruneCmd.Flags().StringVar(&pruneOlderThanFlag, "older-than", "", "Only reels cached longer ago than this (e.g., 30d)")
+	pruneCmd.Flags().StringVar(&pruneLargerThanFlag, "larger-than", "", "Only files larger than this (e.g., 100MB)")
+	pruneCmd.Flags().StringSliceVar(&pruneTypeFlag, "type", []string{ports.CacheFileAudio, ports.CacheFileVideo, ports.CacheFileThumbnail},
+		"Kinds of files to delete: audio, video, thumbnail, raw")
+	cmd.AddCommand(pruneCmd)
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade cache entries written by older versions",
+		Long: `Upgrade cached entries written by older versions to the current format,
+filling in what they lack, such as checksums of their files. Older entries
+are still read without migrating; migrating lets them use newer features.`,
+		Args: cobra.NoArgs,
+		RunE: runCacheMigrate,
+	}
+	cmd.AddCommand(migrateCmd)
+
+	warmCmd := &cobra.Command{
+		Use:   "warm [reel-url|reel-id...]",
+		Short: "Download reels' audio ahead of transcribing",
+		Long: `Download the audio of a list of reels into the cache without
+transcribing them, so a later batch run is local CPU work only. Reels whose
+audio is already cached are skipped.
+
+Example:
+  ig2insights cache warm --file reels.txt
+  ig2insights batch --file reels.txt --offline`,
+		RunE: runCacheWarm,
+	}
+	warmCmd.Flags().StringVarP(&warmFileFlag, "file", "f", "", "File with URLs/IDs (one per line)")
+	cmd.AddCommand(warmCmd)
 
 	return cmd
 }
@@ -46,8 +171,15 @@ func runCacheStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println("Cache Statistics:")
 	fmt.Printf("  Items: %d\n", stats.ItemCount)
 	fmt.Printf("  Size:  %s\n", tui.FormatSize(stats.TotalSize))
-	fmt.Printf("  TTL:   %s\n", app.Config.Defaults.CacheTTL)
+	fmt.Printf("  TTL:   %s\n", cacheTTLLabel(app.Config))
+	if app.Config.Cache.TTL.Media != "" {
+		fmt.Printf("  Media: %s\n", app.Config.Cache.TTL.Media)
+	}
+	if app.CacheLimit > 0 {
+		fmt.Printf("  Limit: %s (least recently used reels are evicted beyond it)\n", tui.FormatSize(app.CacheLimit))
+	}
 	fmt.Println()
+	writeCacheBreakdown(os.Stdout, stats)
 
 	return nil
 }
@@ -75,3 +207,348 @@ func runCacheClear(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runCacheShow(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	reel, err := app.InputSvc.Normalize(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	item, dir, err := app.CacheSvc.Entry(ctx, reel.ID)
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return fmt.Errorf("%s is not cached", reel.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeCacheEntry(os.Stdout, reel.ID, dir, item, time.Now())
+}
+
+func runCacheExport(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	exported, err := app.CacheSvc.Export(context.Background(), f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(args[0])
+		return fmt.Errorf("failed to export cache: %w", err)
+	}
+
+	fmt.Printf("Exported %d entries to %s\n", exported, args[0])
+	return nil
+}
+
+func runCacheImport(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	imported, err := app.CacheSvc.Import(context.Background(), f)
+	if err != nil {
+		return fmt.Errorf("failed to import cache: %w", err)
+	}
+
+	fmt.Printf("Imported %d entries from %s\n", imported, args[0])
+	return nil
+}
+
+func runCacheVerify(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	problems, err := app.CacheSvc.Verify(ctx)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Println("No damaged cache entries found")
+		return nil
+	}
+
+	writeCacheProblems(os.Stdout, problems)
+
+	if !verifyPurgeFlag {
+		if !stdinIsTerminal() || !confirm(os.Stdin, os.Stderr, "Purge the damaged entries?") {
+			return fmt.Errorf("%d damaged files in the cache", len(problems))
+		}
+	}
+	purged, err := app.CacheSvc.Purge(ctx, problems)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Purged %d entries\n", purged)
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	filter, err := pruneFilter(pruneOlderThanFlag, pruneLargerThanFlag, pruneTypeFlag)
+	if err != nil {
+		return err
+	}
+
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	files, freed, err := app.CacheSvc.Prune(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d files, freeing %s\n", files, tui.FormatSize(freed))
+	return nil
+}
+
+func runCacheMigrate(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	migrated, err := app.CacheSvc.Migrate(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Migrated %d entries\n", migrated)
+	return nil
+}
+
+func runCacheWarm(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	reelIDs, err := CollectInputs(ctx, app.InputSvc, args, warmFileFlag)
+	if err != nil {
+		return fmt.Errorf("failed to collect inputs: %w", err)
+	}
+	if len(reelIDs) == 0 {
+		return fmt.Errorf("no valid reel URLs or IDs provided")
+	}
+	if err := checkCooldown(ctx, app); err != nil {
+		return err
+	}
+	if !app.Downloader.IsAvailable() {
+		if err := app.Downloader.Install(ctx, nil); err != nil {
+			name, _ := downloaderName(app.Config)
+			return fmt.Errorf("failed to install %s: %w", name, err)
+		}
+	}
+
+	var opts application.TranscribeOptions
+	if err := applyTranscribeDefaults(&opts, app.Config); err != nil {
+		return err
+	}
+
+	downloaded, cached, failed := 0, 0, 0
+	for i, reelID := range reelIDs {
+		alreadyCached, err := app.TranscribeSvc.Prefetch(ctx, reelID, opts.DownloadRetry)
+		status := "downloaded"
+		switch {
+		case err != nil:
+			recordRateLimit(ctx, app, err)
+			status = "failed: " + err.Error()
+			failed++
+		case alreadyCached:
+			status = "cached"
+			cached++
+		default:
+			downloaded++
+		}
+		if !quietFlag {
+			fmt.Printf("[%d/%d] %s %s\n", i+1, len(reelIDs), reelID, status)
+		}
+	}
+
+	fmt.Printf("Downloaded %d, already cached %d, failed %d\n", downloaded, cached, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reels failed", failed, len(reelIDs))
+	}
+	return nil
+}
+
+// pruneFilter parses the cache prune flags
+func pruneFilter(olderThan, largerThan string, kinds []string) (ports.CachePruneFilter, error) {
+	var filter ports.CachePruneFilter
+	for _, kind := range kinds {
+		switch kind = strings.ToLower(strings.TrimSpace(kind)); kind {
+		case ports.CacheFileAudio, ports.CacheFileVideo, ports.CacheFileThumbnail, ports.CacheFileRaw:
+			filter.Kinds = append(filter.Kinds, kind)
+		default:
+			return filter, fmt.Errorf("invalid --type: %s (use audio, video, thumbnail or raw)", kind)
+		}
+	}
+
+	if olderThan != "" {
+		age, err := config.ParseDuration(olderThan)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --older-than: %s (use format like 12h, 30d)", olderThan)
+		}
+		filter.OlderThan = age
+	}
+	if largerThan != "" {
+		size, err := config.ParseSize(largerThan)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --larger-than: %s (use format like 100MB)", largerThan)
+		}
+		filter.LargerThan = size
+	}
+	return filter, nil
+}
+
+// cacheTTLLabel returns the transcript TTL as configured
+func cacheTTLLabel(cfg *config.Config) string {
+	if cfg.Cache.TTL.Transcript != "" {
+		return cfg.Cache.TTL.Transcript
+	}
+	return cfg.Defaults.CacheTTL
+}
+
+// writeCacheBreakdown prints the cache's usage by kind of file and its
+// largest entries
+func writeCacheBreakdown(w io.Writer, stats *ports.CacheStats) {
+	if stats.ItemCount == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "By Type:")
+	for _, kind := range []struct {
+		label string
+		usage ports.CacheUsage
+	}{
+		{"Transcripts", stats.Transcripts},
+		{"Audio", stats.Audio},
+		{"Video", stats.Video},
+		{"Thumbnails", stats.Thumbnails},
+	} {
+		fmt.Fprintf(w, "  %-12s %5d  %s\n", kind.label+":", kind.usage.Count, tui.FormatSize(kind.usage.Size))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Largest Entries:")
+	for _, item := range stats.Largest {
+		fmt.Fprintf(w, "  %-20s %s\n", item.ReelID, tui.FormatSize(item.Size))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeCacheProblems lists damaged cache files, grouped by reel
+func writeCacheProblems(w io.Writer, problems []ports.CacheProblem) {
+	reelID := ""
+	for _, p := range problems {
+		if p.ReelID != reelID {
+			reelID = p.ReelID
+			fmt.Fprintf(w, "%s:\n", reelID)
+		}
+		fmt.Fprintf(w, "  %s: %s\n", p.File, p.Reason)
+	}
+}
+
+// writeCacheEntry prints a cached item of reelID, stored in dir, as of now
+func writeCacheEntry(w io.Writer, reelID, dir string, item *ports.CachedItem, now time.Time) error {
+	const stamp = "2006-01-02 15:04:05"
+
+	expires := item.ExpiresAt.Format(stamp)
+	if now.After(item.ExpiresAt) {
+		expires += " (expired)"
+	}
+
+	fmt.Fprintf(w, "Entry:     %s\n", reelID)
+	fmt.Fprintf(w, "Directory: %s\n", dir)
+	fmt.Fprintf(w, "Created:   %s\n", item.CreatedAt.Format(stamp))
+	fmt.Fprintf(w, "Expires:   %s\n", expires)
+	if !item.MediaExpiresAt.IsZero() {
+		mediaExpires := item.MediaExpiresAt.Format(stamp)
+		if now.After(item.MediaExpiresAt) {
+			mediaExpires += " (expired)"
+		}
+		fmt.Fprintf(w, "Media:     expires %s\n", mediaExpires)
+	}
+
+	if item.Reel != nil {
+		fmt.Fprintln(w)
+		if err := writeReelInfo(w, item.Reel, false); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Files:")
+	files := []struct{ label, path string }{
+		{"Audio", item.AudioPath},
+		{"Video", item.VideoPath},
+		{"Thumbnail", item.ThumbnailPath},
+		{"Raw output", item.RawOutputPath},
+	}
+	listed := false
+	for _, f := range files {
+		if f.path == "" {
+			continue
+		}
+		listed = true
+		size := "missing"
+		if info, err := os.Stat(f.path); err == nil {
+			size = tui.FormatSize(info.Size())
+		}
+		fmt.Fprintf(w, "  %-11s %-9s %s\n", f.label, size, f.path)
+	}
+	if !listed {
+		fmt.Fprintln(w, "  none")
+	}
+
+	fmt.Fprintln(w)
+	if item.Transcript == nil {
+		fmt.Fprintln(w, "Transcript: none")
+		return nil
+	}
+	t := item.Transcript
+	fmt.Fprintf(w, "Transcript: %d segments, model %s, language %s", len(t.Segments), unknownIfEmpty(t.Model), unknownIfEmpty(t.Language))
+	if !t.TranscribedAt.IsZero() {
+		fmt.Fprintf(w, ", transcribed %s", t.TranscribedAt.Format(stamp))
+	}
+	fmt.Fprintln(w)
+	text := []rune(t.ToText())
+	if len(text) > transcriptPreviewLen {
+		text = append(text[:transcriptPreviewLen-3], []rune("...")...)
+	}
+	fmt.Fprintf(w, "\n%s\n", string(text))
+	return nil
+}
+
+// unknownIfEmpty returns s, or "unknown" when it's empty
+func unknownIfEmpty(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
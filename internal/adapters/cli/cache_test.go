@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestWriteCacheEntry(t *testing.T) {
+	dir := t.TempDir()
+	audio := filepath.Join(dir, "audio.wav")
+	if err := os.WriteFile(audio, make([]byte, 2048), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Date(2025, 3, 4, 12, 0, 0, 0, time.Local)
+	item := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123", Author: "someuser"},
+		Transcript: &domain.Transcript{Text: strings.Repeat("word ", 100), Model: "small", Segments: make([]domain.Segment, 3)},
+		AudioPath:  audio,
+		VideoPath:  filepath.Join(dir, "video.mp4"),
+		CreatedAt:  created,
+		ExpiresAt:  created.Add(24 * time.Hour),
+	}
+
+	var out bytes.Buffer
+	if err := writeCacheEntry(&out, "ABC123", dir, item, created.Add(48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"Entry:     ABC123",
+		"Created:   2025-03-04 12:00:00",
+		"Expires:   2025-03-05 12:00:00 (expired)",
+		"Author:    someuser",
+		"Audio       2 KB",
+		"Video       missing",
+		"Transcript: 3 segments, model small, language unknown",
+		" wo...\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Thumbnail") {
+		t.Errorf("output lists a thumbnail that isn't cached:\n%s", out.String())
+	}
+}
+
+func TestWriteCacheEntry_NoTranscript(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Now()
+	item := &ports.CachedItem{CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	if err := writeCacheEntry(&out, "ABC123", "/tmp/ABC123", item, now); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"  none", "Transcript: none"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "expired") {
+		t.Errorf("fresh entry shown as expired:\n%s", out.String())
+	}
+}
+
+func TestWriteCacheProblems(t *testing.T) {
+	var out bytes.Buffer
+	writeCacheProblems(&out, []ports.CacheProblem{
+		{ReelID: "ABC123", File: "/c/ABC123/audio.wav", Reason: "checksum mismatch"},
+		{ReelID: "ABC123", File: "/c/ABC123/video.mp4", Reason: "empty"},
+		{ReelID: "DEF456", File: "/c/DEF456/meta.json.gz", Reason: "unreadable metadata: unexpected EOF"},
+	})
+	want := "ABC123:\n" +
+		"  /c/ABC123/audio.wav: checksum mismatch\n" +
+		"  /c/ABC123/video.mp4: empty\n" +
+		"DEF456:\n" +
+		"  /c/DEF456/meta.json.gz: unreadable metadata: unexpected EOF\n"
+	if out.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestPruneFilter(t *testing.T) {
+	filter, err := pruneFilter("30d", "100MB", []string{"Video", "raw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.OlderThan != 30*24*time.Hour || filter.LargerThan != 100<<20 {
+		t.Errorf("pruneFilter() = %+v", filter)
+	}
+	if len(filter.Kinds) != 2 || filter.Kinds[0] != ports.CacheFileVideo || filter.Kinds[1] != ports.CacheFileRaw {
+		t.Errorf("pruneFilter() kinds = %v", filter.Kinds)
+	}
+
+	for _, tt := range []struct {
+		olderThan, largerThan string
+		kinds                 []string
+	}{
+		{"a month", "", nil},
+		{"", "huge", nil},
+		{"", "", []string{"transcript"}},
+	} {
+		if _, err := pruneFilter(tt.olderThan, tt.largerThan, tt.kinds); err == nil {
+			t.Errorf("pruneFilter(%q, %q, %v) expected error", tt.olderThan, tt.largerThan, tt.kinds)
+		}
+	}
+}
+
+func TestWriteCacheBreakdown(t *testing.T) {
+	var buf bytes.Buffer
+	writeCacheBreakdown(&buf, &ports.CacheStats{
+		ItemCount:   2,
+		TotalSize:   3 << 20,
+		Transcripts: ports.CacheUsage{Count: 2, Size: 4096},
+		Video:       ports.CacheUsage{Count: 1, Size: 3 << 20},
+		Largest:     []ports.CacheItemSize{{ReelID: "ABC123", Size: 3 << 20}, {ReelID: "DEF456", Size: 2048}},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"Transcripts:     2  4 KB", "Video:           1  3 MB", "Audio:           0  0 B", "ABC123", "DEF456"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "ABC123") > strings.Index(out, "DEF456") {
+		t.Errorf("largest entries out of order:\n%s", out)
+	}
+
+	buf.Reset()
+	writeCacheBreakdown(&buf, &ports.CacheStats{})
+	if buf.Len() != 0 {
+		t.Errorf("empty cache printed %q", buf.String())
+	}
+}
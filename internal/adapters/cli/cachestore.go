@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/devbush/ig2insights/internal/adapters/cache"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// newCacheStore returns the cache kept in cacheDir and capped at maxSize
+// bytes (0 for no cap). When shared is set, the configured S3 bucket or
+// Redis server backs it; client, when set, carries the proxy for bucket
+// requests.
+func newCacheStore(cfg *config.Config, cacheDir string, maxSize int64, shared bool, client *http.Client) (ports.CacheStore, error) {
+	s3, redis := cfg.Cache.S3, cfg.Cache.Redis
+	if !shared {
+		s3, redis = config.S3Config{}, config.RedisConfig{}
+	}
+
+	switch {
+	case s3.Bucket != "" && redis.URL != "":
+		return nil, errors.New("cache s3 and redis can't be used together")
+	case redis.URL != "":
+		if maxSize > 0 {
+			return nil, errors.New("cache max_size isn't supported with redis")
+		}
+		store, err := cache.NewRedisCache(redis.URL, redis.Prefix, cacheDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache redis settings: %w", err)
+		}
+		return store, nil
+	case s3.Bucket != "":
+		accessKeyID, secretAccessKey, sessionToken := cfg.GetS3Credentials()
+		remote, err := cache.NewRemoteCache(cacheDir, cache.S3Options{
+			Endpoint:        s3.Endpoint,
+			Region:          s3.Region,
+			Bucket:          s3.Bucket,
+			Prefix:          s3.Prefix,
+			PathStyle:       s3.PathStyle,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache s3 settings: %w", err)
+		}
+		if client != nil {
+			remote.SetHTTPClient(client)
+		}
+		remote.SetMaxSize(maxSize)
+		remote.SetDeleteCorrupt(cfg.Cache.DeleteCorrupt)
+		return remote, nil
+	default:
+		store := cache.NewFileCache(cacheDir)
+		store.SetMaxSize(maxSize)
+		store.SetDeleteCorrupt(cfg.Cache.DeleteCorrupt)
+		return store, nil
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/cache"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+func TestNewCacheStore(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := config.DefaultConfig()
+	cfg.Cache.Redis.URL = "redis://localhost:6379"
+	store, err := newCacheStore(cfg, dir, 0, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.(*cache.RedisCache); !ok {
+		t.Errorf("newCacheStore() = %T, want *cache.RedisCache", store)
+	}
+
+	// Mock runs never share the cache
+	store, err = newCacheStore(cfg, dir, 0, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.(*cache.FileCache); !ok {
+		t.Errorf("newCacheStore() = %T, want *cache.FileCache when not shared", store)
+	}
+
+	if _, err := newCacheStore(cfg, dir, 1<<30, true, nil); err == nil {
+		t.Error("newCacheStore() expected error for max_size with redis")
+	}
+
+	cfg.Cache.S3.Bucket = "team"
+	if _, err := newCacheStore(cfg, dir, 0, true, nil); err == nil {
+		t.Error("newCacheStore() expected error for s3 and redis together")
+	}
+
+	cfg.Cache.Redis.URL = ""
+	store, err = newCacheStore(cfg, dir, 0, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.(*cache.RemoteCache); !ok {
+		t.Errorf("newCacheStore() = %T, want *cache.RemoteCache", store)
+	}
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// writeCaptionBundle writes what reposting the reel needs: a post caption
+// made from the transcript, trimmed to Instagram's limit with the configured
+// hashtags appended, and an SRT to upload as subtitles. It returns the
+// written paths keyed by label.
+func writeCaptionBundle(result *application.TranscribeResult, cfg *config.Config, outputDir, baseName string) (map[string]string, error) {
+	captionPath, write := outputTarget(filepath.Join(outputDir, baseName+".caption.txt"))
+	if write {
+		caption := domain.BuildCaption(result.Transcript.ToText(), cfg.Output.Caption.Hashtags, domain.InstagramCaptionLimit)
+		if err := os.WriteFile(captionPath, []byte(caption+"\n"), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write caption: %w", err)
+		}
+	}
+
+	srtPath, _, _, err := writeTranscript(result, "srt", cfg, outputDir, baseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write SRT: %w", err)
+	}
+
+	return map[string]string{
+		"Post caption":    captionPath,
+		"Subtitles (SRT)": srtPath,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// writeCaptionComparison saves the reel's platform captions beside the
+// whisper transcript, in the first --format, along with a Markdown report
+// comparing the two. It returns the written paths keyed by label, or none
+// when the reel has no captions.
+func writeCaptionComparison(ctx context.Context, app *App, reelID string, result *application.TranscribeResult, outputDir, baseName string) (map[string]string, error) {
+	captions, err := app.TranscribeSvc.FetchSubtitles(ctx, reelID, languageFlag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch captions: %w", err)
+	}
+	if captions == nil {
+		return nil, nil
+	}
+
+	return writeCaptionFiles(reelID, result, captions, app.Config, outputDir, baseName)
+}
+
+// writeCaptionFiles writes the captions and the comparison report
+func writeCaptionFiles(reelID string, result *application.TranscribeResult, captions *domain.Transcript, cfg *config.Config, outputDir, baseName string) (map[string]string, error) {
+	formats, err := parseFormats(formatFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	captionResult := *result
+	captionResult.Transcript = captions
+	captionsPath, _, _, err := writeTranscript(&captionResult, formats[0], cfg, outputDir, baseName+".captions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write captions: %w", err)
+	}
+
+	reportPath, write := outputTarget(filepath.Join(outputDir, baseName+".captions-compare.md"))
+	if write {
+		report := renderCaptionReport(reelID, captions, result.Transcript)
+		if err := os.WriteFile(reportPath, []byte(report), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]string{
+		"Captions":            captionsPath,
+		"Captions vs whisper": reportPath,
+	}, nil
+}
+
+// renderCaptionReport renders a Markdown report of how the platform captions
+// and the whisper transcript agree, with a word diff between them
+func renderCaptionReport(reelID string, captions, whisper *domain.Transcript) string {
+	agreement := domain.CompareTranscripts(captions, whisper)
+	model := whisper.Model
+	if model == "" {
+		model = "whisper"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Captions vs whisper: %s\n\n", reelID))
+	sb.WriteString("| Source | Words | Segments | Words not in the other |\n")
+	sb.WriteString("|--------|-------|----------|------------------------|\n")
+	sb.WriteString(fmt.Sprintf("| Instagram %s | %d | %d | %d |\n",
+		captions.Source, agreement.WordsA, len(captions.Segments), agreement.OnlyInA))
+	sb.WriteString(fmt.Sprintf("| whisper %s | %d | %d | %d |\n",
+		model, agreement.WordsB, len(whisper.Segments), agreement.OnlyInB))
+	sb.WriteString(fmt.Sprintf("\n**Agreement: %.1f%%** of words match, ignoring case and punctuation.\n", agreement.Similarity*100))
+
+	sb.WriteString("\n## Differences\n\n")
+	sb.WriteString("Only in the captions: ~~struck~~. Only in whisper: **bold**.\n\n")
+	diffs := domain.DiffWords(domain.NormalizeForComparison(captions.ToText()), domain.NormalizeForComparison(whisper.ToText()))
+	sb.WriteString(renderWordDiff(diffs))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
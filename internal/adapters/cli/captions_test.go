@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestWriteCaptionFiles(t *testing.T) {
+	dir := t.TempDir()
+	result := &application.TranscribeResult{
+		Transcript: &domain.Transcript{
+			Text:     "Hey everyone, welcome back to the channel.",
+			Segments: []domain.Segment{{Start: 0, End: 3, Text: "Hey everyone, welcome back to the channel."}},
+			Model:    "small",
+		},
+	}
+	captions := &domain.Transcript{
+		Text:     "hey everyone welcome back to my channel",
+		Segments: []domain.Segment{{Start: 0, End: 1.5, Text: "hey everyone welcome back"}, {Start: 1.5, End: 3, Text: "to my channel"}},
+		Source:   domain.SourceAutoCaptions,
+	}
+
+	oldFormat := formatFlag
+	defer func() { formatFlag = oldFormat }()
+	formatFlag = "srt,json"
+
+	outputs, err := writeCaptionFiles("ABC123", result, captions, config.DefaultConfig(), dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeCaptionFiles() error = %v", err)
+	}
+
+	if got := outputs["Captions"]; got != filepath.Join(dir, "ABC123.captions.srt") {
+		t.Errorf("captions path = %s, want ABC123.captions.srt in the first format", got)
+	}
+
+	report, err := os.ReadFile(outputs["Captions vs whisper"])
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	for _, want := range []string{
+		"# Captions vs whisper: ABC123",
+		"| Instagram auto-captions | 7 | 2 | 1 |",
+		"| whisper small | 7 | 1 | 1 |",
+		"**Agreement: 85.7%**",
+		"~~my~~ **the** channel",
+	} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestWriteCaptionBundle(t *testing.T) {
+	dir := t.TempDir()
+	result := &application.TranscribeResult{
+		Transcript: &domain.Transcript{
+			Text:     "Hey everyone, welcome back to the channel.",
+			Segments: []domain.Segment{{Start: 0, End: 3, Text: "Hey everyone, welcome back to the channel."}},
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Output.Caption.Hashtags = []string{"reels", "#tips"}
+
+	outputs, err := writeCaptionBundle(result, cfg, dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeCaptionBundle() error = %v", err)
+	}
+
+	caption, err := os.ReadFile(outputs["Post caption"])
+	if err != nil {
+		t.Fatalf("caption not written: %v", err)
+	}
+	if want := "Hey everyone, welcome back to the channel.\n\n#reels #tips\n"; string(caption) != want {
+		t.Errorf("caption = %q, want %q", caption, want)
+	}
+
+	if got := outputs["Subtitles (SRT)"]; got != filepath.Join(dir, "ABC123.srt") {
+		t.Errorf("SRT path = %s, want ABC123.srt", got)
+	}
+	srt, err := os.ReadFile(outputs["Subtitles (SRT)"])
+	if err != nil || !strings.Contains(string(srt), "00:00:00,000 --> 00:00:03,000") {
+		t.Errorf("SRT = %q, err = %v", srt, err)
+	}
+}
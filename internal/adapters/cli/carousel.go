@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// carouselSlides returns the videos of a reel linked as a /p/ post when the
+// post is a carousel. Reel links are always single videos and aren't checked.
+func carouselSlides(ctx context.Context, app *App, reel *domain.Reel) ([]*domain.Reel, error) {
+	// Listing needs the downloader; a first run installs it on the way to
+	// transcribing the post as a single video
+	if !reel.Post || !app.Downloader.IsAvailable() {
+		return nil, nil
+	}
+	return app.TranscribeSvc.CarouselSlides(ctx, reel.ID)
+}
+
+// transcribeCarousel transcribes each video of a carousel post in turn,
+// carrying on past slides that fail
+func transcribeCarousel(ctx context.Context, app *App, post *domain.Reel, slides []*domain.Reel, startedAt time.Time) error {
+	if !quietFlag {
+		fmt.Printf("\nCarousel %s has %d videos\n", post.ID, len(slides))
+	}
+
+	var errs []error
+	for _, slide := range slides {
+		_, n := domain.ParseSlideID(slide.ID)
+		if !quietFlag {
+			fmt.Printf("\nSlide %d:\n", n)
+		}
+		if err := transcribeReel(ctx, app, slide, startedAt); err != nil {
+			errs = append(errs, fmt.Errorf("slide %d: %w", n, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// clipboardTools returns the commands tried, in order, to set the system
+// clipboard from stdin. A variable so tests can substitute a fake.
+var clipboardTools = func() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{
+			{"powershell", "-NoProfile", "-Command", "[Console]::InputEncoding = [Text.Encoding]::UTF8; Set-Clipboard -Value ([Console]::In.ReadToEnd())"},
+			{"clip"},
+		}
+	default:
+		tools := [][]string{{"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			tools = append([][]string{{"wl-copy"}}, tools...)
+		}
+		return tools
+	}
+}
+
+// clipboardReadTools returns the commands tried, in order, to print the
+// system clipboard. A variable so tests can substitute a fake.
+var clipboardReadTools = func() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{
+			{"powershell", "-NoProfile", "-Command", "[Console]::OutputEncoding = [Text.Encoding]::UTF8; Get-Clipboard -Raw"},
+		}
+	default:
+		tools := [][]string{{"xclip", "-selection", "clipboard", "-o"}, {"xsel", "--clipboard", "--output"}}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			tools = append([][]string{{"wl-paste", "--no-newline"}}, tools...)
+		}
+		return tools
+	}
+}
+
+// findClipboardTool returns the first installed clipboard command, with its
+// binary resolved to a full path
+func findClipboardTool() ([]string, error) {
+	return findTool(clipboardTools())
+}
+
+// findTool returns the first installed command of tools, with its binary
+// resolved to a full path
+func findTool(tools [][]string) ([]string, error) {
+	for _, tool := range tools {
+		if path, err := exec.LookPath(tool[0]); err == nil {
+			return append([]string{path}, tool[1:]...), nil
+		}
+	}
+	return nil, errors.New("no clipboard tool found; install wl-clipboard, xclip or xsel")
+}
+
+// copyToClipboard puts text on the system clipboard
+func copyToClipboard(text string) error {
+	tool, err := findClipboardTool()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(tool[0], tool[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// readClipboard returns the text on the system clipboard
+func readClipboard() (string, error) {
+	tool, err := findTool(clipboardReadTools())
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(tool[0], tool[1:]...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the clipboard: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// clipboardInput returns the reel URL or ID on the clipboard. When more
+// text was copied along with it, the first Instagram link in it is used.
+func clipboardInput() (string, error) {
+	text, err := readClipboard()
+	if err != nil {
+		return "", err
+	}
+	return reelInputFromText(text)
+}
+
+// reelInputFromText picks the reel input out of copied text
+func reelInputFromText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.New("the clipboard is empty; copy a reel URL first")
+	}
+	if !strings.ContainsAny(text, " \t\r\n") {
+		return text, nil
+	}
+	if urls := domain.ExtractInstagramURLs(text); len(urls) > 0 {
+		return urls[0], nil
+	}
+	return "", fmt.Errorf("%w: no reel URL on the clipboard", domain.ErrInvalidReelInput)
+}
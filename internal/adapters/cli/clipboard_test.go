@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake clipboard tool needs sh")
+	}
+
+	target := filepath.Join(t.TempDir(), "clipboard.txt")
+	oldTools := clipboardTools
+	defer func() { clipboardTools = oldTools }()
+
+	clipboardTools = func() [][]string {
+		return [][]string{{"ig2insights-missing-tool"}, {"sh", "-c", "cat > " + target}}
+	}
+	if err := copyToClipboard("héllo"); err != nil {
+		t.Fatalf("copyToClipboard() error = %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "héllo" {
+		t.Errorf("clipboard = %q, want %q", data, "héllo")
+	}
+
+	clipboardTools = func() [][]string { return [][]string{{"ig2insights-missing-tool"}} }
+	if err := copyToClipboard("x"); err == nil {
+		t.Error("expected error when no clipboard tool is installed")
+	}
+}
+
+func TestClipboardInput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake clipboard tool needs sh")
+	}
+
+	oldTools := clipboardReadTools
+	defer func() { clipboardReadTools = oldTools }()
+
+	clipboardReadTools = func() [][]string {
+		return [][]string{{"ig2insights-missing-tool"}, {"sh", "-c", "printf ' https://www.instagram.com/reel/ABC123/\\n'"}}
+	}
+	if got, err := clipboardInput(); err != nil || got != "https://www.instagram.com/reel/ABC123/" {
+		t.Errorf("clipboardInput() = %q, %v", got, err)
+	}
+
+	clipboardReadTools = func() [][]string { return [][]string{{"ig2insights-missing-tool"}} }
+	if _, err := clipboardInput(); err == nil {
+		t.Error("expected error when no clipboard tool is installed")
+	}
+}
+
+func TestReelInputFromText(t *testing.T) {
+	got, err := reelInputFromText("Check this out: https://www.instagram.com/reel/ABC123/?igsh=x so good")
+	if err != nil || got != "https://www.instagram.com/reel/ABC123/?igsh=x" {
+		t.Errorf("reelInputFromText(message) = %q, %v", got, err)
+	}
+	if got, err := reelInputFromText("  ABC123\n"); err != nil || got != "ABC123" {
+		t.Errorf("reelInputFromText(ID) = %q, %v", got, err)
+	}
+	if _, err := reelInputFromText(" \n"); err == nil {
+		t.Error("expected error for an empty clipboard")
+	}
+	if _, err := reelInputFromText("shopping list: eggs, milk"); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Errorf("reelInputFromText(text) error = %v, want ErrInvalidReelInput", err)
+	}
+}
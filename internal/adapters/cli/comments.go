@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// commentsOutput is the document written to the .comments.json file
+type commentsOutput struct {
+	ReelID    string           `json:"reel_id"`
+	FetchedAt time.Time        `json:"fetched_at"`
+	Comments  []domain.Comment `json:"comments"`
+}
+
+// writeComments saves the reel's --comments most liked comments beside the
+// transcript and returns the written path
+func writeComments(ctx context.Context, app *App, reelID, outputDir, baseName string) (string, error) {
+	path, write := outputTarget(filepath.Join(outputDir, baseName+".comments.json"))
+	if !write {
+		return path, nil
+	}
+
+	comments, err := app.TranscribeSvc.FetchComments(ctx, reelID, commentsFlag)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch comments: %w", err)
+	}
+	if comments == nil {
+		comments = []domain.Comment{}
+	}
+
+	data, err := json.MarshalIndent(commentsOutput{ReelID: reelID, FetchedAt: time.Now().UTC(), Comments: comments}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write comments: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteComments(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+
+	oldComments := commentsFlag
+	defer func() { commentsFlag = oldComments }()
+	commentsFlag = 3
+
+	path, err := writeComments(context.Background(), app, "ABC123", dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeComments() error = %v", err)
+	}
+	if path != filepath.Join(dir, "ABC123.comments.json") {
+		t.Errorf("path = %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out commentsOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	if out.ReelID != "ABC123" || len(out.Comments) != 3 {
+		t.Errorf("comments = %+v, want the top 3 for ABC123", out)
+	}
+	for i := 1; i < len(out.Comments); i++ {
+		if out.Comments[i].LikeCount > out.Comments[i-1].LikeCount {
+			t.Errorf("comments not ranked by likes: %+v", out.Comments)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var compareModelsFlag string
+
+// NewCompareCmd creates the compare command
+func NewCompareCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <reel-url|reel-id>",
+		Short: "Compare transcripts from multiple models",
+		Long: `Transcribe the same reel audio with several Whisper models and write
+a side-by-side diff report, to judge which model is worth the time.
+
+The first model is the baseline the others are diffed against.
+
+Example:
+  ig2insights compare ABC123 --models small,medium`,
+		Args: cobra.ExactArgs(1),
+		RunE: runCompare,
+	}
+
+	cmd.Flags().StringVar(&compareModelsFlag, "models", "small,medium", "Comma-separated models to compare")
+
+	return cmd
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	models := parseModelList(compareModelsFlag)
+	if len(models) < 2 {
+		return fmt.Errorf("need at least two models to compare, got %q", compareModelsFlag)
+	}
+
+	app, err := GetApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	ctx := context.Background()
+
+	reel, err := app.InputSvc.Normalize(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, model := range models {
+		if app.Transcriber.IsModelDownloaded(model) {
+			continue
+		}
+		fmt.Printf("Downloading model '%s'...\n", model)
+		err := app.Transcriber.DownloadModel(ctx, model, func(downloaded, total int64) {
+			if total > 0 && !quietFlag {
+				pct := float64(downloaded) / float64(total) * 100
+				fmt.Printf("\rProgress: %.1f%% (%s / %s)", pct, tui.FormatSize(downloaded), tui.FormatSize(total))
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to download model %s: %w", model, err)
+		}
+		fmt.Println()
+	}
+
+	if !quietFlag {
+		fmt.Printf("Transcribing %s with %s...\n", reel.ID, strings.Join(models, ", "))
+	}
+
+	result, err := app.CompareSvc.Compare(ctx, reel.ID, models, languageFlag, promptFlag)
+	if err != nil {
+		recordRateLimit(ctx, app, err)
+		return err
+	}
+
+	outputDir, baseName := resolveOutputPaths(result.Reel)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	reportPath := filepath.Join(outputDir, baseName+".compare.md")
+	if err := os.WriteFile(reportPath, []byte(renderComparisonReport(reel.ID, result)), 0644); err != nil {
+		return err
+	}
+
+	if !quietFlag {
+		for _, run := range result.Runs {
+			if run.Err != nil {
+				fmt.Printf("  ✗ %-20s %v\n", run.Model, run.Err)
+				continue
+			}
+			fmt.Printf("  ✓ %-20s %.1fs\n", run.Model, run.Duration.Seconds())
+		}
+		fmt.Printf("\nReport: %s\n", reportPath)
+	}
+
+	return nil
+}
+
+// parseModelList splits a comma-separated model list, dropping blanks and duplicates
+func parseModelList(s string) []string {
+	seen := make(map[string]bool)
+	var models []string
+	for _, m := range strings.Split(s, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		models = append(models, m)
+	}
+	return models
+}
+
+// renderComparisonReport renders a Markdown report with a summary table and
+// word diffs of each model's transcript against the first (baseline) model
+func renderComparisonReport(reelID string, result *application.CompareResult) string {
+	var sb strings.Builder
+
+	baseline := result.Runs[0]
+	baselineText := ""
+	if baseline.Err == nil {
+		baselineText = baseline.Transcript.ToText()
+	}
+
+	sb.WriteString(fmt.Sprintf("# Model comparison: %s\n\n", reelID))
+	sb.WriteString(fmt.Sprintf("| Model | Time | Words | Similarity to %s |\n", markdownCell(baseline.Model)))
+	sb.WriteString("|-------|------|-------|------------|\n")
+	for i, run := range result.Runs {
+		if run.Err != nil {
+			sb.WriteString(fmt.Sprintf("| %s | failed | - | %s |\n", markdownCell(run.Model), markdownCell(run.Err.Error())))
+			continue
+		}
+		text := run.Transcript.ToText()
+		similarity := "baseline"
+		if i > 0 {
+			similarity = "-"
+			if baseline.Err == nil {
+				similarity = fmt.Sprintf("%.1f%%", domain.WordSimilarity(baselineText, text)*100)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %.1fs | %d | %s |\n",
+			markdownCell(run.Model), run.Duration.Seconds(), len(strings.Fields(text)), similarity))
+	}
+
+	for i, run := range result.Runs {
+		if run.Err != nil {
+			continue
+		}
+		text := run.Transcript.ToText()
+		if i == 0 || baseline.Err != nil {
+			sb.WriteString(fmt.Sprintf("\n## %s\n\n%s\n", run.Model, text))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n## %s vs %s\n\n", run.Model, baseline.Model))
+		sb.WriteString(fmt.Sprintf("Removed from %s: ~~struck~~. Added by %s: **bold**.\n\n", baseline.Model, run.Model))
+		sb.WriteString(renderWordDiff(domain.DiffWords(baselineText, text)))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// markdownCell makes s safe inside a Markdown table cell: pipes are escaped
+// and line breaks, which would end the row, collapse into spaces
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
+
+// renderWordDiff renders a word diff as Markdown with deletions struck through and insertions bold
+func renderWordDiff(diffs []domain.WordDiff) string {
+	parts := make([]string, 0, len(diffs))
+	for _, d := range diffs {
+		switch d.Op {
+		case domain.DiffDelete:
+			parts = append(parts, "~~"+d.Text+"~~")
+		case domain.DiffInsert:
+			parts = append(parts, "**"+d.Text+"**")
+		default:
+			parts = append(parts, d.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestParseModelList(t *testing.T) {
+	models := parseModelList(" small, medium,,small ,large")
+
+	expected := []string{"small", "medium", "large"}
+	if len(models) != len(expected) {
+		t.Fatalf("expected %d models, got %d: %v", len(expected), len(models), models)
+	}
+	for i, m := range models {
+		if m != expected[i] {
+			t.Errorf("expected model[%d] = %q, got %q", i, expected[i], m)
+		}
+	}
+}
+
+func TestRenderComparisonReport(t *testing.T) {
+	result := &application.CompareResult{
+		Runs: []application.ModelRun{
+			{Model: "small", Transcript: &domain.Transcript{Text: "buy the mavely kit"}},
+			{Model: "medium", Transcript: &domain.Transcript{Text: "buy the Mavely kit"}},
+			{Model: "large", Err: errors.New("model not found")},
+			{Model: "tiny", Err: errors.New("whisper failed:\nexit | status 1")},
+		},
+	}
+
+	report := renderComparisonReport("ABC123", result)
+
+	for _, want := range []string{
+		"# Model comparison: ABC123",
+		"| small | 0.0s | 4 | baseline |",
+		"| medium | 0.0s | 4 | 75.0% |",
+		"| large | failed | - | model not found |",
+		`| tiny | failed | - | whisper failed: exit \| status 1 |`,
+		"buy the ~~mavely~~ **Mavely** kit",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q, got:\n%s", want, report)
+		}
+	}
+}
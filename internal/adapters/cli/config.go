@@ -0,0 +1,453 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/adapters/whisper"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd creates the config subcommand. It reads config.yaml itself
+// rather than through the app, so a broken config can still be fixed.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change settings in config.yaml",
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a setting, e.g. defaults.model, or a whole section",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigGet(os.Stdout, config.ConfigPath(), args[0])
+		},
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting, e.g. config set defaults.model medium",
+		Long: `Change a setting in config.yaml, keeping its other settings and comments.
+Lists take comma-separated values, e.g. config set output.caption.hashtags ugc,reels`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSet(os.Stdout, config.ConfigPath(), args[0], args[1])
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every setting and its current value",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigList(os.Stdout, config.ConfigPath())
+		},
+	}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check config.yaml for mistakes and show the effective settings",
+		Long: `Check config.yaml for unknown keys, values of the wrong type and settings
+that don't parse, such as model names, formats and durations. The effective
+configuration, with IG2INSIGHTS_* variables applied, is printed after.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// The problems are listed; usage wouldn't help fix them
+			cmd.SilenceUsage = true
+			return runConfigValidate(os.Stdout, config.ConfigPath(), cmd.Root())
+		},
+	}
+
+	editCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Open config.yaml in $VISUAL or $EDITOR",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEdit(config.ConfigPath())
+		},
+	}
+
+	cmd.AddCommand(getCmd, setCmd, listCmd, validateCmd, editCmd)
+	return cmd
+}
+
+// selectProfile switches to the profile --profile or $IG2INSIGHTS_PROFILE
+// names, before anything reads the config
+func selectProfile() error {
+	name := profileFlag
+	if name == "" {
+		name = os.Getenv(config.EnvPrefix + "PROFILE")
+	}
+	return config.SetProfile(name)
+}
+
+// selectConfigFile switches to the config file --config or
+// $IG2INSIGHTS_CONFIG names. It must exist, so a mistyped path isn't
+// silently replaced by the defaults, except for the config commands, which
+// may be creating it.
+func selectConfigFile(cmd *cobra.Command) error {
+	path := configFlag
+	if path == "" {
+		path = os.Getenv(config.EnvPrefix + "CONFIG")
+	}
+	config.SetConfigPath(path)
+	if path == "" || isConfigCommand(cmd) {
+		return nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("config file not found: %s", path)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// prepareCommand applies the active profile and config file to the flags
+// of cmd that weren't given, before it runs. A config that doesn't load is
+// reported when the app is built.
+func prepareCommand(cmd *cobra.Command) error {
+	if err := selectProfile(); err != nil {
+		return err
+	}
+	if err := selectConfigFile(cmd); err != nil {
+		return err
+	}
+	migrateAppDir(os.Stderr)
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return nil
+	}
+	// The config commands must work while the config is being fixed
+	if !isConfigCommand(cmd) {
+		if err := applyCommandDefaults(cmd, cfg); err != nil {
+			return err
+		}
+	}
+	applyConfigDefaults(cfg)
+	return nil
+}
+
+// migrateAppDir moves ~/.ig2insights to the XDG base directories that are
+// set, reporting what moved. A failed move is only a warning: the command
+// runs with what's in place.
+func migrateAppDir(w io.Writer) {
+	moves, err := config.MigrateAppDir()
+	for _, m := range moves {
+		fmt.Fprintf(w, "Moved %s to %s\n", m.From, m.To)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			fmt.Fprintf(w, "Warning: %v\n", err)
+		}
+	} else if err != nil {
+		fmt.Fprintf(w, "Warning: %v\n", err)
+	}
+}
+
+// isConfigCommand reports whether cmd is config or one of its subcommands
+func isConfigCommand(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Parent() == c.Root() && c.Name() == "config" {
+			return true
+		}
+	}
+	return false
+}
+
+// commandKey names cmd under commands in config.yaml: root for the root
+// command, otherwise its path without the program name, e.g. "cache warm"
+func commandKey(cmd *cobra.Command) string {
+	if !cmd.HasParent() {
+		return "root"
+	}
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// applyCommandDefaults sets the flags of cmd that weren't given to the
+// values under its commands section, so config.yaml can hold defaults for
+// one subcommand, e.g. batch's concurrency
+func applyCommandDefaults(cmd *cobra.Command, cfg *config.Config) error {
+	key := commandKey(cmd)
+	defaults := cfg.Commands[key]
+	for _, name := range config.SortedKeys(defaults) {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("commands.%s.%s in config: %s has no --%s flag", key, name, cmd.CommandPath(), name)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(name, defaults[name]); err != nil {
+			return fmt.Errorf("commands.%s.%s in config: %w", key, name, err)
+		}
+	}
+	return nil
+}
+
+// applyConfigDefaults fills the flags that default to a config setting and
+// weren't given, so flags override the environment, which overrides
+// config.yaml
+func applyConfigDefaults(cfg *config.Config) {
+	if modelFlag == "" {
+		modelFlag = cfg.Defaults.Model
+	}
+	// --template replaces the formats unless they're asked for
+	if formatFlag == "" && templateFlag == "" {
+		formatFlag = cfg.Defaults.Format
+	}
+}
+
+func runConfigGet(w io.Writer, path, key string) error {
+	cfg, err := config.LoadWithEnv(path)
+	if err != nil {
+		return err
+	}
+	value, err := cfg.Get(key)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, value)
+	return nil
+}
+
+func runConfigSet(w io.Writer, path, key, value string) error {
+	if err := config.SetKey(path, key, value); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Set %s to %s in %s\n", key, value, path)
+	return nil
+}
+
+func runConfigList(w io.Writer, path string) error {
+	cfg, err := config.LoadWithEnv(path)
+	if err != nil {
+		return err
+	}
+	for _, s := range cfg.Settings() {
+		fmt.Fprintf(w, "%s = %s\n", s.Key, s.Value)
+	}
+	return nil
+}
+
+func runConfigValidate(w io.Writer, path string, root *cobra.Command) error {
+	files := []string{path}
+	if project := config.ProjectConfigPath(); project != "" && project != path {
+		files = append(files, project)
+	}
+
+	count := 0
+	for _, file := range files {
+		report, err := config.Check(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		checkConfigValues(report, root)
+
+		problems := report.Problems()
+		count += len(problems)
+		if len(problems) == 0 {
+			fmt.Fprintf(w, "No problems found in %s\n", file)
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", file)
+		for _, p := range problems {
+			fmt.Fprintf(w, "  %s\n", p)
+		}
+	}
+
+	// The project config is applied on top of the first file, as when
+	// the app starts
+	if effective, err := config.LoadWithEnv(path); err != nil {
+		fmt.Fprintf(w, "\nNo effective configuration: %v\n", err)
+	} else {
+		fmt.Fprintln(w, "\nEffective configuration:")
+		for _, s := range effective.Settings() {
+			fmt.Fprintf(w, "  %s = %s\n", s.Key, s.Value)
+		}
+	}
+
+	switch count {
+	case 0:
+		return nil
+	case 1:
+		return errors.New("1 problem found")
+	default:
+		return fmt.Errorf("%d problems found", count)
+	}
+}
+
+// checkConfigValues adds the settings the app would reject when it starts
+// to report: those only the adapters know the valid values of
+func checkConfigValues(report *config.Report, root *cobra.Command) {
+	cfg := report.Config
+	check := func(key string, err error) {
+		if err != nil {
+			report.Add(key, err)
+		}
+	}
+
+	transcriber := whisper.NewTranscriber("")
+	var models []string
+	for _, m := range transcriber.AvailableModels() {
+		models = append(models, m.Name)
+	}
+	checkModel := func(key, model string) {
+		if model != "" && !slices.Contains(models, model) {
+			report.Add(key, fmt.Errorf("unknown model: %s (available: %s)", model, strings.Join(models, ", ")))
+		}
+	}
+	checkModel("defaults.model", cfg.Defaults.Model)
+	checkModel("defaults.fallback_model", cfg.Defaults.FallbackModel)
+
+	if cfg.Defaults.Format != "" {
+		_, err := parseFormats(cfg.Defaults.Format)
+		check("defaults.format", err)
+	}
+	if format := strings.ToLower(cfg.Defaults.AudioFormat); format != "" && !slices.Contains(audioFormats, format) {
+		report.Add("defaults.audio_format", fmt.Errorf("unknown audio format: %s (use %s)", format, strings.Join(audioFormats, ", ")))
+	}
+	_, err := domain.ParseVideoQuality(cfg.Defaults.VideoQuality)
+	check("defaults.video_quality", err)
+	if cfg.Output.Locale != "" {
+		_, err = tui.ResolveLocale(cfg.Output.Locale)
+		check("output.locale", err)
+	}
+	_, err = resolveProxy(cfg, "")
+	check("proxy", err)
+	_, err = downloaderName(cfg)
+	check("downloader", err)
+	switch cfg.YtDlpConfig {
+	case "", ytdlpConfigUser, ytdlpConfigNone, ytdlpConfigManaged:
+	default:
+		report.Add("ytdlp_config", fmt.Errorf("unknown ytdlp_config: %s (use %s, %s or %s)", cfg.YtDlpConfig, ytdlpConfigUser, ytdlpConfigNone, ytdlpConfigManaged))
+	}
+
+	_, err = transcriberFallbacks(cfg, transcriber, nil)
+	check("fallbacks", err)
+	for _, fc := range cfg.Fallbacks {
+		if fc.Backend == backendWhisper {
+			checkModel("fallbacks", fc.Model)
+		}
+	}
+	for i, step := range cfg.Pipeline {
+		switch step.Name {
+		case "punctuation", "corrections", "profanity", "summarizer":
+		case "exporter":
+			for _, f := range step.Formats {
+				_, err = parseFormats(f)
+				check("pipeline", err)
+			}
+		default:
+			report.Add("pipeline", fmt.Errorf("unknown pipeline step %d: %q (use punctuation, corrections, profanity, summarizer, exporter)", i+1, step.Name))
+		}
+	}
+
+	for _, key := range config.SortedKeys(cfg.Commands) {
+		checkCommandDefaults(report, root, key, cfg.Commands[key])
+	}
+}
+
+// checkCommandDefaults adds the flag defaults under commands.<key> that
+// applyCommandDefaults would reject to report, without setting them
+func checkCommandDefaults(report *config.Report, root *cobra.Command, key string, defaults map[string]string) {
+	cmd := root
+	if key != "root" {
+		found, _, err := root.Find(strings.Fields(key))
+		if err != nil || found == root || commandKey(found) != key {
+			report.Add("commands."+key, fmt.Errorf("unknown command: %s", key))
+			return
+		}
+		cmd = found
+	}
+
+	for _, name := range config.SortedKeys(defaults) {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			flag = cmd.InheritedFlags().Lookup(name)
+		}
+		if flag == nil {
+			report.Add("commands."+key+"."+name, fmt.Errorf("%s has no --%s flag", cmd.CommandPath(), name))
+			continue
+		}
+		if err := checkFlagValue(flag.Value.Type(), defaults[name]); err != nil {
+			report.Add("commands."+key+"."+name, err)
+		}
+	}
+}
+
+// checkFlagValue reports whether value parses for a flag of type typ, as
+// pflag names them. Other types are checked when the flag is set.
+func checkFlagValue(typ, value string) error {
+	var err error
+	switch typ {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %s", typ, value)
+	}
+	return nil
+}
+
+func runConfigEdit(path string) error {
+	editor, err := configEditor()
+	if err != nil {
+		return err
+	}
+
+	// Start a new config from the defaults, so there's something to edit
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := config.DefaultConfig().Save(path); err != nil {
+			return err
+		}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor failed: %w", err)
+	}
+
+	if _, err := config.Load(path); err != nil {
+		return fmt.Errorf("%s no longer loads, run config edit again to fix it: %w", path, err)
+	}
+	return nil
+}
+
+// configEditor returns the command that edits files: $VISUAL, $EDITOR, or
+// the platform's usual editor
+func configEditor() ([]string, error) {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if value := os.Getenv(env); value != "" {
+			words, err := splitShellWords(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid $%s: %w", env, err)
+			}
+			if len(words) > 0 {
+				return words, nil
+			}
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}, nil
+	}
+	return []string{"vi"}, nil
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestRunConfigSetGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	var out bytes.Buffer
+	if err := runConfigSet(&out, path, "defaults.model", "medium"); err != nil {
+		t.Fatalf("runConfigSet() error = %v", err)
+	}
+
+	out.Reset()
+	if err := runConfigGet(&out, path, "defaults.model"); err != nil || out.String() != "medium\n" {
+		t.Errorf("runConfigGet() = %q, %v; want medium", out.String(), err)
+	}
+
+	out.Reset()
+	if err := runConfigList(&out, path); err != nil {
+		t.Fatalf("runConfigList() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "defaults.model = medium\n") {
+		t.Errorf("runConfigList() missing the new model:\n%s", out.String())
+	}
+}
+
+func TestConfigEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got, err := configEditor(); err != nil || strings.Join(got, " ") != "code --wait" {
+		t.Errorf("configEditor() = %v, %v; want $EDITOR split into words", got, err)
+	}
+
+	t.Setenv("VISUAL", "nano")
+	if got, _ := configEditor(); len(got) != 1 || got[0] != "nano" {
+		t.Errorf("configEditor() = %v, want $VISUAL first", got)
+	}
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Defaults.Model, cfg.Defaults.Format = "tiny", "srt"
+
+	oldModel, oldFormat, oldTemplate := modelFlag, formatFlag, templateFlag
+	defer func() { modelFlag, formatFlag, templateFlag = oldModel, oldFormat, oldTemplate }()
+
+	// The config fills flags that weren't given
+	modelFlag, formatFlag, templateFlag = "", "", ""
+	applyConfigDefaults(cfg)
+	if modelFlag != "tiny" || formatFlag != "srt" {
+		t.Errorf("flags = %q, %q; want tiny, srt from the config", modelFlag, formatFlag)
+	}
+
+	// Flags that were given win, and --template isn't joined by a format
+	modelFlag, formatFlag, templateFlag = "large", "", "report.md.tmpl"
+	applyConfigDefaults(cfg)
+	if modelFlag != "large" || formatFlag != "" {
+		t.Errorf("flags = %q, %q; want large and no format", modelFlag, formatFlag)
+	}
+}
+
+func TestApplyCommandDefaults(t *testing.T) {
+	var concurrency int
+	var audio bool
+	root := &cobra.Command{Use: "ig2insights"}
+	root.PersistentFlags().BoolVar(&audio, "audio", false, "")
+	batch := &cobra.Command{Use: "batch"}
+	batch.Flags().IntVar(&concurrency, "concurrency", 10, "")
+	root.AddCommand(batch)
+
+	if got := commandKey(batch); got != "batch" {
+		t.Errorf("commandKey(batch) = %q", got)
+	}
+	if got := commandKey(root); got != "root" {
+		t.Errorf("commandKey(root) = %q", got)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Commands = map[string]map[string]string{
+		"batch": {"concurrency": "5", "audio": "true"},
+	}
+	if err := batch.ParseFlags([]string{"--audio=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyCommandDefaults(batch, cfg); err != nil {
+		t.Fatalf("applyCommandDefaults() error = %v", err)
+	}
+	if concurrency != 5 || audio {
+		t.Errorf("concurrency = %d, audio = %v; want 5 from config and the flag given", concurrency, audio)
+	}
+
+	// The flag is now set, so the invalid value is reached on a fresh command
+	cfg.Commands["batch"]["concurrency"] = "many"
+	fresh := &cobra.Command{Use: "batch"}
+	fresh.Flags().IntVar(&concurrency, "concurrency", 10, "")
+	root.AddCommand(fresh)
+	if err := applyCommandDefaults(fresh, cfg); err == nil {
+		t.Error("expected error for an invalid value")
+	}
+	cfg.Commands["batch"] = map[string]string{"colour": "red"}
+	if err := applyCommandDefaults(fresh, cfg); err == nil || !strings.Contains(err.Error(), "commands.batch.colour") {
+		t.Errorf("applyCommandDefaults() error = %v, want one naming the unknown flag", err)
+	}
+}
+
+func TestRunConfigValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "defaults:\n  model: huge\n  format: srt,docx\ncommands:\n  batch:\n    colour: red\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &cobra.Command{Use: "ig2insights"}
+	root.AddCommand(&cobra.Command{Use: "batch"})
+
+	var out bytes.Buffer
+	err := runConfigValidate(&out, path, root)
+	if err == nil || err.Error() != "2 problems found" {
+		t.Errorf("runConfigValidate() error = %v, want 2 problems", err)
+	}
+	for _, want := range []string{
+		"line 2: defaults.model: unknown model: huge",
+		"line 6: commands.batch.colour: ig2insights batch has no --colour flag",
+		"Effective configuration:\n  defaults.model = huge\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runConfigValidate() output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	os.WriteFile(path, []byte("defaults:\n  model: small\n"), 0644)
+	out.Reset()
+	if err := runConfigValidate(&out, path, root); err != nil || !strings.HasPrefix(out.String(), "No problems found") {
+		t.Errorf("runConfigValidate() = %v:\n%s", err, out.String())
+	}
+}
+
+func TestRunConfigValidate_Project(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, config.ProjectFileName)
+	os.WriteFile(project, []byte("defaults:\n  format: srt\n  timeout: soon\n"), 0644)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("defaults:\n  model: small\n"), 0644)
+	t.Chdir(dir)
+	root := &cobra.Command{Use: "ig2insights"}
+
+	// The project's problem is reported once, against the project config
+	var out bytes.Buffer
+	err := runConfigValidate(&out, path, root)
+	if err == nil || err.Error() != "1 problem found" {
+		t.Errorf("runConfigValidate() error = %v, want 1 problem", err)
+	}
+	for _, want := range []string{
+		"No problems found in " + path,
+		project + ":\n  line 3: defaults.timeout:",
+		"  defaults.format = srt\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runConfigValidate() output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// A project config the app refuses leaves no effective configuration
+	os.WriteFile(project, []byte("ytdlp_args: [--exec, x]\n"), 0644)
+	out.Reset()
+	if err := runConfigValidate(&out, path, root); err == nil || !strings.Contains(out.String(), "No effective configuration:") {
+		t.Errorf("runConfigValidate() = %v:\n%s", err, out.String())
+	}
+}
+
+func TestSelectConfigFile(t *testing.T) {
+	defer func() { configFlag = "" }()
+	defer config.SetConfigPath("")
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+
+	root := &cobra.Command{Use: "ig2insights"}
+	configCmd := &cobra.Command{Use: "config"}
+	setCmd := &cobra.Command{Use: "set"}
+	configCmd.AddCommand(setCmd)
+	root.AddCommand(configCmd)
+
+	t.Setenv("IG2INSIGHTS_CONFIG", path)
+	if err := selectConfigFile(root); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("selectConfigFile() = %v, want an error for the missing file", err)
+	}
+	if err := selectConfigFile(setCmd); err != nil || config.ConfigPath() != path {
+		t.Errorf("selectConfigFile() = %v, path %s; config set should create %s", err, config.ConfigPath(), path)
+	}
+
+	configFlag = filepath.Join(t.TempDir(), "flag.yaml")
+	os.WriteFile(configFlag, nil, 0644)
+	if err := selectConfigFile(root); err != nil || config.ConfigPath() != configFlag {
+		t.Errorf("selectConfigFile() = %v, path %s; --config should win over the environment", err, config.ConfigPath())
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+// outputTargets maps output paths that already existed to where they are
+// written instead: a renamed path, or "" to keep the existing file. It is
+// filled in by resolveConflicts before a single-reel run writes anything.
+var outputTargets map[string]string
+
+// outputTarget returns where to write an output planned at path, and false
+// when the existing file should be kept. Without an answer from the user,
+// existing files are overwritten unless --skip-existing is set.
+func outputTarget(path string) (string, bool) {
+	if target, ok := outputTargets[path]; ok {
+		if target == "" {
+			return path, false
+		}
+		return target, true
+	}
+	if skipExistingFlag && fileExists(path) {
+		return path, false
+	}
+	return path, true
+}
+
+// validateConflictFlags rejects contradictory conflict flags
+func validateConflictFlags() error {
+	if forceFlag && skipExistingFlag {
+		return errors.New("--force and --skip-existing cannot be used together")
+	}
+	return nil
+}
+
+// copyOutput copies src to the output planned at path, unless the existing
+// file is kept, and returns the path of the output
+func copyOutput(src, path string) (string, error) {
+	target, write := outputTarget(path)
+	if !write {
+		return target, nil
+	}
+	return target, copyFile(src, target)
+}
+
+// promptForConflicts reports whether existing outputs should be resolved by
+// asking the user: in a terminal, unless --force or --skip-existing decides
+func promptForConflicts() bool {
+	return !forceFlag && !skipExistingFlag && stdinIsTerminal()
+}
+
+// plannedOutputs lists every file a single-reel run may write into
+// outputDir, mirroring writeOutputs and the media, captions and provenance
+// steps of runTranscribe
+func plannedOutputs(cfg *config.Config, outputDir, baseName string) ([]string, error) {
+	var formats []string
+	if formatFlag != "" || templateFlag == "" {
+		var err error
+		if formats, err = parseFormats(formatFlag); err != nil {
+			return nil, err
+		}
+	}
+	for _, step := range cfg.Pipeline {
+		if step.Name != "exporter" {
+			continue
+		}
+		for _, format := range step.Formats {
+			if _, ok := formatExts[format]; ok && !slices.Contains(formats, format) {
+				formats = append(formats, format)
+			}
+		}
+	}
+
+	path := func(ext string) string { return filepath.Join(outputDir, baseName+"."+ext) }
+
+	var paths []string
+	if !clipboardOnlyFlag {
+		for _, format := range formats {
+			paths = append(paths, path(formatExts[format]))
+		}
+		if templateFlag != "" {
+			paths = append(paths, path(templateExt(templateFlag)))
+		}
+	}
+	if audioFlag {
+		format, err := audioFormat(cfg)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path(format))
+	}
+	if videoFlag {
+		paths = append(paths, path("mp4"))
+	}
+	if thumbnailFlag {
+		paths = append(paths, path("jpg"))
+	}
+	if compareCaptionsFlag && len(formats) > 0 {
+		paths = append(paths, path("captions."+formatExts[formats[0]]), path("captions-compare.md"))
+	}
+	if captionBundleFlag {
+		paths = append(paths, path("caption.txt"))
+		if clipboardOnlyFlag || !slices.Contains(formats, "srt") {
+			paths = append(paths, path("srt"))
+		}
+	}
+	if commentsFlag > 0 {
+		paths = append(paths, path("comments.json"))
+	}
+	if provenanceFlag && !clipboardOnlyFlag && !slices.Contains(formats, "json") {
+		paths = append(paths, path("provenance.json"))
+	}
+	return paths, nil
+}
+
+// resolveConflicts asks what to do with each of paths that already exists:
+// overwrite it, write to a renamed file beside it, keep it, or overwrite it
+// and all the rest. It returns the answers in the form of outputTargets.
+func resolveConflicts(paths []string, in io.Reader, out io.Writer) (map[string]string, error) {
+	targets := make(map[string]string)
+	reader := bufio.NewReader(in)
+	overwriteAll := false
+
+	for _, path := range paths {
+		if overwriteAll || !fileExists(path) {
+			continue
+		}
+
+		for answered := false; !answered; {
+			fmt.Fprintf(out, "%s already exists. [o]verwrite, [r]ename, [s]kip, overwrite [a]ll? ", path)
+			line, err := reader.ReadString('\n')
+			if err != nil && line == "" {
+				if errors.Is(err, io.EOF) {
+					return nil, errors.New("no answer for existing output; pass --force or --skip-existing")
+				}
+				return nil, err
+			}
+
+			answered = true
+			switch strings.ToLower(strings.TrimSpace(line)) {
+			case "o", "overwrite":
+			case "a", "all":
+				overwriteAll = true
+			case "r", "rename":
+				targets[path] = renamedPath(path)
+			case "s", "skip":
+				targets[path] = ""
+			default:
+				answered = false
+			}
+		}
+	}
+	return targets, nil
+}
+
+// renamedPath returns the first free path with a -N suffix before the
+// extension, e.g. ABC123-1.txt
+func renamedPath(path string) string {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", stem, i, ext)
+		if !fileExists(candidate) {
+			return candidate
+		}
+	}
+}
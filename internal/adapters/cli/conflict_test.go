@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveConflicts(t *testing.T) {
+	dir := t.TempDir()
+	existing := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	txt, srt, md := existing("ABC123.txt"), existing("ABC123.srt"), existing("ABC123.md")
+	existing("ABC123-1.srt")
+	missing := filepath.Join(dir, "ABC123.json")
+
+	var out bytes.Buffer
+	targets, err := resolveConflicts([]string{missing, txt, srt, md}, strings.NewReader("s\nwhat\nr\no\n"), &out)
+	if err != nil {
+		t.Fatalf("resolveConflicts() error = %v", err)
+	}
+
+	if target, ok := targets[txt]; !ok || target != "" {
+		t.Errorf("skipped target = %q, %v; want kept", target, ok)
+	}
+	if want := filepath.Join(dir, "ABC123-2.srt"); targets[srt] != want {
+		t.Errorf("renamed target = %q, want %q", targets[srt], want)
+	}
+	if _, ok := targets[md]; ok {
+		t.Errorf("overwritten output should have no target")
+	}
+	if _, ok := targets[missing]; ok {
+		t.Errorf("missing output should not be asked about")
+	}
+	if n := strings.Count(out.String(), "already exists"); n != 4 {
+		t.Errorf("prompted %d times, want 4 including the re-prompt", n)
+	}
+}
+
+func TestResolveConflicts_OverwriteAll(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"ABC123.txt", "ABC123.srt"} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte("old"), 0644)
+		paths = append(paths, path)
+	}
+
+	var out bytes.Buffer
+	targets, err := resolveConflicts(paths, strings.NewReader("a\n"), &out)
+	if err != nil || len(targets) != 0 {
+		t.Errorf("resolveConflicts() = %v, %v; want no targets", targets, err)
+	}
+	if n := strings.Count(out.String(), "already exists"); n != 1 {
+		t.Errorf("prompted %d times, want 1", n)
+	}
+}
+
+func TestResolveConflicts_NoAnswer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ABC123.txt")
+	os.WriteFile(path, []byte("old"), 0644)
+
+	if _, err := resolveConflicts([]string{path}, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Error("expected an error when stdin closes without an answer")
+	}
+}
+
+func TestOutputTarget(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "ABC123.txt")
+	os.WriteFile(existing, []byte("old"), 0644)
+	missing := filepath.Join(dir, "ABC123.srt")
+
+	oldSkip, oldTargets := skipExistingFlag, outputTargets
+	defer func() { skipExistingFlag, outputTargets = oldSkip, oldTargets }()
+	outputTargets = nil
+
+	skipExistingFlag = false
+	if path, write := outputTarget(existing); path != existing || !write {
+		t.Errorf("default: got %q, %v; want overwrite", path, write)
+	}
+
+	skipExistingFlag = true
+	if _, write := outputTarget(existing); write {
+		t.Error("--skip-existing should keep an existing file")
+	}
+	if _, write := outputTarget(missing); !write {
+		t.Error("--skip-existing should still write a missing file")
+	}
+
+	renamed := filepath.Join(dir, "ABC123-1.txt")
+	outputTargets = map[string]string{existing: renamed}
+	if path, write := outputTarget(existing); path != renamed || !write {
+		t.Errorf("renamed: got %q, %v; want %q", path, write, renamed)
+	}
+}
+
+func TestValidateConflictFlags(t *testing.T) {
+	oldForce, oldSkip := forceFlag, skipExistingFlag
+	defer func() { forceFlag, skipExistingFlag = oldForce, oldSkip }()
+
+	forceFlag, skipExistingFlag = true, true
+	if err := validateConflictFlags(); err == nil {
+		t.Error("expected an error for --force with --skip-existing")
+	}
+}
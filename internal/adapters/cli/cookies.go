@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+// loginCookies returns the login cookies to pass to yt-dlp: override (from
+// --cookies and --cookies-from-browser) when set, otherwise the config's,
+// otherwise the session stored by 'auth login' at sessionPath. A cookies
+// file from the flags or config must exist.
+func loginCookies(cfg *config.Config, override ytdlp.Cookies, sessionPath string) (ytdlp.Cookies, error) {
+	cookies := ytdlp.Cookies{File: cfg.Cookies.File, FromBrowser: cfg.Cookies.FromBrowser}
+	if override != (ytdlp.Cookies{}) {
+		cookies = override
+	}
+	if cookies == (ytdlp.Cookies{}) {
+		if _, err := os.Stat(sessionPath); err == nil {
+			cookies.File = sessionPath
+		}
+	}
+
+	if cookies.File != "" {
+		if _, err := os.Stat(cookies.File); err != nil {
+			return ytdlp.Cookies{}, fmt.Errorf("cookies file: %w", err)
+		}
+	}
+	return cookies, nil
+}
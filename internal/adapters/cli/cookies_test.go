@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+func TestLoginCookies(t *testing.T) {
+	cookiesFile := filepath.Join(t.TempDir(), "cookies.txt")
+	if err := os.WriteFile(cookiesFile, []byte("# Netscape HTTP Cookie File\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sessionPath := filepath.Join(t.TempDir(), "session.txt")
+	if err := os.WriteFile(sessionPath, []byte("# Netscape HTTP Cookie File\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stored session is used when nothing else is set
+	cfg := config.DefaultConfig()
+	var none ytdlp.Cookies
+	if got, err := loginCookies(cfg, none, sessionPath); err != nil || got != (ytdlp.Cookies{File: sessionPath}) {
+		t.Errorf("session cookies = %+v, %v", got, err)
+	}
+	if got, _ := loginCookies(cfg, none, filepath.Join(t.TempDir(), "none.txt")); got != (ytdlp.Cookies{}) {
+		t.Errorf("cookies without any login = %+v, want none", got)
+	}
+
+	cfg.Cookies.File = cookiesFile
+	if got, err := loginCookies(cfg, none, sessionPath); err != nil || got != (ytdlp.Cookies{File: cookiesFile}) {
+		t.Errorf("config cookies = %+v, %v", got, err)
+	}
+
+	// Flags replace the config's settings
+	if got, err := loginCookies(cfg, ytdlp.Cookies{FromBrowser: "firefox"}, sessionPath); err != nil || got != (ytdlp.Cookies{FromBrowser: "firefox"}) {
+		t.Errorf("flag cookies = %+v, %v", got, err)
+	}
+
+	missing := ytdlp.Cookies{File: filepath.Join(t.TempDir(), "missing.txt"), FromBrowser: "firefox"}
+	if _, err := loginCookies(cfg, missing, sessionPath); err == nil {
+		t.Error("expected error for a missing cookies file")
+	}
+}
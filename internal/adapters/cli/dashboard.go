@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var dashboardAddrFlag string
+
+// NewDashboardCmd creates the dashboard command
+func NewDashboardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Serve a local analytics dashboard",
+		Long: `Serve a page on this machine summarizing cached reels, processing history,
+per-model timings and account analytics. Everything is read from local
+files; nothing is sent anywhere. Only loopback addresses are accepted.`,
+		RunE: runDashboard,
+	}
+
+	cmd.Flags().StringVar(&dashboardAddrFlag, "addr", "127.0.0.1:8765", "Loopback address to listen on")
+
+	return cmd
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	if err := validateLoopbackAddr(dashboardAddrFlag); err != nil {
+		return err
+	}
+
+	app, err := GetApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	listener, err := net.Listen("tcp", dashboardAddrFlag)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", dashboardAddrFlag, err)
+	}
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	server := &http.Server{
+		Handler:           dashboardHandler(app.DashboardSvc, host, port),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Dashboard running at http://%s (Ctrl+C to stop)\n", listener.Addr())
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// validateLoopbackAddr rejects listen addresses reachable from other machines
+func validateLoopbackAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("refusing to listen on %q: the dashboard only binds to loopback addresses", addr)
+}
+
+// dashboardHandler serves the HTML page and its JSON snapshot to requests
+// addressed to a loopback host, or the bound host, on port
+func dashboardHandler(svc *application.DashboardService, host, port string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		snap, err := svc.Snapshot(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(snap)
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		snap, err := svc.Snapshot(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(w, newDashboardView(snap)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	return loopbackHostOnly(host, port, mux)
+}
+
+// loopbackHostOnly rejects requests whose Host header isn't a loopback name,
+// or the bound host, with the dashboard's port. Binding to loopback alone
+// doesn't stop a page that rebinds its own domain to 127.0.0.1 from reading
+// the dashboard.
+func loopbackHostOnly(bound, port string, next http.Handler) http.Handler {
+	allowed := make(map[string]bool)
+	for _, host := range []string{"localhost", "127.0.0.1", "::1", bound} {
+		hostPort := net.JoinHostPort(host, port)
+		allowed[strings.ToLower(hostPort)] = true
+		if port == "80" {
+			// Browsers leave the default port out
+			allowed[strings.ToLower(strings.TrimSuffix(hostPort, ":80"))] = true
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowed[strings.ToLower(r.Host)] {
+			http.Error(w, "invalid Host header", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// dashboardView adds the scale values the page needs to size its bars
+type dashboardView struct {
+	*application.DashboardSnapshot
+	MaxAverage time.Duration
+	MaxViews   int64
+}
+
+func newDashboardView(snap *application.DashboardSnapshot) dashboardView {
+	view := dashboardView{DashboardSnapshot: snap}
+	for _, mt := range snap.ModelTimings {
+		if mt.Average > view.MaxAverage {
+			view.MaxAverage = mt.Average
+		}
+	}
+	for _, as := range snap.Accounts {
+		if as.TotalViews > view.MaxViews {
+			view.MaxViews = as.TotalViews
+		}
+	}
+	return view
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"size": tui.FormatSize,
+	"num":  tui.FormatNumber,
+	"dur": func(d time.Duration) string {
+		return d.Round(100 * time.Millisecond).String()
+	},
+	"when": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format("2006-01-02 15:04")
+	},
+	"pct": func(v, max int64) int64 {
+		if max <= 0 {
+			return 0
+		}
+		return v * 100 / max
+	},
+	"durpct": func(v, max time.Duration) int64 {
+		if max <= 0 {
+			return 0
+		}
+		return int64(v * 100 / max)
+	},
+	"status": func(r domain.RunRecord) string {
+		switch {
+		case !r.Success:
+			return "failed"
+		case r.FromCache:
+			return "cached"
+		default:
+			return "ok"
+		}
+	},
+}).Parse(dashboardHTML))
+
+const dashboardHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>ig2insights dashboard</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+h1 { margin-bottom: 0.2rem; }
+.muted { color: #777; font-size: 0.9rem; }
+.cards { display: flex; gap: 1rem; margin: 1.5rem 0; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: 0.8rem 1.2rem; min-width: 8rem; }
+.card b { display: block; font-size: 1.6rem; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { text-align: left; padding: 0.3rem 0.6rem; border-bottom: 1px solid #eee; }
+.bar { background: #4a7bd0; height: 0.8rem; border-radius: 2px; }
+.failed { color: #b00020; }
+.cached { color: #777; }
+</style>
+</head>
+<body>
+<h1>ig2insights</h1>
+<div class="muted">Generated {{when .GeneratedAt}} from local files only</div>
+
+<div class="cards">
+<div class="card"><b>{{.Cache.ItemCount}}</b>cached reels</div>
+<div class="card"><b>{{size .Cache.TotalSize}}</b>cache size</div>
+<div class="card"><b>{{.TotalRuns}}</b>runs</div>
+<div class="card"><b>{{.CachedRuns}}</b>served from cache</div>
+<div class="card"><b>{{.FailedRuns}}</b>failed</div>
+</div>
+
+<h2>Model timings</h2>
+{{if .ModelTimings}}
+<table>
+<tr><th>Model</th><th>Runs</th><th>Average</th><th>Fastest</th><th>Slowest</th><th style="width:40%"></th></tr>
+{{range .ModelTimings}}
+<tr><td>{{.Model}}</td><td>{{.Runs}}</td><td>{{dur .Average}}</td><td>{{dur .Fastest}}</td><td>{{dur .Slowest}}</td>
+<td><div class="bar" style="width:{{durpct .Average $.MaxAverage}}%"></div></td></tr>
+{{end}}
+</table>
+{{else}}<p class="muted">No fresh transcriptions recorded yet.</p>{{end}}
+
+<h2>Accounts</h2>
+{{if .Accounts}}
+<table>
+<tr><th>Account</th><th>Reels</th><th>Total views</th><th>Avg views</th><th>Likes</th><th>Top reel</th><th style="width:30%"></th></tr>
+{{range .Accounts}}
+<tr><td>@{{.Username}}</td><td>{{.Reels}}</td><td>{{num .TotalViews}}</td><td>{{num .AverageViews}}</td><td>{{num .TotalLikes}}</td><td>{{.TopReelID}}</td>
+<td><div class="bar" style="width:{{pct .TotalViews $.MaxViews}}%"></div></td></tr>
+{{end}}
+</table>
+{{else}}<p class="muted">No cached reels with author metadata.</p>{{end}}
+
+<h2>Recent runs</h2>
+{{if .RecentRuns}}
+<table>
+<tr><th>Started</th><th>Reel</th><th>Model</th><th>Duration</th><th>Status</th></tr>
+{{range .RecentRuns}}
+<tr><td>{{when .StartedAt}}</td><td>{{.ReelID}}</td><td>{{.Model}}</td><td>{{dur .Duration}}</td>
+<td class="{{status .}}">{{status .}}{{if .Error}}: {{.Error}}{{end}}</td></tr>
+{{end}}
+</table>
+{{else}}<p class="muted">No runs recorded yet.</p>{{end}}
+
+<h2>Cache contents</h2>
+{{if .CachedReels}}
+<table>
+<tr><th>Reel</th><th>Author</th><th>Model</th><th>Words</th><th>Views</th><th>Cached</th></tr>
+{{range .CachedReels}}
+<tr{{if .Expired}} class="cached"{{end}}><td>{{.ID}}</td><td>{{.Author}}</td><td>{{.Model}}</td><td>{{.Words}}</td><td>{{num .Views}}</td>
+<td>{{when .CreatedAt}}{{if .Expired}} (expired){{end}}</td></tr>
+{{end}}
+</table>
+{{else}}<p class="muted">The cache is empty.</p>{{end}}
+</body>
+</html>
+`
+
+// recordRun appends a transcription attempt to the local history. Failures
+// to record never interrupt the run itself.
+func recordRun(ctx context.Context, app *App, reelID, model, language string, startedAt time.Time, result *application.TranscribeResult, err error) {
+	record := domain.RunRecord{
+		ReelID:    reelID,
+		Model:     model,
+		Language:  language,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+		Success:   err == nil,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if result != nil {
+		record.FromCache = result.TranscriptFromCache
+		if result.Reel != nil {
+			record.Author = result.Reel.Author
+			record.AudioSeconds = result.Reel.DurationSeconds
+		}
+		record.TranscribeDuration = result.TranscribeDuration
+		record.DownloadDuration = result.DownloadDuration
+		if result.Transcript != nil && result.Transcript.Source != "" {
+			record.Model = result.Transcript.Source
+		} else if result.Transcript != nil && result.Transcript.Model != "" {
+			record.Model = result.Transcript.Model
+		}
+		if result.Transcript != nil {
+			record.Backend = result.Transcript.Backend
+		}
+	}
+	_ = app.HistorySvc.Record(ctx, record)
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cache"
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/adapters/history"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestValidateLoopbackAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		wantErr bool
+	}{
+		{"127.0.0.1:8765", false},
+		{"localhost:8765", false},
+		{"[::1]:8765", false},
+		{"127.0.0.2:8765", false},
+		{"0.0.0.0:8765", true},
+		{":8765", true},
+		{"192.168.1.10:8765", true},
+		{"not-an-address", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			err := validateLoopbackAddr(tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateLoopbackAddr(%q) error = %v, wantErr %v", tt.addr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDashboardHandler(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	cacheStore := cache.NewFileCache(filepath.Join(dir, "cache"))
+	historyStore := history.NewFileStore(filepath.Join(dir, "history.jsonl"))
+
+	item := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "ABC123", Author: "creator", ViewCount: 42},
+		Transcript: &domain.Transcript{Text: "hello there", Model: "small"},
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	if err := cacheStore.Set(ctx, "ABC123", item); err != nil {
+		t.Fatal(err)
+	}
+	if err := historyStore.Append(ctx, domain.RunRecord{ReelID: "ABC123", Model: "small", Duration: time.Second, Success: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := dashboardHandler(application.NewDashboardService(cacheStore, historyStore), "127.0.0.1", "8765")
+	get := func(host, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/", "/api/snapshot"} {
+		rec := get("127.0.0.1:8765", path)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, body = %s", path, rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), "creator") {
+			t.Errorf("GET %s should mention the cached reel's author", path)
+		}
+	}
+
+	if rec := get("localhost:8765", "/missing"); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /missing status = %d, want 404", rec.Code)
+	}
+
+	// A DNS-rebinding page reaches the loopback server under its own name
+	for _, host := range []string{"attacker.example:8765", "localhost:9999", "127.0.0.1"} {
+		if rec := get(host, "/api/snapshot"); rec.Code != http.StatusForbidden {
+			t.Errorf("GET with Host %s status = %d, want 403", host, rec.Code)
+		}
+	}
+	if rec := get("[::1]:8765", "/api/snapshot"); rec.Code != http.StatusOK {
+		t.Errorf("GET with Host [::1]:8765 status = %d, want 200", rec.Code)
+	}
+}
+
+func TestLoopbackHostOnly_BoundHost(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		bound, port, host string
+		want              int
+	}{
+		// Any loopback IP passes validateLoopbackAddr, so the bound one is
+		// reachable under its own name
+		{"127.0.0.2", "8765", "127.0.0.2:8765", http.StatusOK},
+		{"127.0.0.2", "8765", "127.0.0.1:8765", http.StatusOK},
+		{"127.0.0.2", "8765", "127.0.0.3:8765", http.StatusForbidden},
+		{"127.0.0.2", "80", "127.0.0.2", http.StatusOK},
+		{"::1", "80", "[::1]", http.StatusOK},
+		{"127.0.0.1", "8765", "127.0.0.2:8765", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.bound+"/"+tt.host, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+			loopbackHostOnly(tt.bound, tt.port, ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("Host %s status = %d, want %d", tt.host, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessSelectedReels_RecordsRuns(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+	ctx := context.Background()
+
+	oldDir := dirFlag
+	defer func() { dirFlag = oldDir }()
+	dirFlag = filepath.Join(dir, "out")
+
+	reels := []*domain.Reel{{ID: "GOOD1"}, {ID: "privateREEL"}}
+	if err := processSelectedReels(ctx, app, reels, &tui.OutputOptions{}); err != nil {
+		t.Fatalf("processSelectedReels() error = %v", err)
+	}
+
+	records, err := history.NewFileStore(filepath.Join(dir, "history.jsonl")).List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(records) != 2 || !records[0].Success || records[1].Success {
+		t.Errorf("records = %+v, want GOOD1's success and privateREEL's failure", records)
+	}
+}
@@ -7,11 +7,34 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// aria2cDownloader is implemented by downloaders that can hand downloads to
+// aria2c
+type aria2cDownloader interface {
+	Aria2cEnabled() bool
+	IsAria2cAvailable() bool
+	GetAria2cPath() string
+	Aria2cInstructions() string
+	InstallAria2c(ctx context.Context, progress func(downloaded, total int64)) error
+}
+
+// enabledAria2c returns the yt-dlp downloader behind d when it's set to use
+// aria2c
+func enabledAria2c(d Downloader) (aria2cDownloader, bool) {
+	if router, ok := d.(*ytdlpRouter); ok {
+		d = router.ytdlp
+	}
+	aria2c, ok := d.(aria2cDownloader)
+	if !ok || !aria2c.Aria2cEnabled() {
+		return nil, false
+	}
+	return aria2c, true
+}
+
 // NewDepsCmd creates the deps subcommand
 func NewDepsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "deps",
-		Short: "Manage dependencies (yt-dlp, whisper.cpp, ffmpeg)",
+		Short: "Manage dependencies (yt-dlp or gallery-dl, whisper.cpp, ffmpeg)",
 	}
 
 	statusCmd := &cobra.Command{
@@ -22,13 +45,13 @@ func NewDepsCmd() *cobra.Command {
 
 	updateCmd := &cobra.Command{
 		Use:   "update",
-		Short: "Update yt-dlp to latest version",
+		Short: "Update the downloader (yt-dlp or gallery-dl) to latest version",
 		RunE:  runDepsUpdate,
 	}
 
 	installCmd := &cobra.Command{
 		Use:   "install",
-		Short: "Install yt-dlp",
+		Short: "Install the downloader, whisper.cpp and ffmpeg (and aria2c when enabled)",
 		RunE:  runDepsInstall,
 	}
 
@@ -46,12 +69,13 @@ func runDepsStatus(cmd *cobra.Command, args []string) error {
 	fmt.Println("Dependency Status:")
 	fmt.Println()
 
-	// yt-dlp
+	// Downloader backend
+	name, _ := downloaderName(app.Config)
 	if app.Downloader.IsAvailable() {
 		path := app.Downloader.GetBinaryPath()
-		fmt.Printf("  yt-dlp:        installed (%s)\n", path)
+		fmt.Printf("  %-15sinstalled (%s)\n", name+":", path)
 	} else {
-		fmt.Println("  yt-dlp:        not found")
+		fmt.Printf("  %-15snot found\n", name+":")
 	}
 
 	// Whisper binary
@@ -70,6 +94,15 @@ func runDepsStatus(cmd *cobra.Command, args []string) error {
 		fmt.Println("  ffmpeg:        not found")
 	}
 
+	// aria2c, when downloads should use it
+	if aria2c, ok := enabledAria2c(app.Downloader); ok {
+		if aria2c.IsAria2cAvailable() {
+			fmt.Printf("  aria2c:        installed (%s)\n", aria2c.GetAria2cPath())
+		} else {
+			fmt.Println("  aria2c:        not found (downloads use yt-dlp's own downloader)")
+		}
+	}
+
 	// Whisper models
 	models := app.Transcriber.AvailableModels()
 	downloaded := 0
@@ -90,18 +123,19 @@ func runDepsUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	name, _ := downloaderName(app.Config)
 	if !app.Downloader.IsAvailable() {
-		return fmt.Errorf("yt-dlp is not installed. Run 'ig2insights deps install' first")
+		return fmt.Errorf("%s is not installed. Run 'ig2insights deps install' first", name)
 	}
 
-	fmt.Println("Updating yt-dlp...")
+	fmt.Printf("Updating %s...\n", name)
 
 	ctx := context.Background()
 	if err := app.Downloader.Update(ctx); err != nil {
 		return err
 	}
 
-	fmt.Println("yt-dlp updated")
+	fmt.Printf("%s updated\n", name)
 	return nil
 }
 
@@ -119,15 +153,16 @@ func runDepsInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Install yt-dlp
+	// Install the downloader backend
+	name, _ := downloaderName(app.Config)
 	if app.Downloader.IsAvailable() {
-		fmt.Println("yt-dlp is already installed")
+		fmt.Printf("%s is already installed\n", name)
 	} else {
-		fmt.Println("Installing yt-dlp...")
+		fmt.Printf("Installing %s...\n", name)
 		if err := app.Downloader.Install(ctx, progress); err != nil {
-			return fmt.Errorf("failed to install yt-dlp: %w", err)
+			return fmt.Errorf("failed to install %s: %w", name, err)
 		}
-		fmt.Println("\nyt-dlp installed")
+		fmt.Printf("\n%s installed\n", name)
 	}
 
 	// Install whisper.cpp
@@ -157,5 +192,20 @@ func runDepsInstall(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Install aria2c when downloads should use it
+	if aria2c, ok := enabledAria2c(app.Downloader); ok {
+		if aria2c.IsAria2cAvailable() {
+			fmt.Println("aria2c is already installed")
+		} else if instructions := aria2c.Aria2cInstructions(); instructions != "" {
+			fmt.Println(instructions)
+		} else {
+			fmt.Println("Installing aria2c...")
+			if err := aria2c.InstallAria2c(ctx, progress); err != nil {
+				return fmt.Errorf("failed to install aria2c: %w", err)
+			}
+			fmt.Println("\naria2c installed")
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/devbush/ig2insights/internal/adapters/gallerydl"
+	"github.com/devbush/ig2insights/internal/adapters/graphapi"
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+// Downloader backends that can be selected in the config
+const (
+	downloaderYtDlp     = "yt-dlp"
+	downloaderGalleryDL = "gallery-dl"
+	downloaderGraphAPI  = "graph-api"
+)
+
+// downloaderName returns the configured downloader backend, validating it
+func downloaderName(cfg *config.Config) (string, error) {
+	switch cfg.Downloader {
+	case "", downloaderYtDlp:
+		return downloaderYtDlp, nil
+	case downloaderGalleryDL, downloaderGraphAPI:
+		return cfg.Downloader, nil
+	}
+	return "", fmt.Errorf("unknown downloader: %s (use %s, %s or %s)", cfg.Downloader, downloaderYtDlp, downloaderGalleryDL, downloaderGraphAPI)
+}
+
+// selectDownloader returns the configured downloader backend. The others
+// share yt-dlp's ffmpeg handling and leave YouTube and --any-url videos to
+// yt-dlp. gallery-dl gets the same throttle, cookies and proxy; the Graph
+// API only the proxy. Both wait on yt-dlp's rate limiter, so batch workers
+// share its pacing. client is nil when no proxy is set.
+func selectDownloader(cfg *config.Config, yt *ytdlp.Downloader, throttle config.ThrottleProfile, cookies ytdlp.Cookies, proxy string, client *http.Client) (Downloader, error) {
+	name, err := downloaderName(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case downloaderYtDlp:
+		return yt, nil
+	case downloaderGraphAPI:
+		api := graphapi.NewDownloader(cfg.GetGraphAPIToken(), cfg.GraphAPI.UserID, yt)
+		api.SetLimiter(yt)
+		if client != nil {
+			api.SetHTTPClient(client)
+		}
+		return &ytdlpRouter{Downloader: api, ytdlp: yt}, nil
+	}
+
+	gdl := gallerydl.NewDownloader(yt)
+	gdl.SetBinDir(yt.BinDir())
+	gdl.SetThrottle(galleryDLThrottle(throttle))
+	gdl.SetCookies(gallerydl.Cookies(cookies))
+	gdl.SetLimiter(yt)
+	if proxy != "" {
+		gdl.SetProxy(proxy, client)
+	}
+	return &ytdlpRouter{Downloader: gdl, ytdlp: yt}, nil
+}
+
+// galleryDLThrottle converts a throttle profile into the pacing options
+// gallery-dl supports
+func galleryDLThrottle(p config.ThrottleProfile) gallerydl.Throttle {
+	yt := ytdlpThrottle(p)
+	return gallerydl.Throttle{
+		SleepRequests: yt.SleepRequests,
+		SleepInterval: yt.SleepInterval,
+		Retries:       yt.Retries,
+	}
+}
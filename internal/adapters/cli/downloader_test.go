@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/gallerydl"
+	"github.com/devbush/ig2insights/internal/adapters/graphapi"
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestSelectDownloader(t *testing.T) {
+	yt := ytdlp.NewDownloader()
+	cfg := config.DefaultConfig()
+
+	got, err := selectDownloader(cfg, yt, config.ThrottleProfile{}, ytdlp.Cookies{}, "", nil)
+	if err != nil || got != Downloader(yt) {
+		t.Errorf("default downloader = %T, %v; want yt-dlp", got, err)
+	}
+
+	cfg.Downloader = "gallery-dl"
+	got, err = selectDownloader(cfg, yt, config.ThrottleProfile{}, ytdlp.Cookies{}, "", nil)
+	if router, ok := got.(*ytdlpRouter); err != nil || !ok || router.ytdlp != Downloader(yt) {
+		t.Fatalf("gallery-dl downloader = %T, %v; want YouTube routed to yt-dlp", got, err)
+	} else if _, ok := router.Downloader.(*gallerydl.Downloader); !ok {
+		t.Errorf("gallery-dl downloader = %T, %v", got, err)
+	}
+
+	cfg.Downloader = "graph-api"
+	got, err = selectDownloader(cfg, yt, config.ThrottleProfile{}, ytdlp.Cookies{}, "", nil)
+	if router, ok := got.(*ytdlpRouter); err != nil || !ok {
+		t.Fatalf("graph-api downloader = %T, %v; want YouTube routed to yt-dlp", got, err)
+	} else if _, ok := router.Downloader.(*graphapi.Downloader); !ok {
+		t.Errorf("graph-api downloader = %T, %v", got, err)
+	}
+
+	cfg.Downloader = "wget"
+	if _, err := selectDownloader(cfg, yt, config.ThrottleProfile{}, ytdlp.Cookies{}, "", nil); err == nil {
+		t.Error("selectDownloader() expected error for an unknown backend")
+	}
+}
+
+func TestGalleryDLThrottle(t *testing.T) {
+	got := galleryDLThrottle(config.ThrottleProfile{SleepRequests: "2s", SleepInterval: "3s", Retries: 4, Impersonate: "chrome"})
+	if got.SleepRequests.String() != "2s" || got.SleepInterval.String() != "3s" || got.Retries != 4 {
+		t.Errorf("galleryDLThrottle() = %+v", got)
+	}
+}
+
+// recordingDownloader records the reels it was asked for
+type recordingDownloader struct {
+	Downloader
+	name string
+	got  []string
+}
+
+func (d *recordingDownloader) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	d.got = append(d.got, reelID)
+	return &domain.Reel{ID: reelID, Author: d.name}, nil
+}
+
+func TestYouTubeRouter(t *testing.T) {
+	instagram := &recordingDownloader{name: "instagram"}
+	youtube := &recordingDownloader{name: "youtube"}
+	router := &ytdlpRouter{Downloader: instagram, ytdlp: youtube}
+
+	local := domain.LocalFileID("/home/me/talk.mp4", 1024, time.Time{})
+	for _, id := range []string{"DToLsd-EvGJ", domain.YouTubeID("dQw4w9WgXcQ"), "DToLsd-EvGJ.2", local} {
+		if _, err := router.GetReel(context.Background(), id); err != nil {
+			t.Fatalf("GetReel(%q) error = %v", id, err)
+		}
+	}
+	if len(instagram.got) != 2 || len(youtube.got) != 2 || youtube.got[0] != "yt.dQw4w9WgXcQ" || youtube.got[1] != local {
+		t.Errorf("Instagram got %v, YouTube got %v", instagram.got, youtube.got)
+	}
+
+	if result, err := router.DownloadSubtitles(context.Background(), "DToLsd-EvGJ", t.TempDir(), "auto"); result != nil || err != nil {
+		t.Errorf("DownloadSubtitles() = %v, %v; want none from a backend without subtitles", result, err)
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// maxDuration returns the --max-duration limit, falling back to the config default
+func maxDuration(cfg *config.Config) (time.Duration, error) {
+	if maxDurationFlag > 0 {
+		return maxDurationFlag, nil
+	}
+	return cfg.GetMaxDuration()
+}
+
+// checkReelDuration looks up a single reel's length before anything is
+// downloaded and, when it exceeds the limit, asks whether to continue if
+// stdin is a terminal. It returns the limit the transcription should still
+// enforce: zero once the user has agreed to go ahead. Reels whose length
+// can't be looked up are left to the check after download.
+func checkReelDuration(ctx context.Context, app *App, reelID string, cached *ports.CachedItem) (time.Duration, error) {
+	limit, err := maxDuration(app.Config)
+	if err != nil || limit <= 0 {
+		return 0, err
+	}
+
+	var reel *domain.Reel
+	if cached != nil && cached.Reel != nil && cached.Reel.DurationSeconds > 0 {
+		reel = cached.Reel
+	} else if reel, err = app.Downloader.GetReel(ctx, reelID); err != nil {
+		return limit, nil
+	}
+
+	tooLong := reel.CheckDuration(limit)
+	if tooLong == nil {
+		return limit, nil
+	}
+	if !stdinIsTerminal() {
+		return 0, tooLong
+	}
+	if !confirm(os.Stdin, os.Stderr, fmt.Sprintf("%s (%s). Transcribe anyway?", reelID, tooLong)) {
+		return 0, tooLong
+	}
+	return 0, nil
+}
+
+// stdinIsTerminal reports whether a user can answer prompts
+func stdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks a yes/no question on out, defaulting to no
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
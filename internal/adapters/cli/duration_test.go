@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if got := confirm(strings.NewReader(tt.input), &out, "Continue?"); got != tt.want {
+			t.Errorf("confirm(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if out.String() != "Continue? [y/N] " {
+			t.Errorf("prompt = %q", out.String())
+		}
+	}
+}
+
+func TestCheckReelDuration(t *testing.T) {
+	app := newMockApp(t.TempDir())
+	ctx := context.Background()
+
+	oldMax := maxDurationFlag
+	defer func() { maxDurationFlag = oldMax }()
+
+	maxDurationFlag = 0
+	if limit, err := checkReelDuration(ctx, app, "ABC123", nil); limit != 0 || err != nil {
+		t.Errorf("no limit: got %v, %v", limit, err)
+	}
+
+	maxDurationFlag = 10 * time.Minute
+	if limit, err := checkReelDuration(ctx, app, "ABC123", nil); limit != 10*time.Minute || err != nil {
+		t.Errorf("short reel: got %v, %v; want the limit passed on", limit, err)
+	}
+
+	// Cached metadata is used without a lookup; tests never run on a terminal
+	cached := &ports.CachedItem{Reel: &domain.Reel{ID: "LONG1", DurationSeconds: 3600}}
+	if _, err := checkReelDuration(ctx, app, "LONG1", cached); !errors.Is(err, domain.ErrReelTooLong) {
+		t.Errorf("long reel: error = %v, want ErrReelTooLong", err)
+	}
+}
+
+func TestProcessOneReel_MaxDuration(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+
+	oldMax := maxDurationFlag
+	defer func() { maxDurationFlag = oldMax }()
+	maxDurationFlag = time.Second
+
+	ctx := context.Background()
+	result := processOneReel(ctx, app, "ABC123", dir, batchRow{}, newBatchRun(ctx, app, dir))
+	if result.Success || !strings.Contains(result.Error, domain.ErrReelTooLong.Error()) {
+		t.Errorf("result = %+v, want a skip for exceeding the maximum duration", result)
+	}
+}
+
+func TestApplyTranscribeDefaults_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(*config.Config)
+	}{
+		{"timeout", func(c *config.Config) { c.Defaults.Timeout = "soon" }},
+		{"max duration", func(c *config.Config) { c.Defaults.MaxDuration = "long" }},
+		{"download backoff", func(c *config.Config) { c.Defaults.DownloadBackoff = "later" }},
+		{"chunk length", func(c *config.Config) { c.Defaults.ChunkLength = "-1m" }},
+		{"video quality", func(c *config.Config) { c.Defaults.VideoQuality = "huge" }},
+	}
+
+	for _, tt := range tests {
+		cfg := config.DefaultConfig()
+		tt.set(cfg)
+		var opts application.TranscribeOptions
+		if err := applyTranscribeDefaults(&opts, cfg); err == nil {
+			t.Errorf("applyTranscribeDefaults() with an invalid %s expected an error", tt.name)
+		}
+	}
+}
+
+func TestApplyTranscribeDefaults_Retries(t *testing.T) {
+	oldRetries := retriesFlag
+	defer func() { retriesFlag = oldRetries }()
+
+	cfg := config.DefaultConfig()
+	cfg.Defaults.Retries = 2
+	tests := []struct {
+		flag int
+		want int
+	}{
+		{-1, 2}, // unset: the config default
+		{0, 0},  // --retries 0 turns the default off
+		{3, 3},
+	}
+
+	for _, tt := range tests {
+		retriesFlag = tt.flag
+		var opts application.TranscribeOptions
+		if err := applyTranscribeDefaults(&opts, cfg); err != nil {
+			t.Fatalf("applyTranscribeDefaults() error = %v", err)
+		}
+		if opts.Retries != tt.want {
+			t.Errorf("--retries %d: Retries = %d, want %d", tt.flag, opts.Retries, tt.want)
+		}
+	}
+}
+
+func TestRetriesFlagsHelp(t *testing.T) {
+	flags := NewRootCmd().PersistentFlags()
+	for _, name := range []string{"retries", "download-retries"} {
+		if flags.Lookup(name).Value.String() != "-1" {
+			t.Errorf("--%s should still default to -1, the config's value", name)
+		}
+	}
+	if usage := flags.FlagUsages(); strings.Contains(usage, "(default -1)") {
+		t.Errorf("help shows the -1 sentinel:\n%s", usage)
+	}
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// reelEstimate is the predicted whisper work for one reel of a batch
+type reelEstimate struct {
+	ReelID       string
+	AudioSeconds int  // 0 when the length is unknown
+	Cached       bool // a cached transcript makes the run nearly free
+	Time         time.Duration
+}
+
+// estimateReels predicts the transcription time of each reel. Lengths come
+// from cached metadata, or with lookup from Instagram; reels with a cached
+// transcript cost nothing unless --no-cache is set.
+func estimateReels(ctx context.Context, app *App, est domain.Estimator, reelIDs []string, lookup bool) []reelEstimate {
+	estimates := make([]reelEstimate, 0, len(reelIDs))
+	for _, id := range reelIDs {
+		e := reelEstimate{ReelID: id}
+		cached, err := app.Cache.Get(ctx, id)
+		if err == nil && cached != nil {
+			e.Cached = cached.Transcript != nil && !noCacheFlag
+			if cached.Reel != nil {
+				e.AudioSeconds = cached.Reel.DurationSeconds
+			}
+		}
+		if e.AudioSeconds == 0 && !e.Cached && lookup {
+			if reel, err := app.Downloader.GetReel(ctx, id); err == nil {
+				e.AudioSeconds = reel.DurationSeconds
+			}
+		}
+		if !e.Cached {
+			e.Time = est.Estimate(e.AudioSeconds)
+		}
+		estimates = append(estimates, e)
+	}
+	return estimates
+}
+
+// batchEstimator returns the estimator for the batch's model
+func batchEstimator(ctx context.Context, app *App) domain.Estimator {
+	return modelEstimator(ctx, app, modelFlag)
+}
+
+// modelEstimator returns the estimator for model. Unreadable history falls
+// back to the static speeds rather than failing the batch.
+func modelEstimator(ctx context.Context, app *App, model string) domain.Estimator {
+	est, err := app.HistorySvc.Estimator(ctx, model)
+	if err != nil {
+		return domain.NewEstimator(nil, model)
+	}
+	return est
+}
+
+// estimators builds each model's estimator once, so a batch reads the
+// history once per model rather than once per reel. It's safe for
+// concurrent use.
+type estimators struct {
+	app     *App
+	mu      sync.Mutex
+	byModel map[string]domain.Estimator
+}
+
+func newEstimators(app *App) *estimators {
+	return &estimators{app: app, byModel: make(map[string]domain.Estimator)}
+}
+
+// forModel returns model's estimator
+func (e *estimators) forModel(ctx context.Context, model string) domain.Estimator {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	est, ok := e.byModel[model]
+	if !ok {
+		est = modelEstimator(ctx, e.app, model)
+		e.byModel[model] = est
+	}
+	return est
+}
+
+// printBatchPlan lists what a batch would transcribe and how long whisper
+// should take, without downloading anything
+func printBatchPlan(estimates []reelEstimate, est domain.Estimator) {
+	var total time.Duration
+	for _, e := range estimates {
+		length := "unknown"
+		if e.AudioSeconds > 0 {
+			length = domain.FormatChapterTime(float64(e.AudioSeconds))
+		}
+		switch {
+		case e.Cached:
+			fmt.Printf("  %-14s %8s   cached\n", e.ReelID, length)
+		default:
+			fmt.Printf("  %-14s %8s   ~%s\n", e.ReelID, length, formatWait(e.Time))
+		}
+		total += e.Time
+	}
+
+	fmt.Printf("\nEstimated transcription time: ~%s for %d reels with %s\n", formatWait(total), len(estimates), est.Model)
+	if est.Runs > 0 {
+		fmt.Printf("Based on the last %d %s runs on this machine (%.1fx real time)\n", est.Runs, est.Model, est.Speed)
+	} else {
+		fmt.Printf("No %s runs recorded on this machine yet; using a typical speed of %.1fx real time\n", est.Model, est.Speed)
+	}
+}
+
+// attachTimeSaved estimates the time result's cache hits saved, from this
+// machine's history
+func attachTimeSaved(ctx context.Context, app *App, result *application.TranscribeResult) {
+	newEstimators(app).attachTimeSaved(ctx, result)
+}
+
+// attachTimeSaved estimates the time result's cache hits saved with the
+// estimator of the model that made its transcript
+func (e *estimators) attachTimeSaved(ctx context.Context, result *application.TranscribeResult) {
+	if !result.TranscriptFromCache && !result.AudioFromCache {
+		return
+	}
+	audioSeconds := 0
+	if result.Reel != nil {
+		audioSeconds = result.Reel.DurationSeconds
+	}
+	model := modelFlag
+	if result.Transcript != nil && result.Transcript.Model != "" {
+		model = result.Transcript.Model
+	}
+	result.TimeSaved = e.forModel(ctx, model).Saved(audioSeconds, result.TranscriptFromCache, result.AudioFromCache)
+}
+
+// writeCacheMetrics prints how the cache served a batch's reels
+func writeCacheMetrics(w io.Writer, m domain.CacheMetrics) {
+	if m.Hits+m.Misses == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Cache: %d hits, %d misses (%.0f%% hit rate)", m.Hits, m.Misses, m.HitRate()*100)
+	if m.AudioHits > 0 {
+		fmt.Fprintf(w, ", cached audio for %d of the misses", m.AudioHits)
+	}
+	if m.TimeSaved > 0 {
+		fmt.Fprintf(w, ", saved ~%s", formatWait(m.TimeSaved))
+	}
+	fmt.Fprintln(w)
+}
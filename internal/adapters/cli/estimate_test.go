@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestEstimateReels(t *testing.T) {
+	app := newMockApp(t.TempDir())
+	ctx := context.Background()
+
+	oldNoCache := noCacheFlag
+	defer func() { noCacheFlag = oldNoCache }()
+	noCacheFlag = false
+
+	cached := &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "DONE1", DurationSeconds: 90},
+		Transcript: &domain.Transcript{Text: "hello"},
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	if err := app.Cache.Set(ctx, "DONE1", cached); err != nil {
+		t.Fatal(err)
+	}
+
+	est := domain.Estimator{Model: "small", Speed: 2, TypicalReel: 30}
+
+	estimates := estimateReels(ctx, app, est, []string{"DONE1", "NEW1"}, false)
+	if e := estimates[0]; !e.Cached || e.Time != 0 || e.AudioSeconds != 90 {
+		t.Errorf("cached reel = %+v, want no transcription time", e)
+	}
+	if e := estimates[1]; e.AudioSeconds != 0 || e.Time != 15*time.Second {
+		t.Errorf("unknown reel = %+v, want a typical reel's 15s", e)
+	}
+
+	// Looking up the length replaces the typical one
+	e := estimateReels(ctx, app, est, []string{"NEW1"}, true)[0]
+	if e.AudioSeconds == 0 || e.Time != est.Estimate(e.AudioSeconds) {
+		t.Errorf("looked-up reel = %+v", e)
+	}
+}
+
+func TestAttachTimeSaved(t *testing.T) {
+	app := newMockApp(t.TempDir())
+	ctx := context.Background()
+	est := batchEstimator(ctx, app)
+
+	result := &application.TranscribeResult{Reel: &domain.Reel{DurationSeconds: 60}, TranscriptFromCache: true}
+	attachTimeSaved(ctx, app, result)
+	if want := est.Download + est.Estimate(60); result.TimeSaved != want {
+		t.Errorf("transcript hit saved %v, want %v", result.TimeSaved, want)
+	}
+
+	result = &application.TranscribeResult{Reel: &domain.Reel{DurationSeconds: 60}}
+	attachTimeSaved(ctx, app, result)
+	if result.TimeSaved != 0 {
+		t.Errorf("miss saved %v, want 0", result.TimeSaved)
+	}
+}
+
+func TestEstimators_ResultModel(t *testing.T) {
+	app := newMockApp(t.TempDir())
+	ctx := context.Background()
+
+	oldModel := modelFlag
+	defer func() { modelFlag = oldModel }()
+	modelFlag = "tiny"
+
+	est := newEstimators(app)
+	for i := 0; i < 2; i++ {
+		result := &application.TranscribeResult{
+			Reel:                &domain.Reel{DurationSeconds: 60},
+			Transcript:          &domain.Transcript{Model: "large"},
+			TranscriptFromCache: true,
+		}
+		est.attachTimeSaved(ctx, result)
+		large := modelEstimator(ctx, app, "large")
+		if want := large.Download + large.Estimate(60); result.TimeSaved != want {
+			t.Errorf("saved %v, want %v from the transcript's model", result.TimeSaved, want)
+		}
+	}
+	if len(est.byModel) != 1 {
+		t.Errorf("built %d estimators, want one per model", len(est.byModel))
+	}
+}
+
+func TestWriteCacheMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	writeCacheMetrics(&buf, domain.CacheMetrics{})
+	if buf.Len() != 0 {
+		t.Errorf("empty metrics printed %q", buf.String())
+	}
+
+	writeCacheMetrics(&buf, domain.CacheMetrics{Hits: 3, Misses: 1, AudioHits: 1, TimeSaved: 95 * time.Second})
+	want := "Cache: 3 hits, 1 misses (75% hit rate), cached audio for 1 of the misses, saved ~1m35s\n"
+	if buf.String() != want {
+		t.Errorf("printed %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/devbush/ig2insights/internal/adapters/openai"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// Fallback backends that can be named in the config
+const (
+	backendWhisper = "whisper"
+	backendOpenAI  = "openai"
+)
+
+// transcriberFallbacks builds the configured fallback chain. whisper reuses
+// the local transcriber with another model; client, when set, carries the
+// proxy for hosted backends.
+func transcriberFallbacks(cfg *config.Config, whisper ports.Transcriber, client *http.Client) ([]application.TranscriberFallback, error) {
+	fallbacks := make([]application.TranscriberFallback, 0, len(cfg.Fallbacks))
+	for _, fc := range cfg.Fallbacks {
+		fallback := application.TranscriberFallback{Name: fc.Backend, Model: fc.Model}
+		switch fc.Backend {
+		case backendWhisper:
+			if fc.Model == "" {
+				return nil, fmt.Errorf("whisper fallback needs a model (e.g., tiny)")
+			}
+			fallback.Transcriber = whisper
+		case backendOpenAI:
+			t := openai.NewTranscriber(cfg.GetOpenAIAPIKey(), cfg.OpenAI.BaseURL)
+			if client != nil {
+				t.SetHTTPClient(client)
+			}
+			fallback.Transcriber = t
+			if fallback.Model == "" {
+				fallback.Model = openai.DefaultModel
+			}
+		default:
+			return nil, fmt.Errorf("unknown fallback backend: %s (use %s or %s)", fc.Backend, backendWhisper, backendOpenAI)
+		}
+		fallbacks = append(fallbacks, fallback)
+	}
+	return fallbacks, nil
+}
+
+// transcriptionModels returns the whisper models transcribing with model
+// may use: model, the retry model when retries are enabled, and those of the
+// fallback chain. They're downloaded up front so a retry or fallback doesn't
+// fail on a missing model.
+func transcriptionModels(cfg *config.Config, model string) []string {
+	models := []string{model}
+	retriesEnabled := retriesFlag > 0 || (retriesFlag < 0 && cfg.Defaults.Retries > 0)
+	if fallback := retryFallbackModel(cfg); retriesEnabled && fallback != "" && fallback != model {
+		models = append(models, fallback)
+	}
+	for _, m := range fallbackWhisperModels(cfg) {
+		if !slices.Contains(models, m) {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+// downloadModels downloads those of models that aren't downloaded yet,
+// reporting each one's progress
+func downloadModels(ctx context.Context, app *App, models []string, progress func(model string, downloaded, total int64)) error {
+	for _, m := range models {
+		if app.Transcriber.IsModelDownloaded(m) {
+			continue
+		}
+		if err := app.Transcriber.DownloadModel(ctx, m, func(d, t int64) {
+			progress(m, d, t)
+		}); err != nil {
+			return fmt.Errorf("failed to download model %s: %w", m, err)
+		}
+	}
+	return nil
+}
+
+// fallbackWhisperModels returns the whisper models the fallback chain uses
+func fallbackWhisperModels(cfg *config.Config) []string {
+	var models []string
+	for _, fc := range cfg.Fallbacks {
+		if fc.Backend == backendWhisper && fc.Model != "" {
+			models = append(models, fc.Model)
+		}
+	}
+	return models
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+func TestTranscriberFallbacks(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Fallbacks = []config.FallbackConfig{
+		{Backend: "whisper", Model: "tiny"},
+		{Backend: "openai"},
+	}
+	whisper := mock.NewTranscriber(mock.Options{})
+
+	fallbacks, err := transcriberFallbacks(cfg, whisper, nil)
+	if err != nil {
+		t.Fatalf("transcriberFallbacks() error = %v", err)
+	}
+	if len(fallbacks) != 2 {
+		t.Fatalf("got %d fallbacks, want 2", len(fallbacks))
+	}
+	if fallbacks[0].Name != "whisper" || fallbacks[0].Model != "tiny" || fallbacks[0].Transcriber != whisper {
+		t.Errorf("whisper fallback = %+v, want the local transcriber with tiny", fallbacks[0])
+	}
+	if fallbacks[1].Name != "openai" || fallbacks[1].Model != "whisper-1" {
+		t.Errorf("openai fallback = %+v, want whisper-1 by default", fallbacks[1])
+	}
+	if got := fallbackWhisperModels(cfg); !reflect.DeepEqual(got, []string{"tiny"}) {
+		t.Errorf("fallbackWhisperModels() = %v, want [tiny]", got)
+	}
+}
+
+func TestTranscriberFallbacks_Invalid(t *testing.T) {
+	for _, fc := range []config.FallbackConfig{
+		{Backend: "whisper"},
+		{Backend: "azure", Model: "large"},
+	} {
+		cfg := config.DefaultConfig()
+		cfg.Fallbacks = []config.FallbackConfig{fc}
+		if _, err := transcriberFallbacks(cfg, nil, nil); err == nil {
+			t.Errorf("transcriberFallbacks(%+v) expected error", fc)
+		}
+	}
+}
+
+// missingModelsTranscriber is the mock transcriber with some models not
+// downloaded yet, recording which get downloaded
+type missingModelsTranscriber struct {
+	*mock.Transcriber
+	missing    map[string]bool
+	downloaded []string
+}
+
+func (t *missingModelsTranscriber) IsModelDownloaded(model string) bool {
+	return !t.missing[model]
+}
+
+func (t *missingModelsTranscriber) DownloadModel(ctx context.Context, model string, progress func(downloaded, total int64)) error {
+	t.downloaded = append(t.downloaded, model)
+	delete(t.missing, model)
+	return nil
+}
+
+func TestProcessBatch_DownloadsFallbackModels(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+	app.Config.Fallbacks = []config.FallbackConfig{{Backend: "whisper", Model: "tiny"}}
+	transcriber := &missingModelsTranscriber{Transcriber: mock.NewTranscriber(mock.Options{}), missing: map[string]bool{"tiny": true}}
+	app.Transcriber = transcriber
+
+	oldQuiet, oldModel, oldConcurrency := quietFlag, modelFlag, batchConcurrency
+	defer func() { quietFlag, modelFlag, batchConcurrency = oldQuiet, oldModel, oldConcurrency }()
+	quietFlag, modelFlag, batchConcurrency = true, "small", 1
+
+	if err := processBatch(context.Background(), app, []string{"GOOD1"}, filepath.Join(dir, "out"), nil, false); err != nil {
+		t.Fatalf("processBatch() error = %v", err)
+	}
+	if !reflect.DeepEqual(transcriber.downloaded, []string{"tiny"}) {
+		t.Errorf("downloaded models = %v, want the fallback's tiny", transcriber.downloaded)
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+	"golang.org/x/text/unicode/norm"
+)
+
+// validateOutputTemplate checks --output-template's fields
+func validateOutputTemplate() error {
+	if outputTemplateFlag == "" {
+		return nil
+	}
+	return domain.ValidateOutputTemplate(outputTemplateFlag)
+}
+
+// outputReel returns the reel with the metadata --output-template needs:
+// from the cache entry when it has it, otherwise looked up. A failed lookup
+// leaves the fields empty rather than failing the run.
+func outputReel(ctx context.Context, app *App, reel *domain.Reel, cached *ports.CachedItem) *domain.Reel {
+	if !domain.TemplateNeedsMetadata(outputTemplateFlag) || nameFlag != "" {
+		return reel
+	}
+	if cached != nil && cached.Reel != nil && cached.Reel.Author != "" {
+		return cached.Reel
+	}
+	if fetched, err := app.Downloader.GetReel(ctx, reel.ID); err == nil {
+		return fetched
+	}
+	return reel
+}
+
+// transliterations covers Latin letters that don't decompose into a base
+// letter and a combining mark
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'ø': "o", 'Ø': "O",
+	'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D", 'þ': "th", 'Þ': "Th",
+	'ı': "i",
+}
+
+// transliterate decomposes accented letters so SanitizeFilename can drop
+// their marks, and spells out Latin letters that don't decompose
+func transliterate(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		if t, ok := transliterations[r]; ok {
+			sb.WriteString(t)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// sanitizeFilename makes name a safe base filename on this OS, with
+// accented Latin letters written in ASCII
+func sanitizeFilename(name string) string {
+	return domain.SanitizeFilename(transliterate(name), runtime.GOOS)
+}
+
+// templateBaseName renders --output-template for reel on this OS
+func templateBaseName(reel *domain.Reel) string {
+	named := *reel
+	named.Author = transliterate(reel.Author)
+	named.Title = transliterate(reel.Title)
+	return domain.RenderFilename(transliterate(outputTemplateFlag), &named, runtime.GOOS)
+}
+
+// batchNames hands out base names to a batch's reels. Reels whose template
+// renders the same name as another reel's, ignoring case for Windows and
+// macOS filesystems, get their ID appended so no output is overwritten.
+type batchNames struct {
+	mu    sync.Mutex
+	owner map[string]string // lowercased name -> reel ID
+}
+
+// outputNames is reset by each batch
+var outputNames = &batchNames{}
+
+// claim returns reelID's base name for the rendered name
+func (n *batchNames) claim(name, reelID string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.owner == nil {
+		n.owner = make(map[string]string)
+	}
+
+	for _, candidate := range []string{name, name + "_" + reelID} {
+		key := strings.ToLower(candidate)
+		if owner, taken := n.owner[key]; !taken || owner == reelID {
+			n.owner[key] = reelID
+			return candidate
+		}
+	}
+	return reelID
+}
+
+// reset forgets every claimed name
+func (n *batchNames) reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.owner = nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestBatchNames_Claim(t *testing.T) {
+	var names batchNames
+
+	if got := names.claim("mockuser_2025-01-01", "AAA111"); got != "mockuser_2025-01-01" {
+		t.Errorf("first claim = %q", got)
+	}
+	if got := names.claim("mockuser_2025-01-01", "AAA111"); got != "mockuser_2025-01-01" {
+		t.Errorf("the same reel should keep its name, got %q", got)
+	}
+	if got := names.claim("MockUser_2025-01-01", "BBB222"); got != "MockUser_2025-01-01_BBB222" {
+		t.Errorf("colliding claim = %q, want the reel ID appended", got)
+	}
+
+	names.reset()
+	if got := names.claim("mockuser_2025-01-01", "BBB222"); got != "mockuser_2025-01-01" {
+		t.Errorf("claim after reset = %q", got)
+	}
+}
+
+func TestSanitizeFilename_Transliterates(t *testing.T) {
+	for name, want := range map[string]string{
+		"café_crème":  "cafe_creme",
+		"Straße Łódź": "Strasse Lodz",
+		"東京 vlog":     "東京 vlog",
+	} {
+		if got := sanitizeFilename(name); got != want {
+			t.Errorf("sanitizeFilename(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestProcessOneReel_OutputTemplate(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+
+	oldTemplate, oldFormat := outputTemplateFlag, formatFlag
+	defer func() { outputTemplateFlag, formatFlag = oldTemplate, oldFormat }()
+	outputTemplateFlag, formatFlag = "{author}_{date}", "text"
+	outputNames.reset()
+
+	ctx := context.Background()
+	for _, id := range []string{"ABC123", "DEF456"} {
+		if result := processOneReel(ctx, app, id, dir, batchRow{}, newBatchRun(ctx, app, dir)); !result.Success {
+			t.Fatalf("processOneReel(%s) = %+v", id, result)
+		}
+	}
+
+	// Both mock reels share an author and date, so the second gets its ID
+	for _, name := range []string{"mockuser_2025-01-01.txt", "mockuser_2025-01-01_DEF456.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected output %s: %v", name, err)
+		}
+	}
+}
+
+func TestResolveOutputPaths_CarouselSlide(t *testing.T) {
+	oldDir, oldName := dirFlag, nameFlag
+	defer func() { dirFlag, nameFlag = oldDir, oldName }()
+	dirFlag, nameFlag = "", ""
+
+	slide := &domain.Reel{ID: domain.SlideID("ABC123", 2)}
+	if dir, base := resolveOutputPaths(slide); dir != "ABC123" || base != "ABC123_2" {
+		t.Errorf("resolveOutputPaths() = %q, %q, want ABC123, ABC123_2", dir, base)
+	}
+
+	nameFlag = "talk"
+	if _, base := resolveOutputPaths(slide); base != "talk_2" {
+		t.Errorf("base name with --name = %q, want talk_2", base)
+	}
+	if _, base := resolveOutputPaths(&domain.Reel{ID: "ABC123"}); base != "talk" {
+		t.Errorf("base name for a single video = %q, want talk", base)
+	}
+}
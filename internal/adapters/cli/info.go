@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var infoJSONFlag bool
+
+// NewInfoCmd creates the info command
+func NewInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info <reel-url|reel-id>",
+		Short: "Show a reel's metadata without downloading it",
+		Long: `Fetch and print a reel's metadata: author, caption, duration, views,
+likes and upload date. No audio or video is downloaded, so it's a quick way
+to triage a reel or feed scripts.
+
+Example:
+  ig2insights info ABC123
+  ig2insights info ABC123 --json | jq .views`,
+		Args: cobra.ExactArgs(1),
+		RunE: runInfo,
+	}
+
+	cmd.Flags().BoolVar(&infoJSONFlag, "json", false, "Print the metadata as JSON")
+
+	return cmd
+}
+
+// reelInfo is the document printed by info --json
+type reelInfo struct {
+	ID              string     `json:"id"`
+	URL             string     `json:"url"`
+	Author          string     `json:"author,omitempty"`
+	Caption         string     `json:"caption,omitempty"`
+	DurationSeconds int        `json:"duration_seconds"`
+	Views           int64      `json:"views"`
+	Likes           int64      `json:"likes"`
+	Comments        int64      `json:"comments"`
+	UploadedAt      *time.Time `json:"uploaded_at,omitempty"` // omitted when unknown
+}
+
+// newReelInfo builds the info document for reel
+func newReelInfo(reel *domain.Reel) reelInfo {
+	info := reelInfo{
+		ID:              reel.ID,
+		URL:             reel.ReelURL(),
+		Author:          reel.Author,
+		Caption:         reel.Caption,
+		DurationSeconds: reel.DurationSeconds,
+		Views:           reel.ViewCount,
+		Likes:           reel.LikeCount,
+		Comments:        reel.CommentCount,
+	}
+	if !reel.UploadedAt.IsZero() {
+		uploaded := reel.UploadedAt
+		info.UploadedAt = &uploaded
+	}
+	return info
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	ctx := context.Background()
+
+	reel, err := app.InputSvc.Normalize(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	fetched, err := app.Downloader.GetReel(ctx, reel.ID)
+	if err != nil {
+		recordRateLimit(ctx, app, err)
+		return fmt.Errorf("failed to fetch %s: %w", reel.ID, err)
+	}
+
+	return writeReelInfo(os.Stdout, fetched, infoJSONFlag)
+}
+
+// writeReelInfo prints reel's metadata to w as aligned text, or as JSON
+func writeReelInfo(w io.Writer, reel *domain.Reel, asJSON bool) error {
+	info := newReelInfo(reel)
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(info)
+	}
+
+	unknown := func(ok bool, value string) string {
+		if !ok {
+			return "unknown"
+		}
+		return value
+	}
+
+	fmt.Fprintf(w, "ID:        %s\n", info.ID)
+	fmt.Fprintf(w, "URL:       %s\n", info.URL)
+	fmt.Fprintf(w, "Author:    %s\n", unknown(info.Author != "", info.Author))
+	fmt.Fprintf(w, "Duration:  %s\n", unknown(info.DurationSeconds > 0, domain.FormatChapterTime(float64(info.DurationSeconds))))
+	fmt.Fprintf(w, "Views:     %s\n", tui.FormatNumber(info.Views))
+	fmt.Fprintf(w, "Likes:     %s\n", tui.FormatNumber(info.Likes))
+	fmt.Fprintf(w, "Comments:  %s\n", tui.FormatNumber(info.Comments))
+	fmt.Fprintf(w, "Uploaded:  %s\n", unknown(info.UploadedAt != nil, reel.UploadedAt.Format("2006-01-02")))
+	if info.Caption != "" {
+		fmt.Fprintf(w, "\n%s\n", info.Caption)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestWriteReelInfo(t *testing.T) {
+	reel := &domain.Reel{
+		ID:              "ABC123",
+		Author:          "someuser",
+		Caption:         "A caption",
+		DurationSeconds: 95,
+		ViewCount:       12345,
+		LikeCount:       678,
+		UploadedAt:      time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC),
+	}
+
+	var text bytes.Buffer
+	if err := writeReelInfo(&text, reel, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"URL:       https://www.instagram.com/p/ABC123/",
+		"Author:    someuser",
+		"Duration:  01:35",
+		"Views:     12,345",
+		"Uploaded:  2025-03-04",
+		"\nA caption\n",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeReelInfo(&out, reel, true); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if got["id"] != "ABC123" || got["caption"] != "A caption" || got["views"] != float64(12345) || got["duration_seconds"] != float64(95) {
+		t.Errorf("JSON output = %v", got)
+	}
+	if got["uploaded_at"] != "2025-03-04T12:00:00Z" {
+		t.Errorf("uploaded_at = %v", got["uploaded_at"])
+	}
+}
+
+func TestWriteReelInfo_Unknown(t *testing.T) {
+	var text bytes.Buffer
+	if err := writeReelInfo(&text, &domain.Reel{ID: "ABC123"}, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Author:    unknown", "Duration:  unknown", "Uploaded:  unknown"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeReelInfo(&out, &domain.Reel{ID: "ABC123"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "uploaded_at") {
+		t.Errorf("unknown upload date should be omitted:\n%s", out.String())
+	}
+}
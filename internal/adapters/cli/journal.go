@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/journal"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// journalFileName is the batch journal kept in the output directory
+const journalFileName = ".ig2insights-batch.jsonl"
+
+// batchJournal records state transitions for one batch run. Write failures
+// are ignored so a full disk doesn't abort transcriptions already underway.
+type batchJournal struct {
+	store ports.BatchJournal
+}
+
+// openBatchJournal returns the journal for outputDir
+func openBatchJournal(outputDir string) *batchJournal {
+	return &batchJournal{store: journal.NewFileJournal(filepath.Join(outputDir, journalFileName))}
+}
+
+func (j *batchJournal) record(ctx context.Context, reelID string, state domain.JobState, errMsg, output string) {
+	_ = j.store.Append(ctx, domain.JournalEntry{
+		ReelID: reelID,
+		State:  state,
+		At:     time.Now(),
+		Error:  errMsg,
+		Output: output,
+	})
+}
+
+// planResume replays the journal and returns the reels a resumed run should
+// process: interrupted reels first, then pending ones, then any new inputs.
+// Interrupted reels lose their cache entry since a crash mid-download or
+// mid-transcription leaves it unverifiable.
+func planResume(ctx context.Context, app *App, j *batchJournal, inputs []string) ([]string, error) {
+	entries, err := j.store.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch journal: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no batch journal to resume (expected %s in the output directory)", journalFileName)
+	}
+
+	plan := domain.PlanResume(entries)
+	for _, id := range plan.Interrupted {
+		if err := app.Cache.Delete(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to reset cache for interrupted reel %s: %w", id, err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range plan.Done {
+		seen[id] = true
+	}
+
+	var reelIDs []string
+	for _, group := range [][]string{plan.Interrupted, plan.Pending, inputs} {
+		for _, id := range group {
+			if !seen[id] {
+				seen[id] = true
+				reelIDs = append(reelIDs, id)
+			}
+		}
+	}
+
+	if !quietFlag {
+		fmt.Printf("Resuming batch: %d done, %d interrupted (re-verifying), %d remaining\n",
+			len(plan.Done), len(plan.Interrupted), len(reelIDs)-len(plan.Interrupted))
+	}
+	return reelIDs, nil
+}
+
+// failedReels returns the reels whose last state in the journal is failed
+func failedReels(ctx context.Context, j *batchJournal) ([]string, error) {
+	entries, err := j.store.Entries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch journal: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no batch journal to retry (expected %s in the output directory)", journalFileName)
+	}
+
+	var failed []string
+	for _, e := range domain.LastEntries(entries) {
+		if e.State == domain.JobFailed {
+			failed = append(failed, e.ReelID)
+		}
+	}
+	return failed, nil
+}
+
+// runBatchStatus lists the reels in outputDir's journal by their last state
+func runBatchStatus(ctx context.Context, w io.Writer, outputDir string) error {
+	entries, err := openBatchJournal(outputDir).store.Entries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read batch journal: %w", err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no batch journal in %s (expected %s)", outputDir, journalFileName)
+	}
+
+	last := domain.LastEntries(entries)
+	var done, failed, inFlight, pending []domain.JournalEntry
+	for _, e := range last {
+		switch e.State {
+		case domain.JobDone:
+			done = append(done, e)
+		case domain.JobFailed:
+			failed = append(failed, e)
+		case domain.JobDownloading, domain.JobTranscribing:
+			inFlight = append(inFlight, e)
+		default:
+			pending = append(pending, e)
+		}
+	}
+
+	fmt.Fprintf(w, "Batch in %s: %d reels, last update %s\n", outputDir, len(last), entries[len(entries)-1].At.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "  %d done, %d failed, %d in progress or interrupted, %d pending\n", len(done), len(failed), len(inFlight), len(pending))
+
+	if len(failed) > 0 {
+		fmt.Fprintln(w, "\nFailed:")
+		for _, e := range failed {
+			fmt.Fprintf(w, "  %s: %s\n", e.ReelID, e.Error)
+		}
+	}
+	for _, group := range []struct {
+		title   string
+		entries []domain.JournalEntry
+	}{
+		{"In progress or interrupted", inFlight},
+		{"Pending", pending},
+	} {
+		if len(group.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", group.title)
+		for _, e := range group.entries {
+			fmt.Fprintf(w, "  %s\n", e.ReelID)
+		}
+	}
+
+	if len(done) < len(last) {
+		fmt.Fprintf(w, "\nRun batch --resume --dir %s to process the rest.\n", outputDir)
+	}
+	return nil
+}
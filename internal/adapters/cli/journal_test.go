@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cache"
+	"github.com/devbush/ig2insights/internal/adapters/history"
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/adapters/session"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// newMockApp wires an App around the mock adapters with state under dir
+func newMockApp(dir string) *App {
+	cacheStore := cache.NewFileCache(filepath.Join(dir, "cache"))
+	downloader := mock.NewDownloader(mock.Options{})
+	transcriber := mock.NewTranscriber(mock.Options{})
+	return &App{
+		Config:        config.DefaultConfig(),
+		Cache:         cacheStore,
+		Downloader:    downloader,
+		Transcriber:   transcriber,
+		TranscribeSvc: application.NewTranscribeService(cacheStore, downloader, transcriber, time.Hour),
+		BrowseSvc:     application.NewBrowseService(downloader),
+		HistorySvc:    application.NewHistoryService(history.NewFileStore(filepath.Join(dir, "history.jsonl"))),
+		SessionSvc:    application.NewSessionService(session.NewFileStore(filepath.Join(dir, "session")), downloader),
+	}
+}
+
+func TestProcessBatch_Journal(t *testing.T) {
+	dir := t.TempDir()
+	outputDir := filepath.Join(dir, "out")
+	app := newMockApp(dir)
+	ctx := context.Background()
+
+	oldQuiet, oldConcurrency := quietFlag, batchConcurrency
+	defer func() { quietFlag, batchConcurrency = oldQuiet, oldConcurrency }()
+	quietFlag, batchConcurrency = true, 2
+
+	err := processBatch(ctx, app, []string{"GOOD1", "privateREEL"}, outputDir, nil, false)
+	if err == nil {
+		t.Fatal("processBatch() expected an error for the private reel")
+	}
+
+	entries, err := openBatchJournal(outputDir).store.Entries(ctx)
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+
+	states := make(map[string][]string)
+	for _, e := range entries {
+		states[e.ReelID] = append(states[e.ReelID], string(e.State))
+	}
+	if got := strings.Join(states["GOOD1"], ","); got != "queued,downloading,transcribing,done" {
+		t.Errorf("GOOD1 transitions = %s", got)
+	}
+	if got := strings.Join(states["privateREEL"], ","); got != "queued,downloading,failed" {
+		t.Errorf("privateREEL transitions = %s", got)
+	}
+}
+
+func TestPlanResume(t *testing.T) {
+	dir := t.TempDir()
+	app := newMockApp(dir)
+	ctx := context.Background()
+	j := openBatchJournal(filepath.Join(dir, "out"))
+
+	oldQuiet := quietFlag
+	defer func() { quietFlag = oldQuiet }()
+	quietFlag = true
+
+	if _, err := planResume(ctx, app, j, nil); err == nil {
+		t.Error("planResume() expected an error without a journal")
+	}
+
+	// MID crashed while transcribing and left a cache entry behind
+	if err := app.Cache.Set(ctx, "MID", &ports.CachedItem{
+		Reel:      &domain.Reel{ID: "MID"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []struct {
+		id    string
+		state domain.JobState
+	}{
+		{"DONE", domain.JobQueued}, {"MID", domain.JobQueued}, {"WAIT", domain.JobQueued},
+		{"DONE", domain.JobDone}, {"MID", domain.JobDownloading}, {"MID", domain.JobTranscribing},
+	} {
+		j.record(ctx, e.id, e.state, "", "")
+	}
+
+	reelIDs, err := planResume(ctx, app, j, []string{"WAIT", "NEW", "DONE"})
+	if err != nil {
+		t.Fatalf("planResume() error = %v", err)
+	}
+	if got := strings.Join(reelIDs, ","); got != "MID,WAIT,NEW" {
+		t.Errorf("planResume() = %s, want MID,WAIT,NEW", got)
+	}
+	if item, _ := app.Cache.Get(ctx, "MID"); item != nil {
+		t.Error("planResume() should drop the interrupted reel's cache entry")
+	}
+}
+
+func TestFailedReels(t *testing.T) {
+	ctx := context.Background()
+	j := openBatchJournal(t.TempDir())
+	if _, err := failedReels(ctx, j); err == nil {
+		t.Error("failedReels() expected an error without a journal")
+	}
+
+	for _, id := range []string{"A", "B", "C"} {
+		j.record(ctx, id, domain.JobQueued, "", "")
+	}
+	j.record(ctx, "A", domain.JobFailed, "rate limited", "")
+	j.record(ctx, "B", domain.JobFailed, "rate limited", "")
+	j.record(ctx, "B", domain.JobDone, "", "B.txt") // succeeded on a retry
+	j.record(ctx, "C", domain.JobDone, "", "C.txt")
+
+	failed, err := failedReels(ctx, j)
+	if err != nil || strings.Join(failed, ",") != "A" {
+		t.Errorf("failedReels() = %v, %v; want only A", failed, err)
+	}
+}
+
+func TestRetryReels(t *testing.T) {
+	local := domain.LocalFileID("/videos/a.mp4", 100, time.Unix(0, 0))
+	generic := domain.GenericID("https://example.com/v/1")
+	failed := []string{"A", local, generic}
+
+	retry, missing := retryReels(failed, nil)
+	if strings.Join(retry, ",") != "A" || strings.Join(missing, ",") != local+","+generic {
+		t.Errorf("retryReels() without inputs = %v, %v", retry, missing)
+	}
+
+	retry, missing = retryReels(failed, []string{local, "B"})
+	if strings.Join(retry, ",") != "A,"+local || strings.Join(missing, ",") != generic {
+		t.Errorf("retryReels() with the local file = %v, %v", retry, missing)
+	}
+}
+
+func TestRunBatchStatus(t *testing.T) {
+	ctx := context.Background()
+	outputDir := t.TempDir()
+
+	var out bytes.Buffer
+	if err := runBatchStatus(ctx, &out, outputDir); err == nil {
+		t.Error("runBatchStatus() expected an error without a journal")
+	}
+
+	j := openBatchJournal(outputDir)
+	for _, id := range []string{"A", "B", "C", "D"} {
+		j.record(ctx, id, domain.JobQueued, "", "")
+	}
+	j.record(ctx, "A", domain.JobDone, "", "A.txt")
+	j.record(ctx, "B", domain.JobFailed, "reel not found", "")
+	j.record(ctx, "C", domain.JobDownloading, "", "")
+
+	if err := runBatchStatus(ctx, &out, outputDir); err != nil {
+		t.Fatalf("runBatchStatus() error = %v", err)
+	}
+	for _, want := range []string{
+		"4 reels",
+		"1 done, 1 failed, 1 in progress or interrupted, 1 pending",
+		"Failed:\n  B: reel not found\n",
+		"In progress or interrupted:\n  C\n",
+		"Pending:\n  D\n",
+		"batch --resume --dir " + outputDir,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runBatchStatus() output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// JSONSchemaVersion is the version of JSONOutput. It changes only when a
+// field is removed or changes meaning; new fields keep the version.
+const JSONSchemaVersion = 1
+
+// JSONOutput is the document written by --format json
+type JSONOutput struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Reel          *domain.Reel           `json:"reel"`
+	Transcript    *domain.Transcript     `json:"transcript"`
+	Stats         domain.TranscriptStats `json:"stats"`
+	Language      string                 `json:"language,omitempty"` // detected or requested; omitted when unknown
+	Cache         JSONCacheStatus        `json:"cache"`
+	Timings       JSONTimings            `json:"timings"`
+	Files         map[string]string      `json:"files,omitempty"`
+	Provenance    *domain.Provenance     `json:"provenance,omitempty"`
+}
+
+// JSONCacheStatus reports which assets were served from the cache, and the
+// estimated time that saved
+type JSONCacheStatus struct {
+	Transcript       bool    `json:"transcript"`
+	Audio            bool    `json:"audio"`
+	Video            bool    `json:"video"`
+	Thumbnail        bool    `json:"thumbnail"`
+	TimeSavedSeconds float64 `json:"time_saved_seconds"`
+}
+
+// JSONCacheMetrics reports how the cache served a batch's reels
+type JSONCacheMetrics struct {
+	Hits             int     `json:"hits"`
+	Misses           int     `json:"misses"`
+	AudioHits        int     `json:"audio_hits"`
+	HitRate          float64 `json:"hit_rate"`
+	TimeSavedSeconds float64 `json:"time_saved_seconds"`
+}
+
+// newJSONCacheMetrics converts batch cache metrics for JSON output
+func newJSONCacheMetrics(m domain.CacheMetrics) *JSONCacheMetrics {
+	return &JSONCacheMetrics{
+		Hits:             m.Hits,
+		Misses:           m.Misses,
+		AudioHits:        m.AudioHits,
+		HitRate:          math.Round(m.HitRate()*1000) / 1000,
+		TimeSavedSeconds: seconds(m.TimeSaved),
+	}
+}
+
+// JSONTimings reports time spent processing the reel, in seconds
+type JSONTimings struct {
+	DownloadSeconds   float64 `json:"download_seconds"`
+	TranscribeSeconds float64 `json:"transcribe_seconds"`
+}
+
+// newJSONOutput builds the JSON document for a transcription result
+func newJSONOutput(result *application.TranscribeResult, stats domain.TranscriptStats) JSONOutput {
+	out := JSONOutput{
+		SchemaVersion: JSONSchemaVersion,
+		Reel:          result.Reel,
+		Transcript:    result.Transcript,
+		Stats:         stats,
+		Cache: JSONCacheStatus{
+			Transcript:       result.TranscriptFromCache,
+			Audio:            result.AudioFromCache,
+			Video:            result.VideoFromCache,
+			Thumbnail:        result.ThumbnailFromCache,
+			TimeSavedSeconds: seconds(result.TimeSaved),
+		},
+		Timings: JSONTimings{
+			DownloadSeconds:   seconds(result.DownloadDuration),
+			TranscribeSeconds: seconds(result.TranscribeDuration),
+		},
+		Files:      result.Files,
+		Provenance: result.Provenance,
+	}
+	if result.Transcript != nil && result.Transcript.Language != "auto" {
+		out.Language = result.Transcript.Language
+	}
+	return out
+}
+
+// seconds rounds d to milliseconds and returns it in seconds
+func seconds(d time.Duration) float64 {
+	return d.Round(time.Millisecond).Seconds()
+}
+
+// outputFiles returns the files a run writes into outputDir, keyed by format
+// name or media kind: the transcript formats, the --template output and any
+// requested media the result has
+func outputFiles(result *application.TranscribeResult, formats []string, audioExt, outputDir, baseName string) map[string]string {
+	files := make(map[string]string)
+	add := func(key, ext string) {
+		files[key], _ = outputTarget(filepath.Join(outputDir, baseName+"."+ext))
+	}
+	for _, format := range formats {
+		add(format, formatExts[format])
+	}
+	if templateFlag != "" {
+		add("template", templateExt(templateFlag))
+	}
+
+	media := []struct {
+		enabled bool
+		srcPath string
+		kind    string
+		ext     string
+	}{
+		{audioFlag, result.AudioPath, "audio", audioExt},
+		{videoFlag, result.VideoPath, "video", "mp4"},
+		{thumbnailFlag, result.ThumbnailPath, "thumbnail", "jpg"},
+	}
+	for _, m := range media {
+		if m.enabled && m.srcPath != "" {
+			add(m.kind, m.ext)
+		}
+	}
+	return files
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// instagramHost is the key under which Instagram rate limits are tracked
+const instagramHost = "instagram.com"
+
+// NewLimitsCmd creates the limits command
+func NewLimitsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "limits",
+		Short: "Show rate-limit back-off status",
+		Long: `Show recent rate-limit responses and the recommended wait time before
+making more requests. Batch runs consult the same state before starting.`,
+		RunE: runLimitsStatus,
+	}
+
+	resetCmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Forget recorded rate-limit responses",
+		RunE:  runLimitsReset,
+	}
+
+	profilesCmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "List throttle profiles from config",
+		RunE:  runLimitsProfiles,
+	}
+
+	cmd.AddCommand(resetCmd)
+	cmd.AddCommand(profilesCmd)
+	return cmd
+}
+
+func runLimitsStatus(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	statuses, err := app.RateLimitSvc.AllStatuses(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Rate Limit Status:")
+	fmt.Println()
+
+	if len(statuses) == 0 {
+		fmt.Println("  No recent rate limits recorded")
+		fmt.Println()
+		return nil
+	}
+
+	now := time.Now()
+	for _, s := range statuses {
+		state := "ok"
+		if s.CoolingDown(now) {
+			state = fmt.Sprintf("cooling down, wait %s", formatWait(s.Wait(now)))
+		}
+		fmt.Printf("  %-16s %d in last %s, last at %s (%s)\n",
+			s.Host, s.RecentHits, domain.RateLimitWindow, s.LastHit.Local().Format("15:04:05"), state)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runLimitsReset(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	if err := app.RateLimitSvc.Reset(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Println("Rate limit history cleared")
+	return nil
+}
+
+func runLimitsProfiles(cmd *cobra.Command, args []string) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	active := throttleProfileFlag
+	if active == "" {
+		active = app.Config.Throttle.Profile
+	}
+
+	names := make([]string, 0, len(app.Config.Throttle.Profiles))
+	for name := range app.Config.Throttle.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println()
+	fmt.Println("Throttle Profiles:")
+	fmt.Println()
+	for _, name := range names {
+		p := app.Config.Throttle.Profiles[name]
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Printf(" %s %-14s concurrency %d, request sleep %s, download sleep %s-%s, retries %d",
+			marker, name, p.Concurrency, orNone(p.SleepRequests), orNone(p.SleepInterval), orNone(p.MaxSleepInterval), p.Retries)
+		if p.MinDelay != "" || p.Jitter != "" {
+			fmt.Printf(", request gap %s+%s", orNone(p.MinDelay), orNone(p.Jitter))
+		}
+		if p.RequestsPerMinute > 0 {
+			fmt.Printf(", %d requests/min", p.RequestsPerMinute)
+		}
+		if p.Impersonate != "" {
+			fmt.Printf(", impersonate %s", p.Impersonate)
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	fmt.Printf("Edit profiles under throttle.profiles in %s\n", app.Paths.ConfigPath)
+	if active == "" {
+		fmt.Println("No profile active; select one with --throttle-profile or throttle.profile")
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// orNone renders an empty setting as "-"
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// recordRateLimit remembers a rate-limit response so later runs can back off
+func recordRateLimit(ctx context.Context, app *App, err error) {
+	if errors.Is(err, domain.ErrRateLimited) {
+		_ = app.RateLimitSvc.Record(ctx, instagramHost)
+	}
+}
+
+// checkCooldown returns an error if Instagram requests are still backing off
+func checkCooldown(ctx context.Context, app *App) error {
+	status, err := app.RateLimitSvc.Status(ctx, instagramHost)
+	if err != nil {
+		return nil // Missing or unreadable state shouldn't block work
+	}
+
+	now := time.Now()
+	if status.CoolingDown(now) {
+		return fmt.Errorf("%w: %d recent responses from %s; wait %s or use --ignore-limits",
+			domain.ErrRateLimited, status.RecentHits, status.Host, formatWait(status.Wait(now)))
+	}
+	return nil
+}
+
+// formatWait rounds a wait duration to whole seconds for display
+func formatWait(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
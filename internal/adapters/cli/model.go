@@ -49,8 +49,8 @@ func runModelList(cmd *cobra.Command, args []string) error {
 	models := app.Transcriber.AvailableModels()
 
 	fmt.Println()
-	fmt.Printf("  %-10s %-12s %s\n", "Model", "Size", "Status")
-	fmt.Println("  " + strings.Repeat("-", 40))
+	fmt.Printf("  %-20s %-12s %s\n", "Model", "Size", "Status")
+	fmt.Println("  " + strings.Repeat("-", 50))
 
 	for _, m := range models {
 		status := "not downloaded"
@@ -62,7 +62,7 @@ func runModelList(cmd *cobra.Command, args []string) error {
 		}
 
 		size := tui.FormatSize(m.Size)
-		fmt.Printf("  %-10s %-12s %s\n", m.Name, size, status)
+		fmt.Printf("  %-20s %-12s %s\n", m.Name, size, status)
 	}
 	fmt.Println()
 
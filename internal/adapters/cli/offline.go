@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// offlineDownloader stands in for the downloader under --offline. Local files
+// are still read; anything that would reach the network fails at once with
+// domain.ErrOffline. Installing is skipped rather than failing, so reels
+// served entirely from cache don't need yt-dlp.
+type offlineDownloader struct {
+	Downloader
+}
+
+// offlineReel returns the error for reelID's metadata or media, which aren't
+// cached
+func offlineReel(reelID string) error {
+	return fmt.Errorf("%s is not cached: %w", reelID, domain.ErrOffline)
+}
+
+func (d *offlineDownloader) Install(ctx context.Context, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (d *offlineDownloader) InstallFFmpeg(ctx context.Context, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (d *offlineDownloader) Update(ctx context.Context) error {
+	return fmt.Errorf("updating: %w", domain.ErrOffline)
+}
+
+func (d *offlineDownloader) GetAccount(ctx context.Context, username string) (*domain.Account, error) {
+	return nil, fmt.Errorf("looking up @%s: %w", username, domain.ErrOffline)
+}
+
+func (d *offlineDownloader) ListReels(ctx context.Context, username string, sort domain.SortOrder, limit int) ([]*domain.Reel, error) {
+	return nil, fmt.Errorf("listing @%s's reels: %w", username, domain.ErrOffline)
+}
+
+func (d *offlineDownloader) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	if domain.IsLocalFileID(reelID) {
+		return d.Downloader.GetReel(ctx, reelID)
+	}
+	return nil, offlineReel(reelID)
+}
+
+func (d *offlineDownloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	if domain.IsLocalFileID(reelID) {
+		return d.Downloader.DownloadAudio(ctx, reelID, destDir)
+	}
+	return nil, offlineReel(reelID)
+}
+
+func (d *offlineDownloader) DownloadVideo(ctx context.Context, reelID string, destPath string) error {
+	if domain.IsLocalFileID(reelID) {
+		return d.Downloader.DownloadVideo(ctx, reelID, destPath)
+	}
+	return offlineReel(reelID)
+}
+
+func (d *offlineDownloader) DownloadThumbnail(ctx context.Context, reelID string, destPath string) error {
+	if domain.IsLocalFileID(reelID) {
+		return d.Downloader.DownloadThumbnail(ctx, reelID, destPath)
+	}
+	return offlineReel(reelID)
+}
+
+// ListSlides can't tell carousels from single videos offline
+func (d *offlineDownloader) ListSlides(ctx context.Context, shortcode string) ([]*domain.Reel, error) {
+	return nil, fmt.Errorf("listing the videos of post %s: %w", shortcode, domain.ErrOffline)
+}
+
+func (d *offlineDownloader) ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error) {
+	return nil, fmt.Errorf("listing %s: %w", playlistURL, domain.ErrOffline)
+}
+
+func (d *offlineDownloader) FetchComments(ctx context.Context, reelID string, limit int) ([]domain.Comment, error) {
+	return nil, fmt.Errorf("fetching comments of %s: %w", reelID, domain.ErrOffline)
+}
+
+// RegisterURL passes --any-url URLs on, so reels cached from them are still
+// served
+func (d *offlineDownloader) RegisterURL(reelID, rawURL string) {
+	if registrar, ok := d.Downloader.(ports.URLRegistrar); ok {
+		registrar.RegisterURL(reelID, rawURL)
+	}
+}
+
+// RegisterFile passes local files on, since reading them needs no network
+func (d *offlineDownloader) RegisterFile(reelID, path string) {
+	if registrar, ok := d.Downloader.(ports.FileRegistrar); ok {
+		registrar.RegisterFile(reelID, path)
+	}
+}
+
+// offlineTranscriber stands in for whisper under --offline: models that
+// aren't downloaded fail when they're needed rather than being fetched
+type offlineTranscriber struct {
+	Transcriber
+}
+
+func (t *offlineTranscriber) Install(ctx context.Context, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (t *offlineTranscriber) DownloadModel(ctx context.Context, model string, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (t *offlineTranscriber) Transcribe(ctx context.Context, audioPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	if opts.Model != "" && !t.IsModelDownloaded(opts.Model) {
+		return nil, fmt.Errorf("model %s is not downloaded: %w", opts.Model, domain.ErrOffline)
+	}
+	return t.Transcriber.Transcribe(ctx, audioPath, opts)
+}
+
+// offlineCache serves expired entries under --offline, since they can't be
+// refreshed
+type offlineCache struct {
+	ports.CacheStore
+}
+
+func (c *offlineCache) Get(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	item, err := c.CacheStore.Get(ctx, reelID)
+	if errors.Is(err, domain.ErrCacheExpired) {
+		return c.CacheStore.Peek(ctx, reelID)
+	}
+	return item, err
+}
+
+// offlineFallbacks drops the fallbacks hosted backends provide
+func offlineFallbacks(fallbacks []application.TranscriberFallback) []application.TranscriberFallback {
+	var local []application.TranscriberFallback
+	for _, f := range fallbacks {
+		if f.Name == backendWhisper {
+			local = append(local, f)
+		}
+	}
+	return local
+}
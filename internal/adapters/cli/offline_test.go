@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/cache"
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestOffline_ServesOnlyFromCache(t *testing.T) {
+	store := &offlineCache{CacheStore: cache.NewFileCache(t.TempDir())}
+	downloader := &offlineDownloader{Downloader: mock.NewDownloader(mock.Options{})}
+	transcriber := &offlineTranscriber{Transcriber: mock.NewTranscriber(mock.Options{})}
+	svc := application.NewTranscribeService(store, downloader, transcriber, time.Hour)
+	ctx := context.Background()
+
+	// Expired entries are still served, since they can't be refreshed
+	err := store.Set(ctx, "CACHED1", &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "CACHED1"},
+		Transcript: &domain.Transcript{Text: "hello"},
+		CreatedAt:  time.Now().Add(-48 * time.Hour),
+		ExpiresAt:  time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := svc.Transcribe(ctx, "CACHED1", application.TranscribeOptions{Model: "small"})
+	if err != nil {
+		t.Fatalf("Transcribe() of a cached reel error = %v", err)
+	}
+	if !result.TranscriptFromCache || result.Transcript.Text != "hello" {
+		t.Errorf("Transcribe() = %+v, want the cached transcript", result)
+	}
+
+	if _, err := svc.Transcribe(ctx, "UNCACHED1", application.TranscribeOptions{Model: "small"}); !errors.Is(err, domain.ErrOffline) {
+		t.Errorf("Transcribe() of an uncached reel error = %v, want ErrOffline", err)
+	}
+	if _, err := downloader.GetReel(ctx, "UNCACHED1"); !errors.Is(err, domain.ErrOffline) {
+		t.Errorf("GetReel() error = %v, want ErrOffline", err)
+	}
+	if err := downloader.Install(ctx, nil); err != nil {
+		t.Errorf("Install() error = %v, want it skipped", err)
+	}
+	// --any-url and local files still work offline
+	var _ ports.URLRegistrar = downloader
+	var _ ports.FileRegistrar = downloader
+}
+
+func TestOfflineFallbacks(t *testing.T) {
+	fallbacks := offlineFallbacks([]application.TranscriberFallback{
+		{Name: backendOpenAI, Model: "whisper-1"},
+		{Name: backendWhisper, Model: "tiny"},
+	})
+	if len(fallbacks) != 1 || fallbacks[0].Name != backendWhisper {
+		t.Errorf("offlineFallbacks() = %+v, want only the whisper fallback", fallbacks)
+	}
+}
@@ -0,0 +1,404 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
+	"github.com/devbush/ig2insights/internal/adapters/docx"
+	"github.com/devbush/ig2insights/internal/adapters/pdf"
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// renderTranscript renders a transcription result in the given format.
+// Returns the content and the file extension to use. An empty format defaults to text.
+func renderTranscript(result *application.TranscribeResult, format string, cfg *config.Config) (content, ext string, err error) {
+	if format == "" {
+		format = "text"
+	}
+
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	stats := domain.ComputeStats(result.Transcript, result.Reel)
+	showStats := statsFlag || cfg.Output.Stats
+	chapterSecs, err := chapterInterval(cfg, stats)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch format {
+	case "text":
+		text := result.Transcript.ToChapteredText(chapterSecs)
+		if result.Reel != nil && result.Reel.Caption != "" {
+			text += "\n\nCaption:\n" + result.Reel.Caption
+		}
+		if showStats {
+			return stats.Summary() + "\n\n" + text, "txt", nil
+		}
+		return text, "txt", nil
+	case "srt":
+		opts, err := captionOptions(cfg)
+		if err != nil {
+			return "", "", err
+		}
+		return result.Transcript.ShapeCaptions(opts).ToSRT(), "srt", nil
+	case "text-ts":
+		return result.Transcript.ToTimestampedText(), "ts.txt", nil
+	case "lrc":
+		return result.Transcript.ToLRC(), "lrc", nil
+	case "markdown", "md":
+		opts := domain.MarkdownOptions{ChapterInterval: chapterSecs}
+		if showStats {
+			opts.Stats = &stats
+		}
+		return result.Transcript.ToMarkdown(result.Reel, opts), "md", nil
+	case "csv":
+		return result.Transcript.ToDelimited(','), "csv", nil
+	case "tsv":
+		return result.Transcript.ToDelimited('\t'), "tsv", nil
+	case "ttml":
+		return result.Transcript.ToTTML(ttmlStyle(cfg)), "ttml", nil
+	case "ass":
+		return result.Transcript.ToASS(assStyle(cfg)), "ass", nil
+	case "accessible":
+		return result.Transcript.ToAccessible(), "a11y.txt", nil
+	case "json":
+		jsonBytes, err := json.MarshalIndent(newJSONOutput(result, stats), "", "  ")
+		if err != nil {
+			return "", "", err
+		}
+		return string(jsonBytes), "json", nil
+	case "pdf":
+		data, err := pdf.Render(pdfDocument(result, chapterSecs))
+		if err != nil {
+			return "", "", err
+		}
+		return string(data), "pdf", nil
+	case "docx":
+		data, err := docx.Render(docxDocument(result, chapterSecs))
+		if err != nil {
+			return "", "", err
+		}
+		return string(data), "docx", nil
+	case "jsonl":
+		reelID := ""
+		if result.Reel != nil {
+			reelID = result.Reel.ID
+		}
+		return result.Transcript.ToJSONL(reelID), "jsonl", nil
+	default:
+		return "", "", fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// supportedFormats lists every value renderTranscript accepts
+var supportedFormats = []string{
+	"text", "text-ts", "srt", "lrc", "ttml", "ass", "csv", "tsv", "markdown", "md", "pdf", "docx", "accessible", "json", "jsonl",
+}
+
+// formatExts maps each supported format to the extension renderTranscript uses
+var formatExts = map[string]string{
+	"text": "txt", "text-ts": "ts.txt", "srt": "srt", "lrc": "lrc", "ttml": "ttml", "ass": "ass", "csv": "csv", "tsv": "tsv",
+	"markdown": "md", "md": "md", "pdf": "pdf", "docx": "docx", "accessible": "a11y.txt", "json": "json", "jsonl": "jsonl",
+}
+
+// formatAliases maps alternative format names to the name parseFormats
+// returns, so an alias and its format aren't written twice
+var formatAliases = map[string]string{"md": "markdown"}
+
+// parseFormats splits a comma-separated --format value into distinct,
+// supported formats in the order given, with aliases resolved. An empty
+// value means text.
+func parseFormats(spec string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if alias, ok := formatAliases[f]; ok {
+			f = alias
+		}
+		if f == "" || seen[f] {
+			continue
+		}
+		if !isSupportedFormat(f) {
+			return nil, fmt.Errorf("unknown format: %s (supported: %s)", f, strings.Join(supportedFormats, ", "))
+		}
+		seen[f] = true
+		formats = append(formats, f)
+	}
+	if len(formats) == 0 {
+		formats = []string{"text"}
+	}
+	return formats, nil
+}
+
+func isSupportedFormat(format string) bool {
+	for _, f := range supportedFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// binaryExts lists output extensions that are written as-is, without text
+// encoding or echoing to stdout
+var binaryExts = map[string]bool{
+	"pdf":  true,
+	"docx": true,
+}
+
+// documentHeader returns the title and metadata lines shown at the top of
+// document exports
+func documentHeader(result *application.TranscribeResult) (title string, metadata []string) {
+	title = "Transcript"
+	if reel := result.Reel; reel != nil {
+		title = reel.ID
+		if reel.Title != "" {
+			title = reel.Title
+		}
+		metadata = append(metadata, "URL: "+reel.ReelURL())
+		if reel.Author != "" {
+			metadata = append(metadata, "Author: @"+reel.Author)
+		}
+		if !reel.UploadedAt.IsZero() {
+			metadata = append(metadata, "Uploaded: "+reel.UploadedAt.Format("2006-01-02"))
+		}
+		if reel.ViewCount > 0 {
+			metadata = append(metadata, "Views: "+tui.FormatNumber(reel.ViewCount))
+		}
+	}
+	if t := result.Transcript; t.Model != "" {
+		metadata = append(metadata, fmt.Sprintf("Model: %s (%s)", t.Model, t.Language))
+	}
+	return title, metadata
+}
+
+// pdfDocument lays out a transcript and its reel metadata for PDF export.
+// A cached JPEG thumbnail is embedded when available.
+func pdfDocument(result *application.TranscribeResult, chapterSecs float64) pdf.Document {
+	t := result.Transcript
+	doc := pdf.Document{}
+	doc.Title, doc.Metadata = documentHeader(result)
+
+	if result.ThumbnailPath != "" {
+		if img, err := os.ReadFile(result.ThumbnailPath); err == nil && bytes.HasPrefix(img, []byte{0xFF, 0xD8, 0xFF}) {
+			doc.Image = img
+		}
+	}
+
+	if chapters := t.Chapters(chapterSecs); chapters != nil {
+		for _, ch := range chapters {
+			doc.Sections = append(doc.Sections, pdf.Section{Heading: domain.FormatChapterTime(ch.Start), Text: ch.Text})
+		}
+	} else {
+		doc.Sections = []pdf.Section{{Text: t.ToText()}}
+	}
+
+	return doc
+}
+
+// docxDocument lays out a transcript as timestamped paragraphs: one per
+// chapter for long videos, otherwise one per segment
+func docxDocument(result *application.TranscribeResult, chapterSecs float64) docx.Document {
+	t := result.Transcript
+	doc := docx.Document{}
+	doc.Title, doc.Metadata = documentHeader(result)
+
+	if chapters := t.Chapters(chapterSecs); chapters != nil {
+		for _, ch := range chapters {
+			doc.Paragraphs = append(doc.Paragraphs, docx.Paragraph{Timestamp: domain.FormatChapterTime(ch.Start), Text: ch.Text})
+		}
+	} else if len(t.Segments) > 0 {
+		for _, seg := range t.Segments {
+			doc.Paragraphs = append(doc.Paragraphs, docx.Paragraph{Timestamp: domain.FormatChapterTime(seg.Start), Text: strings.TrimSpace(seg.Text)})
+		}
+	} else {
+		doc.Paragraphs = []docx.Paragraph{{Text: t.ToText()}}
+	}
+
+	return doc
+}
+
+// chapterInterval returns the chapter spacing in seconds for long transcripts,
+// or 0 when the transcript is shorter than the configured threshold
+func chapterInterval(cfg *config.Config, stats domain.TranscriptStats) (float64, error) {
+	minDuration, interval, err := cfg.GetChapterSettings()
+	if err != nil || minDuration <= 0 {
+		return 0, err
+	}
+	if stats.DurationSeconds < minDuration.Seconds() {
+		return 0, nil
+	}
+	return interval.Seconds(), nil
+}
+
+// validateEncoding reports an error for unsupported --encoding values
+func validateEncoding(encoding string) error {
+	_, err := encodeOutput("", encoding)
+	return err
+}
+
+// encodeOutput converts rendered text to bytes in the requested encoding.
+// An empty encoding means plain UTF-8. UTF-16LE output always carries a BOM
+// since most consumers need it to detect the encoding.
+func encodeOutput(content, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "utf8":
+		return []byte(content), nil
+	case "utf8-bom":
+		return append([]byte{0xEF, 0xBB, 0xBF}, content...), nil
+	case "utf16le":
+		units := utf16.Encode([]rune(content))
+		out := make([]byte, 2, 2+len(units)*2)
+		out[0], out[1] = 0xFF, 0xFE
+		for _, u := range units {
+			out = append(out, byte(u), byte(u>>8))
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding: %s (use utf8, utf8-bom, or utf16le)", encoding)
+	}
+}
+
+// ttmlStyle converts configured TTML styling into the domain style
+func ttmlStyle(cfg *config.Config) domain.TTMLStyle {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	ttml := cfg.Output.TTML
+	return domain.TTMLStyle{
+		FontFamily:      ttml.FontFamily,
+		FontSize:        ttml.FontSize,
+		Color:           ttml.Color,
+		BackgroundColor: ttml.BackgroundColor,
+		TextAlign:       ttml.TextAlign,
+	}
+}
+
+// captionOptions merges configured SRT shaping with the --srt-* flags,
+// which take precedence when set
+func captionOptions(cfg *config.Config) (domain.CaptionOptions, error) {
+	maxDuration, minDuration, err := cfg.GetSRTDurations()
+	if err != nil {
+		return domain.CaptionOptions{}, err
+	}
+	opts := domain.CaptionOptions{
+		MaxLineChars: cfg.Output.SRT.MaxLineChars,
+		MaxLines:     cfg.Output.SRT.MaxLines,
+		MaxDuration:  maxDuration.Seconds(),
+		MinDuration:  minDuration.Seconds(),
+	}
+	if srtMaxCharsFlag > 0 {
+		opts.MaxLineChars = srtMaxCharsFlag
+	}
+	if srtMaxDurationFlag > 0 {
+		opts.MaxDuration = srtMaxDurationFlag.Seconds()
+	}
+	return opts, nil
+}
+
+// assStyle converts configured ASS styling into the domain style
+func assStyle(cfg *config.Config) domain.ASSStyle {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	ass := cfg.Output.ASS
+	return domain.ASSStyle{
+		FontName:     ass.FontName,
+		FontSize:     ass.FontSize,
+		PrimaryColor: ass.PrimaryColor,
+		OutlineColor: ass.OutlineColor,
+		Bold:         ass.Bold,
+		Position:     ass.Position,
+		MarginV:      ass.MarginV,
+	}
+}
+
+// transcriptText renders the single text used by --clipboard and --stdout:
+// the --template output if set, otherwise the first --format, or plain text
+// when that format is binary
+func transcriptText(result *application.TranscribeResult, cfg *config.Config) (string, error) {
+	result, _, err := applyPipeline(result, cfg.Pipeline)
+	if err != nil {
+		return "", err
+	}
+
+	if templateFlag != "" {
+		tmpl, err := loadOutputTemplate(templateFlag)
+		if err != nil {
+			return "", err
+		}
+		return renderTemplate(tmpl, result)
+	}
+
+	formats, err := parseFormats(formatFlag)
+	if err != nil {
+		return "", err
+	}
+	content, ext, err := renderTranscript(result, formats[0], cfg)
+	if err != nil {
+		return "", err
+	}
+	if binaryExts[ext] {
+		return result.Transcript.ToText(), nil
+	}
+	return content, nil
+}
+
+// validateStdout checks that --stdout is asked for exactly one text output
+// and nothing that needs files
+func validateStdout() error {
+	if audioFlag || videoFlag || thumbnailFlag || compareCaptionsFlag || captionBundleFlag || commentsFlag > 0 {
+		return errors.New("--stdout writes no files; it cannot be combined with --audio, --video, --thumbnail, --compare-captions, --caption-bundle or --comments")
+	}
+	if templateFlag != "" {
+		if formatFlag != "" {
+			return errors.New("--stdout prints one output; use either --format or --template")
+		}
+		return nil
+	}
+
+	formats, err := parseFormats(formatFlag)
+	if err != nil {
+		return err
+	}
+	if len(formats) > 1 {
+		return fmt.Errorf("--stdout prints a single format, got %s", strings.Join(formats, ", "))
+	}
+	if binaryExts[formats[0]] {
+		return fmt.Errorf("%s output is binary and cannot be printed; write it to a file instead", formats[0])
+	}
+	return nil
+}
+
+// printTranscript writes the transcript to stdout in the requested encoding
+func printTranscript(result *application.TranscribeResult, cfg *config.Config) error {
+	content, err := transcriptText(result, cfg)
+	if err != nil {
+		return err
+	}
+	if clipboardFlag || clipboardOnlyFlag {
+		if err := copyToClipboard(content); err != nil {
+			return err
+		}
+	}
+
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	data, err := encodeOutput(content, encodingFlag)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestRenderTranscript_Stats(t *testing.T) {
+	result := &application.TranscribeResult{
+		Reel:       &domain.Reel{ID: "ABC123", DurationSeconds: 30},
+		Transcript: &domain.Transcript{Text: "one two three"},
+	}
+	cfg := config.DefaultConfig()
+
+	plain, _, err := renderTranscript(result, "text", cfg)
+	if err != nil {
+		t.Fatalf("renderTranscript() error = %v", err)
+	}
+	if plain != "one two three" {
+		t.Errorf("text without stats = %q", plain)
+	}
+
+	cfg.Output.Stats = true
+	withStats, _, _ := renderTranscript(result, "text", cfg)
+	if !strings.HasPrefix(withStats, "Words: 3 | Reading time: ~1 min | Duration: 0:30\n\n") {
+		t.Errorf("text with stats = %q", withStats)
+	}
+
+	jsonOut, _, _ := renderTranscript(result, "json", config.DefaultConfig())
+	if !strings.Contains(jsonOut, `"words": 3`) {
+		t.Errorf("json missing stats, got:\n%s", jsonOut)
+	}
+}
+
+func TestRenderTranscript_Caption(t *testing.T) {
+	result := &application.TranscribeResult{
+		Reel:       &domain.Reel{ID: "ABC123", Caption: "Full recipe: https://example.com\n#pasta"},
+		Transcript: &domain.Transcript{Text: "one two three"},
+	}
+	cfg := config.DefaultConfig()
+
+	text, _, _ := renderTranscript(result, "text", cfg)
+	if text != "one two three\n\nCaption:\nFull recipe: https://example.com\n#pasta" {
+		t.Errorf("text = %q", text)
+	}
+
+	jsonOut, _, _ := renderTranscript(result, "json", cfg)
+	if !strings.Contains(jsonOut, `"Caption": "Full recipe: https://example.com\n#pasta"`) {
+		t.Errorf("json missing caption, got:\n%s", jsonOut)
+	}
+}
+
+func TestRenderTranscript_SRTShaping(t *testing.T) {
+	result := &application.TranscribeResult{
+		Reel: &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Segments: []domain.Segment{
+			{Start: 0, End: 8, Text: "this caption is far too long to show on a phone screen at once"},
+		}},
+	}
+	cfg := config.DefaultConfig()
+
+	raw, _, err := renderTranscript(result, "srt", cfg)
+	if err != nil {
+		t.Fatalf("renderTranscript() error = %v", err)
+	}
+	if strings.Contains(raw, "\n2\n") {
+		t.Errorf("srt without shaping should keep one caption, got:\n%s", raw)
+	}
+
+	cfg.Output.SRT.MaxLineChars = 20
+	cfg.Output.SRT.MaxDuration = "4s"
+	shaped, _, err := renderTranscript(result, "srt", cfg)
+	if err != nil {
+		t.Fatalf("renderTranscript() error = %v", err)
+	}
+	if !strings.Contains(shaped, "\n\n2\n") {
+		t.Errorf("shaped srt should be split into several captions, got:\n%s", shaped)
+	}
+
+	cfg.Output.SRT.MaxDuration = "soon"
+	if _, _, err := renderTranscript(result, "srt", cfg); err == nil {
+		t.Error("renderTranscript() expected error for invalid srt max_duration")
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{"", []string{"text"}, false},
+		{"srt", []string{"srt"}, false},
+		{"text, SRT,json", []string{"text", "srt", "json"}, false},
+		{"srt,srt,,json", []string{"srt", "json"}, false},
+		{"md,markdown", []string{"markdown"}, false},
+		{"text,docs", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseFormats(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFormats(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseFormats(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTranscript_SupportedFormats(t *testing.T) {
+	result := &application.TranscribeResult{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello", Segments: []domain.Segment{{Start: 0, End: 1, Text: "hello"}}},
+	}
+
+	for _, format := range supportedFormats {
+		_, ext, err := renderTranscript(result, format, config.DefaultConfig())
+		if err != nil {
+			t.Errorf("renderTranscript(%q) error = %v", format, err)
+		}
+		if ext != formatExts[format] {
+			t.Errorf("renderTranscript(%q) ext = %q, formatExts has %q", format, ext, formatExts[format])
+		}
+	}
+}
+
+func TestWriteOutputs_MultipleFormats(t *testing.T) {
+	dir := t.TempDir()
+	result := &application.TranscribeResult{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello", Segments: []domain.Segment{{Start: 0, End: 1, Text: "hello"}}},
+	}
+	cfg := config.DefaultConfig()
+	cfg.Pipeline = []config.PipelineStep{{Name: "exporter", Formats: []string{"json", "csv"}}}
+
+	oldFormat := formatFlag
+	defer func() { formatFlag = oldFormat }()
+
+	formatFlag = "text,srt,json"
+	paths, echo, err := writeOutputs(result, cfg, dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeOutputs() error = %v", err)
+	}
+
+	var names []string
+	for _, p := range paths {
+		names = append(names, filepath.Base(p))
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("expected %s to be written: %v", p, err)
+		}
+	}
+	if got := strings.Join(names, ","); got != "ABC123.txt,ABC123.srt,ABC123.json,ABC123.csv" {
+		t.Errorf("written files = %s", got)
+	}
+	if echo != "" {
+		t.Errorf("multiple formats should not be echoed, got %q", echo)
+	}
+
+	formatFlag = "text"
+	if _, echo, _ = writeOutputs(result, config.DefaultConfig(), dir, "ABC123"); echo != "hello" {
+		t.Errorf("single text format echo = %q, want hello", echo)
+	}
+}
+
+func TestWriteOutputs_JSONSchema(t *testing.T) {
+	dir := t.TempDir()
+	result := &application.TranscribeResult{
+		Reel:                &domain.Reel{ID: "ABC123"},
+		Transcript:          &domain.Transcript{Text: "hallo", Language: "de"},
+		AudioPath:           "/cache/ABC123/audio.wav",
+		TranscriptFromCache: true,
+		AudioFromCache:      true,
+		DownloadDuration:    1500 * time.Millisecond,
+		TimeSaved:           12 * time.Second,
+	}
+
+	oldFormat, oldAudio := formatFlag, audioFlag
+	defer func() { formatFlag, audioFlag = oldFormat, oldAudio }()
+	formatFlag, audioFlag = "json,srt", true
+
+	paths, _, err := writeOutputs(result, config.DefaultConfig(), dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeOutputs() error = %v", err)
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out JSONOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("schema_version = %d, want %d", out.SchemaVersion, JSONSchemaVersion)
+	}
+	if out.Language != "de" {
+		t.Errorf("language = %q, want de", out.Language)
+	}
+	if !out.Cache.Transcript || !out.Cache.Audio || out.Cache.Video || out.Cache.TimeSavedSeconds != 12 {
+		t.Errorf("cache = %+v", out.Cache)
+	}
+	if out.Timings.DownloadSeconds != 1.5 || out.Timings.TranscribeSeconds != 0 {
+		t.Errorf("timings = %+v", out.Timings)
+	}
+	want := map[string]string{
+		"json":  filepath.Join(dir, "ABC123.json"),
+		"srt":   filepath.Join(dir, "ABC123.srt"),
+		"audio": filepath.Join(dir, "ABC123.wav"),
+	}
+	if !reflect.DeepEqual(out.Files, want) {
+		t.Errorf("files = %v, want %v", out.Files, want)
+	}
+	if result.Files != nil {
+		t.Error("writeOutputs should not modify the caller's result")
+	}
+}
+
+func TestEncodeOutput(t *testing.T) {
+	tests := []struct {
+		encoding string
+		want     []byte
+	}{
+		{"", []byte("hé")},
+		{"utf8", []byte("hé")},
+		{"utf8-bom", []byte{0xEF, 0xBB, 0xBF, 'h', 0xC3, 0xA9}},
+		{"utf16le", []byte{0xFF, 0xFE, 'h', 0x00, 0xE9, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			got, err := encodeOutput("hé", tt.encoding)
+			if err != nil {
+				t.Fatalf("encodeOutput() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("encodeOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeOutput_Unknown(t *testing.T) {
+	if _, err := encodeOutput("hi", "latin1"); err == nil {
+		t.Error("encodeOutput() should reject unknown encodings")
+	}
+}
+
+func TestTranscriptText(t *testing.T) {
+	result := &application.TranscribeResult{
+		Reel:       &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{Text: "hello", Segments: []domain.Segment{{Start: 0, End: 1, Text: "hello"}}},
+	}
+
+	oldFormat := formatFlag
+	defer func() { formatFlag = oldFormat }()
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "hello"},
+		{"srt,json", "1\n00:00:00,000 --> 00:00:01,000\nhello\n"},
+		{"pdf", "hello"},
+	}
+
+	for _, tt := range tests {
+		formatFlag = tt.format
+		got, err := transcriptText(result, config.DefaultConfig())
+		if err != nil {
+			t.Fatalf("transcriptText(%q) error = %v", tt.format, err)
+		}
+		if got != tt.want {
+			t.Errorf("transcriptText(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestValidateStdout(t *testing.T) {
+	oldFormat, oldTemplate, oldAudio := formatFlag, templateFlag, audioFlag
+	defer func() { formatFlag, templateFlag, audioFlag = oldFormat, oldTemplate, oldAudio }()
+
+	tests := []struct {
+		name     string
+		format   string
+		template string
+		audio    bool
+		wantErr  bool
+	}{
+		{"default text", "", "", false, false},
+		{"single json", "json", "", false, false},
+		{"template only", "", "report.tmpl", false, false},
+		{"several formats", "text,srt", "", false, true},
+		{"binary format", "pdf", "", false, true},
+		{"format and template", "srt", "report.tmpl", false, true},
+		{"media file", "", "", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatFlag, templateFlag, audioFlag = tt.format, tt.template, tt.audio
+			if err := validateStdout(); (err != nil) != tt.wantErr {
+				t.Errorf("validateStdout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
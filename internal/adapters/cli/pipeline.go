@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// defaultSummarySentences is used by summarizer steps without a sentence count
+const defaultSummarySentences = 3
+
+// applyPipeline runs the configured post-processors over a result's
+// transcript. It returns a copy of the result, leaving the cached transcript
+// as transcribed, along with any extra formats requested by exporter steps.
+func applyPipeline(result *application.TranscribeResult, steps []config.PipelineStep) (*application.TranscribeResult, []string, error) {
+	if len(steps) == 0 {
+		return result, nil, nil
+	}
+
+	processed := *result
+	t := result.Transcript
+	var exports []string
+
+	for i, step := range steps {
+		switch step.Name {
+		case "punctuation":
+			t = t.MapText(domain.FixPunctuation)
+		case "corrections":
+			t = t.MapText(func(s string) string {
+				return domain.ApplyCorrections(s, step.Corrections)
+			})
+		case "profanity":
+			words := step.Words
+			if len(words) == 0 {
+				words = domain.DefaultProfanity
+			}
+			t = t.MapText(func(s string) string {
+				return domain.MaskProfanity(s, words)
+			})
+		case "summarizer":
+			sentences := step.Sentences
+			if sentences <= 0 {
+				sentences = defaultSummarySentences
+			}
+			summarized := *t
+			summarized.Summary = domain.Summarize(t, sentences)
+			t = &summarized
+		case "exporter":
+			exports = append(exports, step.Formats...)
+		default:
+			return nil, nil, fmt.Errorf("unknown pipeline step %d: %q (use punctuation, corrections, profanity, summarizer, exporter)", i+1, step.Name)
+		}
+	}
+
+	processed.Transcript = t
+	return &processed, exports, nil
+}
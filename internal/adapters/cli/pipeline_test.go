@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestApplyPipeline(t *testing.T) {
+	result := &application.TranscribeResult{
+		Reel: &domain.Reel{ID: "ABC123"},
+		Transcript: &domain.Transcript{
+			Text:     "join mavely , it's damn good",
+			Segments: []domain.Segment{{Start: 0, End: 2, Text: "join mavely , it's damn good"}},
+		},
+	}
+	steps := []config.PipelineStep{
+		{Name: "corrections", Corrections: map[string]string{"mavely": "Mavely"}},
+		{Name: "punctuation"},
+		{Name: "profanity"},
+		{Name: "summarizer", Sentences: 1},
+		{Name: "exporter", Formats: []string{"srt", "json"}},
+	}
+
+	processed, exports, err := applyPipeline(result, steps)
+	if err != nil {
+		t.Fatalf("applyPipeline() error = %v", err)
+	}
+
+	want := "Join Mavely, it's d*** good"
+	if processed.Transcript.Text != want || processed.Transcript.Segments[0].Text != want {
+		t.Errorf("processed transcript = %q / %q, want %q",
+			processed.Transcript.Text, processed.Transcript.Segments[0].Text, want)
+	}
+	if processed.Transcript.Summary != want {
+		t.Errorf("summary = %q, want %q", processed.Transcript.Summary, want)
+	}
+	if strings.Join(exports, ",") != "srt,json" {
+		t.Errorf("exports = %v, want [srt json]", exports)
+	}
+	if result.Transcript.Text != "join mavely , it's damn good" {
+		t.Error("applyPipeline() modified the original transcript")
+	}
+}
+
+func TestApplyPipeline_UnknownStep(t *testing.T) {
+	result := &application.TranscribeResult{Transcript: &domain.Transcript{Text: "hi"}}
+
+	_, _, err := applyPipeline(result, []config.PipelineStep{{Name: "punctuation"}, {Name: "translate"}})
+	if err == nil || !strings.Contains(err.Error(), "translate") {
+		t.Errorf("applyPipeline() error = %v, want unknown step error", err)
+	}
+}
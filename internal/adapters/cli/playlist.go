@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// expandPlaylists replaces the YouTube playlist and channel URLs among args
+// with the IDs of their videos, leaving other args as they are
+func expandPlaylists(ctx context.Context, app *App, args []string) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args {
+		playlistURL, ok := domain.ParseYouTubePlaylist(arg)
+		if !ok {
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		videos, err := app.BrowseSvc.ListPlaylist(ctx, playlistURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", playlistURL, err)
+		}
+		if !quietFlag {
+			fmt.Printf("Found %d videos in %s\n", len(videos), playlistURL)
+		}
+		for _, video := range videos {
+			expanded = append(expanded, video.ID)
+		}
+	}
+	return expanded, nil
+}
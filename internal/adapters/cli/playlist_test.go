@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/application"
+)
+
+func TestExpandPlaylists(t *testing.T) {
+	app := newMockApp(t.TempDir())
+
+	oldQuiet := quietFlag
+	defer func() { quietFlag = oldQuiet }()
+	quietFlag = true
+
+	args := []string{"ABC123", "https://www.youtube.com/@creator", "https://youtu.be/dQw4w9WgXcQ"}
+	got, err := expandPlaylists(context.Background(), app, args)
+	if err != nil {
+		t.Fatalf("expandPlaylists() error = %v", err)
+	}
+	if len(got) != 7 {
+		t.Fatalf("expandPlaylists() = %v, want the channel's 5 mock videos between the other args", got)
+	}
+	if got[0] != "ABC123" || got[6] != "https://youtu.be/dQw4w9WgXcQ" {
+		t.Errorf("other args = %q, %q, want them unchanged", got[0], got[6])
+	}
+	for _, id := range got[1:6] {
+		if !strings.HasPrefix(id, "yt.") {
+			t.Errorf("expanded ID %q is not a YouTube video", id)
+		}
+	}
+
+	ids, err := CollectInputs(context.Background(), application.NewReelInputService(nil), got, "")
+	if err != nil || len(ids) != 7 {
+		t.Errorf("CollectInputs() = %v, %v, want every expanded video accepted", ids, err)
+	}
+}
+
+func TestParseInputFile_Playlists(t *testing.T) {
+	inputs := application.NewReelInputService(nil)
+	inputs.AllowPlaylists(mock.NewDownloader(mock.Options{}))
+
+	old := stdinInput
+	defer func() { stdinInput = old }()
+	stdinInput = strings.NewReader("ABC123\n# https://www.youtube.com/@ignored\nhttps://www.youtube.com/@creator\n")
+
+	ids, err := ParseInputFile(context.Background(), inputs, "-")
+	if err != nil {
+		t.Fatalf("ParseInputFile() error = %v", err)
+	}
+	if len(ids) != 6 || ids[0] != "ABC123" {
+		t.Fatalf("ParseInputFile() = %v, want the reel and the channel's 5 mock videos", ids)
+	}
+
+	// A playlist that can't be listed fails the read instead of vanishing
+	stdinInput = strings.NewReader("https://www.youtube.com/@creator\n")
+	if _, err := ParseInputFile(context.Background(), application.NewReelInputService(nil), "-"); err == nil {
+		t.Error("ParseInputFile() without playlist support should fail on a channel URL")
+	}
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// manifestFile is the batch manifest written alongside outputs when --provenance is set
+const manifestFile = "manifest.json"
+
+// toolVersion returns the ig2insights module version from build info
+func toolVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
+
+// runProvenance holds what every reel of a run shares. Tool versions are
+// looked up once per run and model hashes once per model, since each costs
+// a subprocess or a read of the whole model file.
+type runProvenance struct {
+	app            *App
+	toolVersion    string
+	ytdlpVersion   string
+	whisperVersion string
+	args           []string
+
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// newRunProvenance looks up the tool versions for a run
+func newRunProvenance(ctx context.Context, app *App) *runProvenance {
+	return &runProvenance{
+		app:            app,
+		toolVersion:    toolVersion(),
+		ytdlpVersion:   app.Downloader.Version(ctx),
+		whisperVersion: app.Transcriber.Version(ctx),
+		args:           os.Args[1:],
+		hashes:         make(map[string]string),
+	}
+}
+
+// collect captures the provenance of a finished reel
+func (r *runProvenance) collect(result *application.TranscribeResult, startedAt time.Time) *domain.Provenance {
+	p := &domain.Provenance{
+		ToolVersion:    r.toolVersion,
+		YtDlpVersion:   r.ytdlpVersion,
+		WhisperVersion: r.whisperVersion,
+		Args:           r.args,
+		FromCache:      result.TranscriptFromCache,
+		StartedAt:      startedAt,
+		FinishedAt:     time.Now(),
+	}
+	if result.Transcript != nil {
+		p.Model = result.Transcript.Model
+		p.TranscribedAt = result.Transcript.TranscribedAt
+		p.ModelSHA256 = r.modelHash(p.Model)
+	}
+	return p
+}
+
+// modelHash returns model's hash, or "" when it can't be read
+func (r *runProvenance) modelHash(model string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hash, ok := r.hashes[model]; ok {
+		return hash
+	}
+	hash, err := r.app.Transcriber.ModelHash(model)
+	if err != nil {
+		hash = ""
+	}
+	r.hashes[model] = hash
+	return hash
+}
+
+// collectProvenance captures tool versions, model hash, and flags for a finished run
+func collectProvenance(ctx context.Context, app *App, result *application.TranscribeResult, startedAt time.Time) *domain.Provenance {
+	return newRunProvenance(ctx, app).collect(result, startedAt)
+}
+
+// ManifestEntry describes one reel in a batch manifest
+type ManifestEntry struct {
+	ReelID     string             `json:"reel_id"`
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+	Output     string             `json:"output,omitempty"`
+	Provenance *domain.Provenance `json:"provenance,omitempty"`
+}
+
+// Manifest records every reel produced by a batch run
+type Manifest struct {
+	Args       []string          `json:"args"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Reels      []ManifestEntry   `json:"reels"`
+	Cache      *JSONCacheMetrics `json:"cache"`
+}
+
+// writeManifest writes the batch manifest into outputDir
+func writeManifest(outputDir string, startedAt time.Time, results []BatchResult) (string, error) {
+	m := Manifest{
+		Args:       os.Args[1:],
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		Cache:      newJSONCacheMetrics(batchCacheMetrics(results)),
+	}
+	for _, r := range results {
+		m.Reels = append(m.Reels, ManifestEntry{
+			ReelID:     r.ReelID,
+			Success:    r.Success,
+			Error:      r.Error,
+			Output:     r.Output,
+			Provenance: r.Provenance,
+		})
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(outputDir, manifestFile)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// writeProvenanceSidecar writes provenance next to a non-JSON output file
+func writeProvenanceSidecar(outputDir, baseName string, p *domain.Provenance) (string, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path, write := outputTarget(filepath.Join(outputDir, baseName+".provenance.json"))
+	if !write {
+		return path, nil
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/mock"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	results := []BatchResult{
+		{ReelID: "AAA", Success: true, Output: "AAA.txt", Cached: true, TimeSaved: 8 * time.Second, Provenance: &domain.Provenance{Model: "small", ModelSHA256: "abc"}},
+		{ReelID: "BBB", Success: false, Error: "reel not found"},
+		{ReelID: "CCC", Success: true, Output: "CCC.txt"},
+	}
+
+	path, err := writeManifest(dir, time.Now(), results)
+	if err != nil {
+		t.Fatalf("writeManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	if len(m.Reels) != 3 {
+		t.Fatalf("expected 3 reels, got %d", len(m.Reels))
+	}
+	if m.Reels[0].Provenance == nil || m.Reels[0].Provenance.ModelSHA256 != "abc" {
+		t.Errorf("expected provenance for AAA, got %+v", m.Reels[0].Provenance)
+	}
+	if m.Reels[1].Error != "reel not found" {
+		t.Errorf("expected error for BBB, got %q", m.Reels[1].Error)
+	}
+	want := JSONCacheMetrics{Hits: 1, Misses: 1, HitRate: 0.5, TimeSavedSeconds: 8}
+	if m.Cache == nil || *m.Cache != want {
+		t.Errorf("cache = %+v, want %+v", m.Cache, want)
+	}
+}
+
+// countingTranscriber is the mock transcriber counting the lookups
+// provenance makes
+type countingTranscriber struct {
+	*mock.Transcriber
+	versions, hashes atomic.Int32
+}
+
+func (t *countingTranscriber) Version(ctx context.Context) string {
+	t.versions.Add(1)
+	return t.Transcriber.Version(ctx)
+}
+
+func (t *countingTranscriber) ModelHash(model string) (string, error) {
+	t.hashes.Add(1)
+	return t.Transcriber.ModelHash(model)
+}
+
+func TestProcessBatch_ProvenanceOncePerRun(t *testing.T) {
+	dir := t.TempDir()
+	outputDir := filepath.Join(dir, "out")
+	app := newMockApp(dir)
+	transcriber := &countingTranscriber{Transcriber: mock.NewTranscriber(mock.Options{})}
+	app.Transcriber = transcriber
+
+	oldQuiet, oldProvenance, oldConcurrency := quietFlag, provenanceFlag, batchConcurrency
+	defer func() { quietFlag, provenanceFlag, batchConcurrency = oldQuiet, oldProvenance, oldConcurrency }()
+	quietFlag, provenanceFlag, batchConcurrency = true, true, 3
+
+	if err := processBatch(context.Background(), app, []string{"AAA", "BBB", "CCC"}, outputDir, nil, false); err != nil {
+		t.Fatalf("processBatch() error = %v", err)
+	}
+	if n := transcriber.versions.Load(); n != 1 {
+		t.Errorf("whisper version looked up %d times, want once per batch", n)
+	}
+	if n := transcriber.hashes.Load(); n != 1 {
+		t.Errorf("model hashed %d times, want once per model", n)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, manifestFile))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	for _, r := range m.Reels {
+		if r.Provenance == nil || r.Provenance.WhisperVersion != "mock" || r.Provenance.ModelSHA256 == "" {
+			t.Errorf("%s provenance = %+v", r.ReelID, r.Provenance)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+// resolveProxy returns the proxy for yt-dlp and HTTP downloads: override
+// (from --proxy), otherwise the config's. An empty result leaves the
+// HTTPS_PROXY and HTTP_PROXY environment variables in charge.
+func resolveProxy(cfg *config.Config, override string) (string, error) {
+	proxy := cfg.Proxy
+	if override != "" {
+		proxy = override
+	}
+	if proxy == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return "", fmt.Errorf("invalid proxy %q: %w", proxy, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return "", fmt.Errorf("invalid proxy %q: scheme must be http, https, socks5 or socks5h", displayProxy(proxy))
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid proxy %q: missing host", displayProxy(proxy))
+	}
+	return proxy, nil
+}
+
+// displayProxy returns proxy without its username and password, for output
+func displayProxy(proxy string) string {
+	u, err := url.Parse(proxy)
+	if err != nil || u.User == nil {
+		return proxy
+	}
+	u.User = nil
+	return u.String()
+}
+
+// proxyHTTPClient returns a client that sends every request through proxy,
+// which resolveProxy has validated
+func proxyHTTPClient(proxy string) *http.Client {
+	u, _ := url.Parse(proxy)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(u)
+	return &http.Client{Transport: transport}
+}
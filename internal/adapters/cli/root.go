@@ -3,17 +3,18 @@ package cli
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/devbush/ig2insights/internal/adapters/cli/tui"
 	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
 	"github.com/devbush/ig2insights/internal/domain"
 	"github.com/devbush/ig2insights/internal/ports"
 	"github.com/spf13/cobra"
@@ -21,49 +22,178 @@ import (
 
 var (
 	// Global flags
-	formatFlag    string
-	modelFlag     string
-	cacheTTLFlag  string
-	noCacheFlag   bool
-	dirFlag       string
-	nameFlag      string
-	quietFlag     bool
-	languageFlag  string
-	audioFlag     bool
-	videoFlag     bool
-	thumbnailFlag bool
+	formatFlag     string
+	modelFlag      string
+	cacheTTLFlag   string
+	noCacheFlag    bool
+	dirFlag        string
+	nameFlag       string
+	quietFlag      bool
+	languageFlag   string
+	audioFlag      bool
+	videoFlag      bool
+	thumbnailFlag  bool
+	promptFlag     string
+	timeoutFlag    time.Duration
+	retriesFlag    int
+	fallbackFlag   string
+	provenanceFlag bool
+	encodingFlag   string
+	statsFlag      bool
+	subtitlesFlag  bool
+
+	compareCaptionsFlag bool
+
+	captionBundleFlag bool
+
+	commentsFlag int
+
+	audioFormatFlag  string
+	videoQualityFlag string
+
+	// Sites ig2insights doesn't know, handed to yt-dlp
+	anyURLFlag bool
+
+	// Clipboard and stdout output
+	clipboardFlag     bool
+	clipboardOnlyFlag bool
+	stdoutFlag        bool
+
+	// Reel input read from the clipboard
+	clipboardInputFlag bool
+
+	// Network
+	proxyFlag           string
+	downloadRetriesFlag int
+	downloadBackoffFlag time.Duration
+
+	// Output file naming
+	outputTemplateFlag string
+	forceFlag          bool
+	skipExistingFlag   bool
+
+	throttleProfileFlag string
+	templateFlag        string
+	maxDurationFlag     time.Duration
+
+	// yt-dlp login for private and login-gated reels
+	cookiesFlag            string
+	cookiesFromBrowserFlag string
+
+	ytdlpArgsFlag string
+
+	aria2cFlag bool
+
+	// Serve everything from cache
+	offlineFlag bool
+
+	// Cache kept apart from the default one
+	cacheProfileFlag string
+
+	// Separate config and state, e.g. per client
+	profileFlag string
+
+	// Config file to use instead of the profile's
+	configFlag string
+
+	// SRT caption shaping
+	srtMaxCharsFlag    int
+	srtMaxDurationFlag time.Duration
+
+	// Hidden development flags
+	mockFlag         bool
+	mockDelayFlag    time.Duration
+	mockFailRateFlag float64
 )
 
 // NewRootCmd creates the root command
 func NewRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
-		Use:   "ig2insights [reel-url|reel-id]",
+		Use:   "ig2insights [reel-url|reel-id|file]",
 		Short: "Transcribe Instagram Reels",
 		Long: `ig2insights is a CLI tool that transcribes Instagram Reels.
 
-Provide a reel URL or ID to transcribe it, or run without arguments
-for an interactive menu.`,
+Provide a reel URL or ID to transcribe it, or the path of a video or audio
+file you already have. Run without arguments for an interactive menu.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runRoot,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return prepareCommand(cmd)
+		},
 	}
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "Output format: text, srt, json")
-	rootCmd.PersistentFlags().StringVar(&modelFlag, "model", "small", "Whisper model: tiny, base, small, medium, large")
-	rootCmd.PersistentFlags().StringVar(&cacheTTLFlag, "cache-ttl", "7d", "Cache lifetime (e.g., 24h, 7d)")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "Output formats, comma-separated: text, text-ts, srt, lrc, ttml, ass, csv, tsv, markdown, pdf, docx, accessible, json, jsonl")
+	rootCmd.PersistentFlags().StringVar(&modelFlag, "model", "", "Whisper model: tiny, base, small, medium, large, large-v3-turbo, distil-large-v3, small.en-tdrz (default from config: small)")
+	rootCmd.PersistentFlags().StringVar(&cacheTTLFlag, "cache-ttl", "", "Cache lifetime (e.g., 24h, 7d) (default from config: 7d)")
 	rootCmd.PersistentFlags().BoolVar(&noCacheFlag, "no-cache", false, "Skip cache")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Profile with its own config, cache, history and login, e.g. per client (default: $IG2INSIGHTS_PROFILE)")
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Config file to use instead of config.yaml, e.g. one bundled with a script (default: $IG2INSIGHTS_CONFIG)")
+	rootCmd.PersistentFlags().StringVar(&cacheProfileFlag, "cache-profile", "", "Cache profile from config to keep reels in, apart from the default cache")
 	rootCmd.PersistentFlags().StringVarP(&dirFlag, "dir", "d", "", "Output directory (default: ./{reelID})")
 	rootCmd.PersistentFlags().StringVarP(&nameFlag, "name", "n", "", "Base filename for outputs (default: {reelID})")
+	rootCmd.PersistentFlags().StringVar(&outputTemplateFlag, "output-template", "", "Base filename from reel metadata, e.g. {author}_{date}_{id} (fields: id, author, title, date)")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress progress output")
 	rootCmd.PersistentFlags().StringVarP(&languageFlag, "language", "l", "auto", "Language code (auto, en, fr, es, etc.)")
-	rootCmd.PersistentFlags().BoolVar(&audioFlag, "audio", false, "Download the audio file (WAV)")
+	rootCmd.PersistentFlags().BoolVar(&audioFlag, "audio", false, "Download the audio file (WAV unless --audio-format says otherwise)")
+	rootCmd.PersistentFlags().StringVar(&audioFormatFlag, "audio-format", "", "Format --audio saves: wav, mp3, m4a, flac (default from config: wav)")
 	rootCmd.PersistentFlags().BoolVar(&videoFlag, "video", false, "Download the original video file")
+	rootCmd.PersistentFlags().StringVar(&videoQualityFlag, "video-quality", "", "Quality --video downloads: best, worst, or a maximum height like 720p (default from config: best)")
 	rootCmd.PersistentFlags().BoolVar(&thumbnailFlag, "thumbnail", false, "Download the video thumbnail")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Per-attempt transcription timeout (e.g., 90s, 10m)")
+	rootCmd.PersistentFlags().DurationVar(&maxDurationFlag, "max-duration", 0, "Skip reels longer than this (e.g., 10m); asks first when interactive")
+	rootCmd.PersistentFlags().IntVar(&retriesFlag, "retries", -1, "Retry transcription on timeout or empty output (default from config: 0)")
+	rootCmd.PersistentFlags().IntVar(&downloadRetriesFlag, "download-retries", -1, "Retry rate-limited or failed downloads this many times (default from config: 2)")
+	// -1 stands for the config's default, which the usage text names
+	for _, name := range []string{"retries", "download-retries"} {
+		rootCmd.PersistentFlags().Lookup(name).DefValue = "0"
+	}
+	rootCmd.PersistentFlags().DurationVar(&downloadBackoffFlag, "download-backoff", 0, "Wait before the first download retry, doubling after (e.g., 30s) (default from config: 2s)")
+	rootCmd.PersistentFlags().StringVar(&fallbackFlag, "fallback-model", "", "Whisper model to use for retries")
+	rootCmd.PersistentFlags().BoolVar(&subtitlesFlag, "subtitles", false, "Use Instagram's subtitles when available, falling back to whisper")
+	rootCmd.PersistentFlags().BoolVar(&compareCaptionsFlag, "compare-captions", false, "Also save Instagram's captions and a report comparing them with the whisper transcript")
+	rootCmd.PersistentFlags().BoolVar(&captionBundleFlag, "caption-bundle", false, "Also write a ready-to-paste post caption with hashtags from config, plus an SRT")
+	rootCmd.PersistentFlags().IntVar(&commentsFlag, "comments", 0, "Also save the N most liked comments to a .comments.json file")
+	rootCmd.PersistentFlags().StringVar(&promptFlag, "prompt", "", "Initial prompt with vocabulary hints (e.g., \"Mavely, UGC, affiliate\")")
+	rootCmd.PersistentFlags().StringVar(&encodingFlag, "encoding", "utf8", "Output text encoding: utf8, utf8-bom, utf16le")
+	rootCmd.PersistentFlags().BoolVar(&statsFlag, "stats", false, "Prepend word count, reading time, and duration to text/markdown output")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "Render the result through a Go template file (e.g., report.md.tmpl)")
+	rootCmd.PersistentFlags().IntVar(&srtMaxCharsFlag, "srt-max-chars", 0, "Wrap and split SRT captions at this many characters per line")
+	rootCmd.PersistentFlags().DurationVar(&srtMaxDurationFlag, "srt-max-duration", 0, "Split SRT captions longer than this (e.g., 6s)")
+	rootCmd.PersistentFlags().StringVar(&throttleProfileFlag, "throttle-profile", "", "Request pacing profile from config: conservative, balanced, aggressive, or your own")
+	rootCmd.PersistentFlags().StringVar(&cookiesFlag, "cookies", "", "Netscape-format cookies file for private and login-gated reels")
+	rootCmd.PersistentFlags().StringVar(&cookiesFromBrowserFlag, "cookies-from-browser", "", "Read login cookies from a browser (e.g., firefox, chrome)")
+	rootCmd.PersistentFlags().StringVar(&ytdlpArgsFlag, "ytdlp-args", "", "Extra options added to every yt-dlp request, quoted like a shell (e.g., \"--extractor-args instagram:...\")")
+	rootCmd.PersistentFlags().BoolVar(&aria2cFlag, "aria2c", false, "Download with aria2c over parallel connections when it's installed (see 'deps install')")
+	rootCmd.PersistentFlags().BoolVar(&offlineFlag, "offline", false, "Serve reels from cache only, failing instead of using the network")
+	rootCmd.PersistentFlags().BoolVar(&clipboardFlag, "clipboard", false, "Also copy the transcript to the system clipboard")
+	rootCmd.PersistentFlags().BoolVar(&clipboardOnlyFlag, "clipboard-only", false, "Copy the transcript to the clipboard without writing transcript files")
+	rootCmd.Flags().BoolVar(&clipboardInputFlag, "clipboard-input", false, "Transcribe the reel URL on the system clipboard")
+	rootCmd.PersistentFlags().BoolVar(&stdoutFlag, "stdout", false, "Print the transcript to stdout only, writing no files")
+	rootCmd.PersistentFlags().BoolVar(&anyURLFlag, "any-url", false, "Hand URLs of sites ig2insights doesn't recognize to yt-dlp as-is")
+	rootCmd.PersistentFlags().StringVar(&proxyFlag, "proxy", "", "Proxy URL for downloads (http://, https:// or socks5://)")
+	rootCmd.PersistentFlags().BoolVar(&forceFlag, "force", false, "Overwrite existing output files without asking")
+	rootCmd.PersistentFlags().BoolVar(&skipExistingFlag, "skip-existing", false, "Keep existing output files instead of overwriting them")
+	rootCmd.PersistentFlags().BoolVar(&provenanceFlag, "provenance", false, "Record tool versions, model hash, and flags with the output")
+	rootCmd.PersistentFlags().BoolVar(&mockFlag, "mock", false, "Use deterministic fake downloader and transcriber")
+	rootCmd.PersistentFlags().DurationVar(&mockDelayFlag, "mock-delay", 0, "Simulated latency per mock call")
+	rootCmd.PersistentFlags().Float64Var(&mockFailRateFlag, "mock-fail-rate", 0, "Fraction of reels (0-1) that fail in mock mode")
+	for _, name := range []string{"mock", "mock-delay", "mock-fail-rate"} {
+		_ = rootCmd.PersistentFlags().MarkHidden(name)
+	}
 
 	// Add subcommands
 	rootCmd.AddCommand(NewAccountCmd())
+	rootCmd.AddCommand(NewAccountsCmd())
+	rootCmd.AddCommand(NewAuthCmd())
 	rootCmd.AddCommand(NewBatchCmd())
+	rootCmd.AddCommand(NewBenchNetworkCmd())
 	rootCmd.AddCommand(NewCacheCmd())
+	rootCmd.AddCommand(NewCompareCmd())
+	rootCmd.AddCommand(NewConfigCmd())
+	rootCmd.AddCommand(NewDashboardCmd())
+	rootCmd.AddCommand(NewInfoCmd())
+	rootCmd.AddCommand(NewLimitsCmd())
 	rootCmd.AddCommand(NewModelCmd())
 	rootCmd.AddCommand(NewDepsCmd())
 
@@ -71,6 +201,17 @@ for an interactive menu.`,
 }
 
 func runRoot(cmd *cobra.Command, args []string) error {
+	if clipboardInputFlag {
+		if len(args) > 0 {
+			return errors.New("--clipboard-input takes the reel from the clipboard; drop the reel argument")
+		}
+		input, err := clipboardInput()
+		if err != nil {
+			return err
+		}
+		return runTranscribe(input)
+	}
+
 	if len(args) == 0 {
 		// No arguments - show interactive menu
 		return runInteractiveMenu()
@@ -83,6 +224,7 @@ func runRoot(cmd *cobra.Command, args []string) error {
 func runInteractiveMenu() error {
 	options := []tui.MenuOption{
 		{Label: "Transcribe a single reel", Value: "transcribe"},
+		{Label: "Transcribe the reel URL on the clipboard", Value: "clipboard"},
 		{Label: "Batch process multiple reels", Value: "batch"},
 		// Note: "Browse an account's reels" hidden - Instagram blocking yt-dlp user page scraping
 		{Label: "Manage cache", Value: "cache"},
@@ -96,7 +238,9 @@ func runInteractiveMenu() error {
 
 	switch selected {
 	case "transcribe":
-		return runTranscribeInteractive()
+		return runTranscribeInteractive(false)
+	case "clipboard":
+		return runTranscribeInteractive(true)
 	case "batch":
 		return runBatchInteractive()
 	case "account":
@@ -138,8 +282,13 @@ func runBatchInteractive() error {
 		return nil
 	}
 
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
 	// Collect and process
-	reelIDs, err := CollectInputs(inputs, batchFileFlag)
+	reelIDs, err := CollectInputs(context.Background(), app.InputSvc, inputs, batchFileFlag)
 	if err != nil {
 		return err
 	}
@@ -150,21 +299,19 @@ func runBatchInteractive() error {
 	}
 
 	fmt.Printf("Found %d reels to process\n", len(reelIDs))
-
-	app, err := GetApp()
-	if err != nil {
-		return err
-	}
+	batchConcurrency = resolveConcurrency(nil, app)
 
 	outputDir := dirFlag
 	if outputDir == "" {
 		outputDir = "."
 	}
 
-	return processBatch(context.Background(), app, reelIDs, outputDir)
+	return processBatch(context.Background(), app, reelIDs, outputDir, nil, false)
 }
 
-func runTranscribeInteractive() error {
+// runTranscribeInteractive asks what to get for a reel, then asks for the
+// reel or takes it from the clipboard
+func runTranscribeInteractive(fromClipboard bool) error {
 	// Show output options
 	checkboxOpts := []tui.CheckboxOption{
 		{Label: "Transcript", Value: "transcript", Checked: true},
@@ -200,10 +347,29 @@ func runTranscribeInteractive() error {
 		}
 	}
 
+	if wantTranscript {
+		language, err := pickLanguage()
+		if err != nil {
+			return err
+		}
+		if language == "" {
+			fmt.Println("Cancelled")
+			return nil
+		}
+		languageFlag = language
+	}
+
 	// Get reel URL
-	fmt.Print("Enter reel URL or ID: ")
 	var input string
-	fmt.Scanln(&input)
+	if fromClipboard {
+		if input, err = clipboardInput(); err != nil {
+			return err
+		}
+		fmt.Printf("Reel from clipboard: %s\n", input)
+	} else {
+		fmt.Print("Enter reel URL or ID: ")
+		fmt.Scanln(&input)
+	}
 
 	// Set flags based on selections
 	audioFlag = wantAudio
@@ -218,13 +384,32 @@ func runTranscribeInteractive() error {
 	return runDownloadOnly(input, wantAudio, wantVideo, wantThumbnail)
 }
 
+// recentLanguageCount is how many recently used languages the language
+// picker pins to the top
+const recentLanguageCount = 5
+
+// pickLanguage asks for the transcription language in the interactive flow,
+// unless one was given with --language. It returns "" if cancelled.
+func pickLanguage() (string, error) {
+	if languageFlag != tui.AutoLanguage {
+		return languageFlag, nil
+	}
+
+	var recent []string
+	if app, err := GetApp(); err == nil {
+		recent, _ = app.HistorySvc.RecentLanguages(context.Background(), recentLanguageCount)
+	}
+	return tui.RunLanguagePicker(domain.WhisperLanguages, recent)
+}
+
 // assetDownloadConfig holds configuration for downloading a single asset type
 type assetDownloadConfig struct {
-	enabled     bool
-	cachedPath  string
-	destPath    string
-	assetType   string
-	downloadFn  func() (string, error)
+	enabled    bool
+	cachedPath string
+	destPath   string
+	assetType  string
+	downloadFn func() (string, error)
+	saveFn     func(src, dest string) error // copies the asset out of the cache; nil for a plain copy
 }
 
 func runDownloadOnly(input string, wantAudio, wantVideo, wantThumbnail bool) error {
@@ -233,27 +418,43 @@ func runDownloadOnly(input string, wantAudio, wantVideo, wantThumbnail bool) err
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
 
-	reel, err := domain.ParseReelInput(input)
+	ctx := context.Background()
+
+	reel, err := app.InputSvc.Normalize(ctx, input)
 	if err != nil {
 		return err
 	}
+	if err := checkStoryLogin(app, reel); err != nil {
+		return err
+	}
 
-	ctx := context.Background()
-	outputDir, baseName := resolveOutputPaths(reel.ID)
+	if err := validateOutputTemplate(); err != nil {
+		return err
+	}
+
+	cached, _ := app.Cache.Get(ctx, reel.ID)
+	outputDir, baseName := resolveOutputPaths(outputReel(ctx, app, reel, cached))
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	cached, _ := app.Cache.Get(ctx, reel.ID)
 	cacheDir := app.Cache.GetCacheDir(reel.ID)
+	audioExt, err := audioFormat(app.Config)
+	if err != nil {
+		return err
+	}
+	quality, err := videoQuality(app.Config)
+	if err != nil {
+		return err
+	}
 
 	// Build asset download configurations
 	assets := []assetDownloadConfig{
 		{
 			enabled:    wantAudio,
 			cachedPath: getCachedPath(cached, "audio"),
-			destPath:   filepath.Join(outputDir, baseName+".wav"),
+			destPath:   filepath.Join(outputDir, baseName+"."+audioExt),
 			assetType:  "audio",
 			downloadFn: func() (string, error) {
 				if err := os.MkdirAll(cacheDir, 0755); err != nil {
@@ -265,20 +466,24 @@ func runDownloadOnly(input string, wantAudio, wantVideo, wantThumbnail bool) err
 				}
 				return result.AudioPath, nil
 			},
+			saveFn: func(src, dest string) error {
+				return convertAudio(ctx, app.Downloader.GetFFmpegPath(), audioExt, src, dest)
+			},
 		},
 		{
 			enabled:    wantVideo,
-			cachedPath: getCachedPath(cached, "video"),
+			cachedPath: cachedVideoPath(cached, quality),
 			destPath:   filepath.Join(outputDir, baseName+".mp4"),
 			assetType:  "video",
 			downloadFn: func() (string, error) {
-				cachePath := filepath.Join(cacheDir, "video.mp4")
+				cachePath := filepath.Join(cacheDir, application.VideoCacheName(quality))
 				if err := os.MkdirAll(cacheDir, 0755); err != nil {
 					return "", err
 				}
-				if err := app.Downloader.DownloadVideo(ctx, reel.ID, cachePath); err != nil {
+				if err := app.TranscribeSvc.DownloadVideo(ctx, reel.ID, cachePath, quality); err != nil {
 					return "", err
 				}
+				application.RemoveReplacedVideo(getCachedPath(cached, "video"), cachePath)
 				return cachePath, nil
 			},
 		},
@@ -347,6 +552,15 @@ func getCachedPath(cached *ports.CachedItem, assetType string) string {
 	return ""
 }
 
+// cachedVideoPath returns the cached video if it's of quality
+func cachedVideoPath(cached *ports.CachedItem, quality string) string {
+	path := getCachedPath(cached, "video")
+	if path == "" || filepath.Base(path) != application.VideoCacheName(quality) {
+		return ""
+	}
+	return path
+}
+
 // downloadAsset handles downloading or copying a single asset.
 // Returns the cache path, whether a download occurred, and any error.
 func downloadAsset(cfg assetDownloadConfig) (cachePath string, wasDownloaded bool, err error) {
@@ -354,7 +568,7 @@ func downloadAsset(cfg assetDownloadConfig) (cachePath string, wasDownloaded boo
 		if !quietFlag {
 			fmt.Printf("Copying %s from cache to %s...\n", cfg.assetType, cfg.destPath)
 		}
-		if err := copyFile(cfg.cachedPath, cfg.destPath); err != nil {
+		if err := cfg.save(cfg.cachedPath); err != nil {
 			return "", false, fmt.Errorf("failed to copy %s: %w", cfg.assetType, err)
 		}
 		if !quietFlag {
@@ -370,7 +584,7 @@ func downloadAsset(cfg assetDownloadConfig) (cachePath string, wasDownloaded boo
 	if err != nil {
 		return "", false, fmt.Errorf("%s download failed: %w", cfg.assetType, err)
 	}
-	if err := copyFile(downloadedPath, cfg.destPath); err != nil {
+	if err := cfg.save(downloadedPath); err != nil {
 		return "", false, fmt.Errorf("failed to copy %s: %w", cfg.assetType, err)
 	}
 	if !quietFlag {
@@ -379,6 +593,14 @@ func downloadAsset(cfg assetDownloadConfig) (cachePath string, wasDownloaded boo
 	return downloadedPath, true, nil
 }
 
+// save copies the asset at src to its destination
+func (cfg assetDownloadConfig) save(src string) error {
+	if cfg.saveFn != nil {
+		return cfg.saveFn(src, cfg.destPath)
+	}
+	return copyFile(src, cfg.destPath)
+}
+
 // capitalizeFirst returns the string with its first letter capitalized
 func capitalizeFirst(s string) string {
 	if s == "" {
@@ -390,22 +612,18 @@ func capitalizeFirst(s string) string {
 // updateAssetCache updates the cache with newly downloaded asset paths
 func updateAssetCache(ctx context.Context, app *App, reelID string, cached *ports.CachedItem, newPaths map[string]string) {
 	now := time.Now()
-	ttl, _ := time.ParseDuration(cacheTTLFlag)
-	if ttl == 0 {
-		ttl = 7 * 24 * time.Hour
-	}
-
 	cacheItem := &ports.CachedItem{
 		AudioPath:     newPaths["audio"],
 		VideoPath:     newPaths["video"],
 		ThumbnailPath: newPaths["thumbnail"],
 		CreatedAt:     now,
-		ExpiresAt:     now.Add(ttl),
 	}
+	cacheItem.ExpiresAt, cacheItem.MediaExpiresAt = app.TranscribeSvc.CacheExpiry(now)
 
 	if cached != nil {
 		cacheItem.Reel = cached.Reel
 		cacheItem.Transcript = cached.Transcript
+		cacheItem.RawOutputPath = cached.RawOutputPath
 		if cacheItem.AudioPath == "" {
 			cacheItem.AudioPath = cached.AudioPath
 		}
@@ -421,19 +639,93 @@ func updateAssetCache(ctx context.Context, app *App, reelID string, cached *port
 	_ = app.Cache.Set(ctx, reelID, cacheItem)
 }
 
-// resolveOutputPaths returns the output directory and base filename
-func resolveOutputPaths(reelID string) (outputDir, baseName string) {
+// resolveOutputPaths returns the output directory and base filename: --name,
+// otherwise --output-template filled from the reel's metadata, otherwise
+// the reel ID
+func resolveOutputPaths(reel *domain.Reel) (outputDir, baseName string) {
+	// Carousel slides share their post's directory and add a slide suffix
+	shortcode, _ := domain.ParseSlideID(reel.ID)
+	suffix := domain.SlideSuffix(reel.ID)
+
 	outputDir = dirFlag
 	if outputDir == "" {
-		outputDir = reelID
-	}
-	baseName = nameFlag
-	if baseName == "" {
-		baseName = reelID
+		outputDir = shortcode
+	}
+	switch {
+	case nameFlag != "":
+		baseName = nameFlag + suffix
+	case outputTemplateFlag != "":
+		baseName = templateBaseName(reel)
+		if !strings.Contains(baseName, reel.ID) {
+			baseName += suffix
+		}
+	default:
+		baseName = shortcode + suffix
 	}
 	return outputDir, baseName
 }
 
+// applyTranscribeDefaults sets timeout, retry, subtitle and duration options
+// from flags, falling back to config defaults. Returns an error if a config
+// default is invalid.
+func applyTranscribeDefaults(opts *application.TranscribeOptions, cfg *config.Config) error {
+	opts.Timeout = timeoutFlag
+	if opts.Timeout == 0 {
+		timeout, err := cfg.GetTimeout()
+		if err != nil {
+			return err
+		}
+		opts.Timeout = timeout
+	}
+
+	opts.Retries = retriesFlag
+	if opts.Retries < 0 {
+		opts.Retries = cfg.Defaults.Retries
+	}
+
+	opts.FallbackModel = retryFallbackModel(cfg)
+	// Comparing captions needs a whisper transcript to compare against
+	opts.UseSubtitles = (subtitlesFlag || cfg.Defaults.Subtitles) && !compareCaptionsFlag
+
+	limit, err := maxDuration(cfg)
+	if err != nil {
+		return err
+	}
+	opts.MaxDuration = limit
+	chunkLength, err := cfg.GetChunkLength()
+	if err != nil {
+		return err
+	}
+	opts.ChunkLength = chunkLength
+	quality, err := videoQuality(cfg)
+	if err != nil {
+		return err
+	}
+	opts.VideoQuality = quality
+
+	opts.DownloadRetry.Retries = downloadRetriesFlag
+	if opts.DownloadRetry.Retries < 0 {
+		opts.DownloadRetry.Retries = cfg.Defaults.DownloadRetries
+	}
+	opts.DownloadRetry.Backoff = downloadBackoffFlag
+	if opts.DownloadRetry.Backoff == 0 {
+		backoff, err := cfg.GetDownloadBackoff()
+		if err != nil {
+			return err
+		}
+		opts.DownloadRetry.Backoff = backoff
+	}
+	return nil
+}
+
+// retryFallbackModel returns the model to use for transcription retries, if any
+func retryFallbackModel(cfg *config.Config) string {
+	if fallbackFlag != "" {
+		return fallbackFlag
+	}
+	return cfg.Defaults.FallbackModel
+}
+
 // stepName returns the step name with "(cached)" suffix if cached
 func stepName(name string, cached bool) string {
 	if cached {
@@ -442,12 +734,63 @@ func stepName(name string, cached bool) string {
 	return name
 }
 
+// reelLister fetches up to limit reels for an account in the given sort order
+type reelLister func(ctx context.Context, sort domain.SortOrder, limit int) ([]*domain.Reel, error)
+
 func runAccountInteractive(username string) error {
 	app, err := GetApp()
 	if err != nil {
 		return err
 	}
 
+	return runAccountBrowse(app, username, func(ctx context.Context, sort domain.SortOrder, limit int) ([]*domain.Reel, error) {
+		return app.BrowseSvc.ListReels(ctx, username, sort, limit)
+	})
+}
+
+// runAccountSeeded runs the account browse flow over a known set of reel IDs
+// instead of scraping the profile page. A limit above 0 transcribes the
+// first limit reels in sort order without the selection list.
+func runAccountSeeded(username string, reelIDs []string, sort domain.SortOrder, limit int) error {
+	app, err := GetApp()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	fmt.Printf("Fetching metadata for %d reels from @%s...\n", len(reelIDs), username)
+	seeded, err := app.BrowseSvc.SeedReels(ctx, username, reelIDs, sort)
+	if err != nil {
+		return fmt.Errorf("failed to fetch reel metadata: %w", err)
+	}
+
+	if limit > 0 {
+		if limit < len(seeded) {
+			seeded = seeded[:limit]
+		}
+		outputOpts, err := tui.RunOutputSelector(len(seeded))
+		if err != nil {
+			return err
+		}
+		if outputOpts == nil {
+			return nil // Cancelled
+		}
+		return processSelectedReels(ctx, app, seeded, outputOpts)
+	}
+
+	return runAccountBrowse(app, username, func(ctx context.Context, sort domain.SortOrder, limit int) ([]*domain.Reel, error) {
+		reels := make([]*domain.Reel, len(seeded))
+		copy(reels, seeded)
+		domain.SortReels(reels, sort)
+		if limit > 0 && limit < len(reels) {
+			reels = reels[:limit]
+		}
+		return reels, nil
+	})
+}
+
+func runAccountBrowse(app *App, username string, listReels reelLister) error {
 	ctx := context.Background()
 
 	// Step 1: Ask for sort order
@@ -471,12 +814,14 @@ func runAccountInteractive(username string) error {
 	// Step 2: Fetch initial reels
 	fmt.Printf("Fetching reels from @%s...\n", username)
 	const pageSize = 10
-	reels, err := app.BrowseSvc.ListReels(ctx, username, currentSort, pageSize)
+	reels, err := listReels(ctx, currentSort, pageSize)
 	if err != nil {
+		recordRateLimit(ctx, app, err)
 		if errors.Is(err, domain.ErrInstagramScrapingBlocked) {
 			fmt.Println("\nInstagram is currently blocking profile access.")
 			fmt.Println("This is a yt-dlp limitation - Instagram has restricted scraping of user pages.")
-			fmt.Println("\nWorkaround: Use the 'Transcribe a single reel' option with direct reel URLs instead.")
+			fmt.Println("\nWorkaround: Use the 'Transcribe a single reel' option with direct reel URLs instead,")
+			fmt.Printf("or seed the account with a file of reel URLs: ig2insights account %s --from-file urls.txt\n", username)
 			return nil
 		}
 		return fmt.Errorf("failed to fetch reels: %w", err)
@@ -507,7 +852,7 @@ func runAccountInteractive(username string) error {
 		case tui.ActionLoadMore:
 			fmt.Println("Loading more...")
 			currentCount := len(reels)
-			moreReels, err := app.BrowseSvc.ListReels(ctx, username, currentSort, pageSize+currentCount)
+			moreReels, err := listReels(ctx, currentSort, pageSize+currentCount)
 			if err != nil {
 				fmt.Printf("Error loading more: %v\n", err)
 				continue
@@ -531,7 +876,7 @@ func runAccountInteractive(username string) error {
 				currentSort = domain.SortLatest
 			}
 			fmt.Printf("Fetching reels sorted by %s...\n", currentSort)
-			reels, err = app.BrowseSvc.ListReels(ctx, username, currentSort, pageSize)
+			reels, err = listReels(ctx, currentSort, pageSize)
 			if err != nil {
 				return fmt.Errorf("failed to fetch reels: %w", err)
 			}
@@ -568,13 +913,20 @@ func processSelectedReels(ctx context.Context, app *App, reels []*domain.Reel, o
 		fmt.Printf("Processing %d/%d: %s...\n", i+1, total, reel.ID)
 
 		transcribeOpts := application.TranscribeOptions{
+			Prompt:        promptFlag,
 			SaveAudio:     opts.Audio,
 			SaveVideo:     opts.Video,
 			SaveThumbnail: opts.Thumbnail,
 		}
+		if err := applyTranscribeDefaults(&transcribeOpts, app.Config); err != nil {
+			return err
+		}
 
+		start := time.Now()
 		result, err := app.TranscribeSvc.Transcribe(ctx, reel.ID, transcribeOpts)
+		recordRun(ctx, app, reel.ID, transcribeOpts.Model, transcribeOpts.Language, start, result, err)
 		if err != nil {
+			recordRateLimit(ctx, app, err)
 			failed = append(failed, fmt.Sprintf("%s: %v", reel.ID, err))
 			continue
 		}
@@ -594,8 +946,9 @@ func processSelectedReels(ctx context.Context, app *App, reels []*domain.Reel, o
 		}
 
 		if opts.Audio && result.AudioPath != "" {
-			outPath := filepath.Join(outputDir, baseName+".wav")
-			if err := copyFile(result.AudioPath, outPath); err != nil {
+			format, _ := audioFormat(app.Config)
+			outPath := filepath.Join(outputDir, baseName+"."+format)
+			if err := convertAudio(ctx, app.Downloader.GetFFmpegPath(), format, result.AudioPath, outPath); err != nil {
 				failed = append(failed, fmt.Sprintf("%s (audio): %v", reel.ID, err))
 			}
 		}
@@ -635,19 +988,67 @@ func runCacheInteractive() error {
 }
 
 func runTranscribe(input string) error {
+	if err := validateEncoding(encodingFlag); err != nil {
+		return err
+	}
+	if _, err := parseFormats(formatFlag); err != nil {
+		return err
+	}
+	if templateFlag != "" {
+		if _, err := loadOutputTemplate(templateFlag); err != nil {
+			return err
+		}
+	}
+	if clipboardFlag || clipboardOnlyFlag {
+		if _, err := findClipboardTool(); err != nil {
+			return err
+		}
+	}
+	if err := validateConflictFlags(); err != nil {
+		return err
+	}
+	if err := validateOutputTemplate(); err != nil {
+		return err
+	}
+	if stdoutFlag {
+		if err := validateStdout(); err != nil {
+			return err
+		}
+		// Progress would interleave with the transcript
+		quietFlag = true
+	}
+
 	app, err := GetApp()
 	if err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
 
-	reel, err := domain.ParseReelInput(input)
+	ctx := context.Background()
+	startedAt := time.Now()
+
+	reel, err := app.InputSvc.Normalize(ctx, input)
 	if err != nil {
 		return err
 	}
+	if err := checkStoryLogin(app, reel); err != nil {
+		return err
+	}
 
-	ctx := context.Background()
+	slides, err := carouselSlides(ctx, app, reel)
+	if err != nil {
+		return err
+	}
+	if len(slides) > 0 {
+		return transcribeCarousel(ctx, app, reel, slides, startedAt)
+	}
+	return transcribeReel(ctx, app, reel, startedAt)
+}
 
+// transcribeReel transcribes one reel and writes its outputs, showing
+// progress as it goes
+func transcribeReel(ctx context.Context, app *App, reel *domain.Reel, startedAt time.Time) error {
 	// Pre-flight cache check to determine what's cached
+	var err error
 	var cached *ports.CachedItem
 	if !noCacheFlag {
 		cached, _ = app.Cache.Get(ctx, reel.ID)
@@ -655,12 +1056,36 @@ func runTranscribe(input string) error {
 
 	hasTranscript := cached != nil && cached.Transcript != nil
 	hasAudio := cached != nil && cached.AudioPath != "" && fileExists(cached.AudioPath)
-	hasVideo := cached != nil && cached.VideoPath != "" && fileExists(cached.VideoPath)
+	quality, _ := videoQuality(app.Config)
+	hasVideo := cachedVideoPath(cached, quality) != ""
 	hasThumbnail := cached != nil && cached.ThumbnailPath != "" && fileExists(cached.ThumbnailPath)
 
+	var durationLimit time.Duration
+	if !hasTranscript {
+		if durationLimit, err = checkReelDuration(ctx, app, reel.ID, cached); err != nil {
+			return err
+		}
+	}
+
+	// Settle existing outputs before the slow part rather than after it
+	outputDir, baseName := resolveOutputPaths(outputReel(ctx, app, reel, cached))
+	if !stdoutFlag && promptForConflicts() {
+		planned, err := plannedOutputs(app.Config, outputDir, baseName)
+		if err != nil {
+			return err
+		}
+		if outputTargets, err = resolveConflicts(planned, os.Stdin, os.Stderr); err != nil {
+			return err
+		}
+	}
+
 	// Build step list based on what we're doing and what's cached
 	steps := []string{"Checking dependencies"}
-	steps = append(steps, stepName("Downloading video", hasTranscript))
+	if domain.IsLocalFileID(reel.ID) {
+		steps = append(steps, stepName("Reading file", hasTranscript))
+	} else {
+		steps = append(steps, stepName("Downloading video", hasTranscript))
+	}
 	steps = append(steps, stepName("Extracting audio", hasTranscript))
 	steps = append(steps, stepName("Transcribing", hasTranscript))
 
@@ -686,22 +1111,26 @@ func runTranscribe(input string) error {
 	// Step 1: Check dependencies
 	progress.StartStep(0)
 
-	if !app.Downloader.IsAvailable() {
+	// Local files are read with ffmpeg alone
+	if !app.Downloader.IsAvailable() && !domain.IsLocalFileID(reel.ID) {
 		if err := app.Downloader.Install(context.Background(), func(d, t int64) {
 			progress.UpdateProgress(0, d, t)
 		}); err != nil {
 			progress.FailStep(0, err.Error())
-			return fmt.Errorf("failed to install yt-dlp: %w", err)
+			name, _ := downloaderName(app.Config)
+			return fmt.Errorf("failed to install %s: %w", name, err)
 		}
 	}
 
 	if !app.Transcriber.IsAvailable() {
 		instructions := app.Transcriber.InstallationInstructions()
 		if instructions != "" {
-			progress.FailStep(0, "whisper.cpp not found")
-			return errors.New(instructions)
-		}
-		if err := app.Transcriber.Install(context.Background(), func(d, t int64) {
+			// Configured fallbacks can transcribe without whisper.cpp
+			if len(app.Config.Fallbacks) == 0 {
+				progress.FailStep(0, "whisper.cpp not found")
+				return errors.New(instructions)
+			}
+		} else if err := app.Transcriber.Install(context.Background(), func(d, t int64) {
 			progress.UpdateProgress(0, d, t)
 		}); err != nil {
 			progress.FailStep(0, err.Error())
@@ -728,13 +1157,11 @@ func runTranscribe(input string) error {
 		model = app.Config.Defaults.Model
 	}
 
-	if !app.Transcriber.IsModelDownloaded(model) {
-		if err := app.Transcriber.DownloadModel(context.Background(), model, func(d, t int64) {
-			progress.UpdateProgress(0, d, t)
-		}); err != nil {
-			progress.FailStep(0, err.Error())
-			return fmt.Errorf("failed to download model: %w", err)
-		}
+	if err := downloadModels(context.Background(), app, transcriptionModels(app.Config, model), func(m string, d, t int64) {
+		progress.UpdateProgress(0, d, t)
+	}); err != nil {
+		progress.FailStep(0, err.Error())
+		return err
 	}
 	progress.CompleteStep(0)
 
@@ -750,16 +1177,38 @@ func runTranscribe(input string) error {
 		progress.StartStep(1)
 	}
 
-	result, err := app.TranscribeSvc.Transcribe(ctx, reel.ID, application.TranscribeOptions{
+	transcribeOpts := application.TranscribeOptions{
 		Model:         model,
 		NoCache:       noCacheFlag,
 		Language:      languageFlag,
+		Prompt:        promptFlag,
 		SaveAudio:     audioFlag,
 		SaveVideo:     videoFlag,
 		SaveThumbnail: thumbnailFlag,
-	})
+	}
+	if err := applyTranscribeDefaults(&transcribeOpts, app.Config); err != nil {
+		close(spinnerDone)
+		progress.FailStep(1, err.Error())
+		return err
+	}
+	transcribeOpts.MaxDuration = durationLimit
+	transcribeOpts.OnStage = func(state domain.JobState) {
+		if state == domain.JobTranscribing {
+			progress.CompleteStep(1) // Download
+			progress.CompleteStep(2) // Extract
+			progress.StartStep(3)
+		}
+	}
+	transcribeOpts.OnChunk = func(chunk, total int) {
+		progress.SetDetail(3, fmt.Sprintf("part %d of %d", chunk, total))
+	}
+
+	transcribeStart := time.Now()
+	result, err := app.TranscribeSvc.Transcribe(ctx, reel.ID, transcribeOpts)
+	recordRun(ctx, app, reel.ID, model, transcribeOpts.Language, transcribeStart, result, err)
 
 	if err != nil {
+		recordRateLimit(ctx, app, err)
 		close(spinnerDone)
 		progress.FailStep(1, err.Error())
 		return err
@@ -771,11 +1220,21 @@ func runTranscribe(input string) error {
 		progress.CompleteStep(2) // Extract
 		progress.CompleteStep(3) // Transcribe
 	}
+	attachTimeSaved(ctx, app, result)
 
-	outputDir, baseName := resolveOutputPaths(reel.ID)
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
+	if stdoutFlag {
 		close(spinnerDone)
-		return fmt.Errorf("failed to create output directory: %w", err)
+		if provenanceFlag {
+			result.Provenance = collectProvenance(ctx, app, result, startedAt)
+		}
+		return printTranscript(result, app.Config)
+	}
+
+	if !clipboardOnlyFlag || audioFlag || videoFlag || thumbnailFlag || compareCaptionsFlag || captionBundleFlag || commentsFlag > 0 {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			close(spinnerDone)
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
 	}
 
 	outputs := make(map[string]string)
@@ -788,9 +1247,10 @@ func runTranscribe(input string) error {
 			progress.StartStep(audioStepIdx)
 		}
 
-		audioPath := filepath.Join(outputDir, baseName+".wav")
 		if result.AudioPath != "" {
-			if err := copyFile(result.AudioPath, audioPath); err != nil {
+			format, _ := audioFormat(app.Config)
+			audioPath, err := saveAudio(ctx, app.Downloader.GetFFmpegPath(), format, result.AudioPath, filepath.Join(outputDir, baseName+"."+format))
+			if err != nil {
 				progress.FailStep(audioStepIdx, err.Error())
 			} else {
 				progress.CompleteStep(audioStepIdx)
@@ -810,9 +1270,9 @@ func runTranscribe(input string) error {
 			progress.StartStep(videoStepIdx)
 		}
 
-		videoPath := filepath.Join(outputDir, baseName+".mp4")
 		if result.VideoPath != "" {
-			if err := copyFile(result.VideoPath, videoPath); err != nil {
+			videoPath, err := copyOutput(result.VideoPath, filepath.Join(outputDir, baseName+".mp4"))
+			if err != nil {
 				progress.FailStep(videoStepIdx, err.Error())
 			} else {
 				progress.CompleteStep(videoStepIdx)
@@ -831,8 +1291,11 @@ func runTranscribe(input string) error {
 			progress.StartStep(thumbStepIdx)
 		}
 
-		thumbPath := filepath.Join(outputDir, baseName+".jpg")
-		if result.ThumbnailPath != "" {
+		thumbPath, writeThumb := outputTarget(filepath.Join(outputDir, baseName+".jpg"))
+		if !writeThumb {
+			progress.CompleteStep(thumbStepIdx)
+			outputs["Thumbnail"] = thumbPath
+		} else if result.ThumbnailPath != "" {
 			if err := copyFile(result.ThumbnailPath, thumbPath); err != nil {
 				progress.FailStep(thumbStepIdx, err.Error())
 			} else {
@@ -853,12 +1316,71 @@ func runTranscribe(input string) error {
 	// Stop spinner
 	close(spinnerDone)
 
+	if provenanceFlag {
+		result.Provenance = collectProvenance(ctx, app, result, startedAt)
+	}
+
 	// Output transcript
-	transcriptPath, err := outputResult(result, outputDir, baseName)
+	transcriptPaths, err := outputResult(result, app.Config, outputDir, baseName)
 	if err != nil {
 		return err
 	}
-	outputs["Transcript"] = transcriptPath
+	hasJSON := false
+	for i, path := range transcriptPaths {
+		label := "Transcript"
+		if i > 0 {
+			label = fmt.Sprintf("Transcript (%s)", strings.TrimPrefix(filepath.Ext(path), "."))
+		}
+		outputs[label] = path
+		hasJSON = hasJSON || filepath.Ext(path) == ".json"
+	}
+	if clipboardFlag || clipboardOnlyFlag {
+		outputs["Clipboard"] = "transcript copied"
+	}
+	if result.Transcript != nil && result.Transcript.Backend != "" {
+		outputs["Backend"] = fmt.Sprintf("%s fallback (%s)", result.Transcript.Backend, result.Transcript.Model)
+	}
+
+	if compareCaptionsFlag {
+		captionOutputs, err := writeCaptionComparison(ctx, app, reel.ID, result, outputDir, baseName)
+		if err != nil {
+			return err
+		}
+		if captionOutputs == nil {
+			outputs["Captions"] = "none published for this reel"
+		}
+		for label, path := range captionOutputs {
+			outputs[label] = path
+		}
+	}
+
+	if captionBundleFlag {
+		bundleOutputs, err := writeCaptionBundle(result, app.Config, outputDir, baseName)
+		if err != nil {
+			return err
+		}
+		for label, path := range bundleOutputs {
+			outputs[label] = path
+		}
+	}
+
+	if commentsFlag > 0 {
+		commentsPath, err := writeComments(ctx, app, reel.ID, outputDir, baseName)
+		if err != nil {
+			recordRateLimit(ctx, app, err)
+			return err
+		}
+		outputs["Comments"] = commentsPath
+	}
+
+	// JSON output embeds provenance; other formats get a sidecar file
+	if result.Provenance != nil && !hasJSON && !clipboardOnlyFlag {
+		provenancePath, err := writeProvenanceSidecar(outputDir, baseName, result.Provenance)
+		if err != nil {
+			return fmt.Errorf("failed to write provenance: %w", err)
+		}
+		outputs["Provenance"] = provenancePath
+	}
 
 	if !quietFlag && len(outputs) > 0 {
 		progress.Complete(outputs)
@@ -877,48 +1399,153 @@ func printProgress(downloaded, total int64) {
 	}
 }
 
-func outputResult(result *application.TranscribeResult, outputDir, baseName string) (string, error) {
-	format := formatFlag
-	if format == "" {
-		format = "text"
+// outputResult writes the transcript outputs, copies the transcript to the
+// clipboard when requested and echoes single text outputs to stdout. Returns
+// the written paths, the first --format entry first.
+func outputResult(result *application.TranscribeResult, cfg *config.Config, outputDir, baseName string) ([]string, error) {
+	var paths []string
+	var echo string
+	if !clipboardOnlyFlag {
+		var err error
+		if paths, echo, err = writeOutputs(result, cfg, outputDir, baseName); err != nil {
+			return nil, err
+		}
+	}
+
+	if clipboardFlag || clipboardOnlyFlag {
+		content, err := transcriptText(result, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if err := copyToClipboard(content); err != nil {
+			return nil, err
+		}
+	}
+
+	// Also print text output to stdout (unless quiet)
+	if !quietFlag && echo != "" {
+		fmt.Println(echo)
+	}
+
+	return paths, nil
+}
+
+// writeOutputs runs the post-processing pipeline and writes the transcript in
+// every format from --format followed by any exporter formats, then the
+// --template output. It returns the written paths in that order, and the
+// rendered text to echo when a single text output was requested. With a
+// template and no --format, only the template output is written.
+func writeOutputs(result *application.TranscribeResult, cfg *config.Config, outputDir, baseName string) (paths []string, echo string, err error) {
+	return writeOutputFormats(result, cfg, formatFlag, outputDir, baseName)
+}
+
+// writeOutputFormats is writeOutputs with the formats of spec in place of
+// --format's, e.g. from a row of a CSV batch file
+func writeOutputFormats(result *application.TranscribeResult, cfg *config.Config, spec, outputDir, baseName string) (paths []string, echo string, err error) {
+	var formats []string
+	if spec != "" || templateFlag == "" {
+		if formats, err = parseFormats(spec); err != nil {
+			return nil, "", err
+		}
+	}
+	requested := len(formats)
+	if templateFlag != "" {
+		requested++
 	}
 
-	var output string
-	var ext string
-	switch format {
-	case "text":
-		output = result.Transcript.ToText()
-		ext = "txt"
-	case "srt":
-		output = result.Transcript.ToSRT()
-		ext = "srt"
-	case "json":
-		data := map[string]interface{}{
-			"reel":       result.Reel,
-			"transcript": result.Transcript,
+	result, exports, err := applyPipeline(result, cfg.Pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, format := range exports {
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
 		}
-		jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	}
+	if slices.Contains(formats, "json") {
+		withFiles := *result
+		audioExt, _ := audioFormat(cfg)
+		withFiles.Files = outputFiles(result, formats, audioExt, outputDir, baseName)
+		result = &withFiles
+	}
+
+	for i, format := range formats {
+		path, content, ext, err := writeTranscript(result, format, cfg, outputDir, baseName)
 		if err != nil {
-			return "", err
+			return nil, "", fmt.Errorf("failed to write %s output: %w", format, err)
+		}
+		paths = append(paths, path)
+		if i == 0 && requested == 1 && !binaryExts[ext] {
+			echo = content
 		}
-		output = string(jsonBytes)
-		ext = "json"
-	default:
-		return "", fmt.Errorf("unknown format: %s", format)
 	}
 
-	// Write to file
-	filePath := filepath.Join(outputDir, baseName+"."+ext)
-	if err := os.WriteFile(filePath, []byte(output), 0644); err != nil {
-		return "", err
+	if templateFlag != "" {
+		path, content, err := writeTemplateOutput(result, templateFlag, outputDir, baseName, paths)
+		if err != nil {
+			return nil, "", err
+		}
+		paths = append(paths, path)
+		if requested == 1 {
+			echo = content
+		}
 	}
+	return paths, echo, nil
+}
 
-	// Also print to stdout (unless quiet)
-	if !quietFlag {
-		fmt.Println(output)
+// writeTemplateOutput renders result through the template at tmplPath and
+// writes it beside the other outputs, refusing to overwrite any of written
+func writeTemplateOutput(result *application.TranscribeResult, tmplPath, outputDir, baseName string, written []string) (path, content string, err error) {
+	tmpl, err := loadOutputTemplate(tmplPath)
+	if err != nil {
+		return "", "", err
+	}
+	path = filepath.Join(outputDir, baseName+"."+templateExt(tmplPath))
+	if slices.Contains(written, path) {
+		return "", "", fmt.Errorf("template output %s would overwrite another output; rename the template (e.g., report.md.tmpl)", path)
 	}
 
-	return filePath, nil
+	if content, err = renderTemplate(tmpl, result); err != nil {
+		return "", "", fmt.Errorf("failed to render template: %w", err)
+	}
+	path, write := outputTarget(path)
+	if !write {
+		return path, content, nil
+	}
+	data, err := encodeOutput(content, encodingFlag)
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", "", err
+	}
+	return path, content, nil
+}
+
+// writeTranscript renders result in format and writes it to
+// outputDir/baseName.{ext}, returning the path and rendered content
+func writeTranscript(result *application.TranscribeResult, format string, cfg *config.Config, outputDir, baseName string) (path, content, ext string, err error) {
+	content, ext, err = renderTranscript(result, format, cfg)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	path, write := outputTarget(filepath.Join(outputDir, baseName+"."+ext))
+	if !write {
+		return path, content, ext, nil
+	}
+
+	data := []byte(content)
+	if !binaryExts[ext] {
+		if data, err = encodeOutput(content, encodingFlag); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", "", "", err
+	}
+	return path, content, ext, nil
 }
 
 // fileExists checks if a file exists
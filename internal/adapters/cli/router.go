@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// ytdlpRouter sends YouTube videos, --any-url videos and local files to
+// yt-dlp when another backend is configured for Instagram, so they go
+// through the same pipeline whatever the downloader. Everything else goes to
+// the Instagram backend.
+type ytdlpRouter struct {
+	Downloader
+	ytdlp Downloader
+}
+
+// route returns the downloader responsible for reelID
+func (r *ytdlpRouter) route(reelID string) Downloader {
+	if _, ok := domain.ParseYouTubeID(reelID); ok || domain.IsGenericID(reelID) || domain.IsLocalFileID(reelID) {
+		return r.ytdlp
+	}
+	return r.Downloader
+}
+
+func (r *ytdlpRouter) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	return r.route(reelID).GetReel(ctx, reelID)
+}
+
+func (r *ytdlpRouter) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	return r.route(reelID).DownloadAudio(ctx, reelID, destDir)
+}
+
+func (r *ytdlpRouter) DownloadVideo(ctx context.Context, reelID string, destPath string) error {
+	return r.route(reelID).DownloadVideo(ctx, reelID, destPath)
+}
+
+func (r *ytdlpRouter) DownloadThumbnail(ctx context.Context, reelID string, destPath string) error {
+	return r.route(reelID).DownloadThumbnail(ctx, reelID, destPath)
+}
+
+// DownloadVideoQuality downloads at quality when the backend handling reelID
+// can choose, else its one version
+func (r *ytdlpRouter) DownloadVideoQuality(ctx context.Context, reelID string, destPath string, quality string) error {
+	if downloader, ok := r.route(reelID).(ports.VideoQualityDownloader); ok {
+		return downloader.DownloadVideoQuality(ctx, reelID, destPath, quality)
+	}
+	return r.route(reelID).DownloadVideo(ctx, reelID, destPath)
+}
+
+// DownloadSubtitles fetches captions of yt-dlp's videos with yt-dlp.
+// Instagram reels have none unless the Instagram backend can fetch
+// subtitles itself.
+func (r *ytdlpRouter) DownloadSubtitles(ctx context.Context, reelID string, destDir string, language string) (*ports.SubtitleResult, error) {
+	fetcher, ok := r.route(reelID).(ports.SubtitleFetcher)
+	if !ok {
+		return nil, nil
+	}
+	return fetcher.DownloadSubtitles(ctx, reelID, destDir, language)
+}
+
+// FetchComments fetches comments from whichever backend handles reelID
+func (r *ytdlpRouter) FetchComments(ctx context.Context, reelID string, limit int) ([]domain.Comment, error) {
+	fetcher, ok := r.route(reelID).(ports.CommentFetcher)
+	if !ok {
+		return nil, errors.New("fetching comments is not supported by this downloader")
+	}
+	return fetcher.FetchComments(ctx, reelID, limit)
+}
+
+// ListPlaylist lists YouTube playlists and channels with yt-dlp
+func (r *ytdlpRouter) ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error) {
+	lister, ok := r.ytdlp.(ports.PlaylistLister)
+	if !ok {
+		return nil, errors.New("listing YouTube playlists is not supported by this downloader")
+	}
+	return lister.ListPlaylist(ctx, playlistURL)
+}
+
+// RegisterURL passes --any-url URLs on to yt-dlp
+func (r *ytdlpRouter) RegisterURL(reelID, rawURL string) {
+	if registrar, ok := r.ytdlp.(ports.URLRegistrar); ok {
+		registrar.RegisterURL(reelID, rawURL)
+	}
+}
+
+// RegisterFile passes local files on to the yt-dlp downloader, which reads
+// them with ffmpeg
+func (r *ytdlpRouter) RegisterFile(reelID, path string) {
+	if registrar, ok := r.ytdlp.(ports.FileRegistrar); ok {
+		registrar.RegisterFile(reelID, path)
+	}
+}
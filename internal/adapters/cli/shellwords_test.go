@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitShellWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"  --no-check-certificates  ", []string{"--no-check-certificates"}},
+		{`--extractor-args "instagram:api_version=v1" -v`, []string{"--extractor-args", "instagram:api_version=v1", "-v"}},
+		{`--user-agent 'Mozilla/5.0 (X11; Linux)'`, []string{"--user-agent", "Mozilla/5.0 (X11; Linux)"}},
+		{`--output a\ b "say \"hi\"" 'it''s' ""`, []string{"--output", "a b", `say "hi"`, "its", ""}},
+		{`"C:\path\to"`, []string{`C:\path\to`}},
+	}
+
+	for _, tt := range tests {
+		got, err := splitShellWords(tt.input)
+		if err != nil {
+			t.Errorf("splitShellWords(%q) error = %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitShellWords(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{`--user-agent "Mozilla`, `'open`, `trailing\`} {
+		if _, err := splitShellWords(input); err == nil {
+			t.Errorf("splitShellWords(%q) expected error", input)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// checkStoryLogin fails early for stories when no login is configured,
+// since Instagram only shows stories to logged-in users
+func checkStoryLogin(app *App, reel *domain.Reel) error {
+	if !reel.Story || hasLogin(app) {
+		return nil
+	}
+	return fmt.Errorf("%w: stories are only visible when logged in; run 'ig2insights auth login' or pass --cookies", domain.ErrNoSession)
+}
+
+// hasLogin reports whether downloads carry login cookies: from the flags,
+// the config or a stored session
+func hasLogin(app *App) bool {
+	return cookiesFlag != "" || cookiesFromBrowserFlag != "" ||
+		app.Config.Cookies != (config.CookiesConfig{}) ||
+		app.SessionSvc.CookiesPath() != ""
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestCheckStoryLogin(t *testing.T) {
+	app := newMockApp(t.TempDir())
+	story := &domain.Reel{ID: "C9bFlDJv0uH", Story: true}
+
+	if err := checkStoryLogin(app, &domain.Reel{ID: "ABC123"}); err != nil {
+		t.Errorf("reel: error = %v, want no login needed", err)
+	}
+	if err := checkStoryLogin(app, story); !errors.Is(err, domain.ErrNoSession) {
+		t.Errorf("story without login: error = %v, want ErrNoSession", err)
+	}
+
+	app.Config.Cookies.FromBrowser = "firefox"
+	if err := checkStoryLogin(app, story); err != nil {
+		t.Errorf("story with browser cookies: error = %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// templateSuffixes are stripped from a template's file name to find the
+// extension of the file it renders, so report.md.tmpl writes {name}.md
+var templateSuffixes = []string{".tmpl", ".tpl", ".gotmpl"}
+
+// templateData is the value user templates are executed against
+type templateData struct {
+	Reel       *domain.Reel
+	Transcript *domain.Transcript
+	Stats      domain.TranscriptStats
+	Provenance *domain.Provenance
+	Text       string // plain transcript text
+}
+
+// templateFuncs are available to user templates alongside the builtins
+var templateFuncs = template.FuncMap{
+	"timestamp": domain.FormatChapterTime,
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"trim":      strings.TrimSpace,
+	"join":      strings.Join,
+	"replace":   strings.ReplaceAll,
+}
+
+// loadOutputTemplate reads and parses a --template file
+func loadOutputTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// templateExt returns the output extension for a template path: whatever
+// extension remains after removing the template suffix, or txt
+func templateExt(path string) string {
+	name := filepath.Base(path)
+	for _, suffix := range templateSuffixes {
+		if strings.HasSuffix(strings.ToLower(name), suffix) {
+			name = name[:len(name)-len(suffix)]
+			break
+		}
+	}
+	if ext := strings.TrimPrefix(filepath.Ext(name), "."); ext != "" {
+		return ext
+	}
+	return "txt"
+}
+
+// renderTemplate executes tmpl against result
+func renderTemplate(tmpl *template.Template, result *application.TranscribeResult) (string, error) {
+	data := templateData{
+		Reel:       result.Reel,
+		Transcript: result.Transcript,
+		Stats:      domain.ComputeStats(result.Transcript, result.Reel),
+		Provenance: result.Provenance,
+		Text:       result.Transcript.ToText(),
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/application"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestTemplateExt(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"mytemplate.tmpl", "txt"},
+		{"report.md.tmpl", "md"},
+		{"dir/page.html.gotmpl", "html"},
+		{"notes.TPL", "txt"},
+		{"custom.xml", "xml"},
+	}
+
+	for _, tt := range tests {
+		if got := templateExt(tt.path); got != tt.want {
+			t.Errorf("templateExt(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestWriteOutputs_Template(t *testing.T) {
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "report.md.tmpl")
+	tmpl := `# {{upper .Reel.Author}} ({{.Stats.Words}} words)
+{{range .Transcript.Segments}}- [{{timestamp .Start}}] {{.Text}}
+{{end}}`
+	if err := os.WriteFile(tmplPath, []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := &application.TranscribeResult{
+		Reel: &domain.Reel{ID: "ABC123", Author: "creator"},
+		Transcript: &domain.Transcript{
+			Text: "hello there friend",
+			Segments: []domain.Segment{
+				{Start: 0, End: 1, Text: "hello there"},
+				{Start: 65, End: 66, Text: "friend"},
+			},
+		},
+	}
+
+	oldFormat, oldTemplate := formatFlag, templateFlag
+	defer func() { formatFlag, templateFlag = oldFormat, oldTemplate }()
+
+	formatFlag, templateFlag = "", tmplPath
+	paths, echo, err := writeOutputs(result, config.DefaultConfig(), dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeOutputs() error = %v", err)
+	}
+	if len(paths) != 1 || filepath.Base(paths[0]) != "ABC123.md" {
+		t.Fatalf("paths = %v, want only ABC123.md", paths)
+	}
+	want := "# CREATOR (3 words)\n- [00:00] hello there\n- [01:05] friend\n"
+	if echo != want {
+		t.Errorf("echo = %q, want %q", echo, want)
+	}
+	if data, _ := os.ReadFile(paths[0]); string(data) != want {
+		t.Errorf("file content = %q, want %q", data, want)
+	}
+
+	formatFlag = "text,json"
+	paths, echo, err = writeOutputs(result, config.DefaultConfig(), dir, "ABC123")
+	if err != nil {
+		t.Fatalf("writeOutputs() error = %v", err)
+	}
+	if len(paths) != 3 || filepath.Base(paths[2]) != "ABC123.md" {
+		t.Errorf("paths = %v, want template output last", paths)
+	}
+	if echo != "" {
+		t.Errorf("several outputs should not be echoed, got %q", echo)
+	}
+
+	formatFlag = "markdown"
+	if _, _, err = writeOutputs(result, config.DefaultConfig(), dir, "ABC123"); err == nil || !strings.Contains(err.Error(), "overwrite") {
+		t.Errorf("expected overwrite error, got %v", err)
+	}
+}
+
+func TestLoadOutputTemplate_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Reel"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadOutputTemplate(path); err == nil {
+		t.Error("expected parse error")
+	}
+	if _, err := loadOutputTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("expected read error")
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const maxBatchConcurrency = 50
+
+// ytdlpThrottle converts a throttle profile into yt-dlp pacing options.
+// Durations are validated when the profile is loaded.
+func ytdlpThrottle(p config.ThrottleProfile) ytdlp.Throttle {
+	parse := func(s string) time.Duration {
+		d, _ := time.ParseDuration(s)
+		return d
+	}
+	return ytdlp.Throttle{
+		SleepRequests:    parse(p.SleepRequests),
+		SleepInterval:    parse(p.SleepInterval),
+		MaxSleepInterval: parse(p.MaxSleepInterval),
+		Retries:          p.Retries,
+		Impersonate:      p.Impersonate,
+
+		MinDelay:          parse(p.MinDelay),
+		Jitter:            parse(p.Jitter),
+		RequestsPerMinute: p.RequestsPerMinute,
+	}
+}
+
+// resolveConcurrency returns the batch worker count: --concurrency when set
+// on cmd, otherwise the throttle profile's, clamped to 1-50. A nil cmd means
+// the flag was not given.
+func resolveConcurrency(cmd *cobra.Command, app *App) int {
+	n := batchConcurrency
+	explicit := cmd != nil && cmd.Flags().Changed("concurrency")
+	if !explicit && app.Throttle.Concurrency > 0 {
+		n = app.Throttle.Concurrency
+	}
+	if n < 1 {
+		n = 1
+	}
+	if n > maxBatchConcurrency {
+		n = maxBatchConcurrency
+	}
+	return n
+}
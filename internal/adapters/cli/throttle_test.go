@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestYtdlpThrottle(t *testing.T) {
+	got := ytdlpThrottle(config.ThrottleProfile{
+		SleepRequests:     "1s",
+		SleepInterval:     "2s",
+		MaxSleepInterval:  "6s",
+		Retries:           5,
+		Impersonate:       "chrome",
+		MinDelay:          "1s",
+		Jitter:            "500ms",
+		RequestsPerMinute: 20,
+	})
+
+	if got.SleepRequests != time.Second || got.SleepInterval != 2*time.Second || got.MaxSleepInterval != 6*time.Second {
+		t.Errorf("ytdlpThrottle() durations = %+v", got)
+	}
+	if got.Retries != 5 || got.Impersonate != "chrome" {
+		t.Errorf("ytdlpThrottle() = %+v", got)
+	}
+	if got.MinDelay != time.Second || got.Jitter != 500*time.Millisecond || got.RequestsPerMinute != 20 {
+		t.Errorf("ytdlpThrottle() limiter = %+v", got)
+	}
+}
+
+func TestResolveConcurrency(t *testing.T) {
+	oldConcurrency := batchConcurrency
+	defer func() { batchConcurrency = oldConcurrency }()
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().IntVarP(&batchConcurrency, "concurrency", "c", 10, "")
+		return cmd
+	}
+
+	app := &App{Throttle: config.ThrottleProfile{Concurrency: 2}}
+
+	// The profile wins over the flag default
+	if got := resolveConcurrency(newCmd(), app); got != 2 {
+		t.Errorf("resolveConcurrency() = %d, want profile's 2", got)
+	}
+
+	// An explicit flag wins over the profile
+	cmd := newCmd()
+	_ = cmd.Flags().Set("concurrency", "7")
+	if got := resolveConcurrency(cmd, app); got != 7 {
+		t.Errorf("resolveConcurrency() = %d, want flag's 7", got)
+	}
+
+	// Without a profile the flag value is clamped
+	batchConcurrency = 500
+	if got := resolveConcurrency(nil, &App{}); got != maxBatchConcurrency {
+		t.Errorf("resolveConcurrency() = %d, want %d", got, maxBatchConcurrency)
+	}
+}
@@ -56,6 +56,7 @@ type BatchProgress struct {
 	quiet     bool
 	mu        sync.Mutex
 	rendered  bool
+	estimates map[string]time.Duration
 }
 
 // NewBatchProgress creates a new batch progress display
@@ -94,6 +95,29 @@ func (bp *BatchProgress) AddResult(reelID string, success bool, errMsg string, d
 	bp.render()
 }
 
+// SetEstimates sets the expected processing time of each reel, so the
+// progress line can show the time left
+func (bp *BatchProgress) SetEstimates(estimates map[string]time.Duration) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	bp.estimates = estimates
+}
+
+// remaining sums the estimates of reels without a result yet
+func (bp *BatchProgress) remaining() time.Duration {
+	done := make(map[string]bool, len(bp.results))
+	for _, r := range bp.results {
+		done[r.ReelID] = true
+	}
+	var left time.Duration
+	for id, d := range bp.estimates {
+		if !done[id] {
+			left += d
+		}
+	}
+	return left
+}
+
 func (bp *BatchProgress) render() {
 	if bp.quiet {
 		return
@@ -113,7 +137,11 @@ func (bp *BatchProgress) render() {
 		percent = (bp.completed * 100) / bp.total
 	}
 	progressBar := renderProgressBar(bp.completed, bp.total, 20)
-	fmt.Printf("Batch processing %d/%d reels %s %d%%\n", bp.completed, bp.total, progressBar, percent)
+	eta := ""
+	if bp.estimates != nil && bp.completed < bp.total {
+		eta = fmt.Sprintf(" ~%s left", bp.remaining().Round(time.Second))
+	}
+	fmt.Printf("Batch processing %d/%d reels %s %d%%%s\n", bp.completed, bp.total, progressBar, percent, eta)
 
 	// Render last 10 results
 	startIdx := 0
@@ -1,6 +1,9 @@
 package tui
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestRenderProgressBar(t *testing.T) {
 	tests := []struct {
@@ -22,3 +25,14 @@ func TestRenderProgressBar(t *testing.T) {
 		}
 	}
 }
+
+func TestBatchProgress_Remaining(t *testing.T) {
+	bp := NewBatchProgress(3, true)
+	bp.SetEstimates(map[string]time.Duration{"a": time.Second, "b": 2 * time.Second, "c": 4 * time.Second})
+	bp.AddResult("b", true, "", time.Second, false)
+	bp.AddResult("c", false, "failed", time.Second, false)
+
+	if got := bp.remaining(); got != time.Second {
+		t.Errorf("remaining() = %v, want 1s for the unfinished reel", got)
+	}
+}
@@ -14,39 +14,43 @@ const (
 	GB = MB * 1024
 )
 
-// FormatSize formats a byte count as a human-readable string
-// Examples: 1024 -> "1 KB", 1536000 -> "1.5 MB", 3221225472 -> "3.0 GB"
+// FormatSize formats a byte count as a human-readable string in the locale
+// Examples: 1024 -> "1 KB", 1536000 -> "1 MB", 3221225472 -> "3.0 GB" ("3,0 GB" in de-DE)
 func FormatSize(bytes int64) string {
 	switch {
 	case bytes >= GB:
-		return fmt.Sprintf("%.1f GB", float64(bytes)/GB)
+		return formatDecimal(float64(bytes)/GB, 1) + " GB"
 	case bytes >= MB:
-		return fmt.Sprintf("%.0f MB", float64(bytes)/MB)
+		return formatDecimal(float64(bytes)/MB, 0) + " MB"
 	case bytes >= KB:
-		return fmt.Sprintf("%.0f KB", float64(bytes)/KB)
+		return formatDecimal(float64(bytes)/KB, 0) + " KB"
 	default:
 		return fmt.Sprintf("%d B", bytes)
 	}
 }
 
-// FormatCount formats a number with K/M suffix
-// Examples: 892 -> "892", 1234 -> "1.2K", 1500000 -> "1.5M"
+// FormatCount formats a number with K/M suffix in the locale
+// Examples: 892 -> "892", 1234 -> "1.2K" ("1,2K" in de-DE), 1500000 -> "1.5M"
 func FormatCount(count int64) string {
 	if count >= 1000000 {
-		return fmt.Sprintf("%.1fM", float64(count)/1000000)
+		return formatDecimal(float64(count)/1000000, 1) + "M"
 	}
 	if count >= 1000 {
-		return fmt.Sprintf("%.1fK", float64(count)/1000)
+		return formatDecimal(float64(count)/1000, 1) + "K"
 	}
 	return fmt.Sprintf("%d", count)
 }
 
-// FormatDate formats a date as "Jan 15" style
+// FormatDate formats a date as "Jan 15", or "15 Jan" in locales that put
+// the day first
 func FormatDate(t time.Time) string {
 	if t.IsZero() {
 		return "---"
 	}
-	return t.Format("Jan 2")
+	if monthFirst() {
+		return t.Format("Jan 2")
+	}
+	return t.Format("2 Jan")
 }
 
 // FormatReelLine formats a reel as a single line for display
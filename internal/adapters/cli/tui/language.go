@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// AutoLanguage is the language value that leaves detection to whisper
+const AutoLanguage = "auto"
+
+// languagePickerRows is how many languages the picker shows at once
+const languagePickerRows = 10
+
+// languageItem is one row of the language picker
+type languageItem struct {
+	label string
+	code  string
+}
+
+// LanguagePickerModel is the bubbletea model for choosing a transcription
+// language. Typing filters the list; auto-detection and recently used
+// languages are pinned to the top.
+type LanguagePickerModel struct {
+	languages []domain.Language
+	recent    []string
+	query     string
+	items     []languageItem
+	cursor    int
+	selected  string
+}
+
+// NewLanguagePickerModel creates a language picker over languages, pinning
+// the recent language codes, newest first
+func NewLanguagePickerModel(languages []domain.Language, recent []string) LanguagePickerModel {
+	m := LanguagePickerModel{languages: languages, recent: recent}
+	m.items = m.filter()
+	return m
+}
+
+// filter returns the rows matching the current query
+func (m LanguagePickerModel) filter() []languageItem {
+	var items []languageItem
+	if m.query == "" || strings.Contains("auto-detect", strings.ToLower(m.query)) {
+		items = append(items, languageItem{label: "Auto-detect", code: AutoLanguage})
+	}
+
+	pinned := make(map[string]bool)
+	if m.query == "" {
+		for _, code := range m.recent {
+			pinned[code] = true
+			items = append(items, languageItem{label: fmt.Sprintf("%s (%s) · recent", domain.LanguageName(code), code), code: code})
+		}
+	}
+	for _, lang := range domain.FilterLanguages(m.languages, m.query) {
+		if !pinned[lang.Code] {
+			items = append(items, languageItem{label: fmt.Sprintf("%s (%s)", lang.Name, lang.Code), code: lang.Code})
+		}
+	}
+	return items
+}
+
+func (m LanguagePickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m LanguagePickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.Type {
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown:
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case tea.KeyEnter:
+		if len(m.items) > 0 {
+			m.selected = m.items[m.cursor].code
+			return m, tea.Quit
+		}
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyBackspace:
+		if m.query != "" {
+			runes := []rune(m.query)
+			m.query = string(runes[:len(runes)-1])
+			m.items, m.cursor = m.filter(), 0
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(key.Runes)
+		m.items, m.cursor = m.filter(), 0
+	}
+	return m, nil
+}
+
+func (m LanguagePickerModel) View() string {
+	var sb strings.Builder
+
+	sb.WriteString(titleStyle.Render("Transcription language"))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("Search: %s_\n\n", m.query))
+
+	if len(m.items) == 0 {
+		sb.WriteString(uncheckedStyle.Render("  No matching languages"))
+		sb.WriteString("\n")
+	}
+
+	// Scroll so the cursor stays in view
+	start := 0
+	if m.cursor >= languagePickerRows {
+		start = m.cursor - languagePickerRows + 1
+	}
+	end := min(start+languagePickerRows, len(m.items))
+	for i := start; i < end; i++ {
+		cursor := "  "
+		style := normalStyle
+		if i == m.cursor {
+			cursor = "> "
+			style = selectedStyle
+		}
+		sb.WriteString(fmt.Sprintf("%s%s\n", cursor, style.Render(m.items[i].label)))
+	}
+	if end < len(m.items) {
+		sb.WriteString(uncheckedStyle.Render(fmt.Sprintf("  … %d more", len(m.items)-end)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n(type to search, up/down to navigate, enter to select, esc to cancel)\n")
+	return sb.String()
+}
+
+// Selected returns the chosen language code, or "" if the picker was cancelled
+func (m LanguagePickerModel) Selected() string {
+	return m.selected
+}
+
+// RunLanguagePicker asks for a transcription language and returns its
+// code, AutoLanguage for auto-detection, or "" if cancelled
+func RunLanguagePicker(languages []domain.Language, recent []string) (string, error) {
+	model := NewLanguagePickerModel(languages, recent)
+	p := tea.NewProgram(model)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return finalModel.(LanguagePickerModel).Selected(), nil
+}
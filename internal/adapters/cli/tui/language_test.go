@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// typeKeys feeds keys to the picker as if typed
+func typeKeys(m LanguagePickerModel, keys ...tea.KeyMsg) LanguagePickerModel {
+	for _, key := range keys {
+		next, _ := m.Update(key)
+		m = next.(LanguagePickerModel)
+	}
+	return m
+}
+
+func TestLanguagePicker_PinsAutoAndRecent(t *testing.T) {
+	m := NewLanguagePickerModel(domain.WhisperLanguages, []string{"fr", "de"})
+
+	codes := []string{m.items[0].code, m.items[1].code, m.items[2].code}
+	if codes[0] != AutoLanguage || codes[1] != "fr" || codes[2] != "de" {
+		t.Errorf("first rows = %v, want auto, then recent fr, de", codes)
+	}
+	if len(m.items) != len(domain.WhisperLanguages)+1 {
+		t.Errorf("rows = %d, want each language once plus auto-detect", len(m.items))
+	}
+
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Selected() != AutoLanguage {
+		t.Errorf("Selected() = %q, want auto-detect by default", m.Selected())
+	}
+}
+
+func TestLanguagePicker_Search(t *testing.T) {
+	m := NewLanguagePickerModel(domain.WhisperLanguages, nil)
+
+	m = typeKeys(m,
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("spx")},
+		tea.KeyMsg{Type: tea.KeyBackspace},
+	)
+	if m.query != "sp" || m.items[0].code != "es" {
+		t.Fatalf("query %q first row = %+v, want Spanish", m.query, m.items[0])
+	}
+	if !strings.Contains(m.View(), "Spanish (es)") {
+		t.Errorf("View() = %q, want the match listed", m.View())
+	}
+
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Selected() != "es" {
+		t.Errorf("Selected() = %q, want es", m.Selected())
+	}
+}
+
+func TestLanguagePicker_Cancel(t *testing.T) {
+	m := NewLanguagePickerModel(domain.WhisperLanguages, nil)
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Selected() != "" {
+		t.Errorf("Selected() = %q after esc, want none", m.Selected())
+	}
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// locale is the locale numbers and dates are formatted for
+var locale atomic.Pointer[language.Tag]
+
+// monthFirstRegions write dates month before day ("Jan 15"); everywhere
+// else puts the day first ("15 Jan")
+var monthFirstRegions = map[string]bool{
+	"US": true, "PH": true, "FM": true, "MH": true, "PW": true, "AS": true, "GU": true, "PR": true, "UM": true, "VI": true, "MP": true,
+}
+
+// Locale returns the locale numbers and dates are formatted for, US English
+// until SetLocale is called
+func Locale() language.Tag {
+	if tag := locale.Load(); tag != nil {
+		return *tag
+	}
+	return language.AmericanEnglish
+}
+
+// SetLocale sets the locale numbers and dates are formatted for
+func SetLocale(tag language.Tag) {
+	locale.Store(&tag)
+}
+
+// ParseLocale parses a POSIX locale name like "de_DE.UTF-8" or a BCP 47 tag
+// like "de-DE". "C" and "POSIX" are US English.
+func ParseLocale(name string) (language.Tag, error) {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	if name == "C" || name == "POSIX" {
+		return language.AmericanEnglish, nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+	if err != nil {
+		return language.Und, fmt.Errorf("invalid locale %q (use a name like en-GB or de_DE)", name)
+	}
+	return tag, nil
+}
+
+// ResolveLocale returns override when set, otherwise the locale of the
+// environment ($LC_ALL, then $LANG). Unset or unrecognized environment
+// locales fall back to US English; only a bad override is an error.
+func ResolveLocale(override string) (language.Tag, error) {
+	if override != "" {
+		return ParseLocale(override)
+	}
+	for _, env := range []string{"LC_ALL", "LANG"} {
+		if name := os.Getenv(env); name != "" {
+			if tag, err := ParseLocale(name); err == nil {
+				return tag, nil
+			}
+		}
+	}
+	return language.AmericanEnglish, nil
+}
+
+// FormatNumber formats an integer with the locale's digit grouping
+// Examples: 1234567 -> "1,234,567" (en-US), "1.234.567" (de-DE)
+func FormatNumber(n int64) string {
+	return message.NewPrinter(Locale()).Sprint(number.Decimal(n))
+}
+
+// formatDecimal formats v with exactly digits fraction digits, the locale's
+// decimal separator and no grouping, e.g. "1.5" or "1,5"
+func formatDecimal(v float64, digits int) string {
+	return message.NewPrinter(Locale()).Sprint(number.Decimal(v, number.Scale(digits), number.NoSeparator()))
+}
+
+// monthFirst reports whether the locale writes the month before the day
+func monthFirst() bool {
+	region, _ := Locale().Region()
+	return monthFirstRegions[region.String()]
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// withLocale switches the formatting locale for the rest of the test
+func withLocale(t *testing.T, name string) {
+	t.Helper()
+	previous := Locale()
+	tag, err := ParseLocale(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetLocale(tag)
+	t.Cleanup(func() { SetLocale(previous) })
+}
+
+func TestFormat_Locales(t *testing.T) {
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		locale string
+		count  string
+		number string
+		size   string
+		date   string
+	}{
+		{"en-US", "1.2K", "1,234,567", "3.0 GB", "Jan 15"},
+		{"en_GB.UTF-8", "1.2K", "1,234,567", "3.0 GB", "15 Jan"},
+		{"de_DE.UTF-8", "1,2K", "1.234.567", "3,0 GB", "15 Jan"},
+		{"pt-BR", "1,2K", "1.234.567", "3,0 GB", "15 Jan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			withLocale(t, tt.locale)
+			if got := FormatCount(1234); got != tt.count {
+				t.Errorf("FormatCount() = %q, want %q", got, tt.count)
+			}
+			if got := FormatNumber(1234567); got != tt.number {
+				t.Errorf("FormatNumber() = %q, want %q", got, tt.number)
+			}
+			if got := FormatSize(3 * GB); got != tt.size {
+				t.Errorf("FormatSize() = %q, want %q", got, tt.size)
+			}
+			if got := FormatDate(date); got != tt.date {
+				t.Errorf("FormatDate() = %q, want %q", got, tt.date)
+			}
+		})
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	if tag, err := ResolveLocale(""); err != nil || tag != language.MustParse("fr-FR") {
+		t.Errorf("ResolveLocale() = %v, %v, want fr-FR from $LANG", tag, err)
+	}
+	if tag, err := ResolveLocale("en-GB"); err != nil || tag != language.BritishEnglish {
+		t.Errorf("ResolveLocale(en-GB) = %v, %v, want the override", tag, err)
+	}
+	if _, err := ResolveLocale("not a locale!"); err == nil {
+		t.Error("ResolveLocale() should reject an invalid override")
+	}
+
+	t.Setenv("LANG", "C")
+	if tag, _ := ResolveLocale(""); tag != language.AmericanEnglish {
+		t.Errorf("ResolveLocale() with LANG=C = %v, want en-US", tag)
+	}
+}
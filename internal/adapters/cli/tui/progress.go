@@ -23,6 +23,7 @@ type ProgressStep struct {
 	Progress float64 // 0-100, only used for download steps
 	Total    int64   // Total bytes for download
 	Current  int64   // Current bytes for download
+	Detail   string  // shown beside the spinner, e.g. which part is running
 	Error    string
 }
 
@@ -104,6 +105,17 @@ func (p *ProgressDisplay) UpdateProgress(index int, current, total int64) {
 	}
 }
 
+// SetDetail sets the note shown beside a running step's spinner
+func (p *ProgressDisplay) SetDetail(index int, detail string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if index >= 0 && index < len(p.steps) {
+		p.steps[index].Detail = detail
+		p.render()
+	}
+}
+
 // Tick advances the spinner animation
 func (p *ProgressDisplay) Tick() {
 	p.mu.Lock()
@@ -145,6 +157,9 @@ func (p *ProgressDisplay) render() {
 			} else {
 				// Spinner
 				status = spinnerFrames[p.spinnerIdx]
+				if step.Detail != "" {
+					status += " " + step.Detail
+				}
 			}
 		case StepComplete:
 			status = "✓"
@@ -181,7 +196,7 @@ func formatBytes(b int64) string {
 		div *= unit
 		exp++
 	}
-	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+	return fmt.Sprintf("%s %cB", formatDecimal(float64(b)/float64(div), 1), "KMGTPE"[exp])
 }
 
 // StartSpinner starts a goroutine that ticks the spinner
@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/devbush/ig2insights/internal/config"
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// videoQuality returns the --video-quality to download video at, falling
+// back to the config default
+func videoQuality(cfg *config.Config) (string, error) {
+	input := videoQualityFlag
+	if input == "" {
+		input = cfg.Defaults.VideoQuality
+	}
+	return domain.ParseVideoQuality(input)
+}
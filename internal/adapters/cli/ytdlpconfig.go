@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+// yt-dlp config file choices for ytdlp_config
+const (
+	ytdlpConfigUser    = "user"
+	ytdlpConfigNone    = "none"
+	ytdlpConfigManaged = "managed"
+)
+
+// managedYtDlpConfig is written to the managed yt-dlp config file the first
+// time it's used
+const managedYtDlpConfig = `# yt-dlp options for ig2insights, one per line as on the command line.
+# Read instead of ~/.config/yt-dlp/config because ytdlp_config is "managed"
+# in ~/.ig2insights/config.yaml. See https://github.com/yt-dlp/yt-dlp#configuration
+`
+
+// ytdlpConfigFiles returns the yt-dlp config files the config asks for,
+// creating the managed file at managedPath on first use
+func ytdlpConfigFiles(cfg *config.Config, managedPath string) (ytdlp.ConfigFiles, error) {
+	switch cfg.YtDlpConfig {
+	case "", ytdlpConfigUser:
+		return ytdlp.ConfigFiles{}, nil
+	case ytdlpConfigNone:
+		return ytdlp.ConfigFiles{Ignore: true}, nil
+	case ytdlpConfigManaged:
+		if err := ensureFile(managedPath, managedYtDlpConfig); err != nil {
+			return ytdlp.ConfigFiles{}, fmt.Errorf("failed to create %s: %w", managedPath, err)
+		}
+		return ytdlp.ConfigFiles{Ignore: true, Path: managedPath}, nil
+	}
+	return ytdlp.ConfigFiles{}, fmt.Errorf("unknown ytdlp_config: %s (use %s, %s or %s)", cfg.YtDlpConfig, ytdlpConfigUser, ytdlpConfigNone, ytdlpConfigManaged)
+}
+
+// ensureFile writes content to path unless the file already exists
+func ensureFile(path, content string) error {
+	if _, err := os.Stat(path); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/adapters/ytdlp"
+	"github.com/devbush/ig2insights/internal/config"
+)
+
+func TestYtDlpConfigFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		setting string
+		want    ytdlp.ConfigFiles
+	}{
+		{"", ytdlp.ConfigFiles{}},
+		{"user", ytdlp.ConfigFiles{}},
+		{"none", ytdlp.ConfigFiles{Ignore: true}},
+		{"managed", ytdlp.ConfigFiles{Ignore: true, Path: config.YtDlpConfigPath()}},
+	}
+	for _, tt := range tests {
+		got, err := ytdlpConfigFiles(&config.Config{YtDlpConfig: tt.setting}, config.YtDlpConfigPath())
+		if err != nil {
+			t.Fatalf("ytdlpConfigFiles(%q) error: %v", tt.setting, err)
+		}
+		if got != tt.want {
+			t.Errorf("ytdlpConfigFiles(%q) = %+v, want %+v", tt.setting, got, tt.want)
+		}
+	}
+
+	if _, err := os.Stat(config.YtDlpConfigPath()); err != nil {
+		t.Errorf("managed config file not created: %v", err)
+	}
+
+	if _, err := ytdlpConfigFiles(&config.Config{YtDlpConfig: "global"}, config.YtDlpConfigPath()); err == nil {
+		t.Error("ytdlpConfigFiles(\"global\") should fail")
+	}
+}
+
+func TestYtDlpConfigFiles_KeepsManagedFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := config.YtDlpConfigPath()
+	if err := ensureFile(path, "--force-ipv4\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ytdlpConfigFiles(&config.Config{YtDlpConfig: "managed"}, config.YtDlpConfigPath()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "--force-ipv4\n" {
+		t.Errorf("managed config overwritten: %q", data)
+	}
+}
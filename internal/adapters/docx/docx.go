@@ -0,0 +1,110 @@
+// Package docx renders simple text documents as Word (.docx) files.
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+// Document is the content of a rendered Word document
+type Document struct {
+	Title      string
+	Metadata   []string // one line each, e.g. "Author: someone"
+	Paragraphs []Paragraph
+}
+
+// Paragraph is body text with an optional timestamp label shown in bold
+type Paragraph struct {
+	Timestamp string
+	Text      string
+}
+
+const contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+
+const rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+// Render produces a .docx file for the document
+func Render(doc Document) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"word/document.xml", documentXML(doc)},
+	}
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// documentXML builds the WordprocessingML body using direct formatting, so
+// no styles part is needed
+func documentXML(doc Document) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sb.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+
+	if doc.Title != "" {
+		sb.WriteString(`<w:p><w:pPr><w:spacing w:after="200"/></w:pPr>`)
+		sb.WriteString(run(doc.Title, `<w:b/><w:sz w:val="36"/>`))
+		sb.WriteString(`</w:p>`)
+	}
+	for _, line := range doc.Metadata {
+		sb.WriteString(`<w:p><w:pPr><w:spacing w:after="0"/></w:pPr>`)
+		sb.WriteString(run(line, `<w:color w:val="595959"/><w:sz w:val="20"/>`))
+		sb.WriteString(`</w:p>`)
+	}
+	if len(doc.Metadata) > 0 {
+		sb.WriteString(`<w:p/>`)
+	}
+
+	for _, p := range doc.Paragraphs {
+		sb.WriteString(`<w:p>`)
+		if p.Timestamp != "" {
+			sb.WriteString(run("["+p.Timestamp+"] ", `<w:b/><w:color w:val="808080"/>`))
+		}
+		sb.WriteString(run(p.Text, ""))
+		sb.WriteString(`</w:p>`)
+	}
+
+	sb.WriteString(`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="709" w:footer="709" w:gutter="0"/></w:sectPr>`)
+	sb.WriteString(`</w:body></w:document>`)
+	return sb.String()
+}
+
+// run returns a text run with optional run properties
+func run(text, props string) string {
+	var sb strings.Builder
+	sb.WriteString(`<w:r>`)
+	if props != "" {
+		sb.WriteString(`<w:rPr>` + props + `</w:rPr>`)
+	}
+	sb.WriteString(`<w:t xml:space="preserve">`)
+	_ = xml.EscapeText(&sb, []byte(text))
+	sb.WriteString(`</w:t></w:r>`)
+	return sb.String()
+}
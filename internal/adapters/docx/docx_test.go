@@ -0,0 +1,57 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	data, err := Render(Document{
+		Title:    "Pasta & sauce",
+		Metadata: []string{"Author: @chef"},
+		Paragraphs: []Paragraph{
+			{Timestamp: "00:00", Text: "Boil <salted> water."},
+			{Text: "Untimed note."},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip: %v", err)
+	}
+
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml"} {
+		content, ok := parts[name]
+		if !ok {
+			t.Fatalf("missing part %s", name)
+		}
+		if err := xml.Unmarshal([]byte(content), new(interface{})); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", name, err)
+		}
+	}
+
+	body := parts["word/document.xml"]
+	for _, want := range []string{"Pasta &amp; sauce", "Author: @chef", "[00:00] ", "Boil &lt;salted&gt; water."} {
+		if !strings.Contains(body, want) {
+			t.Errorf("document.xml missing %q", want)
+		}
+	}
+}
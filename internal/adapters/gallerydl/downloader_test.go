@@ -0,0 +1,152 @@
+package gallerydl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+const sampleDump = `[
+  [2, {"post_shortcode": "ABC123", "username": "chef"}],
+  [3, "https://cdn.example/v.mp4", {"post_shortcode": "ABC123", "shortcode": "ABC123", "username": "chef",
+    "description": "Quick pasta\nRecipe below", "date": "2025-03-04 05:06:07", "likes": 42,
+    "video_duration": 31.5, "display_url": "https://cdn.example/t.jpg", "extension": "mp4"}],
+  [3, "https://cdn.example/v2.jpg", {"post_shortcode": "ABC123", "shortcode": "XYZ", "username": "chef"}]
+]`
+
+// fakeGalleryDL installs a script standing in for gallery-dl that prints output
+func fakeGalleryDL(t *testing.T, output string) *Downloader {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gallery-dl is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "dump.json"), []byte(output), 0644); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "gallery-dl")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat "+filepath.Join(dir, "dump.json")+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return &Downloader{binPath: script}
+}
+
+func TestParseDump(t *testing.T) {
+	posts, err := parseDump([]byte(sampleDump))
+	if err != nil {
+		t.Fatalf("parseDump() error = %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("got %d posts, want carousel items merged into 1", len(posts))
+	}
+
+	reel := posts[0].reel()
+	if reel.ID != "ABC123" || reel.Author != "chef" || reel.Title != "Quick pasta" {
+		t.Errorf("reel = %+v", reel)
+	}
+	if reel.DurationSeconds != 31 || reel.LikeCount != 42 {
+		t.Errorf("duration/likes = %d/%d, want 31/42", reel.DurationSeconds, reel.LikeCount)
+	}
+	if want := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC); !reel.UploadedAt.Equal(want) {
+		t.Errorf("UploadedAt = %v, want %v", reel.UploadedAt, want)
+	}
+
+	if _, err := parseDump([]byte("not json")); err == nil {
+		t.Error("parseDump() expected error for invalid output")
+	}
+}
+
+func TestRequestArgs(t *testing.T) {
+	d := NewDownloader(nil)
+	if got := strings.Join(d.requestArgs([]string{"URL"}), " "); got != "URL" {
+		t.Errorf("requestArgs() = %q, want args unchanged", got)
+	}
+
+	d.SetProxy("socks5://127.0.0.1:1080", nil)
+	d.SetCookies(Cookies{File: "/home/me/cookies.txt"})
+	d.SetThrottle(Throttle{SleepRequests: 1500 * time.Millisecond, SleepInterval: 2 * time.Second, Retries: 3})
+	got := strings.Join(d.requestArgs([]string{"URL"}), " ")
+	want := "--proxy socks5://127.0.0.1:1080 --cookies /home/me/cookies.txt --sleep-request 1.5 --sleep 2 --retries 3 URL"
+	if got != want {
+		t.Errorf("requestArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestDetectStderr(t *testing.T) {
+	tests := []struct {
+		stderr string
+		want   error
+	}{
+		{"[instagram][error] HttpError: '429 Too Many Requests'", domain.ErrRateLimited},
+		{"[instagram][error] NotFoundError: Requested post could not be found", domain.ErrReelNotFound},
+		{"[instagram][error] AuthorizationError: login required", domain.ErrInstagramScrapingBlocked},
+		{"[downloader.http][warning] Read timed out", domain.ErrNetworkFailure},
+		{"something else", nil},
+	}
+	for _, tt := range tests {
+		if got := detectStderr(tt.stderr); !errors.Is(got, tt.want) {
+			t.Errorf("detectStderr(%q) = %v, want %v", tt.stderr, got, tt.want)
+		}
+	}
+}
+
+func TestGetReel(t *testing.T) {
+	d := fakeGalleryDL(t, sampleDump)
+
+	reel, err := d.GetReel(context.Background(), "ABC123")
+	if err != nil {
+		t.Fatalf("GetReel() error = %v", err)
+	}
+	if reel.ID != "ABC123" || reel.URL != "https://www.instagram.com/p/ABC123/" || reel.Caption != "Quick pasta\nRecipe below" {
+		t.Errorf("reel = %+v", reel)
+	}
+
+	empty := fakeGalleryDL(t, "[]")
+	if _, err := empty.GetReel(context.Background(), "GONE"); !errors.Is(err, domain.ErrReelNotFound) {
+		t.Errorf("GetReel() error = %v, want ErrReelNotFound", err)
+	}
+}
+
+// countingLimiter counts the requests that waited on it
+type countingLimiter struct{ waits int }
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	return ctx.Err()
+}
+
+func TestLimiter(t *testing.T) {
+	d := fakeGalleryDL(t, sampleDump)
+	limiter := &countingLimiter{}
+	d.SetLimiter(limiter)
+
+	if _, err := d.GetReel(context.Background(), "ABC123"); err != nil {
+		t.Fatalf("GetReel() error = %v", err)
+	}
+	if limiter.waits != 1 {
+		t.Errorf("limiter waits = %d, want 1", limiter.waits)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.GetReel(ctx, "ABC123"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetReel() error = %v, want the limiter's context.Canceled", err)
+	}
+}
+
+func TestGetReel_NoBinary(t *testing.T) {
+	d := &Downloader{}
+	if d.GetBinaryPath() != "" {
+		t.Skip("gallery-dl is installed")
+	}
+	if _, err := d.GetReel(context.Background(), "ABC123"); !errors.Is(err, errNotInstalled) {
+		t.Errorf("GetReel() error = %v, want %v", err, errNotInstalled)
+	}
+}
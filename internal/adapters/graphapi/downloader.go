@@ -0,0 +1,474 @@
+// Package graphapi downloads a creator's own reels through the official
+// Instagram Graph API, so no scraping is involved.
+package graphapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+const (
+	// APIVersion is the Graph API version requests are made against
+	APIVersion = "v21.0"
+
+	defaultBaseURL = "https://graph.facebook.com/" + APIVersion
+
+	mediaFields = "id,shortcode,caption,media_type,media_product_type,media_url,thumbnail_url,permalink,timestamp,username,like_count,comments_count"
+
+	// pageSize and maxPages bound the media scanned when looking up a reel
+	pageSize = 50
+	maxPages = 40
+
+	// timestampLayout is how the Graph API formats media timestamps
+	timestampLayout = "2006-01-02T15:04:05-0700"
+)
+
+// FFmpeg locates and installs the ffmpeg used to extract audio. The yt-dlp
+// downloader implements it.
+type FFmpeg interface {
+	IsFFmpegAvailable() bool
+	GetFFmpegPath() string
+	InstallFFmpeg(ctx context.Context, progress func(downloaded, total int64)) error
+	FFmpegInstructions() string
+}
+
+// Limiter paces requests shared with other downloaders. The yt-dlp
+// downloader implements it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Downloader implements VideoDownloader and AccountFetcher with the
+// Instagram Graph API. It can only see the media of the business or creator
+// account the access token belongs to.
+type Downloader struct {
+	token   string
+	userID  string
+	baseURL string
+	client  *http.Client
+	ffmpeg  FFmpeg
+	limiter Limiter
+
+	mu    sync.Mutex
+	media map[string]media // by shortcode, filled as pages are read
+}
+
+// NewDownloader creates a Graph API downloader for the Instagram account
+// userID, authenticating with a long-lived access token
+func NewDownloader(token, userID string, ffmpeg FFmpeg) *Downloader {
+	return &Downloader{
+		token:   token,
+		userID:  userID,
+		baseURL: defaultBaseURL,
+		client:  http.DefaultClient,
+		ffmpeg:  ffmpeg,
+		media:   make(map[string]media),
+	}
+}
+
+// SetHTTPClient sets the client used for API requests and media downloads,
+// e.g. one that goes through a proxy
+func (d *Downloader) SetHTTPClient(client *http.Client) {
+	d.client = client
+}
+
+// SetLimiter makes every request wait for l first
+func (d *Downloader) SetLimiter(l Limiter) {
+	d.limiter = l
+}
+
+// wait blocks until the limiter, if any, allows another request
+func (d *Downloader) wait(ctx context.Context) error {
+	if d.limiter == nil {
+		return nil
+	}
+	return d.limiter.Wait(ctx)
+}
+
+// media is an IG Media object
+type media struct {
+	ID               string `json:"id"`
+	Shortcode        string `json:"shortcode"`
+	Caption          string `json:"caption"`
+	MediaType        string `json:"media_type"`
+	MediaProductType string `json:"media_product_type"`
+	MediaURL         string `json:"media_url"`
+	ThumbnailURL     string `json:"thumbnail_url"`
+	Permalink        string `json:"permalink"`
+	Timestamp        string `json:"timestamp"`
+	Username         string `json:"username"`
+	LikeCount        int64  `json:"like_count"`
+	CommentsCount    int64  `json:"comments_count"`
+}
+
+func (m media) isVideo() bool {
+	return m.MediaType == "VIDEO"
+}
+
+// reel converts the media to a domain reel. The Graph API reports neither
+// views nor duration.
+func (m media) reel() *domain.Reel {
+	reel := &domain.Reel{
+		ID:           m.Shortcode,
+		URL:          m.Permalink,
+		Author:       m.Username,
+		Title:        firstLine(m.Caption),
+		Caption:      strings.TrimSpace(m.Caption),
+		LikeCount:    m.LikeCount,
+		CommentCount: m.CommentsCount,
+		FetchedAt:    time.Now(),
+	}
+	if t, err := time.Parse(timestampLayout, m.Timestamp); err == nil {
+		reel.UploadedAt = t
+	}
+	return reel
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+// apiError is the error object of a failed Graph API request
+type apiError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// rateLimitCodes are the Graph API error codes for exhausted request quotas
+var rateLimitCodes = map[int]bool{4: true, 17: true, 32: true, 613: true}
+
+// toDomain converts a Graph API error to a domain error where one fits
+func (e apiError) toDomain() error {
+	switch {
+	case rateLimitCodes[e.Code]:
+		return fmt.Errorf("%w: %s", domain.ErrRateLimited, e.Message)
+	case e.Code == 190:
+		return fmt.Errorf("Instagram access token is invalid or expired: %s", e.Message)
+	}
+	return fmt.Errorf("Instagram Graph API: %s", e.Message)
+}
+
+// get requests path with query and decodes the JSON response into out. The
+// token goes in a header rather than the URL, which errors quote.
+func (d *Downloader) get(ctx context.Context, path string, query url.Values, out any) error {
+	if !d.IsAvailable() {
+		return errors.New("Instagram Graph API needs graph_api.access_token and graph_api.user_id in config.yaml")
+	}
+	if err := d.wait(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+d.token)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrNetworkFailure, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrNetworkFailure, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error apiError `json:"error"`
+		}
+		if json.Unmarshal(data, &body) == nil && body.Error.Message != "" {
+			return body.Error.toDomain()
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return domain.ErrRateLimited
+		}
+		return fmt.Errorf("Instagram Graph API returned HTTP %d", resp.StatusCode)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// mediaPage is one page of the account's media
+type mediaPage struct {
+	Data   []media `json:"data"`
+	Paging struct {
+		Cursors struct {
+			After string `json:"after"`
+		} `json:"cursors"`
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+// scanMedia reads the account's media newest first, page by page, until
+// visit returns false or the media runs out
+func (d *Downloader) scanMedia(ctx context.Context, visit func(media) bool) error {
+	after := ""
+	for page := 0; page < maxPages; page++ {
+		query := url.Values{"fields": {mediaFields}, "limit": {fmt.Sprint(pageSize)}}
+		if after != "" {
+			query.Set("after", after)
+		}
+		var p mediaPage
+		if err := d.get(ctx, "/"+d.userID+"/media", query, &p); err != nil {
+			return err
+		}
+
+		d.mu.Lock()
+		for _, m := range p.Data {
+			d.media[m.Shortcode] = m
+		}
+		d.mu.Unlock()
+
+		for _, m := range p.Data {
+			if !visit(m) {
+				return nil
+			}
+		}
+		if p.Paging.Next == "" || p.Paging.Cursors.After == "" {
+			return nil
+		}
+		after = p.Paging.Cursors.After
+	}
+	return nil
+}
+
+// lookup returns the account's media with the given shortcode
+func (d *Downloader) lookup(ctx context.Context, shortcode string) (media, error) {
+	d.mu.Lock()
+	m, ok := d.media[shortcode]
+	d.mu.Unlock()
+	if !ok {
+		err := d.scanMedia(ctx, func(m media) bool {
+			return m.Shortcode != shortcode
+		})
+		if err != nil {
+			return media{}, err
+		}
+		d.mu.Lock()
+		m, ok = d.media[shortcode]
+		d.mu.Unlock()
+	}
+	if !ok || !m.isVideo() {
+		return media{}, domain.ErrReelNotFound
+	}
+	return m, nil
+}
+
+// username returns the username of the account the token belongs to
+func (d *Downloader) username(ctx context.Context) (string, int, error) {
+	var account struct {
+		Username   string `json:"username"`
+		MediaCount int    `json:"media_count"`
+	}
+	if err := d.get(ctx, "/"+d.userID, url.Values{"fields": {"username,media_count"}}, &account); err != nil {
+		return "", 0, err
+	}
+	return account.Username, account.MediaCount, nil
+}
+
+// checkAccount fails for any account but the token's own
+func (d *Downloader) checkAccount(ctx context.Context, username string) (int, error) {
+	own, count, err := d.username(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if !strings.EqualFold(strings.TrimPrefix(username, "@"), own) {
+		return 0, fmt.Errorf("%w: the Graph API only lists reels of @%s", domain.ErrAccountNotFound, own)
+	}
+	return count, nil
+}
+
+func (d *Downloader) GetAccount(ctx context.Context, username string) (*domain.Account, error) {
+	count, err := d.checkAccount(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Account{Username: strings.TrimPrefix(username, "@"), ReelCount: count}, nil
+}
+
+func (d *Downloader) ListReels(ctx context.Context, username string, sortOrder domain.SortOrder, limit int) ([]*domain.Reel, error) {
+	if _, err := d.checkAccount(ctx, username); err != nil {
+		return nil, err
+	}
+
+	var reels []*domain.Reel
+	err := d.scanMedia(ctx, func(m media) bool {
+		if m.isVideo() {
+			reels = append(reels, m.reel())
+		}
+		return len(reels) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sortOrder == domain.SortMostViewed {
+		// The media endpoint has no view counts; likes are the closest measure
+		sort.SliceStable(reels, func(i, j int) bool {
+			return reels[i].LikeCount > reels[j].LikeCount
+		})
+	}
+	return reels, nil
+}
+
+func (d *Downloader) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	m, err := d.lookup(ctx, reelID)
+	if err != nil {
+		return nil, err
+	}
+	return m.reel(), nil
+}
+
+// fetch downloads rawURL to destPath
+func (d *Downloader) fetch(ctx context.Context, rawURL, destPath string) error {
+	if err := d.wait(ctx); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrNetworkFailure, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return domain.ErrRateLimited
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		os.Remove(destPath)
+		return fmt.Errorf("%w: %v", domain.ErrNetworkFailure, err)
+	}
+	return out.Close()
+}
+
+func (d *Downloader) DownloadVideo(ctx context.Context, reelID string, destPath string) error {
+	m, err := d.lookup(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	if m.MediaURL == "" {
+		return fmt.Errorf("failed to download video: Instagram returned no media URL for %s", reelID)
+	}
+	if err := d.fetch(ctx, m.MediaURL, destPath); err != nil {
+		return fmt.Errorf("failed to download video: %w", err)
+	}
+	return nil
+}
+
+func (d *Downloader) DownloadThumbnail(ctx context.Context, reelID string, destPath string) error {
+	m, err := d.lookup(ctx, reelID)
+	if err != nil {
+		return err
+	}
+	if m.ThumbnailURL == "" {
+		return fmt.Errorf("no thumbnail available for %s", reelID)
+	}
+	if err := d.fetch(ctx, m.ThumbnailURL, destPath); err != nil {
+		return fmt.Errorf("failed to download thumbnail: %w", err)
+	}
+	return nil
+}
+
+func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	if !d.IsFFmpegAvailable() {
+		return nil, domain.ErrFFmpegNotFound
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	m, err := d.lookup(ctx, reelID)
+	if err != nil {
+		return nil, err
+	}
+
+	videoPath := filepath.Join(destDir, "source.mp4")
+	if err := d.DownloadVideo(ctx, reelID, videoPath); err != nil {
+		return nil, err
+	}
+	defer os.Remove(videoPath)
+
+	audioPath := filepath.Join(destDir, "audio.wav")
+	args := []string{"-y", "-loglevel", "error", "-i", videoPath, "-vn", "-ac", "1", "-ar", "16000", "-c:a", "pcm_s16le", audioPath}
+	if out, err := exec.CommandContext(ctx, d.GetFFmpegPath(), args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return nil, fmt.Errorf("failed to extract audio: %s", msg)
+		}
+		return nil, fmt.Errorf("failed to extract audio: %w", err)
+	}
+
+	return &ports.DownloadResult{AudioPath: audioPath, Reel: m.reel()}, nil
+}
+
+// IsAvailable reports whether an access token and account are configured
+func (d *Downloader) IsAvailable() bool {
+	return d.token != "" && d.userID != ""
+}
+
+// GetBinaryPath returns the API endpoint; there is no binary to run
+func (d *Downloader) GetBinaryPath() string {
+	return d.baseURL
+}
+
+// Install fails: the API needs credentials rather than an installation
+func (d *Downloader) Install(ctx context.Context, progress func(downloaded, total int64)) error {
+	return errors.New("set graph_api.access_token and graph_api.user_id in config.yaml to use the Instagram Graph API")
+}
+
+// Update does nothing; the API version is fixed by this build
+func (d *Downloader) Update(ctx context.Context) error {
+	return nil
+}
+
+// Version returns the Graph API version in use
+func (d *Downloader) Version(ctx context.Context) string {
+	return "graph-api " + APIVersion
+}
+
+func (d *Downloader) IsFFmpegAvailable() bool {
+	return d.ffmpeg.IsFFmpegAvailable()
+}
+
+func (d *Downloader) GetFFmpegPath() string {
+	return d.ffmpeg.GetFFmpegPath()
+}
+
+func (d *Downloader) InstallFFmpeg(ctx context.Context, progress func(downloaded, total int64)) error {
+	return d.ffmpeg.InstallFFmpeg(ctx, progress)
+}
+
+func (d *Downloader) FFmpegInstructions() string {
+	return d.ffmpeg.FFmpegInstructions()
+}
+
+// Ensure Downloader implements interfaces
+var _ ports.VideoDownloader = (*Downloader)(nil)
+var _ ports.AccountFetcher = (*Downloader)(nil)
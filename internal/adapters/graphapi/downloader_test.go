@@ -0,0 +1,169 @@
+package graphapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// fakeGraphAPI serves two pages of media for account 17841 owned by @chef
+func fakeGraphAPI(t *testing.T) (*Downloader, *int) {
+	t.Helper()
+	mediaRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/cdn/") {
+			w.Write([]byte("media bytes"))
+			return
+		}
+		if r.URL.Query().Has("access_token") || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"Invalid OAuth access token","code":190}}`))
+			return
+		}
+		switch {
+		case r.URL.Path == "/17841":
+			w.Write([]byte(`{"username":"chef","media_count":3}`))
+		case r.URL.Path == "/17841/media" && r.URL.Query().Get("after") == "":
+			mediaRequests++
+			w.Write([]byte(`{"data":[
+				{"shortcode":"PHOTO1","media_type":"IMAGE","username":"chef"},
+				{"shortcode":"REEL1","media_type":"VIDEO","caption":"Pasta night\nrecipe","username":"chef","like_count":5,
+				 "media_url":"` + server.URL + `/cdn/reel1.mp4","thumbnail_url":"` + server.URL + `/cdn/reel1.jpg",
+				 "permalink":"https://www.instagram.com/reel/REEL1/","timestamp":"2025-02-03T04:05:06+0000"}
+			],"paging":{"cursors":{"after":"P2"},"next":"more"}}`))
+		case r.URL.Path == "/17841/media":
+			mediaRequests++
+			w.Write([]byte(`{"data":[{"shortcode":"REEL2","media_type":"VIDEO","username":"chef","like_count":50}],"paging":{}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	d := NewDownloader("token", "17841", nil)
+	d.baseURL = server.URL
+	return d, &mediaRequests
+}
+
+func TestGetReel(t *testing.T) {
+	d, requests := fakeGraphAPI(t)
+	ctx := context.Background()
+
+	reel, err := d.GetReel(ctx, "REEL1")
+	if err != nil {
+		t.Fatalf("GetReel() error = %v", err)
+	}
+	if reel.ID != "REEL1" || reel.Title != "Pasta night" || reel.Caption != "Pasta night\nrecipe" || reel.Author != "chef" || reel.LikeCount != 5 {
+		t.Errorf("reel = %+v", reel)
+	}
+	if reel.UploadedAt.IsZero() {
+		t.Error("UploadedAt not parsed")
+	}
+
+	// A second lookup is served from the media already read
+	if _, err := d.GetReel(ctx, "REEL1"); err != nil || *requests != 1 {
+		t.Errorf("second GetReel() = %v after %d media requests, want 1", err, *requests)
+	}
+
+	if _, err := d.GetReel(ctx, "REEL2"); err != nil {
+		t.Errorf("GetReel() on the second page error = %v", err)
+	}
+	for _, id := range []string{"PHOTO1", "MISSING"} {
+		if _, err := d.GetReel(ctx, id); !errors.Is(err, domain.ErrReelNotFound) {
+			t.Errorf("GetReel(%s) error = %v, want ErrReelNotFound", id, err)
+		}
+	}
+}
+
+func TestListReels(t *testing.T) {
+	d, _ := fakeGraphAPI(t)
+	ctx := context.Background()
+
+	reels, err := d.ListReels(ctx, "@chef", domain.SortMostViewed, 10)
+	if err != nil {
+		t.Fatalf("ListReels() error = %v", err)
+	}
+	if len(reels) != 2 || reels[0].ID != "REEL2" {
+		t.Errorf("ListReels() = %d reels, first %v; want videos only, most liked first", len(reels), reels[0])
+	}
+
+	if _, err := d.ListReels(ctx, "someoneelse", domain.SortLatest, 10); !errors.Is(err, domain.ErrAccountNotFound) {
+		t.Errorf("ListReels() for another account error = %v, want ErrAccountNotFound", err)
+	}
+}
+
+func TestDownloadThumbnail(t *testing.T) {
+	d, _ := fakeGraphAPI(t)
+	dest := filepath.Join(t.TempDir(), "thumb.jpg")
+
+	if err := d.DownloadThumbnail(context.Background(), "REEL1", dest); err != nil {
+		t.Fatalf("DownloadThumbnail() error = %v", err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "media bytes" {
+		t.Errorf("thumbnail = %q", data)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	d, _ := fakeGraphAPI(t)
+	d.token = "expired"
+	if _, err := d.GetReel(context.Background(), "REEL1"); err == nil || !strings.Contains(err.Error(), "access token") {
+		t.Errorf("GetReel() error = %v, want an access token error", err)
+	}
+
+	if err := (apiError{Message: "limit", Code: 4}).toDomain(); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("code 4 = %v, want ErrRateLimited", err)
+	}
+
+	unconfigured := NewDownloader("", "", nil)
+	if unconfigured.IsAvailable() {
+		t.Error("IsAvailable() = true without credentials")
+	}
+	if _, err := unconfigured.GetReel(context.Background(), "REEL1"); err == nil {
+		t.Error("GetReel() expected error without credentials")
+	}
+}
+
+func TestAPIErrors_HideToken(t *testing.T) {
+	d := NewDownloader("secret-token", "17841", nil)
+	d.baseURL = "http://127.0.0.1:0"
+	_, err := d.GetReel(context.Background(), "REEL1")
+	if err == nil || strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("GetReel() error = %v, want a network error without the token", err)
+	}
+}
+
+// countingLimiter counts the requests that waited on it
+type countingLimiter struct{ waits int }
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits++
+	return ctx.Err()
+}
+
+func TestLimiter(t *testing.T) {
+	d, _ := fakeGraphAPI(t)
+	limiter := &countingLimiter{}
+	d.SetLimiter(limiter)
+
+	if err := d.DownloadThumbnail(context.Background(), "REEL1", filepath.Join(t.TempDir(), "thumb.jpg")); err != nil {
+		t.Fatalf("DownloadThumbnail() error = %v", err)
+	}
+	if limiter.waits != 2 {
+		t.Errorf("limiter waits = %d, want 2 (media page and thumbnail)", limiter.waits)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.GetAccount(ctx, "chef"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetAccount() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
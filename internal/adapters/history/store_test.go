@@ -0,0 +1,67 @@
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestFileStore_AppendList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store := NewFileStore(path)
+	ctx := context.Background()
+
+	// Listing before anything is recorded is not an error
+	records, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() on empty store error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("List() on empty store = %v, want empty", records)
+	}
+
+	first := domain.RunRecord{ReelID: "abc123", Model: "small", Duration: 3 * time.Second, Success: true}
+	second := domain.RunRecord{ReelID: "def456", Model: "small", Error: "boom"}
+	for _, r := range []domain.RunRecord{first, second} {
+		if err := store.Append(ctx, r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	records, err = store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("List() returned %d records, want 2", len(records))
+	}
+	if records[0].ReelID != "abc123" || records[0].Duration != 3*time.Second {
+		t.Errorf("first record = %+v", records[0])
+	}
+	if records[1].Error != "boom" || records[1].Success {
+		t.Errorf("second record = %+v", records[1])
+	}
+}
+
+func TestFileStore_SkipsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	content := `{"reel_id":"abc123","model":"small","success":true}
+{"reel_id":"trunc
+{"reel_id":"def456","model":"small","success":true}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := NewFileStore(path).List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("List() returned %d records, want 2", len(records))
+	}
+}
@@ -0,0 +1,122 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+const (
+	dirPerm  = 0755
+	filePerm = 0644
+)
+
+// FileJournal implements ports.BatchJournal as a JSON Lines file. Each
+// append is synced to disk so a crash loses at most the entry being written.
+type FileJournal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileJournal creates a journal backed by the file at path.
+func NewFileJournal(path string) *FileJournal {
+	return &FileJournal{path: path}
+}
+
+func (j *FileJournal) Append(ctx context.Context, entry domain.JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.path), dirPerm); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, filePerm)
+	if err != nil {
+		return err
+	}
+	line := append(data, '\n')
+	// End a line cut short by a crash first, so this entry isn't glued to it
+	cut, err := endsMidLine(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if cut {
+		line = append([]byte{'\n'}, line...)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// endsMidLine reports whether f is non-empty and doesn't end in a newline
+func endsMidLine(f *os.File) (bool, error) {
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return false, err
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return false, err
+	}
+	return last[0] != '\n', nil
+}
+
+// Entries reads the journal. A line cut short by a crash is skipped.
+func (j *FileJournal) Entries(ctx context.Context) ([]domain.JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []domain.JournalEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e domain.JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (j *FileJournal) Reset(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+var _ ports.BatchJournal = (*FileJournal)(nil)
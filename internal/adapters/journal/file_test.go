@@ -0,0 +1,99 @@
+package journal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestFileJournal_AppendEntries(t *testing.T) {
+	j := NewFileJournal(filepath.Join(t.TempDir(), "out", "batch.journal"))
+	ctx := context.Background()
+
+	transitions := []domain.JournalEntry{
+		{ReelID: "ABC123", State: domain.JobQueued, At: time.Now()},
+		{ReelID: "ABC123", State: domain.JobDownloading, At: time.Now()},
+		{ReelID: "ABC123", State: domain.JobDone, At: time.Now(), Output: "ABC123.txt"},
+	}
+	for _, e := range transitions {
+		if err := j.Append(ctx, e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	entries, err := j.Entries(ctx)
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Entries() returned %d entries, want 3", len(entries))
+	}
+	if entries[2].State != domain.JobDone || entries[2].Output != "ABC123.txt" {
+		t.Errorf("last entry = %+v", entries[2])
+	}
+}
+
+func TestFileJournal_TruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.journal")
+	content := `{"reel_id":"A","state":"queued"}
+{"reel_id":"A","state":"downloading"}
+{"reel_id":"A","sta`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := NewFileJournal(path).Entries(context.Background())
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 || entries[1].State != domain.JobDownloading {
+		t.Errorf("Entries() = %+v, want the two complete lines", entries)
+	}
+}
+
+func TestFileJournal_AppendAfterTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.journal")
+	content := `{"reel_id":"A","state":"queued"}
+{"reel_id":"A","sta`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	j := NewFileJournal(path)
+	ctx := context.Background()
+	if err := j.Append(ctx, domain.JournalEntry{ReelID: "A", State: domain.JobDone}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	entries, err := j.Entries(ctx)
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 2 || entries[1].State != domain.JobDone {
+		t.Errorf("Entries() = %+v, want the queued and done entries", entries)
+	}
+}
+
+func TestFileJournal_Reset(t *testing.T) {
+	j := NewFileJournal(filepath.Join(t.TempDir(), "batch.journal"))
+	ctx := context.Background()
+
+	if err := j.Append(ctx, domain.JournalEntry{ReelID: "A", State: domain.JobQueued}); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Reset(ctx); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if entries, _ := j.Entries(ctx); len(entries) != 0 {
+		t.Errorf("Entries() after Reset() = %v, want empty", entries)
+	}
+
+	// Resetting a missing journal is not an error
+	if err := j.Reset(ctx); err != nil {
+		t.Errorf("Reset() on missing journal error = %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+// Package mock provides deterministic fake adapters for exercising the CLI,
+// TUI, and batch logic end-to-end without touching Instagram or whisper.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// Options control simulated behavior of the fake adapters
+type Options struct {
+	Delay       time.Duration // simulated latency per network or transcription call
+	FailureRate float64       // fraction of reels (0-1) that deterministically fail
+}
+
+// Downloader implements VideoDownloader and AccountFetcher with canned data.
+//
+// Reel IDs containing "private" fail with ErrReelNotFound and IDs containing
+// "ratelimit" fail with ErrRateLimited, independent of FailureRate. Only IDs
+// containing "captions" have platform subtitles.
+type Downloader struct {
+	opts Options
+}
+
+// NewDownloader creates a fake downloader
+func NewDownloader(opts Options) *Downloader {
+	return &Downloader{opts: opts}
+}
+
+// wavHeader is a minimal empty 16kHz mono PCM WAV file
+var wavHeader = []byte{
+	'R', 'I', 'F', 'F', 36, 0, 0, 0, 'W', 'A', 'V', 'E',
+	'f', 'm', 't', ' ', 16, 0, 0, 0, 1, 0, 1, 0,
+	0x80, 0x3e, 0, 0, 0, 0x7d, 0, 0, 2, 0, 16, 0,
+	'd', 'a', 't', 'a', 0, 0, 0, 0,
+}
+
+// wait sleeps for the configured delay, returning early if ctx is done
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// fails reports whether the reel is one of the deterministic failures
+func fails(reelID string, rate float64) error {
+	switch {
+	case strings.Contains(reelID, "private"):
+		return domain.ErrReelNotFound
+	case strings.Contains(reelID, "ratelimit"):
+		return domain.ErrRateLimited
+	}
+	if rate <= 0 {
+		return nil
+	}
+	h := fnv.New32a()
+	h.Write([]byte(reelID))
+	if float64(h.Sum32()%1000) < rate*1000 {
+		return fmt.Errorf("%w: simulated failure for %s", domain.ErrNetworkFailure, reelID)
+	}
+	return nil
+}
+
+// fakeReel builds deterministic reel metadata from its ID
+func fakeReel(reelID, author string, index int) *domain.Reel {
+	h := fnv.New32a()
+	h.Write([]byte(reelID))
+	seed := int64(h.Sum32())
+
+	return &domain.Reel{
+		ID:              reelID,
+		URL:             domain.CanonicalReelURL(reelID),
+		Author:          author,
+		Title:           fmt.Sprintf("Mock reel %s", reelID),
+		Caption:         fmt.Sprintf("Mock reel %s #mock", reelID),
+		DurationSeconds: 15 + int(seed%60),
+		ViewCount:       seed % 100000,
+		LikeCount:       seed % 5000,
+		CommentCount:    seed % 300,
+		UploadedAt:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -index),
+		FetchedAt:       time.Now(),
+	}
+}
+
+func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+	if err := fails(reelID, d.opts.FailureRate); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	audioPath := filepath.Join(destDir, "audio.wav")
+	if err := os.WriteFile(audioPath, wavHeader, 0644); err != nil {
+		return nil, err
+	}
+
+	return &ports.DownloadResult{
+		AudioPath: audioPath,
+		Reel:      fakeReel(reelID, "mockuser", 0),
+	}, nil
+}
+
+func (d *Downloader) DownloadVideo(ctx context.Context, reelID string, destPath string) error {
+	return d.writePlaceholder(ctx, reelID, destPath, "mock video")
+}
+
+func (d *Downloader) DownloadThumbnail(ctx context.Context, reelID string, destPath string) error {
+	return d.writePlaceholder(ctx, reelID, destPath, "mock thumbnail")
+}
+
+func (d *Downloader) writePlaceholder(ctx context.Context, reelID, destPath, content string) error {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return err
+	}
+	if err := fails(reelID, d.opts.FailureRate); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(destPath, []byte(content), 0644)
+}
+
+func (d *Downloader) IsAvailable() bool     { return true }
+func (d *Downloader) GetBinaryPath() string { return "mock://yt-dlp" }
+
+func (d *Downloader) Install(ctx context.Context, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (d *Downloader) Update(ctx context.Context) error { return nil }
+
+func (d *Downloader) Version(ctx context.Context) string { return "mock" }
+
+func (d *Downloader) IsFFmpegAvailable() bool { return true }
+func (d *Downloader) GetFFmpegPath() string   { return "mock://ffmpeg" }
+
+func (d *Downloader) InstallFFmpeg(ctx context.Context, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (d *Downloader) FFmpegInstructions() string { return "" }
+
+func (d *Downloader) GetAccount(ctx context.Context, username string) (*domain.Account, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+	return &domain.Account{Username: username, ReelCount: 1}, nil
+}
+
+func (d *Downloader) ListReels(ctx context.Context, username string, sort domain.SortOrder, limit int) ([]*domain.Reel, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+
+	const accountSize = 25
+	if limit <= 0 || limit > accountSize {
+		limit = accountSize
+	}
+
+	reels := make([]*domain.Reel, 0, accountSize)
+	for i := 0; i < accountSize; i++ {
+		reels = append(reels, fakeReel(fmt.Sprintf("%sREEL%02d", username, i), username, i))
+	}
+	domain.SortReels(reels, sort)
+	return reels[:limit], nil
+}
+
+// ListPlaylist returns a fixed set of YouTube videos whose IDs derive from
+// the playlist URL
+func (d *Downloader) ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(playlistURL))
+	const playlistSize = 5
+	reels := make([]*domain.Reel, 0, playlistSize)
+	for i := 0; i < playlistSize; i++ {
+		videoID := fmt.Sprintf("mock%06x%d", h.Sum32()&0xffffff, i)
+		reel := fakeReel(domain.YouTubeID(videoID), "mockchannel", i)
+		reel.URL = domain.YouTubeShortURL(videoID)
+		reels = append(reels, reel)
+	}
+	return reels, nil
+}
+
+// RegisterURL accepts any URL; mock reels are made up from their ID alone
+func (d *Downloader) RegisterURL(reelID, rawURL string) {}
+
+// RegisterFile accepts any file; mock reels are made up from their ID alone
+func (d *Downloader) RegisterFile(reelID, path string) {}
+
+func (d *Downloader) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+	if err := fails(reelID, d.opts.FailureRate); err != nil {
+		return nil, err
+	}
+	return fakeReel(reelID, "mockuser", 0), nil
+}
+
+// FetchComments returns deterministic comments, most liked first
+func (d *Downloader) FetchComments(ctx context.Context, reelID string, limit int) ([]domain.Comment, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+	if err := fails(reelID, d.opts.FailureRate); err != nil {
+		return nil, err
+	}
+
+	reel := fakeReel(reelID, "mockuser", 0)
+	comments := make([]domain.Comment, reel.CommentCount%10)
+	for i := range comments {
+		comments[i] = domain.Comment{
+			ID:        fmt.Sprintf("%s-c%d", reelID, i+1),
+			Author:    fmt.Sprintf("viewer%d", i+1),
+			Text:      fmt.Sprintf("Mock comment %d on %s", i+1, reelID),
+			LikeCount: int64(i * 3),
+			PostedAt:  reel.UploadedAt.Add(time.Duration(i+1) * time.Hour),
+		}
+	}
+	return domain.TopComments(comments, limit), nil
+}
+
+// DownloadSubtitles returns automatic captions resembling the fixture
+// transcript, lowercased and without punctuation as platforms produce them
+func (d *Downloader) DownloadSubtitles(ctx context.Context, reelID string, destDir string, language string) (*ports.SubtitleResult, error) {
+	if err := wait(ctx, d.opts.Delay); err != nil {
+		return nil, err
+	}
+	if err := fails(reelID, d.opts.FailureRate); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(reelID, "captions") {
+		return nil, nil
+	}
+
+	var segments []domain.Segment
+	var texts []string
+	start := 0.0
+	for _, line := range fixtureLines {
+		if strings.HasPrefix(line, "[") {
+			continue
+		}
+		text := strings.ToLower(strings.Map(func(r rune) rune {
+			if strings.ContainsRune(",.'", r) {
+				return -1
+			}
+			return r
+		}, line))
+		segments = append(segments, domain.Segment{Start: start, End: start + 2.5, Text: text})
+		texts = append(texts, text)
+		start += 2.5
+	}
+
+	return &ports.SubtitleResult{
+		Transcript: &domain.Transcript{
+			Text:          strings.Join(texts, " "),
+			Segments:      segments,
+			Language:      "en",
+			Source:        domain.SourceAutoCaptions,
+			TranscribedAt: time.Now(),
+		},
+		Reel: fakeReel(reelID, "mockuser", 0),
+	}, nil
+}
+
+var _ ports.VideoDownloader = (*Downloader)(nil)
+var _ ports.AccountFetcher = (*Downloader)(nil)
+var _ ports.SubtitleFetcher = (*Downloader)(nil)
+var _ ports.CommentFetcher = (*Downloader)(nil)
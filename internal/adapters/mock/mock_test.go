@@ -0,0 +1,89 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func TestDownloader_DownloadAudio(t *testing.T) {
+	d := NewDownloader(Options{})
+	destDir := filepath.Join(t.TempDir(), "ABC123")
+
+	result, err := d.DownloadAudio(context.Background(), "ABC123", destDir)
+	if err != nil {
+		t.Fatalf("DownloadAudio() error = %v", err)
+	}
+
+	if result.AudioPath != filepath.Join(destDir, "audio.wav") {
+		t.Errorf("AudioPath = %s, want audio.wav in destDir", result.AudioPath)
+	}
+	if result.Reel.ID != "ABC123" {
+		t.Errorf("Reel.ID = %s, want ABC123", result.Reel.ID)
+	}
+}
+
+func TestDownloader_DeterministicFailures(t *testing.T) {
+	d := NewDownloader(Options{})
+	ctx := context.Background()
+
+	if _, err := d.DownloadAudio(ctx, "privateReel", t.TempDir()); !errors.Is(err, domain.ErrReelNotFound) {
+		t.Errorf("DownloadAudio(private) error = %v, want ErrReelNotFound", err)
+	}
+	if _, err := d.DownloadAudio(ctx, "ratelimitReel", t.TempDir()); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("DownloadAudio(ratelimit) error = %v, want ErrRateLimited", err)
+	}
+
+	// With a full failure rate, every reel fails the same way each time
+	all := NewDownloader(Options{FailureRate: 1})
+	if _, err := all.DownloadAudio(ctx, "ABC123", t.TempDir()); !errors.Is(err, domain.ErrNetworkFailure) {
+		t.Errorf("DownloadAudio() with FailureRate 1 error = %v, want ErrNetworkFailure", err)
+	}
+}
+
+func TestDownloader_DownloadSubtitles(t *testing.T) {
+	d := NewDownloader(Options{})
+	ctx := context.Background()
+
+	result, err := d.DownloadSubtitles(ctx, "ABC123", t.TempDir(), "auto")
+	if err != nil || result != nil {
+		t.Errorf("DownloadSubtitles(ABC123) = %v, %v, want nil, nil", result, err)
+	}
+
+	result, err = d.DownloadSubtitles(ctx, "withcaptions", t.TempDir(), "auto")
+	if err != nil {
+		t.Fatalf("DownloadSubtitles() error = %v", err)
+	}
+	if result.Transcript.Source != domain.SourceAutoCaptions || len(result.Transcript.Segments) == 0 {
+		t.Errorf("unexpected subtitles: %+v", result.Transcript)
+	}
+	if result.Reel == nil || result.Reel.ID != "withcaptions" {
+		t.Errorf("Reel = %+v, want withcaptions metadata", result.Reel)
+	}
+}
+
+func TestTranscriber_Deterministic(t *testing.T) {
+	tr := NewTranscriber(Options{})
+	ctx := context.Background()
+	audioPath := filepath.Join("cache", "ABC123", "audio.wav")
+
+	first, err := tr.Transcribe(ctx, audioPath, ports.TranscribeOpts{Model: "base"})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	second, _ := tr.Transcribe(ctx, audioPath, ports.TranscribeOpts{Model: "base"})
+
+	if first.Text != second.Text {
+		t.Errorf("Transcribe() not deterministic: %q vs %q", first.Text, second.Text)
+	}
+	if first.Model != "base" {
+		t.Errorf("Model = %s, want base", first.Model)
+	}
+	if len(first.Segments) == 0 {
+		t.Error("Transcribe() returned no segments")
+	}
+}
@@ -0,0 +1,107 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// fixtureLines are the canned transcript lines cycled through by the fake transcriber
+var fixtureLines = []string{
+	"Hey everyone, welcome back to the channel.",
+	"Today I'm sharing three quick tips.",
+	"[Music]",
+	"First, always start with a strong hook.",
+	"Second, keep your captions short and readable.",
+	"Third, post consistently at the same time.",
+	"Thanks for watching, see you next time.",
+}
+
+// Transcriber implements ports.Transcriber with fixture transcripts
+type Transcriber struct {
+	opts Options
+}
+
+// NewTranscriber creates a fake transcriber
+func NewTranscriber(opts Options) *Transcriber {
+	return &Transcriber{opts: opts}
+}
+
+// Transcribe returns a fixture transcript. The reel ID is taken from the audio
+// file's directory (the cache layout) so output is stable per reel.
+func (t *Transcriber) Transcribe(ctx context.Context, videoPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	if err := wait(ctx, t.opts.Delay); err != nil {
+		return nil, err
+	}
+
+	reelID := filepath.Base(filepath.Dir(videoPath))
+	lineCount := 3 + len(reelID)%(len(fixtureLines)-2)
+
+	var segments []domain.Segment
+	var texts []string
+	start := opts.Offset.Seconds()
+	for i := 0; i < lineCount; i++ {
+		text := fixtureLines[i%len(fixtureLines)]
+		end := start + 2.5
+		segments = append(segments, domain.Segment{Start: start, End: end, Text: text})
+		texts = append(texts, text)
+		start = end
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = "small"
+	}
+	language := opts.Language
+	if language == "" {
+		language = "auto"
+	}
+
+	return &domain.Transcript{
+		Text:          strings.Join(texts, " "),
+		Segments:      segments,
+		Model:         model,
+		Language:      language,
+		TranscribedAt: time.Now(),
+	}, nil
+}
+
+func (t *Transcriber) AvailableModels() []ports.Model {
+	return []ports.Model{
+		{Name: "tiny", Size: 75 * 1024 * 1024, Description: "mock", Downloaded: true},
+		{Name: "base", Size: 140 * 1024 * 1024, Description: "mock", Downloaded: true},
+		{Name: "small", Size: 462 * 1024 * 1024, Description: "mock", Downloaded: true},
+		{Name: "medium", Size: 1500 * 1024 * 1024, Description: "mock", Downloaded: true},
+		{Name: "large", Size: 3000 * 1024 * 1024, Description: "mock", Downloaded: true},
+	}
+}
+
+func (t *Transcriber) IsModelDownloaded(model string) bool { return true }
+
+func (t *Transcriber) DownloadModel(ctx context.Context, model string, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+func (t *Transcriber) DeleteModel(model string) error {
+	return fmt.Errorf("cannot delete models in mock mode")
+}
+
+func (t *Transcriber) IsAvailable() bool     { return true }
+func (t *Transcriber) GetBinaryPath() string { return "mock://whisper" }
+
+func (t *Transcriber) InstallationInstructions() string { return "" }
+
+func (t *Transcriber) Version(ctx context.Context) string { return "mock" }
+
+func (t *Transcriber) ModelHash(model string) (string, error) { return "mock-" + model, nil }
+
+func (t *Transcriber) Install(ctx context.Context, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+var _ ports.Transcriber = (*Transcriber)(nil)
@@ -0,0 +1,200 @@
+// Package openai transcribes audio with OpenAI's hosted Whisper API.
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+const (
+	defaultBaseURL = "https://api.openai.com/v1"
+
+	// DefaultModel is the hosted Whisper model
+	DefaultModel = "whisper-1"
+)
+
+// Transcriber implements ports.Transcriber with the OpenAI audio
+// transcription endpoint. Models are hosted, so there is nothing to download.
+type Transcriber struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewTranscriber creates a transcriber authenticating with apiKey. An empty
+// baseURL uses OpenAI's; compatible servers can be given instead.
+func NewTranscriber(apiKey, baseURL string) *Transcriber {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Transcriber{
+		apiKey:  apiKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  http.DefaultClient,
+	}
+}
+
+// SetHTTPClient sets the client used for API requests, e.g. one that goes
+// through a proxy
+func (t *Transcriber) SetHTTPClient(client *http.Client) {
+	t.client = client
+}
+
+// verboseResponse is the verbose_json transcription response
+type verboseResponse struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	} `json:"segments"`
+}
+
+// Transcribe uploads the audio file and returns its timed transcript
+func (t *Transcriber) Transcribe(ctx context.Context, audioPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	if t.apiKey == "" {
+		return nil, fmt.Errorf("%w: no OpenAI API key (set OPENAI_API_KEY or openai.api_key)", domain.ErrTranscriptionFailed)
+	}
+
+	model := opts.Model
+	if model == "" {
+		model = DefaultModel
+	}
+
+	body, contentType, err := t.requestBody(audioPath, model, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.baseURL+"/audio/transcriptions", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrTranscriptionFailed, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrTranscriptionFailed, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: OpenAI API returned HTTP %d: %s", domain.ErrTranscriptionFailed, resp.StatusCode, apiErrorMessage(data))
+	}
+
+	var out verboseResponse
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("%w: invalid OpenAI response: %v", domain.ErrTranscriptionFailed, err)
+	}
+
+	transcript := &domain.Transcript{
+		Text:          strings.TrimSpace(out.Text),
+		Model:         model,
+		Language:      opts.Language,
+		TranscribedAt: time.Now(),
+	}
+	if transcript.Language == "" || transcript.Language == "auto" {
+		transcript.Language = out.Language
+	}
+	for _, seg := range out.Segments {
+		transcript.Segments = append(transcript.Segments, domain.Segment{Start: seg.Start, End: seg.End, Text: seg.Text})
+	}
+	return transcript, nil
+}
+
+// requestBody builds the multipart upload for audioPath
+func (t *Transcriber) requestBody(audioPath, model string, opts ports.TranscribeOpts) (io.Reader, string, error) {
+	f, err := os.Open(audioPath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", filepath.Base(audioPath))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, "", err
+	}
+
+	fields := map[string]string{
+		"model":           model,
+		"response_format": "verbose_json",
+	}
+	if opts.Language != "" && opts.Language != "auto" {
+		fields["language"] = opts.Language
+	}
+	if opts.Prompt != "" {
+		fields["prompt"] = opts.Prompt
+	}
+	if opts.Temperature > 0 {
+		fields["temperature"] = strconv.FormatFloat(opts.Temperature, 'f', 2, 64)
+	}
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, w.FormDataContentType(), nil
+}
+
+// apiErrorMessage extracts the message from an OpenAI error response
+func apiErrorMessage(data []byte) string {
+	var apiErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+		return apiErr.Error.Message
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// AvailableModels returns the hosted model
+func (t *Transcriber) AvailableModels() []ports.Model {
+	return []ports.Model{{Name: DefaultModel, Description: "OpenAI hosted Whisper", Downloaded: true}}
+}
+
+// IsModelDownloaded is always true: hosted models need no download
+func (t *Transcriber) IsModelDownloaded(model string) bool {
+	return true
+}
+
+// DownloadModel does nothing for hosted models
+func (t *Transcriber) DownloadModel(ctx context.Context, model string, progress func(downloaded, total int64)) error {
+	return nil
+}
+
+// DeleteModel fails: hosted models are not stored locally
+func (t *Transcriber) DeleteModel(model string) error {
+	return errors.New("hosted OpenAI models are not stored locally")
+}
+
+var _ ports.Transcriber = (*Transcriber)(nil)
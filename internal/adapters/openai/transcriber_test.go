@@ -0,0 +1,77 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+func writeAudio(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(path, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTranscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/transcriptions" || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("request = %s %s, auth %q", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		if r.FormValue("model") != "whisper-1" || r.FormValue("response_format") != "verbose_json" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+		if _, ok := r.MultipartForm.Value["language"]; ok {
+			t.Error("language sent for auto detection")
+		}
+		if _, _, err := r.FormFile("file"); err != nil {
+			t.Errorf("no audio file uploaded: %v", err)
+		}
+		w.Write([]byte(`{"text":" Hello there ","language":"english","segments":[{"start":0,"end":1.5,"text":" Hello there"}]}`))
+	}))
+	defer server.Close()
+
+	tr := NewTranscriber("secret", server.URL)
+	transcript, err := tr.Transcribe(context.Background(), writeAudio(t), ports.TranscribeOpts{Language: "auto"})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if transcript.Text != "Hello there" || transcript.Model != DefaultModel || transcript.Language != "english" {
+		t.Errorf("transcript = %+v", transcript)
+	}
+	if len(transcript.Segments) != 1 || transcript.Segments[0].End != 1.5 {
+		t.Errorf("segments = %+v", transcript.Segments)
+	}
+}
+
+func TestTranscribe_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"Incorrect API key provided"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewTranscriber("bad", server.URL).Transcribe(context.Background(), writeAudio(t), ports.TranscribeOpts{})
+	if !errors.Is(err, domain.ErrTranscriptionFailed) {
+		t.Errorf("error = %v, want ErrTranscriptionFailed", err)
+	}
+}
+
+func TestTranscribe_NoAPIKey(t *testing.T) {
+	_, err := NewTranscriber("", "").Transcribe(context.Background(), writeAudio(t), ports.TranscribeOpts{})
+	if !errors.Is(err, domain.ErrTranscriptionFailed) {
+		t.Errorf("error = %v, want ErrTranscriptionFailed", err)
+	}
+}
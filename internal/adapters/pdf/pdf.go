@@ -0,0 +1,294 @@
+// Package pdf renders simple text documents to PDF without external tools.
+//
+// Output uses the standard Helvetica fonts with WinAnsi encoding, so no fonts
+// are embedded. Characters outside Windows-1252 are replaced with '?'.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"image/jpeg"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page geometry in points
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 56.0
+
+	titleSize   = 18.0
+	metaSize    = 10.0
+	headingSize = 12.0
+	bodySize    = 11.0
+	lineSpacing = 1.4
+
+	maxImageWidth  = 200.0
+	maxImageHeight = 260.0
+)
+
+// Document is the content of a rendered PDF
+type Document struct {
+	Title    string
+	Metadata []string // one line each, e.g. "Author: someone"
+	Image    []byte   // optional JPEG shown below the metadata
+	Sections []Section
+}
+
+// Section is a block of body text with an optional heading
+type Section struct {
+	Heading string
+	Text    string
+}
+
+// helveticaWidths holds glyph widths (per 1000 em) for ASCII 32-126
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// textWidth returns the approximate width of encoded text in points. Bold text
+// is estimated at 10% wider than regular.
+func textWidth(s []byte, size float64, bold bool) float64 {
+	total := 0
+	for _, c := range s {
+		if c >= 32 && c <= 126 {
+			total += helveticaWidths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	w := float64(total) * size / 1000
+	if bold {
+		w *= 1.1
+	}
+	return w
+}
+
+// encode converts text to Windows-1252 bytes
+func encode(s string) []byte {
+	var out []byte
+	for _, r := range s {
+		if r == '\t' {
+			r = ' '
+		}
+		if b, ok := charmap.Windows1252.EncodeRune(r); ok {
+			out = append(out, b)
+		} else {
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// escape escapes a string for a PDF literal string
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch c {
+		case '(', ')', '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// wrap splits text into lines no wider than width
+func wrap(text string, size, width float64, bold bool) [][]byte {
+	var lines [][]byte
+	for _, para := range strings.Split(text, "\n") {
+		var line []byte
+		for _, word := range strings.Fields(para) {
+			w := encode(word)
+			candidate := w
+			if len(line) > 0 {
+				candidate = append(append(append([]byte{}, line...), ' '), w...)
+			}
+			if len(line) > 0 && textWidth(candidate, size, bold) > width {
+				lines = append(lines, line)
+				candidate = w
+			}
+			line = candidate
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// layout accumulates page content streams
+type layout struct {
+	pages [][]byte
+	cur   bytes.Buffer
+	y     float64
+}
+
+func newLayout() *layout {
+	return &layout{y: pageHeight - margin}
+}
+
+func (l *layout) newPage() {
+	l.pages = append(l.pages, append([]byte{}, l.cur.Bytes()...))
+	l.cur.Reset()
+	l.y = pageHeight - margin
+}
+
+// ensure starts a new page if fewer than h points remain
+func (l *layout) ensure(h float64) {
+	if l.y-h < margin && l.cur.Len() > 0 {
+		l.newPage()
+	}
+}
+
+func (l *layout) text(s string, size float64, bold bool) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	leading := size * lineSpacing
+	for _, line := range wrap(s, size, pageWidth-2*margin, bold) {
+		l.ensure(leading)
+		l.y -= leading
+		if len(line) > 0 {
+			fmt.Fprintf(&l.cur, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, margin, l.y, escape(line))
+		}
+	}
+}
+
+func (l *layout) gap(h float64) {
+	l.y -= h
+}
+
+func (l *layout) image(w, h float64) {
+	l.ensure(h)
+	l.y -= h
+	fmt.Fprintf(&l.cur, "q %.2f 0 0 %.2f %.2f %.2f cm /Im1 Do Q\n", w, h, margin, l.y)
+}
+
+func (l *layout) finish() [][]byte {
+	if l.cur.Len() > 0 || len(l.pages) == 0 {
+		l.newPage()
+	}
+	return l.pages
+}
+
+// Render produces a PDF file for the document
+func Render(doc Document) ([]byte, error) {
+	l := newLayout()
+
+	if doc.Title != "" {
+		l.text(doc.Title, titleSize, true)
+		l.gap(6)
+	}
+	for _, line := range doc.Metadata {
+		l.text(line, metaSize, false)
+	}
+
+	var img *jpegImage
+	if len(doc.Image) > 0 {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(doc.Image))
+		if err != nil {
+			return nil, fmt.Errorf("image is not a JPEG: %w", err)
+		}
+		img = &jpegImage{data: doc.Image, width: cfg.Width, height: cfg.Height, colorSpace: "DeviceRGB"}
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			img.colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			img.colorSpace = "DeviceCMYK"
+		}
+
+		imgW, imgH := float64(cfg.Width), float64(cfg.Height)
+		if scale := maxImageWidth / imgW; scale < 1 {
+			imgW, imgH = imgW*scale, imgH*scale
+		}
+		if scale := maxImageHeight / imgH; scale < 1 {
+			imgW, imgH = imgW*scale, imgH*scale
+		}
+		l.gap(10)
+		l.image(imgW, imgH)
+	}
+
+	for _, sec := range doc.Sections {
+		l.gap(bodySize)
+		if sec.Heading != "" {
+			l.ensure(headingSize*lineSpacing + bodySize*lineSpacing)
+			l.text(sec.Heading, headingSize, true)
+		}
+		l.text(sec.Text, bodySize, false)
+	}
+
+	return assemble(l.finish(), img), nil
+}
+
+// jpegImage is an image passed through to the PDF with DCTDecode
+type jpegImage struct {
+	data          []byte
+	width, height int
+	colorSpace    string
+}
+
+// assemble writes the PDF object graph and cross-reference table
+func assemble(pages [][]byte, img *jpegImage) []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	obj := func(body string, stream []byte) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Fixed objects: 1 catalog, 2 page tree, 3-4 fonts, 5 image (if any)
+	firstPage := 5
+	if img != nil {
+		firstPage = 6
+	}
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPage+i*2))
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)), nil)
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>", nil)
+
+	resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+	if img != nil {
+		obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>",
+			img.width, img.height, img.colorSpace, len(img.data)), img.data)
+		resources += " /XObject << /Im1 5 0 R >>"
+	}
+
+	for i, content := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << %s >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, resources, firstPage+i*2+1), nil)
+		obj(fmt.Sprintf("<< /Length %d >>", len(content)), content)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,108 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// checkXref verifies every cross-reference offset points at its object
+func checkXref(t *testing.T, data []byte) {
+	t.Helper()
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj\n", i+1)
+		if !bytes.HasPrefix(data[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, data[off:off+10], want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	data, err := Render(Document{
+		Title:    "Café (tips)",
+		Metadata: []string{"Author: chef"},
+		Sections: []Section{{Heading: "00:00", Text: "Hello world"}},
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) {
+		t.Error("missing PDF header")
+	}
+	if !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Error("missing EOF marker")
+	}
+	if !bytes.Contains(data, []byte("(Caf\xe9 \\(tips\\)) Tj")) {
+		t.Error("title not WinAnsi-encoded and escaped")
+	}
+	if !bytes.Contains(data, []byte("/Count 1")) {
+		t.Error("expected a single page")
+	}
+	checkXref(t, data)
+}
+
+func TestRender_Paginates(t *testing.T) {
+	long := strings.Repeat("lorem ipsum dolor sit amet ", 2000)
+
+	data, err := Render(Document{Title: "Long", Sections: []Section{{Text: long}}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	m := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("missing page count")
+	}
+	if n, _ := strconv.Atoi(string(m[1])); n < 2 {
+		t.Errorf("page count = %d, want multiple pages", n)
+	}
+	checkXref(t, data)
+}
+
+func TestRender_Image(t *testing.T) {
+	var img bytes.Buffer
+	if err := jpeg.Encode(&img, image.NewRGBA(image.Rect(0, 0, 40, 20)), nil); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+
+	data, err := Render(Document{Title: "Thumb", Image: img.Bytes()})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !bytes.Contains(data, []byte("/Width 40 /Height 20")) {
+		t.Error("image dimensions missing")
+	}
+	if !bytes.Contains(data, []byte("/Im1 Do")) {
+		t.Error("image not drawn")
+	}
+	checkXref(t, data)
+
+	if _, err := Render(Document{Image: []byte("not a jpeg")}); err == nil {
+		t.Error("Render() should reject non-JPEG images")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap("aaa bbb ccc", 10, textWidth([]byte("aaa bbb"), 10, false), false)
+	if len(lines) != 2 || string(lines[0]) != "aaa bbb" || string(lines[1]) != "ccc" {
+		t.Errorf("wrap() = %q", lines)
+	}
+}
@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+const (
+	dirPerm  = 0755
+	filePerm = 0644
+
+	// retention bounds how long hits are kept on disk
+	retention = 24 * time.Hour
+)
+
+// FileStore implements ports.RateLimitStore using a JSON file.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a rate-limit store backed by the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Record(ctx context.Context, host string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hits, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	hits[host] = append(hits[host], at)
+	prune(hits, at.Add(-retention))
+
+	return s.save(hits)
+}
+
+func (s *FileStore) Hits(ctx context.Context) (map[string][]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+func (s *FileStore) Reset(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// load reads hits from disk, returning an empty map if the file does not exist.
+func (s *FileStore) load() (map[string][]time.Time, error) {
+	hits := make(map[string][]time.Time)
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hits, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+func (s *FileStore) save(hits map[string][]time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), dirPerm); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(hits, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, filePerm)
+}
+
+// prune drops hits recorded before cutoff and hosts left without hits.
+func prune(hits map[string][]time.Time, cutoff time.Time) {
+	for host, times := range hits {
+		kept := times[:0]
+		for _, t := range times {
+			if !t.Before(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 {
+			delete(hits, host)
+			continue
+		}
+		hits[host] = kept
+	}
+}
+
+var _ ports.RateLimitStore = (*FileStore)(nil)
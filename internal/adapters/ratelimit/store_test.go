@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore_RecordHits(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "ratelimits.json"))
+	ctx := context.Background()
+	now := time.Now()
+
+	if err := store.Record(ctx, "instagram.com", now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := store.Record(ctx, "instagram.com", now); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	hits, err := store.Hits(ctx)
+	if err != nil {
+		t.Fatalf("Hits() error = %v", err)
+	}
+
+	// The 48h-old hit is pruned when the newer one is recorded
+	if len(hits["instagram.com"]) != 1 {
+		t.Errorf("Hits() returned %d hits, want 1", len(hits["instagram.com"]))
+	}
+}
+
+func TestFileStore_Reset(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "ratelimits.json"))
+	ctx := context.Background()
+
+	if err := store.Record(ctx, "instagram.com", time.Now()); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	hits, err := store.Hits(ctx)
+	if err != nil {
+		t.Fatalf("Hits() error = %v", err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("Hits() after Reset() = %v, want empty", hits)
+	}
+
+	// Resetting an empty store is not an error
+	if err := store.Reset(ctx); err != nil {
+		t.Errorf("Reset() on empty store error = %v", err)
+	}
+}
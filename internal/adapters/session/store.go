@@ -0,0 +1,79 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+const (
+	// dirPerm and filePerm keep the login private to the user
+	dirPerm  = 0700
+	filePerm = 0600
+
+	cookiesName = "cookies.txt"
+	sourceName  = "source.json"
+)
+
+// FileStore implements ports.SessionStore with a cookie jar and a small
+// JSON file recording its source, both in one directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a session store in dir.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) CookiesPath() string {
+	return filepath.Join(s.dir, cookiesName)
+}
+
+func (s *FileStore) Load(ctx context.Context) ([]byte, domain.SessionSource, error) {
+	var source domain.SessionSource
+
+	cookieJar, err := os.ReadFile(s.CookiesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, source, domain.ErrNoSession
+		}
+		return nil, source, err
+	}
+
+	// The source is informational; a jar without one is still usable
+	if data, err := os.ReadFile(filepath.Join(s.dir, sourceName)); err == nil {
+		_ = json.Unmarshal(data, &source)
+	}
+	return cookieJar, source, nil
+}
+
+func (s *FileStore) Save(ctx context.Context, cookieJar []byte, source domain.SessionSource) error {
+	if err := os.MkdirAll(s.dir, dirPerm); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, sourceName), data, filePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(s.CookiesPath(), cookieJar, filePerm)
+}
+
+func (s *FileStore) Delete(ctx context.Context) error {
+	for _, name := range []string{cookiesName, sourceName} {
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ ports.SessionStore = (*FileStore)(nil)
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestFileStore_SaveLoadDelete(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "session"))
+	ctx := context.Background()
+
+	if _, _, err := store.Load(ctx); !errors.Is(err, domain.ErrNoSession) {
+		t.Fatalf("Load() on empty store error = %v, want ErrNoSession", err)
+	}
+
+	jar := []byte("# Netscape HTTP Cookie File\n")
+	source := domain.SessionSource{Browser: "firefox"}
+	if err := store.Save(ctx, jar, source); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	gotJar, gotSource, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if string(gotJar) != string(jar) || gotSource != source {
+		t.Errorf("Load() = %q, %+v", gotJar, gotSource)
+	}
+
+	info, err := os.Stat(store.CookiesPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm&0077 != 0 {
+		t.Errorf("cookie jar permissions = %v, want private to the user", perm)
+	}
+
+	if err := store.Delete(ctx); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := store.Load(ctx); !errors.Is(err, domain.ErrNoSession) {
+		t.Errorf("Load() after Delete() error = %v, want ErrNoSession", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Package share resolves Instagram share links to canonical reel URLs.
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+const (
+	maxRedirects   = 10
+	requestTimeout = 15 * time.Second
+)
+
+// errFound stops redirect following once a reel URL is reached
+var errFound = errors.New("reel URL found")
+
+// Resolver follows share-link redirects over HTTP
+type Resolver struct {
+	client *http.Client
+}
+
+// NewResolver creates a resolver using a default HTTP client
+func NewResolver() *Resolver {
+	return &Resolver{client: &http.Client{Timeout: requestTimeout}}
+}
+
+// SetHTTPClient sends requests through client, e.g. one that goes through a
+// proxy, keeping the resolver's timeout
+func (r *Resolver) SetHTTPClient(client *http.Client) {
+	c := *client
+	c.Timeout = requestTimeout
+	r.client = &c
+}
+
+// Resolve follows redirects from rawURL and returns the first URL that parses
+// as a reel. Redirects stop there so the reel page itself is never fetched.
+func (r *Resolver) Resolve(ctx context.Context, rawURL string) (string, error) {
+	var found string
+	client := *r.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if _, err := domain.ParseReelInput(req.URL.String()); err == nil {
+			found = req.URL.String()
+			return errFound
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if found != "" {
+		return found, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrNetworkFailure, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", domain.ErrRateLimited
+	}
+	return resp.Request.URL.String(), nil
+}
+
+var _ ports.LinkResolver = (*Resolver)(nil)
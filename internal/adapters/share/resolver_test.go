@@ -0,0 +1,49 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+func TestResolve_StopsAtReelURL(t *testing.T) {
+	reelURL := "https://www.instagram.com/reel/DToLsd-EvGJ/?igsh=abc"
+
+	var hops int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops++
+		if r.URL.Path == "/share/reel/XYZ" {
+			http.Redirect(w, r, "/hop", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, reelURL, http.StatusMovedPermanently)
+	}))
+	defer srv.Close()
+
+	got, err := NewResolver().Resolve(context.Background(), srv.URL+"/share/reel/XYZ")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got != reelURL {
+		t.Errorf("Resolve() = %s, want %s", got, reelURL)
+	}
+	if hops != 2 {
+		t.Errorf("expected 2 requests to the share host, got %d", hops)
+	}
+}
+
+func TestResolve_RateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := NewResolver().Resolve(context.Background(), srv.URL)
+	if !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("Resolve() error = %v, want ErrRateLimited", err)
+	}
+}
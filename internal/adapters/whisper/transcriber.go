@@ -2,7 +2,10 @@ package whisper
 
 import (
 	"archive/zip"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,12 +31,31 @@ var availableModels = []ports.Model{
 	{Name: "small", Size: 462 * 1024 * 1024, Description: "~462MB, better accuracy, moderate speed"},
 	{Name: "medium", Size: 1500 * 1024 * 1024, Description: "~1.5GB, great accuracy, slower"},
 	{Name: "large", Size: 3000 * 1024 * 1024, Description: "~3GB, best accuracy, slow"},
+	{Name: "large-v3-turbo", Size: 1549 * 1024 * 1024, Description: "~1.6GB, near-large accuracy, fast"},
+	{Name: "large-v3-turbo-q5_0", Size: 547 * 1024 * 1024, Description: "~547MiB, quantized turbo, fast"},
+	{Name: "distil-large-v3", Size: 1448 * 1024 * 1024, Description: "~1.5GB, near-large accuracy, fast (English)"},
+	{Name: "small.en-tdrz", Size: 465 * 1024 * 1024, Description: "~465MB, small with speaker turns (English)"},
+}
+
+// Default hosts of models and the whisper.cpp binary, replaced by mirrors
+const (
+	modelDownloadBase  = "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/"
+	binaryDownloadBase = "https://github.com/ggerganov/whisper.cpp/releases/latest/download/"
+)
+
+// modelURLs maps models hosted outside the whisper.cpp repository to their download URL
+var modelURLs = map[string]string{
+	"distil-large-v3": "https://huggingface.co/distil-whisper/distil-large-v3-ggml/resolve/main/ggml-distil-large-v3.bin",
 }
 
 // Transcriber implements ports.Transcriber using whisper.cpp
 type Transcriber struct {
-	modelsDir string
-	binPath   string
+	modelsDir    string
+	binDir       string
+	binPath      string
+	client       *http.Client
+	modelMirror  string
+	binaryMirror string
 }
 
 func whisperBinaryName() string {
@@ -52,13 +74,36 @@ func NewTranscriber(modelsDir string) *Transcriber {
 }
 
 func modelURL(name string) string {
-	return fmt.Sprintf("https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-%s.bin", name)
+	if url, ok := modelURLs[name]; ok {
+		return url
+	}
+	return fmt.Sprintf("%sggml-%s.bin", modelDownloadBase, name)
+}
+
+// modelDownloadURL returns where to download the named model: the model
+// mirror, which holds every model under its file name, or its usual host
+func (t *Transcriber) modelDownloadURL(name string) string {
+	if t.modelMirror != "" {
+		return mirrorURL(t.modelMirror, fmt.Sprintf("ggml-%s.bin", name))
+	}
+	return modelURL(name)
+}
+
+// mirrorURL returns the URL of file in the mirror at base
+func mirrorURL(base, file string) string {
+	return strings.TrimSuffix(base, "/") + "/" + file
 }
 
 func (t *Transcriber) modelPath(name string) string {
 	return filepath.Join(t.modelsDir, fmt.Sprintf("ggml-%s.bin", name))
 }
 
+// isTinydiarize reports whether model is a tinydiarize model, which marks
+// speaker turns when run with -tdrz
+func isTinydiarize(model string) bool {
+	return strings.HasSuffix(model, "-tdrz")
+}
+
 func isValidModel(name string) bool {
 	for _, m := range availableModels {
 		if m.Name == name {
@@ -68,15 +113,51 @@ func isValidModel(name string) bool {
 	return false
 }
 
+// SetModelMirror downloads models from baseURL instead of Hugging Face.
+// It must hold each model as ggml-<model>.bin.
+func (t *Transcriber) SetModelMirror(baseURL string) {
+	t.modelMirror = baseURL
+}
+
+// SetBinaryMirror downloads the whisper.cpp binary from baseURL instead of
+// GitHub's latest release
+func (t *Transcriber) SetBinaryMirror(baseURL string) {
+	t.binaryMirror = baseURL
+}
+
+// SetBinDir installs and looks for downloaded binaries in dir instead of
+// config.BinDir
+func (t *Transcriber) SetBinDir(dir string) {
+	t.binDir = dir
+}
+
+// BinDir returns the directory downloaded binaries are installed in
+func (t *Transcriber) BinDir() string {
+	if t.binDir != "" {
+		return t.binDir
+	}
+	return config.BinDir()
+}
+
+// SetHTTPClient sets the client used to download models and binaries, e.g.
+// one that goes through a proxy
+func (t *Transcriber) SetHTTPClient(client *http.Client) {
+	t.client = client
+}
+
 // downloadWithProgress downloads from a URL to destPath with progress reporting and context cancellation.
 // Partial downloads are cleaned up on failure.
-func downloadWithProgress(ctx context.Context, url, destPath string, progress func(downloaded, total int64)) error {
+func (t *Transcriber) downloadWithProgress(ctx context.Context, url, destPath string, progress func(downloaded, total int64)) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	client := t.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download: %w", err)
 	}
@@ -160,7 +241,7 @@ func (t *Transcriber) DownloadModel(ctx context.Context, model string, progress
 	destPath := t.modelPath(model)
 	tempPath := destPath + ".tmp"
 
-	if err := downloadWithProgress(ctx, modelURL(model), tempPath, progress); err != nil {
+	if err := t.downloadWithProgress(ctx, t.modelDownloadURL(model), tempPath, progress); err != nil {
 		return fmt.Errorf("failed to download model: %w", err)
 	}
 
@@ -176,6 +257,45 @@ func (t *Transcriber) DeleteModel(model string) error {
 	return os.Remove(t.modelPath(model))
 }
 
+// ModelHash returns the SHA-256 of a downloaded model file
+func (t *Transcriber) ModelHash(model string) (string, error) {
+	f, err := os.Open(t.modelPath(model))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Version returns the whisper.cpp version reported by the binary, or
+// "unknown" for builds that don't support --version
+func (t *Transcriber) Version(ctx context.Context) string {
+	binPath := t.GetBinaryPath()
+	if binPath == "" {
+		return "unknown"
+	}
+	out, err := exec.CommandContext(ctx, binPath, "--version").CombinedOutput()
+	if err != nil {
+		return "unknown"
+	}
+	return firstLine(string(out))
+}
+
+// firstLine returns the first non-empty trimmed line of s, or "unknown"
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return "unknown"
+}
+
 func (t *Transcriber) GetBinaryPath() string {
 	if t.binPath != "" {
 		return t.binPath
@@ -218,6 +338,25 @@ func (t *Transcriber) Transcribe(ctx context.Context, videoPath string, opts por
 		"-oj",
 		"-l", language,
 	}
+	if opts.RawOutputPath != "" {
+		// Full JSON adds tokens with their probabilities and timestamps
+		args = append(args, "-ojf")
+	}
+	if isTinydiarize(model) {
+		args = append(args, "-tdrz")
+	}
+	if opts.Prompt != "" {
+		args = append(args, "--prompt", opts.Prompt)
+	}
+	if opts.Temperature > 0 {
+		args = append(args, "--temperature", strconv.FormatFloat(opts.Temperature, 'f', 2, 64))
+	}
+	if opts.Offset > 0 {
+		args = append(args, "--offset-t", strconv.FormatInt(opts.Offset.Milliseconds(), 10))
+	}
+	if opts.Duration > 0 {
+		args = append(args, "--duration", strconv.FormatInt(opts.Duration.Milliseconds(), 10))
+	}
 
 	cmd := exec.CommandContext(ctx, whisperBin, args...)
 	var stderr strings.Builder
@@ -233,7 +372,41 @@ func (t *Transcriber) Transcribe(ctx context.Context, videoPath string, opts por
 	jsonPath := outputBase + ".json"
 	defer os.Remove(jsonPath)
 
-	return t.parseWhisperJSON(jsonPath, model)
+	transcript, err := t.parseWhisperJSON(jsonPath, model)
+	if err != nil {
+		return nil, err
+	}
+	if opts.RawOutputPath != "" {
+		if err := saveCompressed(jsonPath, opts.RawOutputPath); err != nil {
+			// Don't leave a previous run's output looking current
+			os.Remove(opts.RawOutputPath)
+		}
+	}
+	return transcript, nil
+}
+
+// saveCompressed writes a gzip-compressed copy of src to dst
+func saveCompressed(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	if _, err := io.Copy(zw, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
 }
 
 func (t *Transcriber) findWhisperBinary() string {
@@ -244,7 +417,7 @@ func (t *Transcriber) findWhisperBinary() string {
 
 	// Check bundled location
 	for _, name := range names {
-		bundled := filepath.Join(config.BinDir(), name)
+		bundled := filepath.Join(t.BinDir(), name)
 		if _, err := os.Stat(bundled); err == nil {
 			return bundled
 		}
@@ -272,27 +445,48 @@ func (t *Transcriber) parseWhisperJSON(path string, model string) (*domain.Trans
 				From string `json:"from"`
 				To   string `json:"to"`
 			} `json:"timestamps"`
-			Text string `json:"text"`
+			Text            string `json:"text"`
+			SpeakerTurnNext bool   `json:"speaker_turn_next"` // set by tinydiarize models
 		} `json:"transcription"`
+		Result struct {
+			Language string `json:"language"` // detected when run with -l auto
+		} `json:"result"`
 	}
 
 	if err := json.Unmarshal(data, &output); err != nil {
 		return nil, err
 	}
 
+	// Speaker labels are only assigned when the model reported any speaker turn
+	diarized := false
+	for _, item := range output.Transcription {
+		if item.SpeakerTurnNext {
+			diarized = true
+			break
+		}
+	}
+
 	var segments []domain.Segment
 	var fullText strings.Builder
+	speaker := 1
 
 	for _, item := range output.Transcription {
 		start := parseTimestamp(item.Timestamps.From)
 		end := parseTimestamp(item.Timestamps.To)
-		text := strings.TrimSpace(item.Text)
+		text := strings.TrimSpace(strings.ReplaceAll(item.Text, speakerTurnToken, ""))
 
-		segments = append(segments, domain.Segment{
+		seg := domain.Segment{
 			Start: start,
 			End:   end,
 			Text:  text,
-		})
+		}
+		if diarized {
+			seg.Speaker = fmt.Sprintf("Speaker %d", speaker)
+			if item.SpeakerTurnNext {
+				speaker++
+			}
+		}
+		segments = append(segments, seg)
 
 		if fullText.Len() > 0 {
 			fullText.WriteString(" ")
@@ -300,15 +494,23 @@ func (t *Transcriber) parseWhisperJSON(path string, model string) (*domain.Trans
 		fullText.WriteString(text)
 	}
 
+	language := output.Result.Language
+	if language == "" {
+		language = "auto"
+	}
+
 	return &domain.Transcript{
 		Text:          fullText.String(),
 		Segments:      segments,
 		Model:         model,
-		Language:      "auto",
+		Language:      language,
 		TranscribedAt: time.Now(),
 	}, nil
 }
 
+// speakerTurnToken is emitted inline by tinydiarize models at speaker changes
+const speakerTurnToken = "[_SOLM_]"
+
 var timestampRegex = regexp.MustCompile(`(\d+):(\d+):(\d+)[,.](\d+)`)
 
 func parseTimestamp(ts string) float64 {
@@ -327,7 +529,10 @@ func parseTimestamp(ts string) float64 {
 
 func (t *Transcriber) getDownloadURL() string {
 	if runtime.GOOS == "windows" {
-		return "https://github.com/ggerganov/whisper.cpp/releases/latest/download/whisper-bin-x64.zip"
+		if t.binaryMirror != "" {
+			return mirrorURL(t.binaryMirror, "whisper-bin-x64.zip")
+		}
+		return binaryDownloadBase + "whisper-bin-x64.zip"
 	}
 	return ""
 }
@@ -349,7 +554,7 @@ func (t *Transcriber) Install(ctx context.Context, progress func(downloaded, tot
 		return fmt.Errorf("no prebuilt whisper.cpp binary for %s.\n%s", runtime.GOOS, t.InstallationInstructions())
 	}
 
-	binDir := config.BinDir()
+	binDir := t.BinDir()
 	if err := os.MkdirAll(binDir, 0755); err != nil {
 		return err
 	}
@@ -357,7 +562,7 @@ func (t *Transcriber) Install(ctx context.Context, progress func(downloaded, tot
 	tmpPath := filepath.Join(os.TempDir(), "whisper-download.zip")
 	defer os.Remove(tmpPath)
 
-	if err := downloadWithProgress(ctx, downloadURL, tmpPath, progress); err != nil {
+	if err := t.downloadWithProgress(ctx, downloadURL, tmpPath, progress); err != nil {
 		return fmt.Errorf("failed to download whisper.cpp: %w", err)
 	}
 
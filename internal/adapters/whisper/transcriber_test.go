@@ -2,7 +2,9 @@ package whisper
 
 import (
 	"archive/zip"
+	"compress/gzip"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -14,8 +16,8 @@ func TestAvailableModels(t *testing.T) {
 	tr := NewTranscriber("")
 	models := tr.AvailableModels()
 
-	if len(models) != 5 {
-		t.Errorf("AvailableModels() returned %d models, want 5", len(models))
+	if len(models) != 9 {
+		t.Errorf("AvailableModels() returned %d models, want 9", len(models))
 	}
 
 	// Check that "small" exists
@@ -52,6 +54,10 @@ func TestModelURLAllModels(t *testing.T) {
 		{"small", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.bin"},
 		{"medium", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.bin"},
 		{"large", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large.bin"},
+		{"large-v3-turbo", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo.bin"},
+		{"large-v3-turbo-q5_0", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo-q5_0.bin"},
+		{"distil-large-v3", "https://huggingface.co/distil-whisper/distil-large-v3-ggml/resolve/main/ggml-distil-large-v3.bin"},
+		{"small.en-tdrz", "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.en-tdrz.bin"},
 	}
 
 	for _, tt := range tests {
@@ -122,6 +128,29 @@ func TestDeleteModelNotExists(t *testing.T) {
 	}
 }
 
+func TestModelHash(t *testing.T) {
+	tmpDir := t.TempDir()
+	tr := NewTranscriber(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "ggml-tiny.bin"), []byte("fake model"), 0644); err != nil {
+		t.Fatalf("failed to create test model file: %v", err)
+	}
+
+	hash, err := tr.ModelHash("tiny")
+	if err != nil {
+		t.Fatalf("ModelHash() error = %v", err)
+	}
+	// sha256("fake model")
+	want := "1eec943f3fbf69947176e7c711415ad88a08184169f72cd31dca0ab071e14939"
+	if hash != want {
+		t.Errorf("ModelHash() = %s, want %s", hash, want)
+	}
+
+	if _, err := tr.ModelHash("small"); err == nil {
+		t.Error("ModelHash() should return error for missing model")
+	}
+}
+
 func TestParseTimestamp(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -243,6 +272,10 @@ func TestParseWhisperJSON(t *testing.T) {
 		t.Errorf("expected model 'small', got %q", result.Model)
 	}
 
+	if result.Language != "auto" {
+		t.Errorf("language without a detection result = %q, want 'auto'", result.Language)
+	}
+
 	// Check first segment
 	if result.Segments[0].Start != 0.0 {
 		t.Errorf("segment[0].Start = %f, want 0.0", result.Segments[0].Start)
@@ -255,6 +288,103 @@ func TestParseWhisperJSON(t *testing.T) {
 	}
 }
 
+func TestParseWhisperJSON_DetectedLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	tr := NewTranscriber(tmpDir)
+
+	jsonContent := `{
+        "result": {"language": "de"},
+        "transcription": [
+            {"timestamps": {"from": "00:00:00,000", "to": "00:00:02,500"}, "text": "Hallo zusammen"}
+        ]
+    }`
+
+	jsonPath := filepath.Join(tmpDir, "test.json")
+	if err := os.WriteFile(jsonPath, []byte(jsonContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := tr.parseWhisperJSON(jsonPath, "small")
+	if err != nil {
+		t.Fatalf("parseWhisperJSON failed: %v", err)
+	}
+	if result.Language != "de" {
+		t.Errorf("Language = %q, want 'de'", result.Language)
+	}
+}
+
+func TestSaveCompressed(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "raw.json")
+	content := `{"transcription": [{"text": "Hello", "tokens": [{"text": "Hello", "p": 0.98}]}]}`
+	if err := os.WriteFile(src, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(tmpDir, "raw.json.gz")
+	if err := saveCompressed(src, dst); err != nil {
+		t.Fatalf("saveCompressed() error = %v", err)
+	}
+
+	f, err := os.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("decompressed = %q, want %q", data, content)
+	}
+}
+
+func TestParseWhisperJSON_SpeakerTurns(t *testing.T) {
+	tmpDir := t.TempDir()
+	tr := NewTranscriber(tmpDir)
+
+	jsonContent := `{
+        "transcription": [
+            {"timestamps": {"from": "00:00:00,000", "to": "00:00:02,500"}, "text": "Hi there [_SOLM_]", "speaker_turn_next": true},
+            {"timestamps": {"from": "00:00:02,500", "to": "00:00:05,000"}, "text": "Hello"}
+        ]
+    }`
+
+	jsonPath := filepath.Join(tmpDir, "test.json")
+	if err := os.WriteFile(jsonPath, []byte(jsonContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := tr.parseWhisperJSON(jsonPath, "small")
+	if err != nil {
+		t.Fatalf("parseWhisperJSON failed: %v", err)
+	}
+
+	if result.Segments[0].Text != "Hi there" {
+		t.Errorf("segment[0].Text = %q, want 'Hi there'", result.Segments[0].Text)
+	}
+	if result.Segments[0].Speaker != "Speaker 1" {
+		t.Errorf("segment[0].Speaker = %q, want 'Speaker 1'", result.Segments[0].Speaker)
+	}
+	if result.Segments[1].Speaker != "Speaker 2" {
+		t.Errorf("segment[1].Speaker = %q, want 'Speaker 2'", result.Segments[1].Speaker)
+	}
+}
+
+func TestIsTinydiarize(t *testing.T) {
+	if !isTinydiarize("small.en-tdrz") {
+		t.Error("isTinydiarize(small.en-tdrz) = false, want true")
+	}
+	if isTinydiarize("small") {
+		t.Error("isTinydiarize(small) = true, want false")
+	}
+}
+
 func TestParseWhisperJSON_InvalidFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	tr := NewTranscriber(tmpDir)
@@ -342,6 +472,18 @@ func TestGetBinaryPath_Bundled(t *testing.T) {
 	_ = tr.IsAvailable()
 }
 
+func TestModelDownloadURL_Mirror(t *testing.T) {
+	tr := NewTranscriber(t.TempDir())
+	tr.SetModelMirror("https://mirror.example.com/whisper/")
+
+	for _, model := range []string{"small", "distil-large-v3"} {
+		want := "https://mirror.example.com/whisper/ggml-" + model + ".bin"
+		if got := tr.modelDownloadURL(model); got != want {
+			t.Errorf("modelDownloadURL(%s) = %s, want %s", model, got, want)
+		}
+	}
+}
+
 func TestGetDownloadURL(t *testing.T) {
 	tr := NewTranscriber(t.TempDir())
 	url := tr.getDownloadURL()
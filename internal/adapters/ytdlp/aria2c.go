@@ -0,0 +1,173 @@
+package ytdlp
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+const aria2cWindowsURL = "https://github.com/aria2/aria2/releases/download/release-1.37.0/aria2-1.37.0-win-64bit-build1.zip"
+
+// aria2cArgs has aria2c fetch each file over 16 connections in 1 MiB pieces
+const aria2cArgs = "aria2c:-x 16 -s 16 -k 1M"
+
+func aria2cBinaryName() string {
+	return executableName("aria2c")
+}
+
+// SetAria2c makes subsequent downloads go through aria2c, which fetches
+// fragments in parallel, when it's installed. yt-dlp's own downloader is used
+// otherwise.
+func (d *Downloader) SetAria2c(enabled bool) {
+	d.aria2c = enabled
+}
+
+// Aria2cEnabled reports whether downloads should go through aria2c
+func (d *Downloader) Aria2cEnabled() bool {
+	return d.aria2c
+}
+
+// aria2cOptions returns the options handing downloads to aria2c, or none
+// when it's disabled or missing
+func (d *Downloader) aria2cOptions() []string {
+	if !d.aria2c {
+		return nil
+	}
+	path := d.GetAria2cPath()
+	if path == "" {
+		return nil
+	}
+	return []string{"--downloader", path, "--downloader-args", aria2cArgs}
+}
+
+func (d *Downloader) findAria2c() string {
+	// Check system PATH first (user may have aria2 installed)
+	if path, err := exec.LookPath(aria2cBinaryName()); err == nil {
+		return path
+	}
+
+	// Check bundled location
+	bundled := filepath.Join(d.BinDir(), aria2cBinaryName())
+	if _, err := os.Stat(bundled); err == nil {
+		return bundled
+	}
+
+	return ""
+}
+
+// GetAria2cPath returns the path to aria2c, or "" if it isn't installed
+func (d *Downloader) GetAria2cPath() string {
+	if d.aria2cPath != "" {
+		return d.aria2cPath
+	}
+	d.aria2cPath = d.findAria2c()
+	return d.aria2cPath
+}
+
+// IsAria2cAvailable checks if aria2c is installed
+func (d *Downloader) IsAria2cAvailable() bool {
+	return d.GetAria2cPath() != ""
+}
+
+// Aria2cInstructions returns how to install aria2c, or "" where
+// InstallAria2c can download it
+func (d *Downloader) Aria2cInstructions() string {
+	switch runtime.GOOS {
+	case "windows":
+		return "" // Auto-download available
+	case "darwin":
+		return "aria2c not found. Install with:\n  brew install aria2"
+	default:
+		return "aria2c not found. Install with:\n  sudo apt install aria2  # Debian/Ubuntu\n  sudo dnf install aria2  # Fedora"
+	}
+}
+
+// InstallAria2c downloads aria2c into the bin directory (Windows only)
+func (d *Downloader) InstallAria2c(ctx context.Context, progress func(downloaded, total int64)) error {
+	if runtime.GOOS != "windows" {
+		return fmt.Errorf("no prebuilt aria2c binary for %s.\n%s", runtime.GOOS, d.Aria2cInstructions())
+	}
+
+	binDir := d.BinDir()
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, aria2cWindowsURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := d.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download aria2c: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download aria2c: HTTP %d", resp.StatusCode)
+	}
+
+	tmpFile, err := os.CreateTemp("", "aria2-*.zip")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	if err := downloadWithProgress(ctx, resp.Body, tmpFile, resp.ContentLength, progress); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	tmpFile.Close()
+
+	aria2cPath := filepath.Join(binDir, aria2cBinaryName())
+	if err := extractAria2cFromZip(tmpPath, aria2cPath); err != nil {
+		os.Remove(aria2cPath)
+		return err
+	}
+
+	d.aria2cPath = aria2cPath
+	return nil
+}
+
+// extractAria2cFromZip copies aria2c.exe out of the release archive, which
+// keeps it in a versioned folder
+func extractAria2cFromZip(archivePath, destPath string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if filepath.Base(f.Name) != "aria2c.exe" {
+			continue
+		}
+
+		src, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		dst, err := os.Create(destPath)
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+
+		if _, err := io.Copy(dst, src); err != nil {
+			return fmt.Errorf("failed to extract aria2c.exe: %w", err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("aria2c.exe not found in zip archive")
+}
@@ -0,0 +1,98 @@
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// commentInfo is one entry of the comments yt-dlp reports with --get-comments
+type commentInfo struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	Author    string `json:"author"`
+	LikeCount int64  `json:"like_count"`
+	Timestamp int64  `json:"timestamp"`
+	Parent    string `json:"parent"` // "root" for top-level comments
+}
+
+// FetchComments returns the reel's most liked comments without downloading
+// any media
+func (d *Downloader) FetchComments(ctx context.Context, reelID string, limit int) ([]domain.Comment, error) {
+	if domain.IsLocalFileID(reelID) {
+		return nil, errors.New("local files have no comments")
+	}
+
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
+	}
+
+	url, err := d.reelURL(reelID)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{
+		"--no-warnings",
+		"--skip-download",
+		"--get-comments",
+		"--dump-json",
+		url,
+	}
+	if _, ok := domain.ParseYouTubeID(reelID); ok && limit > 0 {
+		// YouTube can rank and cap comments itself rather than listing them all
+		args = append([]string{"--extractor-args", fmt.Sprintf("youtube:comment_sort=top;max_comments=%d", limit)}, args...)
+	}
+	args = append(slideArgs(reelID), args...)
+
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		if domainErr := detectYtdlpError(err); domainErr != nil {
+			return nil, domainErr
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to fetch comments: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+
+	var info struct {
+		Comments []commentInfo `json:"comments"`
+	}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse yt-dlp output: %w", err)
+	}
+	return domain.TopComments(parseComments(info.Comments), limit), nil
+}
+
+// parseComments converts yt-dlp's comments to domain comments
+func parseComments(infos []commentInfo) []domain.Comment {
+	comments := make([]domain.Comment, 0, len(infos))
+	for _, c := range infos {
+		comment := domain.Comment{
+			ID:        c.ID,
+			Author:    c.Author,
+			Text:      c.Text,
+			LikeCount: c.LikeCount,
+		}
+		if c.Timestamp > 0 {
+			comment.PostedAt = time.Unix(c.Timestamp, 0).UTC()
+		}
+		if c.Parent != "" && c.Parent != "root" {
+			comment.ReplyTo = c.Parent
+		}
+		comments = append(comments, comment)
+	}
+	return comments
+}
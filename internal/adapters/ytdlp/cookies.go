@@ -0,0 +1,46 @@
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// ExportCookies reads the browser's cookies through yt-dlp and returns them
+// as a Netscape-format cookie jar
+func (d *Downloader) ExportCookies(ctx context.Context, browser string) ([]byte, error) {
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ig2insights-cookies")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	jarPath := filepath.Join(tmpDir, "cookies.txt")
+
+	// Without a URL yt-dlp exits with a usage error, but only after saving
+	// the browser's cookies to the --cookies file
+	cmd := exec.CommandContext(ctx, binPath, "--cookies-from-browser", browser, "--cookies", jarPath)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	_ = cmd.Run()
+
+	data, err := os.ReadFile(jarPath)
+	if err != nil || len(data) == 0 {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to read cookies from %s: %s", browser, msg)
+		}
+		return nil, fmt.Errorf("failed to read cookies from %s", browser)
+	}
+	return data, nil
+}
+
+var _ ports.CookieExporter = (*Downloader)(nil)
@@ -1,6 +1,7 @@
 package ytdlp
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -12,7 +13,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bodgit/sevenzip"
@@ -31,7 +34,20 @@ const (
 // Downloader implements VideoDownloader and AccountFetcher using yt-dlp
 type Downloader struct {
 	binPath    string
+	binDir     string
 	ffmpegPath string
+	throttle   Throttle
+	limiter    *limiter
+	cookies    Cookies
+	configs    ConfigFiles
+	proxy      string
+	client     *http.Client
+	extraArgs  []string
+	aria2c     bool
+	aria2cPath string
+	mirror     string   // base URL yt-dlp itself is downloaded from, instead of GitHub
+	urls       sync.Map // reel ID -> URL of videos on sites ig2insights doesn't know
+	files      sync.Map // reel ID -> path of audio and video files on disk
 }
 
 // NewDownloader creates a new yt-dlp downloader
@@ -39,6 +55,170 @@ func NewDownloader() *Downloader {
 	return &Downloader{}
 }
 
+// Throttle paces yt-dlp's requests to Instagram. Zero values leave yt-dlp's
+// defaults in place.
+type Throttle struct {
+	SleepRequests    time.Duration // pause between metadata requests
+	SleepInterval    time.Duration // pause before each download
+	MaxSleepInterval time.Duration // randomize download pauses up to this
+	Retries          int           // retries for failed requests and extraction
+	Impersonate      string        // browser target such as "chrome"; needs curl_cffi
+
+	// Enforced across all requests through this Downloader, however many
+	// goroutines share it
+	MinDelay          time.Duration // gap between starting two yt-dlp requests
+	Jitter            time.Duration // random extra gap, up to this
+	RequestsPerMinute int           // cap on requests started per minute
+}
+
+// SetThrottle applies t to every subsequent request
+func (d *Downloader) SetThrottle(t Throttle) {
+	d.throttle = t
+	d.limiter = newLimiter(t)
+}
+
+// Wait blocks until the throttle's MinDelay, Jitter and RequestsPerMinute
+// allow another request, so other backends can share its pacing
+func (d *Downloader) Wait(ctx context.Context) error {
+	return d.limiter.wait(ctx)
+}
+
+// apply prepends the throttling options to a yt-dlp argument list
+func (t Throttle) apply(args []string) []string {
+	var opts []string
+	if t.SleepRequests > 0 {
+		opts = append(opts, "--sleep-requests", formatSeconds(t.SleepRequests))
+	}
+	if t.SleepInterval > 0 {
+		opts = append(opts, "--sleep-interval", formatSeconds(t.SleepInterval))
+		if t.MaxSleepInterval > t.SleepInterval {
+			opts = append(opts, "--max-sleep-interval", formatSeconds(t.MaxSleepInterval))
+		}
+	}
+	if t.Retries > 0 {
+		retries := strconv.Itoa(t.Retries)
+		opts = append(opts, "--retries", retries, "--extractor-retries", retries)
+	}
+	if t.Impersonate != "" {
+		opts = append(opts, "--impersonate", t.Impersonate)
+	}
+	return append(opts, args...)
+}
+
+// Cookies logs yt-dlp in for private and login-gated reels
+type Cookies struct {
+	File        string // Netscape-format cookies.txt
+	FromBrowser string // browser to read cookies from, e.g. "firefox" or "chrome:Profile 1"
+}
+
+// SetCookies applies c to every subsequent request
+func (d *Downloader) SetCookies(c Cookies) {
+	d.cookies = c
+}
+
+// apply prepends the cookie options to a yt-dlp argument list
+func (c Cookies) apply(args []string) []string {
+	var opts []string
+	if c.File != "" {
+		opts = append(opts, "--cookies", c.File)
+	}
+	if c.FromBrowser != "" {
+		opts = append(opts, "--cookies-from-browser", c.FromBrowser)
+	}
+	return append(opts, args...)
+}
+
+// ConfigFiles selects the configuration files yt-dlp reads. The zero value
+// leaves yt-dlp's own lookup in place, which includes the user's
+// ~/.config/yt-dlp/config.
+type ConfigFiles struct {
+	Ignore bool   // skip the system and user config files
+	Path   string // a config file to read instead; only used with Ignore
+}
+
+// SetConfigFiles applies c to every subsequent request
+func (d *Downloader) SetConfigFiles(c ConfigFiles) {
+	d.configs = c
+}
+
+// apply prepends the config file options to a yt-dlp argument list
+func (c ConfigFiles) apply(args []string) []string {
+	if !c.Ignore {
+		return args
+	}
+	opts := []string{"--ignore-config"}
+	if c.Path != "" {
+		opts = append(opts, "--config-locations", c.Path)
+	}
+	return append(opts, args...)
+}
+
+// SetProxy routes yt-dlp's requests and binary downloads through proxy, an
+// http, https or socks5 URL, using client for the downloads
+func (d *Downloader) SetProxy(proxy string, client *http.Client) {
+	d.proxy = proxy
+	d.client = client
+}
+
+// httpClient returns the client for binary downloads
+func (d *Downloader) httpClient() *http.Client {
+	if d.client != nil {
+		return d.client
+	}
+	return http.DefaultClient
+}
+
+// SetExtraArgs adds args to the end of every subsequent request, after
+// ig2insights' own options, so they can override them
+func (d *Downloader) SetExtraArgs(args []string) {
+	d.extraArgs = args
+}
+
+// SetMirror downloads yt-dlp from baseURL instead of GitHub's latest
+// release. It must hold the release's files, like yt-dlp and yt-dlp.exe.
+func (d *Downloader) SetMirror(baseURL string) {
+	d.mirror = baseURL
+}
+
+// SetBinDir installs and looks for downloaded binaries in dir instead of
+// config.BinDir
+func (d *Downloader) SetBinDir(dir string) {
+	d.binDir = dir
+}
+
+// BinDir returns the directory downloaded binaries are installed in
+func (d *Downloader) BinDir() string {
+	if d.binDir != "" {
+		return d.binDir
+	}
+	return config.BinDir()
+}
+
+// requestArgs adds the config file, throttling, cookie, proxy and aria2c
+// options and any extra arguments to a yt-dlp request
+func (d *Downloader) requestArgs(args []string) []string {
+	args = d.configs.apply(d.cookies.apply(d.throttle.apply(args)))
+	if d.proxy != "" {
+		args = append([]string{"--proxy", d.proxy}, args...)
+	}
+	args = append(d.aria2cOptions(), args...)
+	return append(args, d.extraArgs...)
+}
+
+// requestCommand builds a yt-dlp request to Instagram once the rate limiter
+// allows it, failing when ctx is cancelled while waiting
+func (d *Downloader) requestCommand(ctx context.Context, binPath string, args []string) (*exec.Cmd, error) {
+	if err := d.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, binPath, d.requestArgs(args)...), nil
+}
+
+// formatSeconds renders d in the fractional seconds yt-dlp expects
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
 // executableName appends .exe suffix on Windows
 func executableName(name string) string {
 	if runtime.GOOS == "windows" {
@@ -59,14 +239,58 @@ func ffprobeBinaryName() string {
 	return executableName("ffprobe")
 }
 
+// buildReelURL returns the URL of reelID's post; carousel slides share theirs
 func buildReelURL(reelID string) string {
-	return fmt.Sprintf(instagramReelURLFormat, reelID)
+	if videoID, ok := domain.ParseYouTubeID(reelID); ok {
+		return domain.YouTubeShortURL(videoID)
+	}
+	shortcode, _ := domain.ParseSlideID(reelID)
+	return fmt.Sprintf(instagramReelURLFormat, shortcode)
+}
+
+// RegisterURL makes reelID, an ID from domain.GenericID, download from
+// rawURL. yt-dlp is handed the URL as-is.
+func (d *Downloader) RegisterURL(reelID, rawURL string) {
+	d.urls.Store(reelID, rawURL)
+}
+
+// reelURL returns the URL yt-dlp fetches reelID from. Generic IDs need their
+// URL registered first, since it can't be rebuilt from the ID.
+func (d *Downloader) reelURL(reelID string) (string, error) {
+	if u, ok := d.urls.Load(reelID); ok {
+		return u.(string), nil
+	}
+	if domain.IsGenericID(reelID) {
+		return "", fmt.Errorf("%w: %s has no known URL; pass the original URL with --any-url", domain.ErrInvalidReelInput, reelID)
+	}
+	return buildReelURL(reelID), nil
+}
+
+// slideArgs narrows yt-dlp to one video of a carousel post for slide IDs
+func slideArgs(reelID string) []string {
+	if _, slide := domain.ParseSlideID(reelID); slide > 0 {
+		return []string{"--playlist-items", strconv.Itoa(slide)}
+	}
+	return nil
 }
 
 func buildReelsURL(username string) string {
 	return fmt.Sprintf(instagramReelsURLFormat, username)
 }
 
+// networkFailureMessages are yt-dlp errors from connections that failed or
+// timed out, which are worth retrying
+var networkFailureMessages = []string{
+	"timed out",
+	"Connection reset",
+	"Connection refused",
+	"Temporary failure in name resolution",
+	"Network is unreachable",
+	"HTTP Error 502",
+	"HTTP Error 503",
+	"HTTP Error 504",
+}
+
 // detectYtdlpError converts yt-dlp stderr messages to domain errors
 func detectYtdlpError(err error) error {
 	var exitErr *exec.ExitError
@@ -85,6 +309,11 @@ func detectYtdlpError(err error) error {
 	if strings.Contains(stderr, "rate") || strings.Contains(stderr, "429") {
 		return domain.ErrRateLimited
 	}
+	for _, msg := range networkFailureMessages {
+		if strings.Contains(stderr, msg) {
+			return domain.ErrNetworkFailure
+		}
+	}
 	if strings.Contains(stderr, "Unable to extract data") || strings.Contains(stderr, "Unsupported URL") {
 		return domain.ErrInstagramScrapingBlocked
 	}
@@ -94,7 +323,7 @@ func detectYtdlpError(err error) error {
 
 func (d *Downloader) findBinary() string {
 	// Check bundled location first
-	bundled := filepath.Join(config.BinDir(), binaryName())
+	bundled := filepath.Join(d.BinDir(), binaryName())
 	if _, err := os.Stat(bundled); err == nil {
 		return bundled
 	}
@@ -126,7 +355,7 @@ func (d *Downloader) findFFmpeg() string {
 	}
 
 	// Check bundled location
-	bundled := filepath.Join(config.BinDir(), ffmpegBinaryName())
+	bundled := filepath.Join(d.BinDir(), ffmpegBinaryName())
 	if _, err := os.Stat(bundled); err == nil {
 		return bundled
 	}
@@ -165,6 +394,13 @@ func (d *Downloader) FFmpegInstructions() string {
 }
 
 func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	if path, ok, err := d.localFile(reelID); ok {
+		if err != nil {
+			return nil, err
+		}
+		return d.localAudio(ctx, path, destDir)
+	}
+
 	binPath := d.GetBinaryPath()
 	if binPath == "" {
 		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
@@ -179,7 +415,10 @@ func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir s
 		return nil, fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	url := buildReelURL(reelID)
+	url, err := d.reelURL(reelID)
+	if err != nil {
+		return nil, err
+	}
 	outputTemplate := filepath.Join(destDir, "audio.%(ext)s")
 
 	// Run yt-dlp with JSON output for metadata
@@ -191,8 +430,12 @@ func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir s
 		"-o", outputTemplate,
 		url,
 	}
+	args = append(slideArgs(reelID), args...)
 
-	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
 	output, err := cmd.Output()
 	if err != nil {
 		if domainErr := detectYtdlpError(err); domainErr != nil {
@@ -209,6 +452,7 @@ func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir s
 	var info struct {
 		ID                 string  `json:"id"`
 		Title              string  `json:"title"`
+		Description        string  `json:"description"`
 		Uploader           string  `json:"uploader"`
 		Duration           float64 `json:"duration"`
 		ViewCount          int64   `json:"view_count"`
@@ -255,6 +499,7 @@ func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir s
 			URL:             url,
 			Author:          info.Uploader,
 			Title:           info.Title,
+			Caption:         info.Description,
 			DurationSeconds: int(info.Duration),
 			ViewCount:       info.ViewCount,
 			FetchedAt:       time.Now(),
@@ -262,8 +507,38 @@ func (d *Downloader) DownloadAudio(ctx context.Context, reelID string, destDir s
 	}, nil
 }
 
+// videoArgs builds a video download of url into destPath. Partial files
+// are kept beside destPath and picked up again by the next attempt, even if
+// the user's yt-dlp config says otherwise, so an interrupted download
+// resumes instead of restarting.
+func videoArgs(url, destPath, quality string) []string {
+	return []string{
+		"--no-warnings",
+		"--continue",
+		"--part",
+		"-f", formatSelector(quality),
+		"--merge-output-format", "mp4",
+		"-o", destPath,
+		url,
+	}
+}
+
+// formatSelector returns the yt-dlp format selector for a video quality:
+// separate video and audio streams merged, else a single stream. Capped
+// heights fall back to the smallest version when none fits.
+func formatSelector(quality string) string {
+	switch quality {
+	case domain.VideoQualityWorst:
+		return "wv*+wa/w"
+	}
+	if height := domain.VideoQualityHeight(quality); height > 0 {
+		return fmt.Sprintf("bv*[height<=%[1]d]+ba/b[height<=%[1]d]/wv*+ba/w", height)
+	}
+	return "bv*+ba/b"
+}
+
 func (d *Downloader) Install(ctx context.Context, progress func(downloaded, total int64)) error {
-	binDir := config.BinDir()
+	binDir := d.BinDir()
 	if err := os.MkdirAll(binDir, 0755); err != nil {
 		return err
 	}
@@ -275,7 +550,7 @@ func (d *Downloader) Install(ctx context.Context, progress func(downloaded, tota
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := d.httpClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download yt-dlp: %w", err)
 	}
@@ -314,16 +589,36 @@ func (d *Downloader) Install(ctx context.Context, progress func(downloaded, tota
 }
 
 func (d *Downloader) getDownloadURL() string {
+	base := ytdlpDownloadBase
+	if d.mirror != "" {
+		base = strings.TrimSuffix(d.mirror, "/") + "/"
+	}
 	switch runtime.GOOS {
 	case "windows":
-		return ytdlpDownloadBase + "yt-dlp.exe"
+		return base + "yt-dlp.exe"
 	case "darwin":
-		return ytdlpDownloadBase + "yt-dlp_macos"
+		return base + "yt-dlp_macos"
 	default:
-		return ytdlpDownloadBase + "yt-dlp"
+		return base + "yt-dlp"
 	}
 }
 
+// Version returns the installed yt-dlp version, or "unknown" if it can't be determined
+func (d *Downloader) Version(ctx context.Context) string {
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return "unknown"
+	}
+	out, err := exec.CommandContext(ctx, binPath, "--version").Output()
+	if err != nil {
+		return "unknown"
+	}
+	if v := strings.TrimSpace(string(out)); v != "" {
+		return v
+	}
+	return "unknown"
+}
+
 func (d *Downloader) Update(ctx context.Context) error {
 	binPath := d.GetBinaryPath()
 	if binPath == "" {
@@ -349,7 +644,10 @@ func (d *Downloader) GetAccount(ctx context.Context, username string) (*domain.A
 		url,
 	}
 
-	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
 	output, err := cmd.Output()
 	if err != nil {
 		if domainErr := detectYtdlpError(err); domainErr != nil {
@@ -388,7 +686,10 @@ func (d *Downloader) ListReels(ctx context.Context, username string, sortOrder d
 		url,
 	}
 
-	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
 	output, err := cmd.Output()
 	if err != nil {
 		if domainErr := detectYtdlpError(err); domainErr != nil {
@@ -406,10 +707,163 @@ func (d *Downloader) ListReels(ctx context.Context, username string, sortOrder d
 	return reels, nil
 }
 
+func (d *Downloader) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	if path, ok, err := d.localFile(reelID); ok {
+		if err != nil {
+			return nil, err
+		}
+		return d.localReel(ctx, path)
+	}
+
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
+	}
+
+	url, err := d.reelURL(reelID)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{
+		"--no-warnings",
+		"--skip-download",
+		"--dump-json",
+		url,
+	}
+	args = append(slideArgs(reelID), args...)
+
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		if domainErr := detectYtdlpError(err); domainErr != nil {
+			return nil, domainErr
+		}
+		return nil, fmt.Errorf("failed to fetch reel: %w", err)
+	}
+
+	reels := parseReelsFromOutput(output)
+	if len(reels) == 0 {
+		return nil, domain.ErrReelNotFound
+	}
+
+	reel := reels[0]
+	reel.ID = reelID // yt-dlp's IDs differ for stories, slides and YouTube videos
+	reel.URL = url
+	return reel, nil
+}
+
+// ListPlaylist returns the videos of a YouTube playlist or channel tab,
+// without fetching each video's page
+func (d *Downloader) ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error) {
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
+	}
+
+	args := []string{
+		"--no-warnings",
+		"--flat-playlist",
+		"--dump-json",
+		playlistURL,
+	}
+
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		if domainErr := detectYtdlpError(err); domainErr != nil {
+			return nil, domainErr
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to list playlist: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to list playlist: %w", err)
+	}
+	return parsePlaylist(output), nil
+}
+
+// parsePlaylist turns yt-dlp's flat playlist entries into reels, skipping
+// entries that aren't videos, such as a channel's nested playlists
+func parsePlaylist(output []byte) []*domain.Reel {
+	var reels []*domain.Reel
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var info reelInfo
+		if err := json.Unmarshal([]byte(line), &info); err != nil {
+			continue
+		}
+		id := domain.YouTubeID(info.ID)
+		if _, ok := domain.ParseYouTubeID(id); !ok {
+			continue
+		}
+		reel := reelFromInfo(info)
+		reel.ID = id
+		reel.URL = domain.YouTubeShortURL(info.ID)
+		reels = append(reels, reel)
+	}
+	return reels
+}
+
+// ListSlides returns the videos of a carousel post, or nil for a single
+// video. Photo slides have no video and are skipped, so slide numbers have
+// gaps where they were.
+func (d *Downloader) ListSlides(ctx context.Context, shortcode string) ([]*domain.Reel, error) {
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
+	}
+
+	url := buildReelURL(shortcode)
+	args := []string{
+		"--no-warnings",
+		"--skip-download",
+		"--dump-json",
+		"--ignore-errors",
+		url,
+	}
+
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
+	output, err := cmd.Output()
+	// --ignore-errors still exits non-zero after skipping photo slides
+	if err != nil && len(bytes.TrimSpace(output)) == 0 {
+		if domainErr := detectYtdlpError(err); domainErr != nil {
+			return nil, domainErr
+		}
+		return nil, fmt.Errorf("failed to list carousel: %w", err)
+	}
+	return parseSlides(shortcode, url, output), nil
+}
+
+// parseSlides turns yt-dlp's entries for a post into carousel slides. Only
+// carousel entries carry a playlist_index.
+func parseSlides(shortcode, url string, output []byte) []*domain.Reel {
+	var slides []*domain.Reel
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		var info reelInfo
+		if err := json.Unmarshal([]byte(line), &info); err != nil || info.PlaylistIndex == 0 {
+			continue
+		}
+		slide := reelFromInfo(info)
+		slide.ID = domain.SlideID(shortcode, info.PlaylistIndex)
+		slide.URL = url
+		slides = append(slides, slide)
+	}
+	return slides
+}
+
 // reelInfo represents the JSON structure returned by yt-dlp for a reel
 type reelInfo struct {
 	ID           string  `json:"id"`
 	Title        string  `json:"title"`
+	Description  string  `json:"description"`
 	Uploader     string  `json:"uploader"`
 	Duration     float64 `json:"duration"`
 	ViewCount    int64   `json:"view_count"`
@@ -417,6 +871,8 @@ type reelInfo struct {
 	CommentCount int64   `json:"comment_count"`
 	UploadDate   string  `json:"upload_date"` // YYYYMMDD format
 	Timestamp    int64   `json:"timestamp"`   // Unix timestamp
+
+	PlaylistIndex int `json:"playlist_index"` // position in a carousel post
 }
 
 func parseReelsFromOutput(output []byte) []*domain.Reel {
@@ -433,22 +889,27 @@ func parseReelsFromOutput(output []byte) []*domain.Reel {
 			continue
 		}
 
-		reels = append(reels, &domain.Reel{
-			ID:              info.ID,
-			Author:          info.Uploader,
-			Title:           info.Title,
-			DurationSeconds: int(info.Duration),
-			ViewCount:       info.ViewCount,
-			LikeCount:       info.LikeCount,
-			CommentCount:    info.CommentCount,
-			UploadedAt:      parseUploadTime(info.Timestamp, info.UploadDate),
-			FetchedAt:       time.Now(),
-		})
+		reels = append(reels, reelFromInfo(info))
 	}
 
 	return reels
 }
 
+func reelFromInfo(info reelInfo) *domain.Reel {
+	return &domain.Reel{
+		ID:              info.ID,
+		Author:          info.Uploader,
+		Title:           info.Title,
+		Caption:         info.Description,
+		DurationSeconds: int(info.Duration),
+		ViewCount:       info.ViewCount,
+		LikeCount:       info.LikeCount,
+		CommentCount:    info.CommentCount,
+		UploadedAt:      parseUploadTime(info.Timestamp, info.UploadDate),
+		FetchedAt:       time.Now(),
+	}
+}
+
 func parseUploadTime(timestamp int64, uploadDate string) time.Time {
 	if timestamp > 0 {
 		return time.Unix(timestamp, 0)
@@ -505,7 +966,7 @@ func (d *Downloader) InstallFFmpeg(ctx context.Context, progress func(downloaded
 		return fmt.Errorf("no prebuilt ffmpeg binary for %s.\n%s", runtime.GOOS, d.FFmpegInstructions())
 	}
 
-	binDir := config.BinDir()
+	binDir := d.BinDir()
 	if err := os.MkdirAll(binDir, 0755); err != nil {
 		return err
 	}
@@ -515,7 +976,7 @@ func (d *Downloader) InstallFFmpeg(ctx context.Context, progress func(downloaded
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := d.httpClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download ffmpeg: %w", err)
 	}
@@ -622,12 +1083,22 @@ func (d *Downloader) extractFileFrom7z(f *sevenzip.File, destPath string) error
 }
 
 func (d *Downloader) DownloadThumbnail(ctx context.Context, reelID string, destPath string) error {
+	if path, ok, err := d.localFile(reelID); ok {
+		if err != nil {
+			return err
+		}
+		return d.localThumbnail(ctx, path, destPath)
+	}
+
 	binPath := d.GetBinaryPath()
 	if binPath == "" {
 		return fmt.Errorf("yt-dlp not found")
 	}
 
-	url := buildReelURL(reelID)
+	url, err := d.reelURL(reelID)
+	if err != nil {
+		return err
+	}
 
 	// Download thumbnail only
 	args := []string{
@@ -638,8 +1109,12 @@ func (d *Downloader) DownloadThumbnail(ctx context.Context, reelID string, destP
 		"-o", strings.TrimSuffix(destPath, filepath.Ext(destPath)),
 		url,
 	}
+	args = append(slideArgs(reelID), args...)
 
-	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return err
+	}
 	if err := cmd.Run(); err != nil {
 		if domainErr := detectYtdlpError(err); domainErr != nil {
 			return domainErr
@@ -669,6 +1144,19 @@ func (d *Downloader) DownloadThumbnail(ctx context.Context, reelID string, destP
 }
 
 func (d *Downloader) DownloadVideo(ctx context.Context, reelID string, destPath string) error {
+	return d.DownloadVideoQuality(ctx, reelID, destPath, domain.VideoQualityBest)
+}
+
+// DownloadVideoQuality downloads the video at quality, merged into an MP4.
+// Local files are copied as they are, whatever the quality.
+func (d *Downloader) DownloadVideoQuality(ctx context.Context, reelID string, destPath string, quality string) error {
+	if path, ok, err := d.localFile(reelID); ok {
+		if err != nil {
+			return err
+		}
+		return d.localVideo(ctx, path, destPath)
+	}
+
 	binPath := d.GetBinaryPath()
 	if binPath == "" {
 		return fmt.Errorf("yt-dlp not found")
@@ -679,19 +1167,20 @@ func (d *Downloader) DownloadVideo(ctx context.Context, reelID string, destPath
 		return domain.ErrFFmpegNotFound
 	}
 
-	url := buildReelURL(reelID)
-
-	// Download best video+audio combined, fallback to best single stream
-	args := []string{
-		"--no-warnings",
-		"-f", "bv*+ba/b",
-		"--merge-output-format", "mp4",
-		"-o", destPath,
-		url,
+	url, err := d.reelURL(reelID)
+	if err != nil {
+		return err
 	}
 
-	cmd := exec.CommandContext(ctx, binPath, args...)
-	if err := cmd.Run(); err != nil {
+	args := append(slideArgs(reelID), videoArgs(url, destPath, quality)...)
+
+	// Output, not Run, so stderr is kept to tell network failures apart;
+	// retrying those resumes the partial download
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return err
+	}
+	if _, err := cmd.Output(); err != nil {
 		if domainErr := detectYtdlpError(err); domainErr != nil {
 			return domainErr
 		}
@@ -708,3 +1197,4 @@ func (d *Downloader) DownloadVideo(ctx context.Context, reelID string, destPath
 // Ensure Downloader implements interfaces
 var _ ports.VideoDownloader = (*Downloader)(nil)
 var _ ports.AccountFetcher = (*Downloader)(nil)
+var _ ports.VideoQualityDownloader = (*Downloader)(nil)
@@ -2,11 +2,15 @@ package ytdlp
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/devbush/ig2insights/internal/domain"
 )
@@ -32,6 +36,106 @@ func TestBuildReelURL(t *testing.T) {
 	if url != expected {
 		t.Errorf("buildReelURL() = %s, want %s", url, expected)
 	}
+
+	if url := buildReelURL("DToLsd-EvGJ.2"); url != expected {
+		t.Errorf("buildReelURL() for a slide = %s, want the post URL %s", url, expected)
+	}
+}
+
+func TestBuildReelURL_YouTube(t *testing.T) {
+	if url := buildReelURL("yt.dQw4w9WgXcQ"); url != "https://www.youtube.com/shorts/dQw4w9WgXcQ" {
+		t.Errorf("buildReelURL() = %s, want the Shorts URL", url)
+	}
+}
+
+func TestReelURL_Registered(t *testing.T) {
+	d := NewDownloader()
+	vimeo := "https://vimeo.com/76979871"
+	id := domain.GenericID(vimeo)
+
+	if _, err := d.reelURL(id); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Errorf("reelURL() for an unregistered URL error = %v, want ErrInvalidReelInput", err)
+	}
+
+	d.RegisterURL(id, vimeo)
+	if url, err := d.reelURL(id); err != nil || url != vimeo {
+		t.Errorf("reelURL() = %s, %v; want %s verbatim", url, err, vimeo)
+	}
+	if url, err := d.reelURL("DToLsd-EvGJ"); err != nil || url != buildReelURL("DToLsd-EvGJ") {
+		t.Errorf("reelURL() for a shortcode = %s, %v", url, err)
+	}
+}
+
+func TestDownloader_LocalFile(t *testing.T) {
+	d := NewDownloader()
+	ctx := context.Background()
+	id := domain.LocalFileID("/home/me/talk.mp3", 1024, time.Time{})
+
+	if _, err := d.GetReel(ctx, id); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Errorf("GetReel() of an unregistered file error = %v, want ErrInvalidReelInput", err)
+	}
+
+	d.RegisterFile(id, "/home/me/talk.mp3")
+	if path, ok, err := d.localFile(id); path != "/home/me/talk.mp3" || !ok || err != nil {
+		t.Errorf("localFile() = %s, %v, %v", path, ok, err)
+	}
+	if _, ok, _ := d.localFile("DToLsd-EvGJ"); ok {
+		t.Error("localFile() should leave shortcodes to yt-dlp")
+	}
+	if err := d.DownloadVideo(ctx, id, filepath.Join(t.TempDir(), "video.mp4")); !errors.Is(err, errNoLocalVideo) {
+		t.Errorf("DownloadVideo() of an audio file error = %v, want errNoLocalVideo", err)
+	}
+	if result, err := d.DownloadSubtitles(ctx, id, t.TempDir(), "auto"); result != nil || err != nil {
+		t.Errorf("DownloadSubtitles() = %v, %v; want none for a local file", result, err)
+	}
+}
+
+func TestParsePlaylist(t *testing.T) {
+	output := `{"id": "dQw4w9WgXcQ", "title": "First", "description": "Link in bio #shorts", "view_count": 1200}
+{"id": "UUxyz", "title": "Nested playlist"}
+{"id": "abcdefghijk", "title": "Second", "duration": 42}
+`
+	reels := parsePlaylist([]byte(output))
+	if len(reels) != 2 {
+		t.Fatalf("got %d videos, want 2 with the nested playlist skipped", len(reels))
+	}
+	if reels[0].ID != "yt.dQw4w9WgXcQ" || reels[0].URL != "https://www.youtube.com/shorts/dQw4w9WgXcQ" || reels[0].ViewCount != 1200 || reels[0].Caption != "Link in bio #shorts" {
+		t.Errorf("first video = %+v", reels[0])
+	}
+	if reels[1].ID != "yt.abcdefghijk" || reels[1].DurationSeconds != 42 {
+		t.Errorf("second video = %+v", reels[1])
+	}
+}
+
+func TestSlideArgs(t *testing.T) {
+	if args := slideArgs("DToLsd-EvGJ"); args != nil {
+		t.Errorf("slideArgs() = %v, want none for a single video", args)
+	}
+	if got := strings.Join(slideArgs("DToLsd-EvGJ.2"), " "); got != "--playlist-items 2" {
+		t.Errorf("slideArgs() = %q, want --playlist-items 2", got)
+	}
+}
+
+func TestParseSlides(t *testing.T) {
+	url := buildReelURL("POST1")
+	output := `{"id": "v1", "uploader": "chef", "duration": 12.5, "playlist_index": 1}
+{"id": "v3", "uploader": "chef", "duration": 30, "playlist_index": 3}
+`
+	slides := parseSlides("POST1", url, []byte(output))
+	if len(slides) != 2 {
+		t.Fatalf("got %d slides, want 2", len(slides))
+	}
+	if slides[0].ID != "POST1.1" || slides[1].ID != "POST1.3" {
+		t.Errorf("slide IDs = %s, %s, want POST1.1, POST1.3", slides[0].ID, slides[1].ID)
+	}
+	if slides[1].URL != url || slides[1].Author != "chef" || slides[1].DurationSeconds != 30 {
+		t.Errorf("slide = %+v", slides[1])
+	}
+
+	single := `{"id": "POST1", "uploader": "chef", "duration": 12.5}`
+	if slides := parseSlides("POST1", url, []byte(single)); slides != nil {
+		t.Errorf("parseSlides() = %v, want nil for a single video", slides)
+	}
 }
 
 func TestNewDownloader(t *testing.T) {
@@ -197,6 +301,9 @@ func TestSortByViews(t *testing.T) {
 	})
 }
 
+// errYtdlpNotFound is the error returned by account operations when yt-dlp is missing
+const errYtdlpNotFound = "yt-dlp not found; run 'ig2insights deps install'"
+
 func TestGetAccount_NoBinary(t *testing.T) {
 	d := &Downloader{binPath: ""}
 
@@ -221,8 +328,8 @@ func TestGetAccount_NoBinary(t *testing.T) {
 			if err == nil {
 				t.Error("GetAccount() expected error when binary not found")
 			}
-			if err.Error() != "yt-dlp not found" {
-				t.Errorf("GetAccount() error = %q, want %q", err.Error(), "yt-dlp not found")
+			if err.Error() != errYtdlpNotFound {
+				t.Errorf("GetAccount() error = %q, want %q", err.Error(), errYtdlpNotFound)
 			}
 		}
 	})
@@ -238,8 +345,25 @@ func TestListReels_NoBinary(t *testing.T) {
 			if err == nil {
 				t.Error("ListReels() expected error when binary not found")
 			}
-			if err.Error() != "yt-dlp not found" {
-				t.Errorf("ListReels() error = %q, want %q", err.Error(), "yt-dlp not found")
+			if err.Error() != errYtdlpNotFound {
+				t.Errorf("ListReels() error = %q, want %q", err.Error(), errYtdlpNotFound)
+			}
+		}
+	})
+}
+
+func TestGetReel_NoBinary(t *testing.T) {
+	t.Run("returns error when binary not found", func(t *testing.T) {
+		testDownloader := &Downloader{}
+
+		// Only run this assertion if yt-dlp is not actually installed
+		if testDownloader.GetBinaryPath() == "" {
+			_, err := testDownloader.GetReel(nil, "ABC123")
+			if err == nil {
+				t.Fatal("GetReel() expected error when binary not found")
+			}
+			if err.Error() != errYtdlpNotFound {
+				t.Errorf("GetReel() error = %q, want %q", err.Error(), errYtdlpNotFound)
 			}
 		}
 	})
@@ -254,6 +378,7 @@ func TestAccountFetcherInterface(t *testing.T) {
 		// If this compiles, the interface is satisfied
 		_ = d.GetAccount
 		_ = d.ListReels
+		_ = d.GetReel
 	})
 }
 
@@ -300,6 +425,18 @@ func TestGetFFmpegPath_Caching(t *testing.T) {
 	}
 }
 
+func TestGetDownloadURL_Mirror(t *testing.T) {
+	d := NewDownloader()
+	if url := d.getDownloadURL(); !strings.HasPrefix(url, ytdlpDownloadBase) {
+		t.Errorf("getDownloadURL() = %q, want GitHub's latest release", url)
+	}
+
+	d.SetMirror("https://mirror.example.com/yt-dlp")
+	if url := d.getDownloadURL(); !strings.HasPrefix(url, "https://mirror.example.com/yt-dlp/yt-dlp") {
+		t.Errorf("getDownloadURL() = %q, want the mirror", url)
+	}
+}
+
 func TestGetFFmpegDownloadURL(t *testing.T) {
 	d := NewDownloader()
 	url := d.getFFmpegDownloadURL()
@@ -365,3 +502,145 @@ func TestInstallFFmpeg_NonWindows(t *testing.T) {
 		t.Errorf("InstallFFmpeg() error should mention 'no prebuilt', got: %v", err)
 	}
 }
+
+func TestThrottle_Apply(t *testing.T) {
+	base := []string{"--no-warnings", "URL"}
+
+	if got := (Throttle{}).apply(base); strings.Join(got, " ") != "--no-warnings URL" {
+		t.Errorf("zero Throttle.apply() = %v, want args unchanged", got)
+	}
+
+	throttle := Throttle{
+		SleepRequests:    1500 * time.Millisecond,
+		SleepInterval:    2 * time.Second,
+		MaxSleepInterval: 8 * time.Second,
+		Retries:          5,
+		Impersonate:      "chrome",
+	}
+	got := strings.Join(throttle.apply(base), " ")
+	want := "--sleep-requests 1.5 --sleep-interval 2 --max-sleep-interval 8 --retries 5 --extractor-retries 5 --impersonate chrome --no-warnings URL"
+	if got != want {
+		t.Errorf("Throttle.apply() = %q, want %q", got, want)
+	}
+
+	// A max interval not above the minimum is dropped
+	throttle = Throttle{SleepInterval: 3 * time.Second, MaxSleepInterval: time.Second}
+	if got := strings.Join(throttle.apply(nil), " "); got != "--sleep-interval 3" {
+		t.Errorf("Throttle.apply() = %q, want only --sleep-interval", got)
+	}
+}
+
+func TestDownloader_RequestArgs(t *testing.T) {
+	d := NewDownloader()
+	base := []string{"--no-warnings", "URL"}
+
+	if got := strings.Join(d.requestArgs(base), " "); got != "--no-warnings URL" {
+		t.Errorf("requestArgs() = %q, want args unchanged", got)
+	}
+
+	d.SetThrottle(Throttle{Retries: 2})
+	d.SetCookies(Cookies{File: "/home/me/cookies.txt", FromBrowser: "firefox"})
+	got := strings.Join(d.requestArgs(base), " ")
+	want := "--cookies /home/me/cookies.txt --cookies-from-browser firefox --retries 2 --extractor-retries 2 --no-warnings URL"
+	if got != want {
+		t.Errorf("requestArgs() = %q, want %q", got, want)
+	}
+	d.SetProxy("socks5://127.0.0.1:1080", nil)
+	if got := d.requestArgs(base); got[0] != "--proxy" || got[1] != "socks5://127.0.0.1:1080" {
+		t.Errorf("requestArgs() = %q, want --proxy first", got)
+	}
+
+	d.SetConfigFiles(ConfigFiles{Ignore: true, Path: "/home/me/.ig2insights/yt-dlp.conf"})
+	got = strings.Join(d.requestArgs(base), " ")
+	if !strings.Contains(got, "--ignore-config --config-locations /home/me/.ig2insights/yt-dlp.conf --cookies") {
+		t.Errorf("requestArgs() = %q, want the config file options", got)
+	}
+	d.SetConfigFiles(ConfigFiles{Ignore: true})
+	if got := d.requestArgs(base); !reflect.DeepEqual(got[2:4], []string{"--ignore-config", "--cookies"}) {
+		t.Errorf("requestArgs() = %q, want --ignore-config alone", got)
+	}
+
+	d.SetExtraArgs([]string{"--extractor-args", "instagram:api=1"})
+	got = strings.Join(d.requestArgs(base), " ")
+	if !strings.HasSuffix(got, "--no-warnings URL --extractor-args instagram:api=1") {
+		t.Errorf("requestArgs() = %q, want extra args last", got)
+	}
+
+	d.aria2cPath = "/usr/bin/aria2c"
+	if got := d.requestArgs(base); got[0] == "--downloader" {
+		t.Errorf("requestArgs() = %q, want no aria2c until enabled", got)
+	}
+	d.SetAria2c(true)
+	got = strings.Join(d.requestArgs(base), " ")
+	if !strings.HasPrefix(got, "--downloader /usr/bin/aria2c --downloader-args aria2c:-x 16 -s 16 -k 1M --proxy") {
+		t.Errorf("requestArgs() = %q, want the aria2c options first", got)
+	}
+}
+
+func TestDownloader_RequestCommand(t *testing.T) {
+	d := NewDownloader()
+	d.SetThrottle(Throttle{Retries: 1})
+
+	cmd, err := d.requestCommand(context.Background(), "/usr/bin/yt-dlp", []string{"URL"})
+	if err != nil {
+		t.Fatalf("requestCommand() error = %v", err)
+	}
+	want := []string{"/usr/bin/yt-dlp", "--retries", "1", "--extractor-retries", "1", "URL"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("requestCommand() args = %q, want %q", cmd.Args, want)
+	}
+
+	// A request cancelled while waiting for the limiter isn't started
+	d.SetThrottle(Throttle{MinDelay: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.requestCommand(ctx, "/usr/bin/yt-dlp", []string{"URL"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("requestCommand() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	output := `{"id": "POST1", "comments": [
+		{"id": "1", "text": "Great recipe", "author": "ana", "like_count": 3, "timestamp": 1741060800, "parent": "root"},
+		{"id": "2", "text": "Thanks!", "author": "chef", "like_count": 12, "parent": "1"},
+		{"id": "3", "text": "Where's the link?", "author": "bo", "like_count": null}
+	]}`
+	var info struct {
+		Comments []commentInfo `json:"comments"`
+	}
+	if err := json.Unmarshal([]byte(output), &info); err != nil {
+		t.Fatal(err)
+	}
+
+	comments := domain.TopComments(parseComments(info.Comments), 2)
+	if len(comments) != 2 || comments[0].ID != "2" || comments[1].ID != "1" {
+		t.Fatalf("comments = %+v, want 2 then 1", comments)
+	}
+	if comments[0].ReplyTo != "1" || comments[1].ReplyTo != "" {
+		t.Errorf("ReplyTo = %q, %q; want 1 and none", comments[0].ReplyTo, comments[1].ReplyTo)
+	}
+	if want := time.Unix(1741060800, 0).UTC(); !comments[1].PostedAt.Equal(want) {
+		t.Errorf("PostedAt = %v, want %v", comments[1].PostedAt, want)
+	}
+}
+
+func TestFormatSelector(t *testing.T) {
+	tests := map[string]string{
+		"best":  "bv*+ba/b",
+		"worst": "wv*+wa/w",
+		"720p":  "bv*[height<=720]+ba/b[height<=720]/wv*+ba/w",
+	}
+	for quality, want := range tests {
+		if got := formatSelector(quality); got != want {
+			t.Errorf("formatSelector(%s) = %s, want %s", quality, got, want)
+		}
+	}
+}
+
+func TestVideoArgs(t *testing.T) {
+	got := strings.Join(videoArgs("URL", "/cache/ABC/video.mp4", "best"), " ")
+	want := "--no-warnings --continue --part -f bv*+ba/b --merge-output-format mp4 -o /cache/ABC/video.mp4 URL"
+	if got != want {
+		t.Errorf("videoArgs() = %q, want %q", got, want)
+	}
+}
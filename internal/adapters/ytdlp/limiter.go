@@ -0,0 +1,94 @@
+package ytdlp
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// limiter spaces out yt-dlp invocations across every goroutine sharing a
+// Downloader, so batch workers together stay under Instagram's rate limits.
+// Each caller reserves the next free slot and sleeps until it.
+type limiter struct {
+	minDelay  time.Duration // gap between the starts of two requests
+	jitter    time.Duration // random extra gap, up to this
+	perMinute int           // requests started in any minute; 0 is unlimited
+
+	mu     sync.Mutex
+	last   time.Time
+	recent []time.Time // slots reserved within the last minute, oldest first
+
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	random func(n int64) int64
+}
+
+// newLimiter returns a limiter for t, or nil when t sets no limits
+func newLimiter(t Throttle) *limiter {
+	if t.MinDelay <= 0 && t.Jitter <= 0 && t.RequestsPerMinute <= 0 {
+		return nil
+	}
+	return &limiter{
+		minDelay:  t.MinDelay,
+		jitter:    t.Jitter,
+		perMinute: t.RequestsPerMinute,
+		now:       time.Now,
+		sleep:     sleepContext,
+		random:    rand.Int64N,
+	}
+}
+
+// wait blocks until the caller may start a request. A nil limiter never waits.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	return l.sleep(ctx, l.reserve().Sub(l.now()))
+}
+
+// reserve claims the earliest slot the limits allow
+func (l *limiter) reserve() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	slot := now
+	if !l.last.IsZero() {
+		gap := l.minDelay
+		if l.jitter > 0 {
+			gap += time.Duration(l.random(int64(l.jitter)))
+		}
+		if next := l.last.Add(gap); next.After(slot) {
+			slot = next
+		}
+	}
+
+	if l.perMinute > 0 {
+		for len(l.recent) > 0 && !l.recent[0].After(slot.Add(-time.Minute)) {
+			l.recent = l.recent[1:]
+		}
+		if len(l.recent) >= l.perMinute {
+			slot = l.recent[len(l.recent)-l.perMinute].Add(time.Minute)
+		}
+		l.recent = append(l.recent, slot)
+	}
+
+	l.last = slot
+	return slot
+}
+
+// sleepContext sleeps for d, returning early with the context's error
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,67 @@
+package ytdlp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeLimiter returns a limiter on a fixed clock with no jitter randomness
+func fakeLimiter(t Throttle) (*limiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := newLimiter(t)
+	l.now = func() time.Time { return now }
+	l.random = func(n int64) int64 { return n / 2 }
+	return l, &now
+}
+
+func TestNewLimiter_NoLimits(t *testing.T) {
+	l := newLimiter(Throttle{SleepRequests: time.Second})
+	if l != nil {
+		t.Fatalf("newLimiter() = %+v, want nil without limiter settings", l)
+	}
+	if err := l.wait(context.Background()); err != nil {
+		t.Errorf("nil limiter wait() = %v", err)
+	}
+}
+
+func TestLimiter_MinDelayAndJitter(t *testing.T) {
+	l, now := fakeLimiter(Throttle{MinDelay: 2 * time.Second, Jitter: time.Second})
+
+	if got := l.reserve().Sub(*now); got != 0 {
+		t.Errorf("first request waits %v, want none", got)
+	}
+	if got := l.reserve().Sub(*now); got != 2500*time.Millisecond {
+		t.Errorf("second request waits %v, want 2s plus half the jitter", got)
+	}
+	if got := l.reserve().Sub(*now); got != 5*time.Second {
+		t.Errorf("third request waits %v, want a slot after the second", got)
+	}
+}
+
+func TestLimiter_RequestsPerMinute(t *testing.T) {
+	l, now := fakeLimiter(Throttle{RequestsPerMinute: 2})
+
+	l.reserve()
+	*now = now.Add(10 * time.Second)
+	l.reserve()
+	start := now.Add(-10 * time.Second)
+
+	if got := l.reserve(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("third request at %v, want a minute after the first", got.Sub(start))
+	}
+	if got := l.reserve(); !got.Equal(start.Add(70 * time.Second)) {
+		t.Errorf("fourth request at %v, want a minute after the second", got.Sub(start))
+	}
+}
+
+func TestLimiter_WaitCancelled(t *testing.T) {
+	l := newLimiter(Throttle{MinDelay: time.Hour})
+	l.reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(ctx); err != context.Canceled {
+		t.Errorf("wait() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,117 @@
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// errNoLocalVideo is returned for video and thumbnails of audio-only files
+var errNoLocalVideo = errors.New("the file has no video")
+
+// RegisterFile makes reelID, an ID from domain.LocalFileID, read from the
+// file at path. Local files go straight to ffmpeg; yt-dlp isn't run.
+func (d *Downloader) RegisterFile(reelID, path string) {
+	d.files.Store(reelID, path)
+}
+
+// localFile returns the file registered for reelID, if it's a local file
+func (d *Downloader) localFile(reelID string) (string, bool, error) {
+	if !domain.IsLocalFileID(reelID) {
+		return "", false, nil
+	}
+	if path, ok := d.files.Load(reelID); ok {
+		return path.(string), true, nil
+	}
+	return "", true, fmt.Errorf("%w: %s has no known file; pass the file's path", domain.ErrInvalidReelInput, reelID)
+}
+
+// localReel returns the reel of a local file, with its duration when ffprobe
+// can read it
+func (d *Downloader) localReel(ctx context.Context, path string) (*domain.Reel, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	reel := domain.LocalFileReel(path, info.Size(), info.ModTime())
+	reel.DurationSeconds = int(d.probeDuration(ctx, path))
+	reel.FetchedAt = time.Now()
+	return reel, nil
+}
+
+// probeDuration returns the length of the media file at path in seconds, or
+// 0 when ffprobe isn't installed or can't tell
+func (d *Downloader) probeDuration(ctx context.Context, path string) float64 {
+	ffprobe, err := exec.LookPath(ffprobeBinaryName())
+	if err != nil {
+		ffprobe = filepath.Join(filepath.Dir(d.GetFFmpegPath()), ffprobeBinaryName())
+	}
+	out, err := exec.CommandContext(ctx, ffprobe, "-v", "error", "-show_entries", "format=duration", "-of", "default=noprint_wrappers=1:nokey=1", path).Output()
+	if err != nil {
+		return 0
+	}
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+	if err != nil {
+		return 0
+	}
+	return seconds
+}
+
+// runFFmpeg runs ffmpeg quietly, reporting its error output on failure
+func (d *Downloader) runFFmpeg(ctx context.Context, action string, args ...string) error {
+	if !d.IsFFmpegAvailable() {
+		return domain.ErrFFmpegNotFound
+	}
+	args = append([]string{"-y", "-loglevel", "error"}, args...)
+	if out, err := exec.CommandContext(ctx, d.GetFFmpegPath(), args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("failed to %s: %s", action, msg)
+		}
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return nil
+}
+
+// localAudio converts a local file's soundtrack to 16 kHz mono WAV for whisper
+func (d *Downloader) localAudio(ctx context.Context, path, destDir string) (*ports.DownloadResult, error) {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	audioPath := filepath.Join(destDir, "audio.wav")
+	if err := d.runFFmpeg(ctx, "extract audio", "-i", path, "-vn", "-ac", "1", "-ar", "16000", "-c:a", "pcm_s16le", audioPath); err != nil {
+		return nil, err
+	}
+	reel, err := d.localReel(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.DownloadResult{AudioPath: audioPath, Reel: reel}, nil
+}
+
+// localVideo copies a local video into an MP4 without re-encoding it
+func (d *Downloader) localVideo(ctx context.Context, path, destPath string) error {
+	if !domain.IsLocalVideoPath(path) {
+		return errNoLocalVideo
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	return d.runFFmpeg(ctx, "copy video", "-i", path, "-c", "copy", "-movflags", "+faststart", destPath)
+}
+
+// localThumbnail saves a local video's first frame as its thumbnail
+func (d *Downloader) localThumbnail(ctx context.Context, path, destPath string) error {
+	if !domain.IsLocalVideoPath(path) {
+		return errNoLocalVideo
+	}
+	return d.runFFmpeg(ctx, "extract thumbnail", "-i", path, "-frames:v", "1", "-q:v", "2", destPath)
+}
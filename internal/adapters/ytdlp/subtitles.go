@@ -0,0 +1,237 @@
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// subtitleInfo is the part of yt-dlp's JSON output describing subtitle tracks
+type subtitleInfo struct {
+	reelInfo
+	Subtitles         map[string]json.RawMessage `json:"subtitles"`
+	AutomaticCaptions map[string]json.RawMessage `json:"automatic_captions"`
+	Language          string                     `json:"language"` // spoken language, when known
+}
+
+// origSuffix marks YouTube's untranslated automatic track, e.g. en-orig,
+// among the machine translations of it
+const origSuffix = "-orig"
+
+// DownloadSubtitles fetches the reel's published subtitles without
+// downloading any media
+func (d *Downloader) DownloadSubtitles(ctx context.Context, reelID string, destDir string, language string) (*ports.SubtitleResult, error) {
+	if domain.IsLocalFileID(reelID) {
+		return nil, nil // nothing published alongside a file on disk
+	}
+
+	binPath := d.GetBinaryPath()
+	if binPath == "" {
+		return nil, fmt.Errorf("yt-dlp not found; run 'ig2insights deps install'")
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	// A fresh directory per download keeps subtitles left behind by an
+	// earlier download from being picked up as this one's
+	subDir, err := os.MkdirTemp(destDir, ".subtitles-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subtitles directory: %w", err)
+	}
+	defer os.RemoveAll(subDir)
+
+	url, err := d.reelURL(reelID)
+	if err != nil {
+		return nil, err
+	}
+
+	langs := language
+	if langs == "" || langs == "auto" {
+		// Asking for every track would also fetch YouTube's machine
+		// translations, 100+ per video, so list the tracks first and
+		// request only the creator's and the original automatic one
+		info, err := d.fetchSubtitleInfo(ctx, binPath, reelID, url)
+		if err != nil {
+			return nil, err
+		}
+		langs = strings.Join(subtitleLangs(info), ",")
+		if langs == "" {
+			return nil, nil
+		}
+	}
+
+	info, err := d.fetchSubtitleInfo(ctx, binPath, reelID, url,
+		"--no-simulate",
+		"--write-subs",
+		"--write-auto-subs",
+		"--sub-langs", langs,
+		"--sub-format", "vtt/srt/best",
+		"-o", filepath.Join(subDir, "subtitles.%(ext)s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	path, lang := pickSubtitleFile(subDir, info.Subtitles, info.Language)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subtitles: %w", err)
+	}
+
+	transcript, err := domain.ParseSubtitles(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(transcript.Text) == "" {
+		return nil, nil
+	}
+	transcript.Language = strings.TrimSuffix(lang, origSuffix)
+	transcript.Source = domain.SourceAutoCaptions
+	if _, ok := info.Subtitles[lang]; ok {
+		transcript.Source = domain.SourceSubtitles
+	}
+
+	return &ports.SubtitleResult{
+		Transcript: transcript,
+		Reel: &domain.Reel{
+			ID:              reelID,
+			URL:             url,
+			Author:          info.Uploader,
+			Title:           info.Title,
+			Caption:         info.Description,
+			DurationSeconds: int(info.Duration),
+			ViewCount:       info.ViewCount,
+			LikeCount:       info.LikeCount,
+			CommentCount:    info.CommentCount,
+			UploadedAt:      parseUploadTime(info.Timestamp, info.UploadDate),
+			FetchedAt:       time.Now(),
+		},
+	}, nil
+}
+
+// fetchSubtitleInfo runs yt-dlp for the reel's JSON with args, which download
+// subtitles when given
+func (d *Downloader) fetchSubtitleInfo(ctx context.Context, binPath, reelID, url string, args ...string) (*subtitleInfo, error) {
+	args = append([]string{"--no-warnings", "--skip-download", "--dump-json"}, args...)
+	args = append(append(slideArgs(reelID), args...), url)
+
+	cmd, err := d.requestCommand(ctx, binPath, args)
+	if err != nil {
+		return nil, err
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		if domainErr := detectYtdlpError(err); domainErr != nil {
+			return nil, domainErr
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to fetch subtitles: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to fetch subtitles: %w", err)
+	}
+
+	var info subtitleInfo
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse yt-dlp output: %w", err)
+	}
+	return &info, nil
+}
+
+// subtitleLangs returns the tracks worth downloading: every creator-provided
+// language and the original automatic track, sorted
+func subtitleLangs(info *subtitleInfo) []string {
+	var langs []string
+	for lang := range info.Subtitles {
+		if lang != "live_chat" {
+			langs = append(langs, lang)
+		}
+	}
+	if lang := originalAutoLang(info); lang != "" {
+		if _, ok := info.Subtitles[lang]; !ok {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// originalAutoLang returns the automatic track in the spoken language:
+// YouTube's -orig one, otherwise the one named after the reel's language
+func originalAutoLang(info *subtitleInfo) string {
+	var origs []string
+	for lang := range info.AutomaticCaptions {
+		if strings.HasSuffix(lang, origSuffix) {
+			origs = append(origs, lang)
+		}
+	}
+	sort.Strings(origs)
+	for _, lang := range origs {
+		if info.Language == "" || lang == info.Language+origSuffix {
+			return lang
+		}
+	}
+	if len(origs) > 0 {
+		return origs[0]
+	}
+	if _, ok := info.AutomaticCaptions[info.Language]; ok {
+		return info.Language
+	}
+	return ""
+}
+
+// pickSubtitleFile chooses among the subtitles.{lang}.{ext} files yt-dlp
+// wrote to dir, preferring creator-provided languages, then the spoken
+// language and the original automatic track, then alphabetical order
+func pickSubtitleFile(dir string, creatorLangs map[string]json.RawMessage, spokenLang string) (path, lang string) {
+	original := func(lang string) bool {
+		if spokenLang != "" {
+			return strings.TrimSuffix(lang, origSuffix) == spokenLang
+		}
+		return strings.HasSuffix(lang, origSuffix)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "subtitles.*.*"))
+	sort.Slice(matches, func(i, j int) bool {
+		li, lj := subtitleLang(matches[i]), subtitleLang(matches[j])
+		_, ci := creatorLangs[li]
+		_, cj := creatorLangs[lj]
+		if ci != cj {
+			return ci
+		}
+		if oi, oj := original(li), original(lj); oi != oj {
+			return oi
+		}
+		return matches[i] < matches[j]
+	})
+
+	for _, m := range matches {
+		switch filepath.Ext(m) {
+		case ".vtt", ".srt":
+			return m, subtitleLang(m)
+		}
+	}
+	return "", ""
+}
+
+// subtitleLang extracts the language from a subtitles.{lang}.{ext} path
+func subtitleLang(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.TrimPrefix(name, "subtitles.")
+}
+
+var _ ports.SubtitleFetcher = (*Downloader)(nil)
@@ -0,0 +1,137 @@
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPickSubtitleFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"subtitles.de.vtt", "subtitles.en.vtt", "subtitles.fr.json3", "subtitles.es-orig.vtt", "audio.wav"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		creator  map[string]json.RawMessage
+		spoken   string
+		wantLang string
+	}{
+		{"original automatic track without creator tracks", nil, "", "es-orig"},
+		{"spoken language preferred", nil, "en", "en"},
+		{"creator track preferred", map[string]json.RawMessage{"en": nil}, "es", "en"},
+		{"unusable format ignored", map[string]json.RawMessage{"fr": nil}, "es", "es-orig"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, lang := pickSubtitleFile(dir, tt.creator, tt.spoken)
+			if lang != tt.wantLang {
+				t.Errorf("lang = %q, want %q", lang, tt.wantLang)
+			}
+			if filepath.Base(path) != "subtitles."+tt.wantLang+".vtt" {
+				t.Errorf("path = %q", path)
+			}
+		})
+	}
+
+	if path, _ := pickSubtitleFile(t.TempDir(), nil, ""); path != "" {
+		t.Errorf("expected no file in empty directory, got %q", path)
+	}
+}
+
+func TestDownloadSubtitles_NoBinary(t *testing.T) {
+	testDownloader := &Downloader{}
+
+	// Only run this assertion if yt-dlp is not actually installed
+	if testDownloader.GetBinaryPath() == "" {
+		_, err := testDownloader.DownloadSubtitles(context.Background(), "ABC123", t.TempDir(), "auto")
+		if err == nil {
+			t.Fatal("DownloadSubtitles() expected error when binary not found")
+		}
+		if err.Error() != errYtdlpNotFound {
+			t.Errorf("DownloadSubtitles() error = %q, want %q", err.Error(), errYtdlpNotFound)
+		}
+	}
+}
+
+func TestDownloadSubtitles_IgnoresStaleFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+	binDir := t.TempDir()
+	bin := filepath.Join(binDir, "yt-dlp")
+	// Reports the reel but writes no subtitles
+	script := "#!/bin/sh\necho '{\"id\":\"ABC123\",\"subtitles\":{}}'\n"
+	if err := os.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := t.TempDir()
+	stale := "WEBVTT\n\n00:00:00.000 --> 00:00:01.000\nleft over from another reel\n"
+	if err := os.WriteFile(filepath.Join(destDir, "subtitles.en.vtt"), []byte(stale), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Downloader{binPath: bin}
+	result, err := d.DownloadSubtitles(context.Background(), "ABC123", destDir, "auto")
+	if err != nil {
+		t.Fatalf("DownloadSubtitles() error = %v", err)
+	}
+	if result != nil {
+		t.Errorf("DownloadSubtitles() = %q, want nil for a download without subtitles", result.Transcript.Text)
+	}
+}
+
+func TestDownloadSubtitles_OriginalAutoTrack(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	bin := filepath.Join(binDir, "yt-dlp")
+	// Lists machine translations next to the original track, and writes
+	// several automatic tracks whatever is asked for
+	script := `#!/bin/sh
+echo "$@" >> ` + argsFile + `
+out=""
+while [ $# -gt 0 ]; do
+  if [ "$1" = "-o" ]; then out="$2"; fi
+  shift
+done
+echo '{"id":"dQw4w9WgXcQ","language":"en","subtitles":{},"automatic_captions":{"af":[],"de":[],"en":[],"en-orig":[],"zu":[]}}'
+if [ -n "$out" ]; then
+  dir=$(dirname "$out")
+  for lang in af de en-orig; do
+    printf 'WEBVTT\n\n00:00:00.000 --> 00:00:01.000\n%s words\n' "$lang" > "$dir/subtitles.$lang.vtt"
+  done
+fi
+`
+	if err := os.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Downloader{binPath: bin}
+	result, err := d.DownloadSubtitles(context.Background(), "yt.dQw4w9WgXcQ", t.TempDir(), "auto")
+	if err != nil {
+		t.Fatalf("DownloadSubtitles() error = %v", err)
+	}
+	if result == nil || result.Transcript.Text != "en-orig words" || result.Transcript.Language != "en" {
+		t.Fatalf("DownloadSubtitles() = %+v, want the original English track", result)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(args), "--sub-langs en-orig ") {
+		t.Errorf("yt-dlp args = %q, want only en-orig requested", args)
+	}
+}
@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	"github.com/devbush/ig2insights/internal/domain"
 	"github.com/devbush/ig2insights/internal/ports"
@@ -26,3 +28,96 @@ func (s *BrowseService) GetAccount(ctx context.Context, username string) (*domai
 func (s *BrowseService) ListReels(ctx context.Context, username string, sort domain.SortOrder, limit int) ([]*domain.Reel, error) {
 	return s.fetcher.ListReels(ctx, username, sort, limit)
 }
+
+// ListPlaylist retrieves the videos of a YouTube playlist or channel
+func (s *BrowseService) ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error) {
+	lister, ok := s.fetcher.(ports.PlaylistLister)
+	if !ok {
+		return nil, errors.New("listing YouTube playlists is not supported by this downloader")
+	}
+	return lister.ListPlaylist(ctx, playlistURL)
+}
+
+// SeedReels builds an account's reel list from known reel IDs, for use when
+// profile scraping is blocked. Metadata is fetched per reel; reels whose
+// metadata cannot be fetched are kept with just their ID so they stay selectable.
+func (s *BrowseService) SeedReels(ctx context.Context, username string, reelIDs []string, sort domain.SortOrder) ([]*domain.Reel, error) {
+	reels := make([]*domain.Reel, 0, len(reelIDs))
+	for _, id := range reelIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		reel, err := s.fetcher.GetReel(ctx, id)
+		if err != nil || reel == nil {
+			reel = &domain.Reel{ID: id}
+		}
+		if reel.Author == "" {
+			reel.Author = username
+		}
+		reels = append(reels, reel)
+	}
+
+	domain.SortReels(reels, sort)
+	return reels, nil
+}
+
+// ErrSkippedAfterRateLimit marks accounts that were never requested because
+// an earlier request in the same listing was rate limited
+var ErrSkippedAfterRateLimit = errors.New("skipped after Instagram rate limited an earlier account")
+
+// AccountReels holds the reels fetched for one account in a multi-account listing
+type AccountReels struct {
+	Username string
+	Reels    []*domain.Reel
+	Err      error
+}
+
+// ListLatestForAccounts fetches the newest limit reels for each account using
+// up to concurrency parallel requests. Results keep the input order. Once any
+// request is rate limited, accounts not yet started are skipped with
+// ErrSkippedAfterRateLimit rather than adding to the pressure.
+func (s *BrowseService) ListLatestForAccounts(ctx context.Context, usernames []string, limit, concurrency int) []AccountReels {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]AccountReels, len(usernames))
+
+	var limited bool
+	var mu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, username := range usernames {
+		results[i].Username = username
+		sem <- struct{}{}
+
+		mu.Lock()
+		skip := limited
+		mu.Unlock()
+		if skip {
+			<-sem
+			results[i].Err = ErrSkippedAfterRateLimit
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, username string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			reels, err := s.fetcher.ListReels(ctx, username, domain.SortLatest, limit)
+			if errors.Is(err, domain.ErrRateLimited) {
+				mu.Lock()
+				limited = true
+				mu.Unlock()
+			}
+			results[i].Reels = reels
+			results[i].Err = err
+		}(i, username)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -11,9 +11,12 @@ import (
 
 // mockAccountFetcher implements ports.AccountFetcher for testing
 type mockAccountFetcher struct {
-	account *domain.Account
-	reels   []*domain.Reel
-	err     error
+	account  *domain.Account
+	reels    []*domain.Reel
+	err      error
+	reelByID map[string]*domain.Reel
+
+	errByUser map[string]error
 }
 
 func (m *mockAccountFetcher) GetAccount(ctx context.Context, username string) (*domain.Account, error) {
@@ -33,6 +36,9 @@ func (m *mockAccountFetcher) ListReels(ctx context.Context, username string, sor
 	if m.err != nil {
 		return nil, m.err
 	}
+	if err, ok := m.errByUser[username]; ok {
+		return nil, err
+	}
 	if m.reels != nil {
 		if limit > 0 && limit < len(m.reels) {
 			return m.reels[:limit], nil
@@ -45,6 +51,13 @@ func (m *mockAccountFetcher) ListReels(ctx context.Context, username string, sor
 	}, nil
 }
 
+func (m *mockAccountFetcher) GetReel(ctx context.Context, reelID string) (*domain.Reel, error) {
+	if reel, ok := m.reelByID[reelID]; ok {
+		return reel, nil
+	}
+	return nil, domain.ErrReelNotFound
+}
+
 func TestBrowseService_GetAccount(t *testing.T) {
 	fetcher := &mockAccountFetcher{}
 	svc := NewBrowseService(fetcher)
@@ -140,3 +153,77 @@ func TestBrowseService_ListReels_Error(t *testing.T) {
 		t.Errorf("ListReels() error = %v, want %v", err, expectedErr)
 	}
 }
+
+func TestBrowseService_SeedReels(t *testing.T) {
+	fetcher := &mockAccountFetcher{
+		reelByID: map[string]*domain.Reel{
+			"reel1": {ID: "reel1", Author: "testuser", ViewCount: 100},
+			"reel2": {ID: "reel2", Author: "testuser", ViewCount: 900},
+		},
+	}
+	svc := NewBrowseService(fetcher)
+
+	ctx := context.Background()
+	reels, err := svc.SeedReels(ctx, "testuser", []string{"reel1", "missing", "reel2"}, domain.SortMostViewed)
+
+	if err != nil {
+		t.Fatalf("SeedReels() error = %v", err)
+	}
+
+	if len(reels) != 3 {
+		t.Fatalf("SeedReels() returned %d reels, want 3", len(reels))
+	}
+
+	if reels[0].ID != "reel2" {
+		t.Errorf("First reel ID = %s, want 'reel2'", reels[0].ID)
+	}
+
+	// Reels without metadata are kept and attributed to the account
+	missing := reels[2]
+	if missing.ID != "missing" || missing.Author != "testuser" {
+		t.Errorf("Fallback reel = %+v, want ID 'missing' with Author 'testuser'", missing)
+	}
+}
+
+func TestBrowseService_ListLatestForAccounts(t *testing.T) {
+	fetcher := &mockAccountFetcher{
+		errByUser: map[string]error{"gone": domain.ErrAccountNotFound},
+	}
+	svc := NewBrowseService(fetcher)
+
+	results := svc.ListLatestForAccounts(context.Background(), []string{"alice", "gone", "bob"}, 1, 2)
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, want := range []string{"alice", "gone", "bob"} {
+		if results[i].Username != want {
+			t.Errorf("results[%d].Username = %s, want %s", i, results[i].Username, want)
+		}
+	}
+	if len(results[0].Reels) != 2 || results[0].Err != nil {
+		t.Errorf("alice: got %d reels, err %v", len(results[0].Reels), results[0].Err)
+	}
+	if !errors.Is(results[1].Err, domain.ErrAccountNotFound) {
+		t.Errorf("gone: err = %v, want ErrAccountNotFound", results[1].Err)
+	}
+}
+
+func TestBrowseService_ListLatestForAccounts_StopsOnRateLimit(t *testing.T) {
+	fetcher := &mockAccountFetcher{
+		errByUser: map[string]error{"first": domain.ErrRateLimited},
+	}
+	svc := NewBrowseService(fetcher)
+
+	// Concurrency 1 makes the ordering deterministic
+	results := svc.ListLatestForAccounts(context.Background(), []string{"first", "second", "third"}, 5, 1)
+
+	if !errors.Is(results[0].Err, domain.ErrRateLimited) {
+		t.Errorf("first: err = %v, want ErrRateLimited", results[0].Err)
+	}
+	for _, r := range results[1:] {
+		if !errors.Is(r.Err, ErrSkippedAfterRateLimit) || errors.Is(r.Err, domain.ErrRateLimited) {
+			t.Errorf("%s: err = %v, want only ErrSkippedAfterRateLimit", r.Username, r.Err)
+		}
+	}
+}
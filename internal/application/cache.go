@@ -2,14 +2,25 @@ package application
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/devbush/ig2insights/internal/ports"
 )
 
 // CacheStats holds cache statistics
-type CacheStats struct {
-	ItemCount int
-	TotalSize int64
+type CacheStats = ports.CacheStats
+
+// cacheExpiry returns when an item cached at now expires given ttl, and when
+// its media does given mediaTTL. Media expiry is zero when mediaTTL is, so
+// media lasts as long as the item.
+func cacheExpiry(now time.Time, ttl, mediaTTL time.Duration) (expiresAt, mediaExpiresAt time.Time) {
+	expiresAt = now.Add(ttl)
+	if mediaTTL > 0 {
+		mediaExpiresAt = now.Add(mediaTTL)
+	}
+	return expiresAt, mediaExpiresAt
 }
 
 // CacheService handles cache management operations
@@ -24,14 +35,80 @@ func NewCacheService(cache ports.CacheStore) *CacheService {
 
 // Stats returns cache statistics
 func (s *CacheService) Stats(ctx context.Context) (*CacheStats, error) {
-	count, size, err := s.cache.Stats(ctx)
+	return s.cache.Stats(ctx)
+}
+
+// Entry returns the cached item for reelID, expired or not, and the
+// directory holding its files
+func (s *CacheService) Entry(ctx context.Context, reelID string) (*ports.CachedItem, string, error) {
+	item, err := s.cache.Peek(ctx, reelID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	return item, s.cache.GetCacheDir(reelID), nil
+}
+
+// Export writes the whole cache to w as an archive
+func (s *CacheService) Export(ctx context.Context, w io.Writer) (int, error) {
+	archiver, ok := s.cache.(ports.CacheArchiver)
+	if !ok {
+		return 0, errors.New("exporting is not supported by this cache")
+	}
+	return archiver.Export(ctx, w)
+}
+
+// Import reads a cache archive from r into the cache
+func (s *CacheService) Import(ctx context.Context, r io.Reader) (int, error) {
+	archiver, ok := s.cache.(ports.CacheArchiver)
+	if !ok {
+		return 0, errors.New("importing is not supported by this cache")
+	}
+	return archiver.Import(ctx, r)
+}
+
+// Verify returns the damaged files in the cache
+func (s *CacheService) Verify(ctx context.Context) ([]ports.CacheProblem, error) {
+	verifier, ok := s.cache.(ports.CacheVerifier)
+	if !ok {
+		return nil, errors.New("verifying is not supported by this cache")
+	}
+	return verifier.Verify(ctx)
+}
+
+// Purge deletes the entries of the reels with problems and returns how many
+// it deleted
+func (s *CacheService) Purge(ctx context.Context, problems []ports.CacheProblem) (int, error) {
+	purged := make(map[string]bool)
+	for _, p := range problems {
+		if purged[p.ReelID] {
+			continue
+		}
+		if err := s.cache.Delete(ctx, p.ReelID); err != nil {
+			return len(purged), err
+		}
+		purged[p.ReelID] = true
+	}
+	return len(purged), nil
+}
+
+// Prune deletes the cached files filter selects, keeping transcripts, and
+// returns how many it deleted and their total size
+func (s *CacheService) Prune(ctx context.Context, filter ports.CachePruneFilter) (int, int64, error) {
+	pruner, ok := s.cache.(ports.CachePruner)
+	if !ok {
+		return 0, 0, errors.New("pruning is not supported by this cache")
+	}
+	return pruner.Prune(ctx, filter)
+}
+
+// Migrate upgrades entries cached in an older format and returns how many it
+// upgraded
+func (s *CacheService) Migrate(ctx context.Context) (int, error) {
+	migrator, ok := s.cache.(ports.CacheMigrator)
+	if !ok {
+		return 0, errors.New("migrating is not supported by this cache")
 	}
-	return &CacheStats{
-		ItemCount: count,
-		TotalSize: size,
-	}, nil
+	return migrator.Migrate(ctx)
 }
 
 // CleanExpired removes expired cache entries
@@ -3,8 +3,11 @@ package application
 import (
 	"context"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/devbush/ig2insights/internal/domain"
 	"github.com/devbush/ig2insights/internal/ports"
 )
 
@@ -16,17 +19,29 @@ type mockCacheStore struct {
 	statsErr     error
 	cleanErr     error
 	clearErr     error
+	items        []*ports.CachedItem
+	deleted      []string
 }
 
 func (m *mockCacheStore) Get(ctx context.Context, reelID string) (*ports.CachedItem, error) {
 	return nil, nil
 }
 
+func (m *mockCacheStore) Peek(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	for _, item := range m.items {
+		if item.Reel != nil && item.Reel.ID == reelID {
+			return item, nil
+		}
+	}
+	return nil, domain.ErrCacheMiss
+}
+
 func (m *mockCacheStore) Set(ctx context.Context, reelID string, item *ports.CachedItem) error {
 	return nil
 }
 
 func (m *mockCacheStore) Delete(ctx context.Context, reelID string) error {
+	m.deleted = append(m.deleted, reelID)
 	return nil
 }
 
@@ -45,11 +60,15 @@ func (m *mockCacheStore) GetCacheDir(reelID string) string {
 	return "/tmp/cache/" + reelID
 }
 
-func (m *mockCacheStore) Stats(ctx context.Context) (int, int64, error) {
+func (m *mockCacheStore) Stats(ctx context.Context) (*ports.CacheStats, error) {
 	if m.statsErr != nil {
-		return 0, 0, m.statsErr
+		return nil, m.statsErr
 	}
-	return m.itemCount, m.totalSize, nil
+	return &ports.CacheStats{ItemCount: m.itemCount, TotalSize: m.totalSize}, nil
+}
+
+func (m *mockCacheStore) List(ctx context.Context) ([]*ports.CachedItem, error) {
+	return m.items, nil
 }
 
 func TestCacheService_Stats(t *testing.T) {
@@ -153,3 +172,61 @@ func TestCacheService_Clear_Error(t *testing.T) {
 		t.Errorf("Clear() error = %v, want %v", err, expectedErr)
 	}
 }
+
+func TestCacheService_Entry(t *testing.T) {
+	item := &ports.CachedItem{Reel: &domain.Reel{ID: "ABC123"}}
+	svc := NewCacheService(&mockCacheStore{items: []*ports.CachedItem{item}})
+
+	ctx := context.Background()
+	got, dir, err := svc.Entry(ctx, "ABC123")
+	if err != nil {
+		t.Fatalf("Entry() error = %v", err)
+	}
+	if got != item || dir != "/tmp/cache/ABC123" {
+		t.Errorf("Entry() = %v, %q", got, dir)
+	}
+
+	if _, _, err := svc.Entry(ctx, "MISSING"); !errors.Is(err, domain.ErrCacheMiss) {
+		t.Errorf("Entry() error = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestCacheService_ExportImport_Unsupported(t *testing.T) {
+	svc := NewCacheService(&mockCacheStore{})
+	ctx := context.Background()
+
+	if _, err := svc.Export(ctx, io.Discard); err == nil {
+		t.Error("Export() expected error for a cache without archive support")
+	}
+	if _, err := svc.Import(ctx, strings.NewReader("")); err == nil {
+		t.Error("Import() expected error for a cache without archive support")
+	}
+}
+
+func TestCacheService_Purge(t *testing.T) {
+	cache := &mockCacheStore{}
+	svc := NewCacheService(cache)
+
+	problems := []ports.CacheProblem{
+		{ReelID: "ABC123", File: "audio.wav", Reason: "checksum mismatch"},
+		{ReelID: "ABC123", File: "video.mp4", Reason: "empty"},
+		{ReelID: "DEF456", File: "meta.json.gz", Reason: "unreadable metadata"},
+	}
+	purged, err := svc.Purge(context.Background(), problems)
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("Purge() = %d, want 2 entries", purged)
+	}
+	if len(cache.deleted) != 2 || cache.deleted[0] != "ABC123" || cache.deleted[1] != "DEF456" {
+		t.Errorf("deleted = %v, want each damaged reel once", cache.deleted)
+	}
+
+	if _, err := svc.Verify(context.Background()); err == nil {
+		t.Error("Verify() expected error for a cache without checksums")
+	}
+	if _, err := svc.Migrate(context.Background()); err == nil {
+		t.Error("Migrate() expected error for a cache without versioned entries")
+	}
+}
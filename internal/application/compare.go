@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// ModelRun holds the outcome of transcribing with a single model
+type ModelRun struct {
+	Model      string
+	Transcript *domain.Transcript
+	Duration   time.Duration
+	Err        error
+}
+
+// CompareResult contains transcripts of the same audio from several models
+type CompareResult struct {
+	Reel *domain.Reel
+	Runs []ModelRun
+}
+
+// CompareService transcribes one reel with multiple models for side-by-side review
+type CompareService struct {
+	cache       ports.CacheStore
+	downloader  ports.VideoDownloader
+	transcriber ports.Transcriber
+	cacheTTL    time.Duration
+	mediaTTL    time.Duration
+}
+
+// NewCompareService creates a new model comparison service
+func NewCompareService(
+	cache ports.CacheStore,
+	downloader ports.VideoDownloader,
+	transcriber ports.Transcriber,
+	cacheTTL time.Duration,
+) *CompareService {
+	return &CompareService{
+		cache:       cache,
+		downloader:  downloader,
+		transcriber: transcriber,
+		cacheTTL:    cacheTTL,
+	}
+}
+
+// SetMediaTTL makes cached audio expire ttl after it's cached, ahead of the
+// rest of the item. Zero keeps it as long as the item.
+func (s *CompareService) SetMediaTTL(ttl time.Duration) {
+	s.mediaTTL = ttl
+}
+
+// Compare transcribes the reel's audio once per model. Audio is downloaded at
+// most once and reused from cache when available. A failing model is recorded
+// in its run rather than aborting the comparison.
+func (s *CompareService) Compare(ctx context.Context, reelID string, models []string, language, prompt string) (*CompareResult, error) {
+	if len(models) == 0 {
+		return nil, errors.New("no models to compare")
+	}
+
+	audioPath, reel, err := s.resolveAudio(ctx, reelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if language == "" {
+		language = defaultLanguage
+	}
+
+	result := &CompareResult{Reel: reel}
+	for _, model := range models {
+		start := time.Now()
+		transcript, err := s.transcriber.Transcribe(ctx, audioPath, ports.TranscribeOpts{
+			Model:    model,
+			Language: language,
+			Prompt:   prompt,
+		})
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		result.Runs = append(result.Runs, ModelRun{
+			Model:      model,
+			Transcript: transcript,
+			Duration:   time.Since(start),
+			Err:        err,
+		})
+	}
+
+	return result, nil
+}
+
+// resolveAudio returns cached audio for the reel, downloading and caching it if missing
+func (s *CompareService) resolveAudio(ctx context.Context, reelID string) (string, *domain.Reel, error) {
+	cached, err := s.cache.Get(ctx, reelID)
+	if err != nil {
+		cached = nil
+	}
+	if cached != nil && cached.AudioPath != "" && fileExists(cached.AudioPath) {
+		return cached.AudioPath, cached.Reel, nil
+	}
+
+	download, err := s.downloader.DownloadAudio(ctx, reelID, s.cache.GetCacheDir(reelID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	item := &ports.CachedItem{
+		Reel:      download.Reel,
+		AudioPath: download.AudioPath,
+		CreatedAt: now,
+	}
+	item.ExpiresAt, item.MediaExpiresAt = cacheExpiry(now, s.cacheTTL, s.mediaTTL)
+	if cached != nil {
+		item.Transcript = cached.Transcript
+		item.VideoPath = cached.VideoPath
+		item.ThumbnailPath = cached.ThumbnailPath
+		item.RawOutputPath = cached.RawOutputPath
+		item.CreatedAt = cached.CreatedAt
+	}
+	_ = s.cache.Set(ctx, reelID, item)
+
+	return download.AudioPath, download.Reel, nil
+}
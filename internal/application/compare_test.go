@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// failingModelTranscriber fails for one model and succeeds for the rest
+type failingModelTranscriber struct {
+	mockTranscriber
+	failModel string
+}
+
+func (m *failingModelTranscriber) Transcribe(ctx context.Context, videoPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	if opts.Model == m.failModel {
+		return nil, domain.ErrModelNotFound
+	}
+	return &domain.Transcript{Text: "text from " + opts.Model, Model: opts.Model}, nil
+}
+
+func TestCompareService_Compare(t *testing.T) {
+	cache := newMockCache()
+	transcriber := &failingModelTranscriber{failModel: "large"}
+	svc := NewCompareService(cache, &mockDownloader{available: true}, transcriber, 24*time.Hour)
+
+	ctx := context.Background()
+	result, err := svc.Compare(ctx, "cmp123", []string{"small", "large", "medium"}, "", "")
+
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if len(result.Runs) != 3 {
+		t.Fatalf("Compare() returned %d runs, want 3", len(result.Runs))
+	}
+
+	if result.Runs[0].Transcript.Text != "text from small" {
+		t.Errorf("Runs[0] text = %q, want 'text from small'", result.Runs[0].Transcript.Text)
+	}
+
+	if !errors.Is(result.Runs[1].Err, domain.ErrModelNotFound) {
+		t.Errorf("Runs[1].Err = %v, want ErrModelNotFound", result.Runs[1].Err)
+	}
+
+	// Downloaded audio is cached for later runs
+	cached, err := cache.Get(ctx, "cmp123")
+	if err != nil {
+		t.Fatalf("Cache.Get() error = %v", err)
+	}
+	if cached.AudioPath == "" {
+		t.Error("AudioPath should be cached after comparison")
+	}
+}
+
+func TestCompareService_NoModels(t *testing.T) {
+	svc := NewCompareService(newMockCache(), &mockDownloader{available: true}, &mockTranscriber{}, 24*time.Hour)
+
+	if _, err := svc.Compare(context.Background(), "cmp123", nil, "", ""); err == nil {
+		t.Error("Compare() expected error with no models")
+	}
+}
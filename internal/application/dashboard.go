@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// recentRunLimit caps how many runs a dashboard snapshot lists individually
+const recentRunLimit = 50
+
+// CachedReelSummary describes one cached reel for the dashboard
+type CachedReelSummary struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	Words     int       `json:"words"`
+	Views     int64     `json:"views"`
+	CreatedAt time.Time `json:"created_at"`
+	Expired   bool      `json:"expired"`
+}
+
+// DashboardSnapshot is everything the local dashboard displays
+type DashboardSnapshot struct {
+	GeneratedAt  time.Time             `json:"generated_at"`
+	Cache        CacheStats            `json:"cache"`
+	CachedReels  []CachedReelSummary   `json:"cached_reels"`
+	TotalRuns    int                   `json:"total_runs"`
+	FailedRuns   int                   `json:"failed_runs"`
+	CachedRuns   int                   `json:"cached_runs"`
+	RecentRuns   []domain.RunRecord    `json:"recent_runs"`
+	ModelTimings []domain.ModelTiming  `json:"model_timings"`
+	Accounts     []domain.AccountStats `json:"accounts"`
+}
+
+// HistoryService records transcription runs for later analysis
+type HistoryService struct {
+	store ports.HistoryStore
+}
+
+// NewHistoryService creates a new history service
+func NewHistoryService(store ports.HistoryStore) *HistoryService {
+	return &HistoryService{store: store}
+}
+
+// Record appends a run to the history
+func (s *HistoryService) Record(ctx context.Context, record domain.RunRecord) error {
+	return s.store.Append(ctx, record)
+}
+
+// Estimator predicts transcription times for model from the runs recorded on
+// this machine
+func (s *HistoryService) Estimator(ctx context.Context, model string) (domain.Estimator, error) {
+	records, err := s.store.List(ctx)
+	if err != nil {
+		return domain.Estimator{}, err
+	}
+	return domain.NewEstimator(records, model), nil
+}
+
+// RecentLanguages returns up to limit languages recently asked for, newest first
+func (s *HistoryService) RecentLanguages(ctx context.Context, limit int) ([]string, error) {
+	records, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.RecentLanguages(records, limit), nil
+}
+
+// DashboardService assembles dashboard data from local cache and history
+type DashboardService struct {
+	cache   ports.CacheStore
+	history ports.HistoryStore
+	now     func() time.Time
+}
+
+// NewDashboardService creates a new dashboard service
+func NewDashboardService(cache ports.CacheStore, history ports.HistoryStore) *DashboardService {
+	return &DashboardService{cache: cache, history: history, now: time.Now}
+}
+
+// Snapshot gathers the current cache contents, run history and derived analytics
+func (s *DashboardService) Snapshot(ctx context.Context) (*DashboardSnapshot, error) {
+	now := s.now()
+	snap := &DashboardSnapshot{GeneratedAt: now}
+
+	stats, err := s.cache.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snap.Cache = *stats
+
+	items, err := s.cache.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reels := make([]*domain.Reel, 0, len(items))
+	snap.CachedReels = make([]CachedReelSummary, 0, len(items))
+	for _, item := range items {
+		summary := CachedReelSummary{
+			CreatedAt: item.CreatedAt,
+			Expired:   !item.ExpiresAt.IsZero() && now.After(item.ExpiresAt),
+		}
+		if item.Reel != nil {
+			summary.ID = item.Reel.ID
+			summary.Author = item.Reel.Author
+			summary.Title = item.Reel.Title
+			summary.Views = item.Reel.ViewCount
+			reels = append(reels, item.Reel)
+		}
+		if item.Transcript != nil {
+			summary.Model = item.Transcript.Model
+			summary.Words = domain.ComputeStats(item.Transcript, nil).Words
+		}
+		snap.CachedReels = append(snap.CachedReels, summary)
+	}
+	sort.Slice(snap.CachedReels, func(i, j int) bool {
+		return snap.CachedReels[i].CreatedAt.After(snap.CachedReels[j].CreatedAt)
+	})
+	snap.Accounts = domain.SummarizeAccounts(reels)
+
+	records, err := s.history.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snap.TotalRuns = len(records)
+	for _, r := range records {
+		if !r.Success {
+			snap.FailedRuns++
+		}
+		if r.FromCache {
+			snap.CachedRuns++
+		}
+	}
+	snap.ModelTimings = domain.ModelTimings(records)
+
+	snap.RecentRuns = make([]domain.RunRecord, 0, recentRunLimit)
+	for i := len(records) - 1; i >= 0 && len(snap.RecentRuns) < recentRunLimit; i-- {
+		snap.RecentRuns = append(snap.RecentRuns, records[i])
+	}
+
+	return snap, nil
+}
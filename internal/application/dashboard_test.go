@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// mockHistoryStore implements ports.HistoryStore in memory
+type mockHistoryStore struct {
+	records []domain.RunRecord
+}
+
+func (m *mockHistoryStore) Append(ctx context.Context, record domain.RunRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockHistoryStore) List(ctx context.Context) ([]domain.RunRecord, error) {
+	return m.records, nil
+}
+
+func TestHistoryService_Record(t *testing.T) {
+	store := &mockHistoryStore{}
+	svc := NewHistoryService(store)
+
+	if err := svc.Record(context.Background(), domain.RunRecord{ReelID: "abc123"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(store.records) != 1 || store.records[0].ReelID != "abc123" {
+		t.Errorf("store records = %+v", store.records)
+	}
+}
+
+func TestHistoryService_RecentLanguages(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewHistoryService(&mockHistoryStore{records: []domain.RunRecord{
+		{ReelID: "a", Language: "fr", StartedAt: start},
+		{ReelID: "b", Language: "auto", StartedAt: start.Add(time.Minute)},
+		{ReelID: "c", Language: "de", StartedAt: start.Add(2 * time.Minute)},
+	}})
+
+	got, err := svc.RecentLanguages(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("RecentLanguages() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "de" || got[1] != "fr" {
+		t.Errorf("RecentLanguages() = %v, want [de fr]", got)
+	}
+}
+
+func TestDashboardService_Snapshot(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := &mockCacheStore{
+		itemCount: 2,
+		totalSize: 2048,
+		items: []*ports.CachedItem{
+			{
+				Reel:       &domain.Reel{ID: "old", Author: "alice", ViewCount: 100},
+				Transcript: &domain.Transcript{Text: "one two three", Model: "small"},
+				CreatedAt:  now.Add(-48 * time.Hour),
+				ExpiresAt:  now.Add(-time.Hour),
+			},
+			{
+				Reel:      &domain.Reel{ID: "new", Author: "alice", ViewCount: 300},
+				CreatedAt: now.Add(-time.Hour),
+				ExpiresAt: now.Add(time.Hour),
+			},
+		},
+	}
+	history := &mockHistoryStore{records: []domain.RunRecord{
+		{ReelID: "old", Model: "small", Duration: 10 * time.Second, Success: true},
+		{ReelID: "old", Model: "small", Success: true, FromCache: true},
+		{ReelID: "bad", Model: "small", Error: "boom"},
+	}}
+
+	svc := NewDashboardService(cache, history)
+	svc.now = func() time.Time { return now }
+
+	snap, err := svc.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	if snap.Cache.ItemCount != 2 || snap.Cache.TotalSize != 2048 {
+		t.Errorf("Cache = %+v", snap.Cache)
+	}
+	if len(snap.CachedReels) != 2 || snap.CachedReels[0].ID != "new" {
+		t.Fatalf("CachedReels should list newest first, got %+v", snap.CachedReels)
+	}
+	if !snap.CachedReels[1].Expired || snap.CachedReels[0].Expired {
+		t.Error("only the old reel should be marked expired")
+	}
+	if snap.CachedReels[1].Words != 3 || snap.CachedReels[1].Model != "small" {
+		t.Errorf("old reel summary = %+v", snap.CachedReels[1])
+	}
+
+	if snap.TotalRuns != 3 || snap.FailedRuns != 1 || snap.CachedRuns != 1 {
+		t.Errorf("runs = %d total, %d failed, %d cached", snap.TotalRuns, snap.FailedRuns, snap.CachedRuns)
+	}
+	if snap.RecentRuns[0].ReelID != "bad" {
+		t.Errorf("RecentRuns should be newest first, got %s", snap.RecentRuns[0].ReelID)
+	}
+	if len(snap.ModelTimings) != 1 || snap.ModelTimings[0].Runs != 1 {
+		t.Errorf("ModelTimings = %+v", snap.ModelTimings)
+	}
+	if len(snap.Accounts) != 1 || snap.Accounts[0].TotalViews != 400 {
+		t.Errorf("Accounts = %+v", snap.Accounts)
+	}
+}
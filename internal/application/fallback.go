@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// TranscriberFallback is a transcription backend tried when the primary
+// transcriber fails
+type TranscriberFallback struct {
+	Name        string // recorded as the transcript's Backend
+	Transcriber ports.Transcriber
+	Model       string // empty keeps the requested model
+}
+
+// SetFallbacks sets the backends tried, in order, when the primary
+// transcriber fails or is unavailable
+func (s *TranscribeService) SetFallbacks(fallbacks []TranscriberFallback) {
+	s.fallbacks = fallbacks
+}
+
+// transcribeFallbacks tries each fallback once, returning the first
+// transcript produced. When all fail, the primary's error is returned with
+// the fallbacks' failures appended.
+func (s *TranscribeService) transcribeFallbacks(
+	ctx context.Context,
+	audioPath, cacheDir, model, language string,
+	opts TranscribeOptions,
+	primaryErr error,
+) (*domain.Transcript, error) {
+	err := primaryErr
+	for _, fallback := range s.fallbacks {
+		tOpts := ports.TranscribeOpts{
+			Model:         model,
+			Language:      language,
+			Prompt:        opts.Prompt,
+			RawOutputPath: filepath.Join(cacheDir, rawOutputFile),
+		}
+		if fallback.Model != "" {
+			tOpts.Model = fallback.Model
+		}
+
+		transcript, fbErr := s.transcribeAttempt(ctx, fallback.Transcriber, audioPath, tOpts, opts.Timeout)
+		if fbErr == nil {
+			transcript.Backend = fallback.Name
+			if transcript.Model == "" {
+				transcript.Model = tOpts.Model
+			}
+			return transcript, nil
+		}
+		if ctx.Err() != nil {
+			return nil, fbErr
+		}
+		err = fmt.Errorf("%w; %s fallback: %v", err, fallback.Name, fbErr)
+	}
+	return nil, err
+}
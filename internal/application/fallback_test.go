@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// failingTranscriber fails every transcription with err
+type failingTranscriber struct {
+	mockTranscriber
+	err   error
+	calls int
+}
+
+func (f *failingTranscriber) Transcribe(ctx context.Context, audioPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	f.calls++
+	f.lastOpts = opts
+	return nil, f.err
+}
+
+func TestTranscribe_FallsBackAfterPrimaryFails(t *testing.T) {
+	primary := &failingTranscriber{err: errors.New("out of memory")}
+	broken := &failingTranscriber{err: errors.New("model missing")}
+	hosted := &mockTranscriber{modelDownloaded: true}
+
+	svc := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, primary, time.Hour)
+	svc.SetFallbacks([]TranscriberFallback{
+		{Name: "whisper", Transcriber: broken, Model: "tiny"},
+		{Name: "openai", Transcriber: hosted, Model: "whisper-1"},
+	})
+
+	result, err := svc.Transcribe(context.Background(), "fallback123", TranscribeOptions{Model: "small"})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if primary.calls != 1 || broken.calls != 1 {
+		t.Errorf("calls = primary %d, first fallback %d; want one each", primary.calls, broken.calls)
+	}
+	if broken.lastOpts.Model != "tiny" {
+		t.Errorf("first fallback model = %q, want tiny", broken.lastOpts.Model)
+	}
+	if result.Transcript.Backend != "openai" || result.Transcript.Model != "whisper-1" {
+		t.Errorf("transcript backend/model = %q/%q, want openai/whisper-1", result.Transcript.Backend, result.Transcript.Model)
+	}
+}
+
+func TestTranscribe_PrimarySuccessSkipsFallbacks(t *testing.T) {
+	fallback := &failingTranscriber{err: errors.New("unused")}
+	svc := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, &mockTranscriber{modelDownloaded: true}, time.Hour)
+	svc.SetFallbacks([]TranscriberFallback{{Name: "whisper", Transcriber: fallback, Model: "tiny"}})
+
+	result, err := svc.Transcribe(context.Background(), "primary123", TranscribeOptions{Model: "small"})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if fallback.calls != 0 || result.Transcript.Backend != "" {
+		t.Errorf("fallback calls = %d, backend = %q; want the primary's transcript", fallback.calls, result.Transcript.Backend)
+	}
+}
+
+func TestTranscribe_AllFallbacksFail(t *testing.T) {
+	primary := &failingTranscriber{err: domain.ErrTranscriptionFailed}
+	svc := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, primary, time.Hour)
+	svc.SetFallbacks([]TranscriberFallback{{Name: "openai", Transcriber: &failingTranscriber{err: errors.New("no API key")}}})
+
+	_, err := svc.Transcribe(context.Background(), "failing123", TranscribeOptions{Model: "small"})
+	if !errors.Is(err, domain.ErrTranscriptionFailed) {
+		t.Errorf("error = %v, want the primary's ErrTranscriptionFailed", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "openai fallback: no API key") {
+		t.Errorf("error = %v, want the fallback's failure included", err)
+	}
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// RateLimitService tracks rate-limit responses and derives back-off status
+type RateLimitService struct {
+	store ports.RateLimitStore
+	now   func() time.Time
+}
+
+// NewRateLimitService creates a new rate-limit service
+func NewRateLimitService(store ports.RateLimitStore) *RateLimitService {
+	return &RateLimitService{store: store, now: time.Now}
+}
+
+// Record notes a rate-limit response from host
+func (s *RateLimitService) Record(ctx context.Context, host string) error {
+	return s.store.Record(ctx, host, s.now())
+}
+
+// Status returns the back-off status for a single host
+func (s *RateLimitService) Status(ctx context.Context, host string) (domain.BackoffStatus, error) {
+	hits, err := s.store.Hits(ctx)
+	if err != nil {
+		return domain.BackoffStatus{Host: host}, err
+	}
+	return domain.ComputeBackoff(host, hits[host], s.now()), nil
+}
+
+// AllStatuses returns back-off status for every host with recorded hits, sorted by host
+func (s *RateLimitService) AllStatuses(ctx context.Context) ([]domain.BackoffStatus, error) {
+	hits, err := s.store.Hits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	statuses := make([]domain.BackoffStatus, 0, len(hits))
+	for host, times := range hits {
+		statuses = append(statuses, domain.ComputeBackoff(host, times, now))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Host < statuses[j].Host
+	})
+	return statuses, nil
+}
+
+// Reset clears all recorded rate-limit responses
+func (s *RateLimitService) Reset(ctx context.Context) error {
+	return s.store.Reset(ctx)
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// mockRateLimitStore implements ports.RateLimitStore in memory
+type mockRateLimitStore struct {
+	hits map[string][]time.Time
+}
+
+func (m *mockRateLimitStore) Record(ctx context.Context, host string, at time.Time) error {
+	if m.hits == nil {
+		m.hits = make(map[string][]time.Time)
+	}
+	m.hits[host] = append(m.hits[host], at)
+	return nil
+}
+
+func (m *mockRateLimitStore) Hits(ctx context.Context) (map[string][]time.Time, error) {
+	return m.hits, nil
+}
+
+func (m *mockRateLimitStore) Reset(ctx context.Context) error {
+	m.hits = nil
+	return nil
+}
+
+func TestRateLimitService_RecordAndStatus(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewRateLimitService(&mockRateLimitStore{})
+	svc.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if err := svc.Record(ctx, "instagram.com"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	status, err := svc.Status(ctx, "instagram.com")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	if !status.CoolingDown(now) {
+		t.Error("Status() should be cooling down right after a rate limit")
+	}
+
+	other, err := svc.Status(ctx, "youtube.com")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if other.CoolingDown(now) {
+		t.Error("Status() for a host without hits should not be cooling down")
+	}
+
+	statuses, err := svc.AllStatuses(ctx)
+	if err != nil {
+		t.Fatalf("AllStatuses() error = %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Host != "instagram.com" {
+		t.Errorf("AllStatuses() = %+v, want only instagram.com", statuses)
+	}
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// ReelInputService validates and normalizes user-supplied reel URLs and IDs
+type ReelInputService struct {
+	resolver  ports.LinkResolver
+	anyURL    ports.URLRegistrar
+	files     ports.FileRegistrar
+	playlists ports.PlaylistLister
+}
+
+// NewReelInputService creates a new input service. A nil resolver leaves
+// share links unsupported.
+func NewReelInputService(resolver ports.LinkResolver) *ReelInputService {
+	return &ReelInputService{resolver: resolver}
+}
+
+// AllowAnyURL makes Normalize accept URLs of any site as a last resort,
+// registering them with downloader to be fetched verbatim
+func (s *ReelInputService) AllowAnyURL(downloader ports.URLRegistrar) {
+	s.anyURL = downloader
+}
+
+// AllowLocalFiles makes Normalize accept paths of audio and video files on
+// disk, registering them with downloader to be read in place
+func (s *ReelInputService) AllowLocalFiles(downloader ports.FileRegistrar) {
+	s.files = downloader
+}
+
+// AllowPlaylists makes Expand list the videos of YouTube playlist and
+// channel URLs with lister
+func (s *ReelInputService) AllowPlaylists(lister ports.PlaylistLister) {
+	s.playlists = lister
+}
+
+// Expand normalizes input like Normalize, except that a YouTube playlist or
+// channel URL becomes the reels of its videos. Without AllowPlaylists such
+// URLs fail rather than being dropped.
+func (s *ReelInputService) Expand(ctx context.Context, input string) ([]*domain.Reel, error) {
+	playlistURL, ok := domain.ParseYouTubePlaylist(input)
+	if !ok {
+		reel, err := s.Normalize(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return []*domain.Reel{reel}, nil
+	}
+
+	if s.playlists == nil {
+		return nil, fmt.Errorf("listing YouTube playlists is not supported by this downloader: %s", playlistURL)
+	}
+	reels, err := s.playlists.ListPlaylist(ctx, playlistURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", playlistURL, err)
+	}
+	return reels, nil
+}
+
+// Normalize parses input into a reel with a canonical URL, resolving share
+// links through their redirect when a resolver is configured. With
+// AllowAnyURL, other http(s) URLs become reels with a GenericID; with
+// AllowLocalFiles, media file paths become reels with a LocalFileID.
+func (s *ReelInputService) Normalize(ctx context.Context, input string) (*domain.Reel, error) {
+	if s.files != nil && domain.IsLocalMediaPath(input) {
+		return s.localFile(input)
+	}
+
+	reel, err := domain.ParseReelInput(input)
+	if err != nil && !errors.Is(err, domain.ErrShareLink) && s.anyURL != nil {
+		if generic, gerr := domain.ParseGenericURL(input); gerr == nil {
+			s.anyURL.RegisterURL(generic.ID, generic.URL)
+			return generic, nil
+		}
+	}
+	if !errors.Is(err, domain.ErrShareLink) || s.resolver == nil {
+		return reel, err
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve share link: %w", err)
+	}
+
+	reel, err = domain.ParseReelInput(resolved)
+	if errors.Is(err, domain.ErrShareLink) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidReelInput, input)
+	}
+	return reel, err
+}
+
+// localFile returns the reel of the media file at path, registering it with
+// the downloader
+func (s *ReelInputService) localFile(path string) (*domain.Reel, error) {
+	abs, err := filepath.Abs(strings.TrimSpace(path))
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is a directory", domain.ErrInvalidReelInput, path)
+	}
+
+	reel := domain.LocalFileReel(abs, info.Size(), info.ModTime())
+	s.files.RegisterFile(reel.ID, abs)
+	return reel, nil
+}
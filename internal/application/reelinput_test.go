@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// mockResolver implements ports.LinkResolver for testing
+type mockResolver struct {
+	target string
+	err    error
+	calls  int
+}
+
+func (m *mockResolver) Resolve(ctx context.Context, rawURL string) (string, error) {
+	m.calls++
+	return m.target, m.err
+}
+
+func TestReelInputService_Normalize(t *testing.T) {
+	resolver := &mockResolver{target: "https://www.instagram.com/reel/DToLsd-EvGJ/?igsh=x"}
+	svc := NewReelInputService(resolver)
+	ctx := context.Background()
+
+	reel, err := svc.Normalize(ctx, "https://www.instagram.com/reel/ABCDEF123/?igsh=y")
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if reel.ID != "ABCDEF123" || resolver.calls != 0 {
+		t.Errorf("Normalize() = %s with %d resolves, want ABCDEF123 without resolving", reel.ID, resolver.calls)
+	}
+
+	reel, err = svc.Normalize(ctx, "https://www.instagram.com/share/reel/BAFg2xYz1/")
+	if err != nil {
+		t.Fatalf("Normalize(share) error = %v", err)
+	}
+	if reel.ID != "DToLsd-EvGJ" {
+		t.Errorf("Normalize(share) ID = %s, want DToLsd-EvGJ", reel.ID)
+	}
+	if reel.URL != "https://www.instagram.com/p/DToLsd-EvGJ/" {
+		t.Errorf("Normalize(share) URL = %s, want canonical", reel.URL)
+	}
+}
+
+func TestReelInputService_Normalize_ShareErrors(t *testing.T) {
+	ctx := context.Background()
+	share := "https://www.instagram.com/share/reel/BAFg2xYz1/"
+
+	if _, err := NewReelInputService(nil).Normalize(ctx, share); !errors.Is(err, domain.ErrShareLink) {
+		t.Errorf("Normalize() without resolver error = %v, want ErrShareLink", err)
+	}
+
+	failing := NewReelInputService(&mockResolver{err: domain.ErrRateLimited})
+	if _, err := failing.Normalize(ctx, share); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("Normalize() error = %v, want ErrRateLimited", err)
+	}
+
+	loop := NewReelInputService(&mockResolver{target: share})
+	if _, err := loop.Normalize(ctx, share); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Errorf("Normalize() error = %v, want ErrInvalidReelInput", err)
+	}
+}
+
+// mockRegistrar implements ports.URLRegistrar for testing
+type mockRegistrar struct {
+	urls map[string]string
+}
+
+func (m *mockRegistrar) RegisterURL(reelID, rawURL string) {
+	m.urls[reelID] = rawURL
+}
+
+func TestReelInputService_AnyURL(t *testing.T) {
+	ctx := context.Background()
+	vimeo := "https://vimeo.com/76979871"
+
+	svc := NewReelInputService(nil)
+	if _, err := svc.Normalize(ctx, vimeo); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Fatalf("Normalize() without AllowAnyURL error = %v, want ErrInvalidReelInput", err)
+	}
+
+	registrar := &mockRegistrar{urls: make(map[string]string)}
+	svc.AllowAnyURL(registrar)
+
+	reel, err := svc.Normalize(ctx, vimeo)
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if !domain.IsGenericID(reel.ID) || reel.URL != vimeo || registrar.urls[reel.ID] != vimeo {
+		t.Errorf("Normalize() = %s, %s; registered %v", reel.ID, reel.URL, registrar.urls)
+	}
+
+	// Known platforms and bare IDs keep their own handling
+	reel, err = svc.Normalize(ctx, "https://www.instagram.com/reel/ABCDEF123/")
+	if err != nil || reel.ID != "ABCDEF123" {
+		t.Errorf("Normalize(reel) = %v, %v", reel, err)
+	}
+	if _, err := svc.Normalize(ctx, "not a url"); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Errorf("Normalize(garbage) error = %v, want ErrInvalidReelInput", err)
+	}
+	if len(registrar.urls) != 1 {
+		t.Errorf("registered %v, want only the Vimeo URL", registrar.urls)
+	}
+}
+
+// mockFileRegistrar implements ports.FileRegistrar for testing
+type mockFileRegistrar struct {
+	files map[string]string
+}
+
+func (m *mockFileRegistrar) RegisterFile(reelID, path string) {
+	m.files[reelID] = path
+}
+
+func TestReelInputService_LocalFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	video := filepath.Join(dir, "talk.mp4")
+	if err := os.WriteFile(video, []byte("not really a video"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewReelInputService(nil)
+	if _, err := svc.Normalize(ctx, video); !errors.Is(err, domain.ErrInvalidReelInput) {
+		t.Fatalf("Normalize() without AllowLocalFiles error = %v, want ErrInvalidReelInput", err)
+	}
+
+	registrar := &mockFileRegistrar{files: make(map[string]string)}
+	svc.AllowLocalFiles(registrar)
+
+	reel, err := svc.Normalize(ctx, video)
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if !domain.IsLocalFileID(reel.ID) || reel.Title != "talk" || registrar.files[reel.ID] != video {
+		t.Errorf("Normalize() = %s, %q; registered %v", reel.ID, reel.Title, registrar.files)
+	}
+
+	if _, err := svc.Normalize(ctx, filepath.Join(dir, "missing.wav")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Normalize(missing) error = %v, want ErrNotExist", err)
+	}
+
+	// Reel IDs are never taken for files
+	reel, err = svc.Normalize(ctx, "ABCDEF123")
+	if err != nil || reel.ID != "ABCDEF123" {
+		t.Errorf("Normalize(ID) = %v, %v", reel, err)
+	}
+}
+
+// mockPlaylistLister implements ports.PlaylistLister for testing
+type mockPlaylistLister struct {
+	videos []*domain.Reel
+	err    error
+}
+
+func (m *mockPlaylistLister) ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error) {
+	return m.videos, m.err
+}
+
+func TestReelInputService_Expand(t *testing.T) {
+	ctx := context.Background()
+	channel := "https://www.youtube.com/@creator"
+
+	svc := NewReelInputService(nil)
+	if _, err := svc.Expand(ctx, channel); err == nil {
+		t.Error("Expand(channel) without AllowPlaylists should fail rather than drop it")
+	}
+
+	lister := &mockPlaylistLister{videos: []*domain.Reel{{ID: "yt.aaaaaaaaaaa"}, {ID: "yt.bbbbbbbbbbb"}}}
+	svc.AllowPlaylists(lister)
+	reels, err := svc.Expand(ctx, channel)
+	if err != nil || len(reels) != 2 || reels[1].ID != "yt.bbbbbbbbbbb" {
+		t.Errorf("Expand(channel) = %v, %v, want the listed videos", reels, err)
+	}
+
+	reels, err = svc.Expand(ctx, "https://www.instagram.com/reel/ABCDEF123/")
+	if err != nil || len(reels) != 1 || reels[0].ID != "ABCDEF123" {
+		t.Errorf("Expand(reel) = %v, %v, want the reel alone", reels, err)
+	}
+
+	lister.err = domain.ErrNetworkFailure
+	if _, err := svc.Expand(ctx, channel); !errors.Is(err, domain.ErrNetworkFailure) {
+		t.Errorf("Expand(channel) error = %v, want the listing error", err)
+	}
+}
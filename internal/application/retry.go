@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// retryDownload runs download until it succeeds, fails with an error that
+// is not transient or runs out of retries, backing off exponentially in
+// between. The last error is returned.
+func (s *TranscribeService) retryDownload(ctx context.Context, policy domain.RetryPolicy, download func() error) error {
+	err := download()
+	for retry := 1; retry <= policy.Retries && domain.IsTransient(err); retry++ {
+		if waitErr := s.sleep(ctx, policy.Delay(retry)); waitErr != nil {
+			return err
+		}
+		err = download()
+	}
+	return err
+}
+
+// sleepContext sleeps for d, returning early with the context's error
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// flakyDownloader fails DownloadAudio with err for the first failures calls
+type flakyDownloader struct {
+	mockDownloader
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakyDownloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return f.mockDownloader.DownloadAudio(ctx, reelID, destDir)
+}
+
+func TestTranscribe_RetriesTransientDownloadFailures(t *testing.T) {
+	downloader := &flakyDownloader{mockDownloader: mockDownloader{available: true}, failures: 2, err: domain.ErrRateLimited}
+	svc := NewTranscribeService(newMockCache(), downloader, &mockTranscriber{modelDownloaded: true}, time.Hour)
+	var waits []time.Duration
+	svc.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	opts := TranscribeOptions{DownloadRetry: domain.RetryPolicy{Retries: 2, Backoff: time.Second}}
+	if _, err := svc.Transcribe(context.Background(), "flaky123", opts); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if downloader.calls != 3 {
+		t.Errorf("DownloadAudio called %d times, want 3", downloader.calls)
+	}
+	if len(waits) != 2 || waits[0] != time.Second || waits[1] != 2*time.Second {
+		t.Errorf("backoff waits = %v, want [1s 2s]", waits)
+	}
+}
+
+func TestTranscribe_DownloadRetryLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retries   int
+		wantCalls int
+	}{
+		{"permanent errors are not retried", domain.ErrReelNotFound, 3, 1},
+		{"retries run out", domain.ErrNetworkFailure, 1, 2},
+		{"retries disabled", domain.ErrRateLimited, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downloader := &flakyDownloader{mockDownloader: mockDownloader{available: true}, failures: 10, err: tt.err}
+			svc := NewTranscribeService(newMockCache(), downloader, &mockTranscriber{modelDownloaded: true}, time.Hour)
+			svc.sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+			opts := TranscribeOptions{DownloadRetry: domain.RetryPolicy{Retries: tt.retries, Backoff: time.Second}}
+			_, err := svc.Transcribe(context.Background(), "flaky123", opts)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Transcribe() error = %v, want %v", err, tt.err)
+			}
+			if downloader.calls != tt.wantCalls {
+				t.Errorf("DownloadAudio called %d times, want %d", downloader.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+	"github.com/devbush/ig2insights/internal/ports"
+)
+
+// SessionStatus describes the stored Instagram login
+type SessionStatus struct {
+	Session *domain.Session
+	Source  domain.SessionSource
+	Err     error // nil when valid, otherwise why the session can't be used
+}
+
+// SessionService imports, validates and refreshes the Instagram login that
+// downloads use
+type SessionService struct {
+	store    ports.SessionStore
+	exporter ports.CookieExporter // nil when the downloader can't read browsers
+	now      func() time.Time
+}
+
+// NewSessionService creates a new session service. Browser imports need a
+// downloader that implements ports.CookieExporter.
+func NewSessionService(store ports.SessionStore, downloader ports.VideoDownloader) *SessionService {
+	exporter, _ := downloader.(ports.CookieExporter)
+	return &SessionService{store: store, exporter: exporter, now: time.Now}
+}
+
+// Login imports a session from source, replacing any stored one. Only
+// Instagram's cookies are kept. It fails without saving when source has no
+// valid Instagram login.
+func (s *SessionService) Login(ctx context.Context, source domain.SessionSource) (*domain.Session, error) {
+	cookieJar, err := s.read(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	cookieJar = domain.InstagramCookies(cookieJar)
+
+	session, err := domain.ParseSession(cookieJar)
+	if err == nil {
+		err = session.Validate(s.now())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s; log in to instagram.com there first", err, source)
+	}
+
+	if err := s.store.Save(ctx, cookieJar, source); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Refresh re-imports the stored session from where it came from
+func (s *SessionService) Refresh(ctx context.Context) (*domain.Session, error) {
+	_, source, err := s.store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if source == (domain.SessionSource{}) {
+		return nil, errors.New("the stored session's source is unknown; log in again")
+	}
+	return s.Login(ctx, source)
+}
+
+// Status reports the stored session and whether it is usable
+func (s *SessionService) Status(ctx context.Context) (SessionStatus, error) {
+	cookieJar, source, err := s.store.Load(ctx)
+	if err != nil {
+		return SessionStatus{}, err
+	}
+
+	status := SessionStatus{Source: source}
+	status.Session, status.Err = domain.ParseSession(cookieJar)
+	if status.Err == nil {
+		status.Err = status.Session.Validate(s.now())
+	}
+	return status, nil
+}
+
+// Logout removes the stored session
+func (s *SessionService) Logout(ctx context.Context) error {
+	return s.store.Delete(ctx)
+}
+
+// CookiesPath returns the stored cookie jar for the downloader, or "" when
+// no session is stored
+func (s *SessionService) CookiesPath() string {
+	path := s.store.CookiesPath()
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func (s *SessionService) read(ctx context.Context, source domain.SessionSource) ([]byte, error) {
+	if source.File != "" {
+		return os.ReadFile(source.File)
+	}
+	if s.exporter == nil {
+		return nil, errors.New("reading browser cookies is not supported by this downloader")
+	}
+	return s.exporter.ExportCookies(ctx, source.Browser)
+}
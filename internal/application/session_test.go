@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+type memSessionStore struct {
+	jar    []byte
+	source domain.SessionSource
+}
+
+func (m *memSessionStore) Load(ctx context.Context) ([]byte, domain.SessionSource, error) {
+	if m.jar == nil {
+		return nil, domain.SessionSource{}, domain.ErrNoSession
+	}
+	return m.jar, m.source, nil
+}
+
+func (m *memSessionStore) Save(ctx context.Context, jar []byte, source domain.SessionSource) error {
+	m.jar, m.source = jar, source
+	return nil
+}
+
+func (m *memSessionStore) Delete(ctx context.Context) error {
+	m.jar, m.source = nil, domain.SessionSource{}
+	return nil
+}
+
+func (m *memSessionStore) CookiesPath() string { return "" }
+
+// cookieDownloader exports a fixed cookie jar for any browser
+type cookieDownloader struct {
+	mockDownloader
+	jar      string
+	browsers []string
+}
+
+func (m *cookieDownloader) ExportCookies(ctx context.Context, browser string) ([]byte, error) {
+	m.browsers = append(m.browsers, browser)
+	return []byte(m.jar), nil
+}
+
+func TestSessionService_LoginAndRefresh(t *testing.T) {
+	store := &memSessionStore{}
+	downloader := &cookieDownloader{
+		jar: "#HttpOnly_.instagram.com\tTRUE\t/\tTRUE\t1893456000\tsessionid\t123%3Axyz\n" +
+			".instagram.com\tTRUE\t/\tTRUE\t1893456000\tds_user_id\t123\n" +
+			".bank.example\tTRUE\t/\tTRUE\t1893456000\tsession\tsecret\n",
+	}
+	svc := NewSessionService(store, downloader)
+	svc.now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	session, err := svc.Login(ctx, domain.SessionSource{Browser: "firefox"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if session.UserID != "123" || store.source.Browser != "firefox" {
+		t.Errorf("Login() = %+v, stored source %+v", session, store.source)
+	}
+	if strings.Contains(string(store.jar), "bank.example") {
+		t.Errorf("stored jar = %q, want only Instagram's cookies", store.jar)
+	}
+
+	if _, err := svc.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if len(downloader.browsers) != 2 || downloader.browsers[1] != "firefox" {
+		t.Errorf("browsers read = %v, want firefox twice", downloader.browsers)
+	}
+
+	status, err := svc.Status(ctx)
+	if err != nil || status.Err != nil {
+		t.Errorf("Status() = %+v, %v; want a valid session", status, err)
+	}
+
+	// After expiry the session is reported as unusable
+	svc.now = func() time.Time { return time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC) }
+	if status, _ := svc.Status(ctx); !errors.Is(status.Err, domain.ErrSessionExpired) {
+		t.Errorf("Status().Err = %v, want ErrSessionExpired", status.Err)
+	}
+}
+
+func TestSessionService_LoginRejectsMissingSession(t *testing.T) {
+	store := &memSessionStore{}
+	svc := NewSessionService(store, &mockDownloader{})
+
+	file := filepath.Join(t.TempDir(), "cookies.txt")
+	if err := os.WriteFile(file, []byte("# Netscape HTTP Cookie File\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := svc.Login(context.Background(), domain.SessionSource{File: file})
+	if !errors.Is(err, domain.ErrNoSession) {
+		t.Errorf("Login() error = %v, want ErrNoSession", err)
+	}
+	if store.jar != nil {
+		t.Error("a file without a login should not be stored")
+	}
+
+	// The mock downloader can't read browsers
+	if _, err := svc.Login(context.Background(), domain.SessionSource{Browser: "firefox"}); err == nil {
+		t.Error("expected error reading a browser without a cookie exporter")
+	}
+}
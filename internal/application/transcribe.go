@@ -2,8 +2,11 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/devbush/ig2insights/internal/domain"
@@ -13,6 +16,12 @@ import (
 const (
 	defaultModel    = "small"
 	defaultLanguage = "auto"
+
+	// retryTemperatureStep is added to the sampling temperature on each retry
+	retryTemperatureStep = 0.2
+
+	// rawOutputFile holds the transcriber's raw output in the reel's cache directory
+	rawOutputFile = "transcript.raw.json.gz"
 )
 
 // TranscribeOptions configures the transcription
@@ -20,11 +29,34 @@ type TranscribeOptions struct {
 	Model         string
 	Format        string // text, srt, json
 	NoCache       bool
-	Language      string // empty defaults to "auto"
-	SaveAudio     bool   // Save WAV audio file
-	SaveVideo     bool   // Save MP4 video file
+	Language      string             // empty defaults to "auto"
+	Prompt        string             // initial prompt with vocabulary hints for whisper
+	Timeout       time.Duration      // per-attempt transcription timeout; zero disables
+	Retries       int                // extra attempts after a timeout or empty transcript
+	FallbackModel string             // model used for retries; empty keeps Model
+	UseSubtitles  bool               // use the platform's subtitles when available instead of whisper
+	MaxDuration   time.Duration      // refuse to transcribe reels longer than this; zero disables
+	ChunkLength   time.Duration      // transcribe reels longer than this in chunks of this length; zero disables
+	DownloadRetry domain.RetryPolicy // retries of rate-limited or failed downloads
+	SaveAudio     bool               // Save WAV audio file
+	SaveVideo     bool               // Save MP4 video file
+	VideoQuality  string             // from domain.ParseVideoQuality; empty means best
 	SaveThumbnail bool
 	OutputDir     string // directory for outputs
+
+	// OnStage, when set, is called as the reel starts downloading and
+	// transcribing. Stages served from cache are not reported.
+	OnStage func(domain.JobState)
+
+	// OnChunk, when set, is called as each chunk of a long reel starts
+	// transcribing, numbered from 1
+	OnChunk func(chunk, total int)
+}
+
+func (o TranscribeOptions) notify(state domain.JobState) {
+	if o.OnStage != nil {
+		o.OnStage(state)
+	}
 }
 
 // TranscribeResult contains the transcription result
@@ -40,6 +72,21 @@ type TranscribeResult struct {
 	AudioFromCache      bool
 	VideoFromCache      bool
 	ThumbnailFromCache  bool
+
+	// Time spent downloading and running whisper; zero for cached steps
+	DownloadDuration   time.Duration
+	TranscribeDuration time.Duration
+
+	// Provenance is attached by callers that request it and included in JSON output
+	Provenance *domain.Provenance
+
+	// TimeSaved estimates the downloading and transcribing the cache hits
+	// skipped; attached by callers and included in JSON output
+	TimeSaved time.Duration
+
+	// Files maps output kinds to the files written for this result; attached
+	// by callers as they write outputs and included in JSON output
+	Files map[string]string
 }
 
 // TranscribeService orchestrates the transcription process
@@ -48,6 +95,9 @@ type TranscribeService struct {
 	downloader  ports.VideoDownloader
 	transcriber ports.Transcriber
 	cacheTTL    time.Duration
+	mediaTTL    time.Duration
+	fallbacks   []TranscriberFallback
+	sleep       func(ctx context.Context, d time.Duration) error
 }
 
 // NewTranscribeService creates a new transcription service
@@ -62,12 +112,26 @@ func NewTranscribeService(
 		downloader:  downloader,
 		transcriber: transcriber,
 		cacheTTL:    cacheTTL,
+		sleep:       sleepContext,
 	}
 }
 
+// SetMediaTTL makes cached audio, video and thumbnails expire ttl after
+// they're cached, ahead of the transcript. Zero keeps them as long as the
+// transcript.
+func (s *TranscribeService) SetMediaTTL(ttl time.Duration) {
+	s.mediaTTL = ttl
+}
+
+// CacheExpiry returns when an item cached at now expires, and when its media
+// does
+func (s *TranscribeService) CacheExpiry(now time.Time) (expiresAt, mediaExpiresAt time.Time) {
+	return cacheExpiry(now, s.cacheTTL, s.mediaTTL)
+}
+
 // cacheState tracks what assets are available from cache
 type cacheState struct {
-	item         *ports.CachedItem
+	item          *ports.CachedItem
 	hasTranscript bool
 	hasAudio      bool
 	hasVideo      bool
@@ -78,22 +142,49 @@ type cacheState struct {
 func (s *TranscribeService) Transcribe(ctx context.Context, reelID string, opts TranscribeOptions) (*TranscribeResult, error) {
 	cacheDir := s.cache.GetCacheDir(reelID)
 	cache := s.loadCacheState(ctx, reelID, opts.NoCache)
+	if cache.hasTranscript && cache.item.Transcript.Source != "" && !opts.UseSubtitles {
+		// Platform subtitles are only reused when asked for; whisper runs otherwise
+		cache.hasTranscript = false
+	}
 
 	reel := s.reelFromCache(cache)
-	audioPath, reel, err := s.resolveAudio(ctx, reelID, cacheDir, opts, cache, reel)
-	if err != nil {
-		return nil, err
-	}
+	downloadStart := time.Now()
+	subtitles, reel := s.resolveSubtitles(ctx, reelID, cacheDir, opts, cache, reel)
 
-	transcript, err := s.resolveTranscript(ctx, audioPath, opts, cache)
+	audioPath, reel, err := s.resolveAudio(ctx, reelID, cacheDir, opts, cache, reel, subtitles == nil)
 	if err != nil {
 		return nil, err
 	}
+	downloadDuration := time.Since(downloadStart)
+
+	var transcribeDuration time.Duration
+	transcript := subtitles
+	if transcript == nil {
+		if !cache.hasTranscript {
+			if err := reel.CheckDuration(opts.MaxDuration); err != nil {
+				return nil, err
+			}
+		}
+		transcribeStart := time.Now()
+		transcript, err = s.resolveTranscript(ctx, audioPath, cacheDir, opts, cache, reel)
+		if err != nil {
+			return nil, err
+		}
+		if !cache.hasTranscript {
+			transcribeDuration = time.Since(transcribeStart)
+		}
+	}
 
+	mediaStart := time.Now()
 	videoPath := s.resolveVideo(ctx, reelID, cacheDir, opts, cache)
 	thumbnailPath := s.resolveThumbnail(ctx, reelID, cacheDir, opts, cache)
+	downloadDuration += time.Since(mediaStart)
 
-	s.updateCache(ctx, reelID, reel, transcript, audioPath, videoPath, thumbnailPath, cache)
+	rawOutputPath := ""
+	if subtitles == nil {
+		rawOutputPath = s.resolveRawOutput(cacheDir, cache)
+	}
+	s.updateCache(ctx, reelID, reel, transcript, audioPath, videoPath, thumbnailPath, rawOutputPath, cache)
 
 	return &TranscribeResult{
 		Reel:                reel,
@@ -103,11 +194,42 @@ func (s *TranscribeService) Transcribe(ctx context.Context, reelID string, opts
 		ThumbnailPath:       thumbnailPath,
 		TranscriptFromCache: cache.hasTranscript,
 		AudioFromCache:      cache.hasAudio && (opts.SaveAudio || !cache.hasTranscript),
-		VideoFromCache:      cache.hasVideo && opts.SaveVideo,
+		VideoFromCache:      cache.hasVideo && opts.SaveVideo && videoPath == cache.item.VideoPath,
 		ThumbnailFromCache:  cache.hasThumbnail && opts.SaveThumbnail,
+		DownloadDuration:    downloadDuration,
+		TranscribeDuration:  transcribeDuration,
 	}, nil
 }
 
+// Prefetch downloads a reel's audio into the cache without transcribing it,
+// so transcribing it later needs no network. It reports whether the audio
+// was already cached.
+func (s *TranscribeService) Prefetch(ctx context.Context, reelID string, retry domain.RetryPolicy) (bool, error) {
+	cache := s.loadCacheState(ctx, reelID, false)
+	if cache.hasAudio {
+		return true, nil
+	}
+
+	opts := TranscribeOptions{SaveAudio: true, DownloadRetry: retry}
+	audioPath, reel, err := s.resolveAudio(ctx, reelID, s.cache.GetCacheDir(reelID), opts, cache, s.reelFromCache(cache), false)
+	if err != nil {
+		return false, err
+	}
+
+	// Keep whatever else is cached for the reel
+	var transcript *domain.Transcript
+	var videoPath, thumbnailPath, rawOutputPath string
+	if cache.item != nil {
+		transcript = cache.item.Transcript
+		videoPath, thumbnailPath, rawOutputPath = cache.item.VideoPath, cache.item.ThumbnailPath, cache.item.RawOutputPath
+		if reel == nil {
+			reel = cache.item.Reel
+		}
+	}
+	s.updateCache(ctx, reelID, reel, transcript, audioPath, videoPath, thumbnailPath, rawOutputPath, cache)
+	return false, nil
+}
+
 func (s *TranscribeService) loadCacheState(ctx context.Context, reelID string, noCache bool) cacheState {
 	if noCache {
 		return cacheState{}
@@ -140,8 +262,9 @@ func (s *TranscribeService) resolveAudio(
 	opts TranscribeOptions,
 	cache cacheState,
 	reel *domain.Reel,
+	forTranscript bool,
 ) (string, *domain.Reel, error) {
-	needTranscript := !cache.hasTranscript
+	needTranscript := forTranscript && !cache.hasTranscript
 	needAudio := (opts.SaveAudio || needTranscript) && !cache.hasAudio
 
 	if !needAudio {
@@ -151,18 +274,86 @@ func (s *TranscribeService) resolveAudio(
 		return "", reel, nil
 	}
 
-	result, err := s.downloader.DownloadAudio(ctx, reelID, cacheDir)
+	opts.notify(domain.JobDownloading)
+	var result *ports.DownloadResult
+	err := s.retryDownload(ctx, opts.DownloadRetry, func() (err error) {
+		result, err = s.downloader.DownloadAudio(ctx, reelID, cacheDir)
+		return err
+	})
 	if err != nil {
 		return "", nil, err
 	}
 	return result.AudioPath, result.Reel, nil
 }
 
+// resolveSubtitles returns the platform's subtitles when requested, not
+// already cached and supported by the downloader. Any failure falls back to
+// whisper, which reports download problems itself.
+func (s *TranscribeService) resolveSubtitles(
+	ctx context.Context,
+	reelID, cacheDir string,
+	opts TranscribeOptions,
+	cache cacheState,
+	reel *domain.Reel,
+) (*domain.Transcript, *domain.Reel) {
+	if !opts.UseSubtitles || cache.hasTranscript {
+		return nil, reel
+	}
+	fetcher, ok := s.downloader.(ports.SubtitleFetcher)
+	if !ok {
+		return nil, reel
+	}
+
+	opts.notify(domain.JobDownloading)
+	result, err := fetcher.DownloadSubtitles(ctx, reelID, cacheDir, opts.Language)
+	if err != nil || result == nil || result.Transcript == nil {
+		return nil, reel
+	}
+	if result.Reel != nil {
+		reel = result.Reel
+	}
+	return result.Transcript, reel
+}
+
+// FetchSubtitles returns the platform's subtitles for a reel, or nil when it
+// has none or the downloader cannot fetch subtitles
+func (s *TranscribeService) FetchSubtitles(ctx context.Context, reelID, language string) (*domain.Transcript, error) {
+	fetcher, ok := s.downloader.(ports.SubtitleFetcher)
+	if !ok {
+		return nil, nil
+	}
+	result, err := fetcher.DownloadSubtitles(ctx, reelID, s.cache.GetCacheDir(reelID), language)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return result.Transcript, nil
+}
+
+// FetchComments returns up to limit of the reel's most liked comments
+func (s *TranscribeService) FetchComments(ctx context.Context, reelID string, limit int) ([]domain.Comment, error) {
+	fetcher, ok := s.downloader.(ports.CommentFetcher)
+	if !ok {
+		return nil, errors.New("fetching comments is not supported by this downloader")
+	}
+	return fetcher.FetchComments(ctx, reelID, limit)
+}
+
+// CarouselSlides returns the videos of a carousel post, or nil when the post
+// is a single video or the downloader cannot list carousels
+func (s *TranscribeService) CarouselSlides(ctx context.Context, shortcode string) ([]*domain.Reel, error) {
+	lister, ok := s.downloader.(ports.CarouselLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.ListSlides(ctx, shortcode)
+}
+
 func (s *TranscribeService) resolveTranscript(
 	ctx context.Context,
-	audioPath string,
+	audioPath, cacheDir string,
 	opts TranscribeOptions,
 	cache cacheState,
+	reel *domain.Reel,
 ) (*domain.Transcript, error) {
 	if cache.hasTranscript {
 		return cache.item.Transcript, nil
@@ -178,10 +369,121 @@ func (s *TranscribeService) resolveTranscript(
 		language = defaultLanguage
 	}
 
-	return s.transcriber.Transcribe(ctx, audioPath, ports.TranscribeOpts{
-		Model:    model,
-		Language: language,
-	})
+	opts.notify(domain.JobTranscribing)
+
+	var length time.Duration
+	if reel != nil {
+		length = time.Duration(reel.DurationSeconds) * time.Second
+	}
+
+	var transcript *domain.Transcript
+	var err error
+	if chunks := domain.SplitDuration(length, opts.ChunkLength); chunks != nil {
+		transcript, err = s.transcribeChunks(ctx, audioPath, cacheDir, model, language, opts, chunks)
+	} else {
+		transcript, err = s.transcribeWithRetries(ctx, audioPath, cacheDir, model, language, opts, domain.AudioChunk{})
+	}
+	if err != nil && ctx.Err() == nil && len(s.fallbacks) > 0 {
+		return s.transcribeFallbacks(ctx, audioPath, cacheDir, model, language, opts, err)
+	}
+	return transcript, err
+}
+
+// transcribeChunks transcribes long audio one chunk at a time, so that
+// timeouts and retries apply per chunk and progress can be reported, then
+// merges the chunks' transcripts. The raw output isn't kept for chunked audio.
+func (s *TranscribeService) transcribeChunks(
+	ctx context.Context,
+	audioPath, cacheDir, model, language string,
+	opts TranscribeOptions,
+	chunks []domain.AudioChunk,
+) (*domain.Transcript, error) {
+	os.Remove(filepath.Join(cacheDir, rawOutputFile))
+
+	// The last chunk runs to the end in case the reel's duration is rounded down
+	chunks[len(chunks)-1].Length = 0
+
+	parts := make([]*domain.Transcript, 0, len(chunks))
+	for i, chunk := range chunks {
+		if opts.OnChunk != nil {
+			opts.OnChunk(i+1, len(chunks))
+		}
+		part, err := s.transcribeWithRetries(ctx, audioPath, cacheDir, model, language, opts, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+		parts = append(parts, part)
+	}
+	return domain.MergeTranscripts(parts), nil
+}
+
+// transcribeWithRetries runs the primary transcriber over window (all of
+// the audio when zero), retrying timeouts and empty transcripts up to
+// opts.Retries times
+func (s *TranscribeService) transcribeWithRetries(
+	ctx context.Context,
+	audioPath, cacheDir, model, language string,
+	opts TranscribeOptions,
+	window domain.AudioChunk,
+) (*domain.Transcript, error) {
+	var transcript *domain.Transcript
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		tOpts := ports.TranscribeOpts{
+			Model:    model,
+			Language: language,
+			Prompt:   opts.Prompt,
+			Offset:   window.Start,
+			Duration: window.Length,
+		}
+		if window == (domain.AudioChunk{}) {
+			tOpts.RawOutputPath = filepath.Join(cacheDir, rawOutputFile)
+		}
+		if attempt > 0 {
+			if opts.FallbackModel != "" {
+				tOpts.Model = opts.FallbackModel
+			}
+			tOpts.Temperature = float64(attempt) * retryTemperatureStep
+		}
+
+		result, err := s.transcribeAttempt(ctx, s.transcriber, audioPath, tOpts, opts.Timeout)
+		if err != nil {
+			// Only timeouts are retried; parent cancellation and other failures are final
+			if errors.Is(err, domain.ErrTranscriptionTimeout) && attempt < opts.Retries {
+				continue
+			}
+			return nil, err
+		}
+
+		transcript = result
+		if strings.TrimSpace(transcript.ToText()) != "" {
+			break
+		}
+	}
+
+	// An empty transcript after all retries is returned as-is; the reel may have no speech
+	return transcript, nil
+}
+
+// transcribeAttempt runs a single transcription, bounded by timeout when non-zero
+func (s *TranscribeService) transcribeAttempt(
+	ctx context.Context,
+	transcriber ports.Transcriber,
+	audioPath string,
+	opts ports.TranscribeOpts,
+	timeout time.Duration,
+) (*domain.Transcript, error) {
+	if timeout <= 0 {
+		return transcriber.Transcribe(ctx, audioPath, opts)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	transcript, err := transcriber.Transcribe(attemptCtx, audioPath, opts)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w after %s", domain.ErrTranscriptionTimeout, timeout)
+	}
+	return transcript, err
 }
 
 func (s *TranscribeService) resolveVideo(
@@ -194,7 +496,8 @@ func (s *TranscribeService) resolveVideo(
 		return ""
 	}
 
-	if cache.hasVideo {
+	videoName := VideoCacheName(opts.VideoQuality)
+	if cache.hasVideo && filepath.Base(cache.item.VideoPath) == videoName {
 		return cache.item.VideoPath
 	}
 
@@ -202,13 +505,43 @@ func (s *TranscribeService) resolveVideo(
 		return ""
 	}
 
-	videoPath := filepath.Join(cacheDir, "video.mp4")
-	if err := s.downloader.DownloadVideo(ctx, reelID, videoPath); err != nil {
+	videoPath := filepath.Join(cacheDir, videoName)
+	download := func() error { return s.DownloadVideo(ctx, reelID, videoPath, opts.VideoQuality) }
+	if err := s.retryDownload(ctx, opts.DownloadRetry, download); err != nil {
 		return ""
 	}
+	if cache.hasVideo {
+		RemoveReplacedVideo(cache.item.VideoPath, videoPath)
+	}
 	return videoPath
 }
 
+// DownloadVideo downloads the reel's video at quality, or the downloader's
+// one version when it can't choose
+func (s *TranscribeService) DownloadVideo(ctx context.Context, reelID, destPath, quality string) error {
+	if downloader, ok := s.downloader.(ports.VideoQualityDownloader); ok && quality != "" {
+		return downloader.DownloadVideoQuality(ctx, reelID, destPath, quality)
+	}
+	return s.downloader.DownloadVideo(ctx, reelID, destPath)
+}
+
+// VideoCacheName is the name a video of quality is cached under, so a
+// preview copy is never served when the full video is wanted, or vice versa
+func VideoCacheName(quality string) string {
+	if quality == "" || quality == domain.VideoQualityBest {
+		return "video.mp4"
+	}
+	return "video." + quality + ".mp4"
+}
+
+// RemoveReplacedVideo deletes a cached video of another quality once newPath
+// takes its place in the entry, which would otherwise keep it on disk unlisted
+func RemoveReplacedVideo(oldPath, newPath string) {
+	if oldPath != "" && oldPath != newPath {
+		_ = os.Remove(oldPath)
+	}
+}
+
 func (s *TranscribeService) resolveThumbnail(
 	ctx context.Context,
 	reelID, cacheDir string,
@@ -224,7 +557,8 @@ func (s *TranscribeService) resolveThumbnail(
 	}
 
 	thumbnailPath := filepath.Join(cacheDir, "thumbnail.jpg")
-	if err := s.downloader.DownloadThumbnail(ctx, reelID, thumbnailPath); err != nil {
+	download := func() error { return s.downloader.DownloadThumbnail(ctx, reelID, thumbnailPath) }
+	if err := s.retryDownload(ctx, opts.DownloadRetry, download); err != nil {
 		return ""
 	}
 	return thumbnailPath
@@ -235,7 +569,7 @@ func (s *TranscribeService) updateCache(
 	reelID string,
 	reel *domain.Reel,
 	transcript *domain.Transcript,
-	audioPath, videoPath, thumbnailPath string,
+	audioPath, videoPath, thumbnailPath, rawOutputPath string,
 	cache cacheState,
 ) {
 	now := time.Now()
@@ -244,17 +578,32 @@ func (s *TranscribeService) updateCache(
 		createdAt = cache.item.CreatedAt
 	}
 
+	expiresAt, mediaExpiresAt := s.CacheExpiry(now)
 	_ = s.cache.Set(ctx, reelID, &ports.CachedItem{
-		Reel:          reel,
-		Transcript:    transcript,
-		AudioPath:     audioPath,
-		VideoPath:     videoPath,
-		ThumbnailPath: thumbnailPath,
-		CreatedAt:     createdAt,
-		ExpiresAt:     now.Add(s.cacheTTL),
+		Reel:           reel,
+		Transcript:     transcript,
+		AudioPath:      audioPath,
+		VideoPath:      videoPath,
+		ThumbnailPath:  thumbnailPath,
+		RawOutputPath:  rawOutputPath,
+		CreatedAt:      createdAt,
+		ExpiresAt:      expiresAt,
+		MediaExpiresAt: mediaExpiresAt,
 	})
 }
 
+// resolveRawOutput returns the raw transcriber output kept for the
+// transcript: the cached one, or the file the transcriber just saved
+func (s *TranscribeService) resolveRawOutput(cacheDir string, cache cacheState) string {
+	if cache.hasTranscript {
+		return cache.item.RawOutputPath
+	}
+	if path := filepath.Join(cacheDir, rawOutputFile); fileExists(path) {
+		return path
+	}
+	return ""
+}
+
 // fileExists checks if a file exists at the given path
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
@@ -2,6 +2,9 @@ package application
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -25,6 +28,10 @@ func (m *mockCache) Get(ctx context.Context, reelID string) (*ports.CachedItem,
 	return nil, domain.ErrCacheMiss
 }
 
+func (m *mockCache) Peek(ctx context.Context, reelID string) (*ports.CachedItem, error) {
+	return m.Get(ctx, reelID)
+}
+
 func (m *mockCache) Set(ctx context.Context, reelID string, item *ports.CachedItem) error {
 	m.items[reelID] = item
 	return nil
@@ -38,8 +45,15 @@ func (m *mockCache) Delete(ctx context.Context, reelID string) error {
 func (m *mockCache) CleanExpired(ctx context.Context) (int, error) { return 0, nil }
 func (m *mockCache) Clear(ctx context.Context) error              { return nil }
 func (m *mockCache) GetCacheDir(reelID string) string             { return "/tmp/" + reelID }
-func (m *mockCache) Stats(ctx context.Context) (int, int64, error) {
-	return len(m.items), 0, nil
+func (m *mockCache) Stats(ctx context.Context) (*ports.CacheStats, error) {
+	return &ports.CacheStats{ItemCount: len(m.items)}, nil
+}
+func (m *mockCache) List(ctx context.Context) ([]*ports.CachedItem, error) {
+	items := make([]*ports.CachedItem, 0, len(m.items))
+	for _, item := range m.items {
+		items = append(items, item)
+	}
+	return items, nil
 }
 
 type mockDownloader struct {
@@ -75,9 +89,11 @@ func (m *mockDownloader) DownloadThumbnail(ctx context.Context, reelID string, d
 
 type mockTranscriber struct {
 	modelDownloaded bool
+	lastOpts        ports.TranscribeOpts
 }
 
 func (m *mockTranscriber) Transcribe(ctx context.Context, videoPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	m.lastOpts = opts
 	return &domain.Transcript{
 		Text: "Hello world transcription",
 		Segments: []domain.Segment{
@@ -133,6 +149,69 @@ func TestTranscribeService_Transcribe(t *testing.T) {
 	}
 }
 
+func TestTranscribeService_MediaTTL(t *testing.T) {
+	cache := newMockCache()
+	downloader := &mockDownloader{available: true}
+	transcriber := &mockTranscriber{modelDownloaded: true}
+
+	svc := NewTranscribeService(cache, downloader, transcriber, 30*24*time.Hour)
+	svc.SetMediaTTL(72 * time.Hour)
+
+	before := time.Now()
+	if _, err := svc.Transcribe(context.Background(), "test123", TranscribeOptions{Model: "small"}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	item := cache.items["test123"]
+	if got := item.ExpiresAt.Sub(before); got < 30*24*time.Hour || got > 30*24*time.Hour+time.Minute {
+		t.Errorf("ExpiresAt is %v after caching, want 30 days", got)
+	}
+	if got := item.MediaExpiresAt.Sub(before); got < 72*time.Hour || got > 72*time.Hour+time.Minute {
+		t.Errorf("MediaExpiresAt is %v after caching, want 3 days", got)
+	}
+
+	// Without a media TTL, media lasts as long as the item
+	svc.SetMediaTTL(0)
+	if _, mediaExpiresAt := svc.CacheExpiry(time.Now()); !mediaExpiresAt.IsZero() {
+		t.Errorf("CacheExpiry() media = %v, want zero", mediaExpiresAt)
+	}
+}
+
+func TestTranscribeService_Prefetch(t *testing.T) {
+	cache := newMockCache()
+	transcriber := &mockTranscriber{modelDownloaded: true}
+	svc := NewTranscribeService(cache, &mockDownloader{available: true}, transcriber, time.Hour)
+
+	cached, err := svc.Prefetch(context.Background(), "test123", domain.RetryPolicy{})
+	if err != nil {
+		t.Fatalf("Prefetch() error = %v", err)
+	}
+	if cached {
+		t.Error("Prefetch() reported audio that wasn't cached as cached")
+	}
+
+	item := cache.items["test123"]
+	if item == nil || item.AudioPath != "/tmp/test123/audio.wav" || item.Transcript != nil {
+		t.Fatalf("cached item = %+v, want the audio without a transcript", item)
+	}
+	if item.Reel == nil || item.Reel.Author != "testuser" {
+		t.Errorf("cached reel = %+v, want the downloaded metadata", item.Reel)
+	}
+	if transcriber.lastOpts.Model != "" {
+		t.Error("Prefetch() transcribed the reel")
+	}
+
+	// Audio already cached isn't downloaded again
+	audio := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(audio, []byte("RIFF"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	item.AudioPath = audio
+	if cached, err := svc.Prefetch(context.Background(), "test123", domain.RetryPolicy{}); err != nil || !cached {
+		t.Errorf("Prefetch() of cached audio = %v, %v, want true, nil", cached, err)
+	}
+}
+
 func TestTranscribeService_CacheHit(t *testing.T) {
 	cache := newMockCache()
 	downloader := &mockDownloader{available: true}
@@ -163,6 +242,10 @@ func TestTranscribeService_CacheHit(t *testing.T) {
 	if !result.TranscriptFromCache {
 		t.Errorf("TranscriptFromCache should be true for cached result")
 	}
+
+	if result.TranscribeDuration != 0 {
+		t.Errorf("TranscribeDuration = %v, want 0 for cached result", result.TranscribeDuration)
+	}
 }
 
 func TestTranscribeService_NoCacheBypass(t *testing.T) {
@@ -337,3 +420,422 @@ func TestTranscribeService_ThumbnailCaching(t *testing.T) {
 		t.Errorf("ThumbnailPath should be set in cache")
 	}
 }
+
+func TestTranscribeService_PassesPrompt(t *testing.T) {
+	cache := newMockCache()
+	downloader := &mockDownloader{available: true}
+	transcriber := &mockTranscriber{modelDownloaded: true}
+
+	svc := NewTranscribeService(cache, downloader, transcriber, 24*time.Hour)
+
+	ctx := context.Background()
+	_, err := svc.Transcribe(ctx, "prompt123", TranscribeOptions{
+		Prompt: "Mavely, UGC, affiliate",
+	})
+
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	if transcriber.lastOpts.Prompt != "Mavely, UGC, affiliate" {
+		t.Errorf("Prompt = %q, want %q", transcriber.lastOpts.Prompt, "Mavely, UGC, affiliate")
+	}
+}
+
+// scriptedTranscriber returns queued responses in order, blocking until the
+// context is done when a response is nil
+type scriptedTranscriber struct {
+	mockTranscriber
+	responses []*domain.Transcript
+	calls     []ports.TranscribeOpts
+}
+
+func (m *scriptedTranscriber) Transcribe(ctx context.Context, videoPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	m.calls = append(m.calls, opts)
+	resp := m.responses[len(m.calls)-1]
+	if resp == nil {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return resp, nil
+}
+
+func TestTranscribeService_RetryOnTimeout(t *testing.T) {
+	transcriber := &scriptedTranscriber{
+		responses: []*domain.Transcript{nil, {Text: "Recovered"}},
+	}
+	svc := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, transcriber, 24*time.Hour)
+
+	result, err := svc.Transcribe(context.Background(), "hang123", TranscribeOptions{
+		Model:         "medium",
+		Timeout:       10 * time.Millisecond,
+		Retries:       1,
+		FallbackModel: "small",
+	})
+
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	if result.Transcript.Text != "Recovered" {
+		t.Errorf("Transcript text = %q, want 'Recovered'", result.Transcript.Text)
+	}
+
+	if len(transcriber.calls) != 2 {
+		t.Fatalf("Transcribe called %d times, want 2", len(transcriber.calls))
+	}
+
+	retry := transcriber.calls[1]
+	if retry.Model != "small" {
+		t.Errorf("Retry model = %s, want 'small'", retry.Model)
+	}
+	if retry.Temperature <= 0 {
+		t.Errorf("Retry temperature = %v, want > 0", retry.Temperature)
+	}
+}
+
+func TestTranscribeService_TimeoutExhausted(t *testing.T) {
+	transcriber := &scriptedTranscriber{
+		responses: []*domain.Transcript{nil},
+	}
+	svc := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, transcriber, 24*time.Hour)
+
+	_, err := svc.Transcribe(context.Background(), "hang123", TranscribeOptions{
+		Timeout: 10 * time.Millisecond,
+	})
+
+	if !errors.Is(err, domain.ErrTranscriptionTimeout) {
+		t.Errorf("Transcribe() error = %v, want ErrTranscriptionTimeout", err)
+	}
+}
+
+func TestTranscribeService_RetryOnEmptyTranscript(t *testing.T) {
+	transcriber := &scriptedTranscriber{
+		responses: []*domain.Transcript{{Text: ""}, {Text: "Second try"}},
+	}
+	svc := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, transcriber, 24*time.Hour)
+
+	result, err := svc.Transcribe(context.Background(), "empty123", TranscribeOptions{
+		Retries: 2,
+	})
+
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	if result.Transcript.Text != "Second try" {
+		t.Errorf("Transcript text = %q, want 'Second try'", result.Transcript.Text)
+	}
+
+	if len(transcriber.calls) != 2 {
+		t.Errorf("Transcribe called %d times, want 2", len(transcriber.calls))
+	}
+}
+
+func TestTranscribeService_OnStage(t *testing.T) {
+	cache := newMockCache()
+	svc := NewTranscribeService(cache, &mockDownloader{available: true}, &mockTranscriber{modelDownloaded: true}, 24*time.Hour)
+
+	var stages []domain.JobState
+	opts := TranscribeOptions{
+		Model:   "small",
+		OnStage: func(s domain.JobState) { stages = append(stages, s) },
+	}
+
+	if _, err := svc.Transcribe(context.Background(), "stage123", opts); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if len(stages) != 2 || stages[0] != domain.JobDownloading || stages[1] != domain.JobTranscribing {
+		t.Errorf("stages = %v, want [downloading transcribing]", stages)
+	}
+
+	// A cached transcript reports no stages
+	stages = nil
+	if _, err := svc.Transcribe(context.Background(), "stage123", opts); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if len(stages) != 0 {
+		t.Errorf("stages for cached reel = %v, want none", stages)
+	}
+}
+
+// mockSubtitleDownloader also serves platform subtitles when subtitles is set
+type mockSubtitleDownloader struct {
+	mockDownloader
+	subtitles     *domain.Transcript
+	audioRequests int
+}
+
+func (m *mockSubtitleDownloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	m.audioRequests++
+	return m.mockDownloader.DownloadAudio(ctx, reelID, destDir)
+}
+
+func (m *mockSubtitleDownloader) DownloadSubtitles(ctx context.Context, reelID string, destDir string, language string) (*ports.SubtitleResult, error) {
+	if m.subtitles == nil {
+		return nil, nil
+	}
+	return &ports.SubtitleResult{
+		Transcript: m.subtitles,
+		Reel:       &domain.Reel{ID: reelID, Author: "captioned"},
+	}, nil
+}
+
+func TestTranscribeService_UseSubtitles(t *testing.T) {
+	subs := &domain.Transcript{
+		Text:     "from the platform",
+		Segments: []domain.Segment{{Start: 0, End: 2, Text: "from the platform"}},
+		Source:   domain.SourceSubtitles,
+	}
+	downloader := &mockSubtitleDownloader{mockDownloader: mockDownloader{available: true}, subtitles: subs}
+	transcriber := &mockTranscriber{modelDownloaded: true}
+	svc := NewTranscribeService(newMockCache(), downloader, transcriber, 24*time.Hour)
+
+	result, err := svc.Transcribe(context.Background(), "subs123", TranscribeOptions{UseSubtitles: true})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if result.Transcript != subs {
+		t.Errorf("Transcript = %+v, want platform subtitles", result.Transcript)
+	}
+	if result.Reel == nil || result.Reel.Author != "captioned" {
+		t.Errorf("Reel = %+v, want metadata from the subtitle fetch", result.Reel)
+	}
+	if downloader.audioRequests != 0 || transcriber.lastOpts.Model != "" {
+		t.Error("audio should not be downloaded or transcribed when subtitles exist")
+	}
+
+	// Without subtitles the reel falls back to whisper
+	downloader.subtitles = nil
+	result, err = svc.Transcribe(context.Background(), "nosubs123", TranscribeOptions{UseSubtitles: true})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if result.Transcript.Text != "Hello world transcription" || downloader.audioRequests != 1 {
+		t.Errorf("expected whisper fallback, got %q after %d audio downloads", result.Transcript.Text, downloader.audioRequests)
+	}
+}
+
+func TestTranscribeService_FetchSubtitles(t *testing.T) {
+	subs := &domain.Transcript{Text: "captions", Source: domain.SourceAutoCaptions}
+	downloader := &mockSubtitleDownloader{mockDownloader: mockDownloader{available: true}, subtitles: subs}
+	svc := NewTranscribeService(newMockCache(), downloader, &mockTranscriber{}, time.Hour)
+
+	got, err := svc.FetchSubtitles(context.Background(), "abc", "auto")
+	if err != nil || got != subs {
+		t.Errorf("FetchSubtitles() = %v, %v, want the platform subtitles", got, err)
+	}
+
+	// Downloaders without subtitle support report none
+	plain := NewTranscribeService(newMockCache(), &mockDownloader{available: true}, &mockTranscriber{}, time.Hour)
+	if got, err := plain.FetchSubtitles(context.Background(), "abc", "auto"); got != nil || err != nil {
+		t.Errorf("FetchSubtitles() = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestTranscribeService_CachedSubtitlesNeedOptIn(t *testing.T) {
+	cache := newMockCache()
+	cache.items["subs123"] = &ports.CachedItem{
+		Transcript: &domain.Transcript{Text: "from the platform", Source: domain.SourceSubtitles},
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+	svc := NewTranscribeService(cache, &mockDownloader{available: true}, &mockTranscriber{modelDownloaded: true}, time.Hour)
+
+	result, err := svc.Transcribe(context.Background(), "subs123", TranscribeOptions{UseSubtitles: true})
+	if err != nil || !result.TranscriptFromCache {
+		t.Fatalf("cached subtitles should be reused when requested: %v", err)
+	}
+
+	result, err = svc.Transcribe(context.Background(), "subs123", TranscribeOptions{})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if result.TranscriptFromCache || result.Transcript.Source != "" {
+		t.Errorf("expected a fresh whisper transcript, got %+v", result.Transcript)
+	}
+}
+
+// longReelDownloader reports a reel that runs for an hour
+type longReelDownloader struct {
+	mockDownloader
+}
+
+func (m *longReelDownloader) DownloadAudio(ctx context.Context, reelID string, destDir string) (*ports.DownloadResult, error) {
+	return &ports.DownloadResult{
+		AudioPath: destDir + "/audio.wav",
+		Reel:      &domain.Reel{ID: reelID, DurationSeconds: 3600},
+	}, nil
+}
+
+func TestTranscribeService_MaxDuration(t *testing.T) {
+	transcriber := &mockTranscriber{modelDownloaded: true}
+	svc := NewTranscribeService(newMockCache(), &longReelDownloader{}, transcriber, time.Hour)
+
+	_, err := svc.Transcribe(context.Background(), "long123", TranscribeOptions{Model: "small", MaxDuration: 10 * time.Minute})
+	if !errors.Is(err, domain.ErrReelTooLong) {
+		t.Fatalf("Transcribe() error = %v, want ErrReelTooLong", err)
+	}
+	if transcriber.lastOpts.Model != "" {
+		t.Error("a reel over the limit should not be transcribed")
+	}
+
+	if _, err := svc.Transcribe(context.Background(), "long123", TranscribeOptions{Model: "small", MaxDuration: 2 * time.Hour}); err != nil {
+		t.Errorf("Transcribe() under the limit error = %v", err)
+	}
+}
+
+func TestTranscribeService_Chunked(t *testing.T) {
+	transcriber := &scriptedTranscriber{
+		responses: []*domain.Transcript{
+			{Text: "One", Segments: []domain.Segment{{Start: 0, End: 2, Text: "One"}}, Model: "small"},
+			{Text: "Two", Segments: []domain.Segment{{Start: 1500, End: 1502, Text: "Two"}}, Model: "small"},
+			{Text: "Three", Segments: []domain.Segment{{Start: 3000, End: 3002, Text: "Three"}}, Model: "small"},
+		},
+	}
+	svc := NewTranscribeService(newMockCache(), &longReelDownloader{}, transcriber, time.Hour)
+
+	var reported []int
+	result, err := svc.Transcribe(context.Background(), "chunk123", TranscribeOptions{
+		Model:       "small",
+		ChunkLength: 25 * time.Minute,
+		OnChunk: func(chunk, total int) {
+			if total != 3 {
+				t.Errorf("OnChunk total = %d, want 3", total)
+			}
+			reported = append(reported, chunk)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+
+	if len(transcriber.calls) != 3 {
+		t.Fatalf("Transcribe called %d times, want 3", len(transcriber.calls))
+	}
+	wantWindows := []struct{ offset, duration time.Duration }{
+		{0, 25 * time.Minute},
+		{25 * time.Minute, 25 * time.Minute},
+		{50 * time.Minute, 0},
+	}
+	for i, want := range wantWindows {
+		call := transcriber.calls[i]
+		if call.Offset != want.offset || call.Duration != want.duration {
+			t.Errorf("chunk %d window = %v+%v, want %v+%v", i+1, call.Offset, call.Duration, want.offset, want.duration)
+		}
+		if call.RawOutputPath != "" {
+			t.Errorf("chunk %d RawOutputPath = %q, want none", i+1, call.RawOutputPath)
+		}
+	}
+	if len(reported) != 3 || reported[0] != 1 || reported[2] != 3 {
+		t.Errorf("OnChunk reported %v, want [1 2 3]", reported)
+	}
+
+	if result.Transcript.Text != "One Two Three" {
+		t.Errorf("Transcript text = %q, want 'One Two Three'", result.Transcript.Text)
+	}
+	if len(result.Transcript.Segments) != 3 {
+		t.Errorf("Segments = %d, want 3", len(result.Transcript.Segments))
+	}
+}
+
+// rawOutputTranscriber saves a raw output file like whisper does
+type rawOutputTranscriber struct {
+	mockTranscriber
+}
+
+func (m *rawOutputTranscriber) Transcribe(ctx context.Context, videoPath string, opts ports.TranscribeOpts) (*domain.Transcript, error) {
+	if err := os.MkdirAll(filepath.Dir(opts.RawOutputPath), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(opts.RawOutputPath, []byte("raw"), 0644); err != nil {
+		return nil, err
+	}
+	return m.mockTranscriber.Transcribe(ctx, videoPath, opts)
+}
+
+func TestTranscribeService_KeepsRawOutput(t *testing.T) {
+	cache := newMockCache()
+	reelID := "raw" + filepath.Base(t.TempDir())
+	defer os.RemoveAll(cache.GetCacheDir(reelID))
+
+	svc := NewTranscribeService(cache, &mockDownloader{available: true}, &rawOutputTranscriber{}, 24*time.Hour)
+	ctx := context.Background()
+
+	if _, err := svc.Transcribe(ctx, reelID, TranscribeOptions{}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	want := filepath.Join(cache.GetCacheDir(reelID), rawOutputFile)
+	if cache.items[reelID].RawOutputPath != want {
+		t.Errorf("RawOutputPath = %q, want %q", cache.items[reelID].RawOutputPath, want)
+	}
+
+	// A cache hit keeps the raw output of the cached transcript
+	if _, err := svc.Transcribe(ctx, reelID, TranscribeOptions{}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if cache.items[reelID].RawOutputPath != want {
+		t.Errorf("RawOutputPath after cache hit = %q, want %q", cache.items[reelID].RawOutputPath, want)
+	}
+}
+
+// qualityDownloader records the video quality it was asked for
+type qualityDownloader struct {
+	mockDownloader
+	quality, path string
+}
+
+func (m *qualityDownloader) DownloadVideoQuality(ctx context.Context, reelID string, destPath string, quality string) error {
+	m.quality, m.path = quality, destPath
+	return nil
+}
+
+func TestTranscribeService_VideoQuality(t *testing.T) {
+	cache := newMockCache()
+	downloader := &qualityDownloader{mockDownloader: mockDownloader{available: true}}
+	transcriber := &mockTranscriber{modelDownloaded: true}
+
+	// The full video is cached, but a preview is wanted
+	fullVideo := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(fullVideo, []byte("full"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache.Set(context.Background(), "quality123", &ports.CachedItem{
+		Reel:       &domain.Reel{ID: "quality123"},
+		Transcript: &domain.Transcript{Text: "Cached transcript"},
+		VideoPath:  fullVideo,
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+	})
+
+	svc := NewTranscribeService(cache, downloader, transcriber, 24*time.Hour)
+
+	result, err := svc.Transcribe(context.Background(), "quality123", TranscribeOptions{
+		SaveVideo:    true,
+		VideoQuality: "720p",
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if downloader.quality != "720p" || filepath.Base(downloader.path) != "video.720p.mp4" {
+		t.Errorf("downloaded %s at %q, want video.720p.mp4 at 720p", downloader.path, downloader.quality)
+	}
+	if result.VideoFromCache || result.VideoPath != downloader.path {
+		t.Errorf("VideoPath = %s (from cache: %v), want the new preview", result.VideoPath, result.VideoFromCache)
+	}
+	if _, err := os.Stat(fullVideo); !os.IsNotExist(err) {
+		t.Errorf("replaced full video still on disk (stat error %v)", err)
+	}
+
+	// The best quality is served from the cache as before
+	if err := os.WriteFile(fullVideo, []byte("full"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	downloader.quality = ""
+	cache.items["quality123"].VideoPath = fullVideo
+	result, err = svc.Transcribe(context.Background(), "quality123", TranscribeOptions{SaveVideo: true})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if !result.VideoFromCache || result.VideoPath != fullVideo || downloader.quality != "" {
+		t.Errorf("VideoPath = %s (from cache: %v), want the cached full video", result.VideoPath, result.VideoFromCache)
+	}
+}
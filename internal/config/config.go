@@ -2,10 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -13,15 +16,46 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Defaults DefaultsConfig `yaml:"defaults"`
-	Paths    PathsConfig    `yaml:"paths"`
+	Defaults    DefaultsConfig `yaml:"defaults"`
+	Paths       PathsConfig    `yaml:"paths"`
+	Output      OutputConfig   `yaml:"output"`
+	Throttle    ThrottleConfig `yaml:"throttle"`
+	Cache       CacheConfig    `yaml:"cache,omitempty"`
+	Cookies     CookiesConfig  `yaml:"cookies,omitempty"`
+	Proxy       string         `yaml:"proxy,omitempty"`        // http, https or socks5 proxy URL for all downloads
+	Downloader  string         `yaml:"downloader,omitempty"`   // yt-dlp (default), gallery-dl or graph-api
+	YtDlpArgs   []string       `yaml:"ytdlp_args,omitempty"`   // extra options added to every yt-dlp request
+	YtDlpConfig string         `yaml:"ytdlp_config,omitempty"` // yt-dlp config files to load: user (default), none or managed
+	Aria2c      bool           `yaml:"aria2c,omitempty"`       // let aria2c download yt-dlp's fragments in parallel when installed
+	GraphAPI    GraphAPIConfig `yaml:"graph_api,omitempty"`
+	Pipeline    []PipelineStep `yaml:"pipeline,omitempty"`
+	Mirrors     MirrorsConfig  `yaml:"mirrors,omitempty"`
+
+	Fallbacks []FallbackConfig `yaml:"fallbacks,omitempty"` // backends tried in order when whisper fails
+	OpenAI    OpenAIConfig     `yaml:"openai,omitempty"`
+
+	// Commands holds flag defaults per subcommand, keyed by the command
+	// ("root", "batch", "cache warm") and then the flag name, e.g.
+	// batch: {concurrency: 5}. Flags given on the command line win.
+	Commands map[string]map[string]string `yaml:"commands,omitempty"`
 }
 
 // DefaultsConfig holds default values
 type DefaultsConfig struct {
-	Model    string `yaml:"model"`
-	Format   string `yaml:"format"`
-	CacheTTL string `yaml:"cache_ttl"`
+	Model         string `yaml:"model"`
+	Format        string `yaml:"format"`
+	CacheTTL      string `yaml:"cache_ttl"`
+	Timeout       string `yaml:"timeout,omitempty"`        // per-attempt transcription timeout (e.g., 10m)
+	Retries       int    `yaml:"retries,omitempty"`        // extra transcription attempts on timeout or empty output
+	FallbackModel string `yaml:"fallback_model,omitempty"` // model used for retries
+	Subtitles     bool   `yaml:"subtitles,omitempty"`      // use Instagram's subtitles when available instead of whisper
+	MaxDuration   string `yaml:"max_duration,omitempty"`   // skip reels longer than this (e.g., 10m)
+	ChunkLength   string `yaml:"chunk_length,omitempty"`   // transcribe longer videos in chunks of this length; "0" disables
+	AudioFormat   string `yaml:"audio_format,omitempty"`   // format --audio saves: wav (default), mp3, m4a or flac
+	VideoQuality  string `yaml:"video_quality,omitempty"`  // quality --video downloads: best (default), worst or a height like 720p
+
+	DownloadRetries int    `yaml:"download_retries"`           // retries of rate-limited or failed downloads
+	DownloadBackoff string `yaml:"download_backoff,omitempty"` // wait before the first retry, doubling after (e.g., 2s)
 }
 
 // PathsConfig holds custom path overrides
@@ -29,6 +63,167 @@ type PathsConfig struct {
 	YtDlp string `yaml:"yt_dlp"`
 }
 
+// OutputConfig holds settings for rendered output formats
+type OutputConfig struct {
+	Stats    bool           `yaml:"stats"` // prepend word count, reading time, and duration to text/markdown
+	TTML     TTMLConfig     `yaml:"ttml"`
+	ASS      ASSConfig      `yaml:"ass"`
+	SRT      SRTConfig      `yaml:"srt"`
+	Chapters ChaptersConfig `yaml:"chapters"`
+	Caption  CaptionConfig  `yaml:"caption"`
+	Locale   string         `yaml:"locale,omitempty"` // formatting of numbers and dates, e.g. de-DE; empty follows $LC_ALL/$LANG
+}
+
+// CaptionConfig controls the post caption written by --caption-bundle
+type CaptionConfig struct {
+	Hashtags []string `yaml:"hashtags"` // appended to the caption, '#' optional
+}
+
+// ChaptersConfig controls timestamped headings in long text/markdown transcripts
+type ChaptersConfig struct {
+	MinDuration string `yaml:"min_duration"` // only chapter videos at least this long
+	Interval    string `yaml:"interval"`     // target time between headings
+}
+
+// TTMLConfig holds styling defaults for TTML caption output
+type TTMLConfig struct {
+	FontFamily      string `yaml:"font_family"`
+	FontSize        string `yaml:"font_size"`
+	Color           string `yaml:"color"`
+	BackgroundColor string `yaml:"background_color"`
+	TextAlign       string `yaml:"text_align"`
+}
+
+// ASSConfig holds styling defaults for ASS/SSA subtitle output
+type ASSConfig struct {
+	FontName     string `yaml:"font_name"`
+	FontSize     int    `yaml:"font_size"`
+	PrimaryColor string `yaml:"primary_color"` // #RRGGBB
+	OutlineColor string `yaml:"outline_color"` // #RRGGBB
+	Bold         bool   `yaml:"bold"`
+	Position     string `yaml:"position"` // top, middle, bottom
+	MarginV      int    `yaml:"margin_v"`
+}
+
+// SRTConfig controls how transcript segments are shaped into SRT captions.
+// Zero values leave whisper's segments untouched.
+type SRTConfig struct {
+	MaxLineChars int    `yaml:"max_line_chars"` // wrap lines at this width
+	MaxLines     int    `yaml:"max_lines"`      // lines per caption before splitting (default 2)
+	MaxDuration  string `yaml:"max_duration"`   // split captions longer than this (e.g., 6s)
+	MinDuration  string `yaml:"min_duration"`   // merge captions shorter than this (e.g., 1s)
+}
+
+// ThrottleConfig holds named request-pacing profiles
+type ThrottleConfig struct {
+	Profile  string                     `yaml:"profile"` // used when --throttle-profile is not given; empty disables
+	Profiles map[string]ThrottleProfile `yaml:"profiles"`
+}
+
+// ThrottleProfile bundles the settings that keep Instagram from blocking requests
+type ThrottleProfile struct {
+	Concurrency      int    `yaml:"concurrency"`                  // batch workers, unless --concurrency is given
+	SleepRequests    string `yaml:"sleep_requests,omitempty"`     // pause between metadata requests (e.g., 1s)
+	SleepInterval    string `yaml:"sleep_interval,omitempty"`     // pause before each download
+	MaxSleepInterval string `yaml:"max_sleep_interval,omitempty"` // randomize download pauses up to this
+	Retries          int    `yaml:"retries,omitempty"`            // yt-dlp retries for failed requests
+	Impersonate      string `yaml:"impersonate,omitempty"`        // browser to impersonate (e.g., chrome)
+
+	// Shared by all batch workers, unlike yt-dlp's per-process sleeps
+	MinDelay          string `yaml:"min_delay,omitempty"`           // gap between starting two requests (e.g., 2s)
+	Jitter            string `yaml:"jitter,omitempty"`              // random extra gap, up to this
+	RequestsPerMinute int    `yaml:"requests_per_minute,omitempty"` // cap on requests started per minute
+}
+
+// CacheConfig bounds the reel cache and optionally shares it
+type CacheConfig struct {
+	MaxSize string         `yaml:"max_size,omitempty"` // e.g. 10GB; least recently used reels are evicted beyond it
+	TTL     CacheTTLConfig `yaml:"ttl,omitempty"`
+	S3      S3Config       `yaml:"s3,omitempty"`
+	Redis   RedisConfig    `yaml:"redis,omitempty"`
+
+	DeleteCorrupt bool `yaml:"delete_corrupt,omitempty"` // delete entries with unreadable metadata when they're read
+
+	Profile  string                  `yaml:"profile,omitempty"` // used when --cache-profile is not given; empty uses the default cache
+	Profiles map[string]CacheProfile `yaml:"profiles,omitempty"`
+}
+
+// CacheProfile is a cache kept apart from the default one, e.g. for one
+// client's work, so it can be sized and purged on its own. Profiles are
+// local: the shared bucket or Redis server only backs the default cache.
+type CacheProfile struct {
+	Dir     string `yaml:"dir,omitempty"`      // defaults to caches/<name> in the cache directory
+	MaxSize string `yaml:"max_size,omitempty"` // replaces cache.max_size for this profile
+}
+
+// CacheTTLConfig sets separate lifetimes for cached transcripts and media
+type CacheTTLConfig struct {
+	Transcript string `yaml:"transcript,omitempty"` // e.g. 30d, or never; defaults to defaults.cache_ttl
+	Media      string `yaml:"media,omitempty"`      // audio, video and thumbnails, e.g. 3d; defaults to the transcript's
+}
+
+// RedisConfig keeps cached metadata and transcripts in Redis, for workers
+// sharing a server. Media stays in the cache directory.
+type RedisConfig struct {
+	URL    string `yaml:"url,omitempty"`    // e.g. redis://:password@localhost:6379/0; rediss:// for TLS
+	Prefix string `yaml:"prefix,omitempty"` // key prefix, defaults to ig2insights:
+}
+
+// S3Config shares the cache through an S3-compatible bucket, such as AWS S3
+// or MinIO. The cache is only shared when Bucket is set.
+type S3Config struct {
+	Bucket          string `yaml:"bucket,omitempty"`
+	Prefix          string `yaml:"prefix,omitempty"`            // key prefix, e.g. team/ig2insights
+	Endpoint        string `yaml:"endpoint,omitempty"`          // e.g. https://minio.example.com; AWS when empty
+	Region          string `yaml:"region,omitempty"`            // defaults to us-east-1
+	PathStyle       bool   `yaml:"path_style,omitempty"`        // bucket in the URL path rather than the host name, as MinIO expects
+	AccessKeyID     string `yaml:"access_key_id,omitempty"`     // falls back to $AWS_ACCESS_KEY_ID
+	SecretAccessKey string `yaml:"secret_access_key,omitempty"` // falls back to $AWS_SECRET_ACCESS_KEY
+}
+
+// MirrorsConfig replaces the hosts models and binaries are downloaded from,
+// for networks that block them. Each is a base URL the usual file names are
+// appended to; empty keeps the default host.
+type MirrorsConfig struct {
+	Models  string `yaml:"models,omitempty"`  // holds ggml-<model>.bin, like huggingface.co/ggerganov/whisper.cpp/resolve/main
+	Whisper string `yaml:"whisper,omitempty"` // holds whisper.cpp's release files, like whisper-bin-x64.zip
+	YtDlp   string `yaml:"yt_dlp,omitempty"`  // holds yt-dlp's release files, like yt-dlp and yt-dlp.exe
+}
+
+// CookiesConfig logs yt-dlp in to download private and login-gated reels
+type CookiesConfig struct {
+	File        string `yaml:"file,omitempty"`         // Netscape-format cookies.txt exported from a browser
+	FromBrowser string `yaml:"from_browser,omitempty"` // browser to read cookies from (e.g., firefox, chrome:Profile 1)
+}
+
+// FallbackConfig names a transcription backend to try when the previous one fails
+type FallbackConfig struct {
+	Backend string `yaml:"backend"`         // whisper or openai
+	Model   string `yaml:"model,omitempty"` // e.g., tiny for whisper; whisper-1 for openai
+}
+
+// GraphAPIConfig holds credentials for the Instagram Graph API downloader
+type GraphAPIConfig struct {
+	AccessToken string `yaml:"access_token,omitempty"` // long-lived token; falls back to $INSTAGRAM_ACCESS_TOKEN
+	UserID      string `yaml:"user_id,omitempty"`      // Instagram business or creator account ID
+}
+
+// OpenAIConfig holds credentials for the OpenAI transcription backend
+type OpenAIConfig struct {
+	APIKey  string `yaml:"api_key,omitempty"`  // falls back to $OPENAI_API_KEY
+	BaseURL string `yaml:"base_url,omitempty"` // for OpenAI-compatible servers
+}
+
+// PipelineStep configures one post-processor. Steps run in order on every
+// transcript before it is rendered; the cached transcript is left untouched.
+type PipelineStep struct {
+	Name        string            `yaml:"name"`                  // punctuation, corrections, profanity, summarizer, exporter
+	Corrections map[string]string `yaml:"corrections,omitempty"` // corrections: heard phrase -> replacement
+	Words       []string          `yaml:"words,omitempty"`       // profanity: words to mask (built-in list if empty)
+	Sentences   int               `yaml:"sentences,omitempty"`   // summarizer: sentences to keep (default 3)
+	Formats     []string          `yaml:"formats,omitempty"`     // exporter: extra formats written next to the main output
+}
+
 // DefaultConfig returns configuration with default values
 func DefaultConfig() *Config {
 	return &Config{
@@ -36,11 +231,66 @@ func DefaultConfig() *Config {
 			Model:    "small",
 			Format:   "text",
 			CacheTTL: "7d",
+
+			ChunkLength: "10m",
+
+			DownloadRetries: 2,
+			DownloadBackoff: "2s",
+		},
+		Output: OutputConfig{
+			TTML: TTMLConfig{
+				FontFamily:      "sansSerif",
+				FontSize:        "100%",
+				Color:           "white",
+				BackgroundColor: "black",
+				TextAlign:       "center",
+			},
+			ASS: ASSConfig{
+				FontName:     "Arial",
+				FontSize:     64,
+				PrimaryColor: "#FFFFFF",
+				OutlineColor: "#000000",
+				Position:     "bottom",
+				MarginV:      200,
+			},
+			Chapters: ChaptersConfig{
+				MinDuration: "3m",
+				Interval:    "60s",
+			},
+		},
+		Throttle: ThrottleConfig{
+			Profiles: map[string]ThrottleProfile{
+				"conservative": {
+					Concurrency:       1,
+					SleepRequests:     "3s",
+					SleepInterval:     "5s",
+					MaxSleepInterval:  "15s",
+					Retries:           10,
+					MinDelay:          "3s",
+					Jitter:            "2s",
+					RequestsPerMinute: 10,
+				},
+				"balanced": {
+					Concurrency:       3,
+					SleepRequests:     "1s",
+					SleepInterval:     "2s",
+					MaxSleepInterval:  "6s",
+					Retries:           5,
+					MinDelay:          "1s",
+					Jitter:            "1s",
+					RequestsPerMinute: 30,
+				},
+				"aggressive": {
+					Concurrency: 10,
+					Retries:     3,
+				},
+			},
 		},
 	}
 }
 
-// AppDir returns the application directory (~/.ig2insights)
+// AppDir returns the application directory (~/.ig2insights), which holds
+// everything unless the XDG base directories are set
 func AppDir() string {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -49,35 +299,132 @@ func AppDir() string {
 	return filepath.Join(home, ".ig2insights")
 }
 
+// xdgDir returns the ig2insights directory under the XDG base directory
+// named by env, or AppDir when it isn't set. Relative paths are ignored, as
+// the XDG spec asks.
+func xdgDir(env string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "ig2insights")
+	}
+	return AppDir()
+}
+
+// ConfigHome returns the directory holding config files:
+// $XDG_CONFIG_HOME/ig2insights or AppDir
+func ConfigHome() string {
+	return xdgDir("XDG_CONFIG_HOME")
+}
+
+// CacheHome returns the directory holding caches:
+// $XDG_CACHE_HOME/ig2insights or AppDir
+func CacheHome() string {
+	return xdgDir("XDG_CACHE_HOME")
+}
+
+// DataHome returns the directory holding models, binaries, history and
+// logins: $XDG_DATA_HOME/ig2insights or AppDir
+func DataHome() string {
+	return xdgDir("XDG_DATA_HOME")
+}
+
+// activeProfile is the profile set with SetProfile, "" for the default one
+var activeProfile string
+
+// SetProfile switches the config file and the directories below to the
+// named profile's, so it keeps its own config, cache, history and login.
+// Models, binaries and rate-limit state are shared. "" selects the default
+// profile.
+func SetProfile(name string) error {
+	if name != "" && (name != filepath.Base(name) || name == "." || name == "..") {
+		return fmt.Errorf("invalid profile name: %s (use a plain name like client-acme)", name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// Profile returns the active profile's name, "" for the default one
+func Profile() string {
+	return activeProfile
+}
+
+// ProfileDir returns the directory holding the active profile's config:
+// ConfigHome for the default profile
+func ProfileDir() string {
+	return profileDir(ConfigHome())
+}
+
+// profileDir returns the active profile's directory under home
+func profileDir(home string) string {
+	if activeProfile == "" {
+		return home
+	}
+	return filepath.Join(home, "profiles", activeProfile)
+}
+
 // ModelsDir returns the models directory
 func ModelsDir() string {
-	return filepath.Join(AppDir(), "models")
+	return filepath.Join(DataHome(), "models")
 }
 
 // CacheDir returns the cache directory
 func CacheDir() string {
-	return filepath.Join(AppDir(), "cache")
+	return filepath.Join(profileDir(CacheHome()), "cache")
+}
+
+// CacheProfilesDir returns the directory holding cache profiles that don't
+// set a dir of their own
+func CacheProfilesDir() string {
+	return filepath.Join(profileDir(CacheHome()), "caches")
+}
+
+// CacheProfileDir returns the default directory of the named cache profile
+func CacheProfileDir(name string) string {
+	return filepath.Join(CacheProfilesDir(), name)
 }
 
 // BinDir returns the bin directory
 func BinDir() string {
-	return filepath.Join(AppDir(), "bin")
+	return filepath.Join(DataHome(), "bin")
 }
 
-// ConfigPath returns the config file path
-func ConfigPath() string {
-	return filepath.Join(AppDir(), "config.yaml")
+// RateLimitStatePath returns the file tracking recent rate-limit responses
+func RateLimitStatePath() string {
+	return filepath.Join(DataHome(), "ratelimits.json")
 }
 
-// EnsureDirs creates all required directories
-func EnsureDirs() error {
-	dirs := []string{AppDir(), ModelsDir(), CacheDir(), BinDir()}
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
+// HistoryPath returns the file recording past transcription runs
+func HistoryPath() string {
+	return filepath.Join(profileDir(DataHome()), "history.jsonl")
+}
+
+// SessionDir returns the directory holding the stored Instagram login
+func SessionDir() string {
+	return filepath.Join(profileDir(DataHome()), "session")
+}
+
+// YtDlpConfigPath returns the yt-dlp config file ig2insights manages, read
+// instead of the user's own when ytdlp_config is "managed"
+func YtDlpConfigPath() string {
+	return filepath.Join(ProfileDir(), "yt-dlp.conf")
+}
+
+// configFile is the config file set with SetConfigPath, "" for the
+// profile's
+var configFile string
+
+// SetConfigPath reads and writes settings in the file at path instead of
+// the active profile's config.yaml, e.g. one a script bundles. The cache,
+// history and login stay the profile's. "" restores the profile's file.
+func SetConfigPath(path string) {
+	configFile = path
+}
+
+// ConfigPath returns the config file path
+func ConfigPath() string {
+	if configFile != "" {
+		return configFile
 	}
-	return nil
+	return filepath.Join(ProfileDir(), "config.yaml")
 }
 
 // Load reads config from file, returns default if not exists
@@ -98,23 +445,68 @@ func Load(path string) (*Config, error) {
 	return cfg, nil
 }
 
-// LoadDefault loads config from default path
+// LoadDefault loads config from default path, with the environment's
+// overrides applied
 func LoadDefault() (*Config, error) {
-	return Load(ConfigPath())
+	return LoadWithEnv(ConfigPath())
+}
+
+// LoadWithEnv reads config like Load, then applies the project config of
+// the working directory and the environment's overrides
+func LoadWithEnv(path string) (*Config, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if project := ProjectConfigPath(); project != "" && project != path {
+		if err := cfg.ApplyProject(project); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
+// configFilePerm keeps config files private, since they can hold API keys
+// and access tokens
+const configFilePerm = 0600
+
 // Save writes config to file
 func (c *Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return writeConfigFile(path, data)
+}
+
+// writeConfigFile replaces the config file at path with data. It's written
+// to a private temporary file that's renamed over path, so the file is
+// never readable by other users, nor left half-written.
+func writeConfigFile(path string, data []byte) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(c)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return fmt.Errorf("failed to write config file: %w", err)
 	}
-
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	tmpPath := tmp.Name()
+	err = tmp.Chmod(configFilePerm)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 	return nil
@@ -125,9 +517,288 @@ func (c *Config) SaveDefault() error {
 	return c.Save(ConfigPath())
 }
 
-// GetCacheTTL returns the cache TTL as a duration
+// GetChapterSettings returns the chaptering threshold and interval.
+// An empty min_duration disables chaptering.
+func (c *Config) GetChapterSettings() (minDuration, interval time.Duration, err error) {
+	ch := c.Output.Chapters
+	if ch.MinDuration == "" {
+		return 0, 0, nil
+	}
+	minDuration, err = time.ParseDuration(ch.MinDuration)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid chapters min_duration: %s (use format like 3m)", ch.MinDuration)
+	}
+	interval, err = time.ParseDuration(ch.Interval)
+	if err != nil || interval <= 0 {
+		return 0, 0, fmt.Errorf("invalid chapters interval: %s (use format like 60s)", ch.Interval)
+	}
+	return minDuration, interval, nil
+}
+
+// GetSRTDurations returns the caption duration limits, zero when unset
+func (c *Config) GetSRTDurations() (maxDuration, minDuration time.Duration, err error) {
+	srt := c.Output.SRT
+	if srt.MaxDuration != "" {
+		if maxDuration, err = time.ParseDuration(srt.MaxDuration); err != nil {
+			return 0, 0, fmt.Errorf("invalid srt max_duration: %s (use format like 6s)", srt.MaxDuration)
+		}
+	}
+	if srt.MinDuration != "" {
+		if minDuration, err = time.ParseDuration(srt.MinDuration); err != nil {
+			return 0, 0, fmt.Errorf("invalid srt min_duration: %s (use format like 1s)", srt.MinDuration)
+		}
+	}
+	return maxDuration, minDuration, nil
+}
+
+// GetThrottleProfile returns the named profile, or the configured default
+// when name is empty. ok is false when no profile is selected.
+func (c *Config) GetThrottleProfile(name string) (profile ThrottleProfile, ok bool, err error) {
+	if name == "" {
+		name = c.Throttle.Profile
+	}
+	if name == "" {
+		return ThrottleProfile{}, false, nil
+	}
+
+	profile, ok = c.Throttle.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(c.Throttle.Profiles))
+		for n := range c.Throttle.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return ThrottleProfile{}, false, fmt.Errorf("unknown throttle profile: %s (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	for field, value := range map[string]string{
+		"sleep_requests":     profile.SleepRequests,
+		"sleep_interval":     profile.SleepInterval,
+		"max_sleep_interval": profile.MaxSleepInterval,
+		"min_delay":          profile.MinDelay,
+		"jitter":             profile.Jitter,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			return ThrottleProfile{}, false, fmt.Errorf("invalid %s in throttle profile %s: %s (use format like 2s)", field, name, value)
+		}
+	}
+	return profile, true, nil
+}
+
+// GetTimeout returns the per-attempt transcription timeout, or zero if unset
+func (c *Config) GetTimeout() (time.Duration, error) {
+	if c.Defaults.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Defaults.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout: %s (use format like 90s, 10m)", c.Defaults.Timeout)
+	}
+	return d, nil
+}
+
+// GetMaxDuration returns the longest reel to transcribe, or zero if unset
+func (c *Config) GetMaxDuration() (time.Duration, error) {
+	if c.Defaults.MaxDuration == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Defaults.MaxDuration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max_duration: %s (use format like 10m, 1h)", c.Defaults.MaxDuration)
+	}
+	return d, nil
+}
+
+// GetChunkLength returns the length of the chunks long videos are
+// transcribed in, or zero if chunking is off
+func (c *Config) GetChunkLength() (time.Duration, error) {
+	if c.Defaults.ChunkLength == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Defaults.ChunkLength)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid chunk_length: %s (use format like 10m, or 0 to disable)", c.Defaults.ChunkLength)
+	}
+	return d, nil
+}
+
+// GetDownloadBackoff returns the wait before the first download retry
+func (c *Config) GetDownloadBackoff() (time.Duration, error) {
+	if c.Defaults.DownloadBackoff == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Defaults.DownloadBackoff)
+	if err != nil {
+		return 0, fmt.Errorf("invalid download_backoff: %s (use format like 2s)", c.Defaults.DownloadBackoff)
+	}
+	return d, nil
+}
+
+// GetOpenAIAPIKey returns the configured OpenAI API key, or $OPENAI_API_KEY
+func (c *Config) GetOpenAIAPIKey() string {
+	if c.OpenAI.APIKey != "" {
+		return c.OpenAI.APIKey
+	}
+	return os.Getenv("OPENAI_API_KEY")
+}
+
+// GetGraphAPIToken returns the configured Graph API access token, or $INSTAGRAM_ACCESS_TOKEN
+func (c *Config) GetGraphAPIToken() string {
+	if c.GraphAPI.AccessToken != "" {
+		return c.GraphAPI.AccessToken
+	}
+	return os.Getenv("INSTAGRAM_ACCESS_TOKEN")
+}
+
+// GetS3Credentials returns the configured S3 access key, secret and session
+// token, falling back to $AWS_ACCESS_KEY_ID, $AWS_SECRET_ACCESS_KEY and
+// $AWS_SESSION_TOKEN
+func (c *Config) GetS3Credentials() (accessKeyID, secretAccessKey, sessionToken string) {
+	if c.Cache.S3.AccessKeyID != "" {
+		return c.Cache.S3.AccessKeyID, c.Cache.S3.SecretAccessKey, ""
+	}
+	return os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")
+}
+
+// GetMirrors returns the download mirrors, checking they're http or https
+// URLs. They're checked in the order they're documented, so the same config
+// always reports the same error.
+func (c *Config) GetMirrors() (MirrorsConfig, error) {
+	for _, mirror := range []struct{ field, value string }{
+		{"models", c.Mirrors.Models},
+		{"whisper", c.Mirrors.Whisper},
+		{"yt_dlp", c.Mirrors.YtDlp},
+	} {
+		field, value := mirror.field, mirror.value
+		if value == "" {
+			continue
+		}
+		if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return MirrorsConfig{}, fmt.Errorf("invalid %s mirror: %s (use an http or https URL)", field, value)
+		}
+	}
+	return c.Mirrors, nil
+}
+
+// NeverExpires is the TTL given as "never"
+const NeverExpires = 100 * 365 * 24 * time.Hour
+
+// GetCacheTTL returns how long cached transcripts and metadata live:
+// cache.ttl.transcript, or defaults.cache_ttl
 func (c *Config) GetCacheTTL() (time.Duration, error) {
-	return ParseDuration(c.Defaults.CacheTTL)
+	if c.Cache.TTL.Transcript != "" {
+		return parseTTL(c.Cache.TTL.Transcript)
+	}
+	return parseTTL(c.Defaults.CacheTTL)
+}
+
+// GetCacheMediaTTL returns how long cached audio, video and thumbnails
+// live, or zero when they live as long as the transcript
+func (c *Config) GetCacheMediaTTL() (time.Duration, error) {
+	if c.Cache.TTL.Media == "" {
+		return 0, nil
+	}
+	ttl, err := parseTTL(c.Cache.TTL.Media)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache ttl media: %s (use format like 24h, 3d, or never)", c.Cache.TTL.Media)
+	}
+	return ttl, nil
+}
+
+// parseTTL parses a duration like ParseDuration, or "never"
+func parseTTL(s string) (time.Duration, error) {
+	if strings.EqualFold(s, "never") {
+		return NeverExpires, nil
+	}
+	return ParseDuration(s)
+}
+
+// GetCacheMaxSize returns the cache's size cap in bytes, or zero if unset
+func (c *Config) GetCacheMaxSize() (int64, error) {
+	if c.Cache.MaxSize == "" {
+		return 0, nil
+	}
+	size, err := ParseSize(c.Cache.MaxSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache max_size: %s (use format like 500MB, 10GB)", c.Cache.MaxSize)
+	}
+	return size, nil
+}
+
+// GetCacheProfile returns the directory and size cap in bytes of the named
+// cache profile, or of the configured default profile when name is empty.
+// ok is false when no profile is selected, and the default cache is used.
+func (c *Config) GetCacheProfile(name string) (dir string, maxSize int64, ok bool, err error) {
+	return c.GetCacheProfileIn(name, CacheDir(), CacheProfilesDir())
+}
+
+// GetCacheProfileIn is GetCacheProfile with the default cache in cacheDir
+// and profiles without a dir of their own under profilesDir
+func (c *Config) GetCacheProfileIn(name, cacheDir, profilesDir string) (dir string, maxSize int64, ok bool, err error) {
+	if name == "" {
+		name = c.Cache.Profile
+	}
+	if name == "" {
+		maxSize, err = c.GetCacheMaxSize()
+		return cacheDir, maxSize, false, err
+	}
+
+	profile, found := c.Cache.Profiles[name]
+	if !found {
+		names := make([]string, 0, len(c.Cache.Profiles))
+		for n := range c.Cache.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return "", 0, false, fmt.Errorf("unknown cache profile: %s (none are configured under cache.profiles)", name)
+		}
+		return "", 0, false, fmt.Errorf("unknown cache profile: %s (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	dir = profile.Dir
+	if dir == "" {
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			return "", 0, false, fmt.Errorf("invalid cache profile name: %s (set a dir for it, or use a plain name)", name)
+		}
+		dir = filepath.Join(profilesDir, name)
+	}
+	if profile.MaxSize == "" {
+		maxSize, err = c.GetCacheMaxSize()
+		return dir, maxSize, true, err
+	}
+	if maxSize, err = ParseSize(profile.MaxSize); err != nil {
+		return "", 0, false, fmt.Errorf("invalid max_size in cache profile %s: %s (use format like 500MB, 10GB)", name, profile.MaxSize)
+	}
+	return dir, maxSize, true, nil
+}
+
+var sizePattern = regexp.MustCompile(`(?i)^(\d+(?:\.\d+)?)\s*(B|KB|MB|GB|TB)?$`)
+
+// sizeUnits are binary multiples, matching the sizes the CLI prints
+var sizeUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+	"TB": 1 << 40,
+}
+
+// ParseSize parses sizes like "500MB", "10GB" or "1.5 TB" into bytes
+func ParseSize(s string) (int64, error) {
+	matches := sizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if len(matches) != 3 {
+		return 0, fmt.Errorf("invalid size format: %s (use format like 500MB, 10GB)", s)
+	}
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(value * float64(sizeUnits[strings.ToUpper(matches[2])])), nil
 }
 
 var durationPattern = regexp.MustCompile(`^(\d+)(h|d)$`)
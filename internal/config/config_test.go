@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -19,6 +20,12 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Defaults.CacheTTL != "7d" {
 		t.Errorf("Default cache TTL = %s, want 7d", cfg.Defaults.CacheTTL)
 	}
+	if cfg.Output.TTML.FontFamily != "sansSerif" {
+		t.Errorf("Default TTML font family = %s, want sansSerif", cfg.Output.TTML.FontFamily)
+	}
+	if cfg.Output.ASS.Position != "bottom" {
+		t.Errorf("Default ASS position = %s, want bottom", cfg.Output.ASS.Position)
+	}
 }
 
 func TestParseDuration(t *testing.T) {
@@ -71,6 +78,7 @@ func TestConfig_Save_Load(t *testing.T) {
 }
 
 func TestAppDir(t *testing.T) {
+	clearXDG(t)
 	dir := AppDir()
 	if dir == "" {
 		t.Error("AppDir() returned empty string")
@@ -83,6 +91,116 @@ func TestAppDir(t *testing.T) {
 	}
 }
 
+func TestSetProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	clearXDG(t)
+	defer SetProfile("")
+
+	if err := SetProfile("acme"); err != nil {
+		t.Fatalf("SetProfile(acme) error = %v", err)
+	}
+	profileDir := filepath.Join(AppDir(), "profiles", "acme")
+	if Profile() != "acme" || ProfileDir() != profileDir {
+		t.Errorf("profile = %q in %s, want acme in %s", Profile(), ProfileDir(), profileDir)
+	}
+	if ConfigPath() != filepath.Join(profileDir, "config.yaml") || CacheDir() != filepath.Join(profileDir, "cache") || HistoryPath() != filepath.Join(profileDir, "history.jsonl") {
+		t.Errorf("profile paths = %s, %s, %s", ConfigPath(), CacheDir(), HistoryPath())
+	}
+	if ModelsDir() != filepath.Join(AppDir(), "models") || BinDir() != filepath.Join(AppDir(), "bin") {
+		t.Error("models and binaries should be shared between profiles")
+	}
+
+	for _, name := range []string{"a/b", "..", "."} {
+		if err := SetProfile(name); err == nil {
+			t.Errorf("SetProfile(%q) expected error", name)
+		}
+	}
+
+	if err := SetProfile(""); err != nil || ProfileDir() != AppDir() {
+		t.Errorf("SetProfile(\"\") = %v, dir %s; want the default profile", err, ProfileDir())
+	}
+}
+
+func TestSetConfigPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	clearXDG(t)
+	defer SetConfigPath("")
+
+	SetConfigPath("ci/ig2insights.yaml")
+	if ConfigPath() != "ci/ig2insights.yaml" {
+		t.Errorf("ConfigPath() = %s, want the file set", ConfigPath())
+	}
+	if CacheDir() != filepath.Join(AppDir(), "cache") {
+		t.Errorf("CacheDir() = %s, should stay the profile's", CacheDir())
+	}
+
+	SetConfigPath("")
+	if ConfigPath() != filepath.Join(AppDir(), "config.yaml") {
+		t.Errorf("ConfigPath() = %s, want the profile's again", ConfigPath())
+	}
+}
+
+// clearXDG unsets the XDG base directories for the test, so paths fall
+// back to AppDir
+func clearXDG(t *testing.T) {
+	for _, env := range []string{"XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_DATA_HOME"} {
+		t.Setenv(env, "")
+	}
+}
+
+func TestXDGDirs(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("HOME", base)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(base, "conf"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(base, "cache"))
+	t.Setenv("XDG_DATA_HOME", "relative/data")
+	defer SetProfile("")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"ConfigPath", ConfigPath(), filepath.Join(base, "conf", "ig2insights", "config.yaml")},
+		{"CacheDir", CacheDir(), filepath.Join(base, "cache", "ig2insights", "cache")},
+		// Relative XDG paths are ignored
+		{"ModelsDir", ModelsDir(), filepath.Join(base, ".ig2insights", "models")},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s() = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	SetProfile("acme")
+	if want := filepath.Join(base, "cache", "ig2insights", "profiles", "acme", "cache"); CacheDir() != want {
+		t.Errorf("CacheDir() = %s, want %s", CacheDir(), want)
+	}
+}
+
+func TestGetMirrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Mirrors.Models = "https://mirror.example.com/models"
+	if mirrors, err := cfg.GetMirrors(); err != nil || mirrors.Models != cfg.Mirrors.Models {
+		t.Errorf("GetMirrors() = %+v, %v", mirrors, err)
+	}
+
+	for _, bad := range []string{"mirror.example.com/yt-dlp", "ftp://mirror.example.com", "https://"} {
+		cfg.Mirrors.YtDlp = bad
+		if _, err := cfg.GetMirrors(); err == nil {
+			t.Errorf("GetMirrors() expected error for %q", bad)
+		}
+	}
+
+	// With several invalid mirrors, the first in order is always reported
+	cfg.Mirrors = MirrorsConfig{Models: "ftp://a", Whisper: "ftp://b", YtDlp: "ftp://c"}
+	for i := 0; i < 20; i++ {
+		if _, err := cfg.GetMirrors(); err == nil || !strings.Contains(err.Error(), "models") {
+			t.Fatalf("GetMirrors() error = %v, want the models mirror's", err)
+		}
+	}
+}
+
 func TestGetCacheTTL(t *testing.T) {
 	cfg := DefaultConfig()
 	dur, err := cfg.GetCacheTTL()
@@ -95,6 +213,274 @@ func TestGetCacheTTL(t *testing.T) {
 	}
 }
 
+func TestGetCacheTTL_PerAsset(t *testing.T) {
+	cfg := DefaultConfig()
+	if ttl, err := cfg.GetCacheMediaTTL(); err != nil || ttl != 0 {
+		t.Errorf("GetCacheMediaTTL() = %v, %v; want the transcript's by default", ttl, err)
+	}
+
+	cfg.Cache.TTL.Transcript = "never"
+	cfg.Cache.TTL.Media = "3d"
+	if ttl, err := cfg.GetCacheTTL(); err != nil || ttl != NeverExpires {
+		t.Errorf("GetCacheTTL() = %v, %v; want NeverExpires", ttl, err)
+	}
+	if ttl, err := cfg.GetCacheMediaTTL(); err != nil || ttl != 3*24*time.Hour {
+		t.Errorf("GetCacheMediaTTL() = %v, %v; want 3 days", ttl, err)
+	}
+
+	cfg.Cache.TTL.Media = "soon"
+	if _, err := cfg.GetCacheMediaTTL(); err == nil {
+		t.Error("GetCacheMediaTTL() expected error for an invalid TTL")
+	}
+}
+
+func TestGetCacheMaxSize(t *testing.T) {
+	cfg := DefaultConfig()
+	if size, err := cfg.GetCacheMaxSize(); err != nil || size != 0 {
+		t.Errorf("GetCacheMaxSize() = %d, %v; want no cap by default", size, err)
+	}
+
+	cfg.Cache.MaxSize = "10GB"
+	if size, err := cfg.GetCacheMaxSize(); err != nil || size != 10<<30 {
+		t.Errorf("GetCacheMaxSize() = %d, %v; want 10 GiB", size, err)
+	}
+
+	cfg.Cache.MaxSize = "ten gigs"
+	if _, err := cfg.GetCacheMaxSize(); err == nil {
+		t.Error("GetCacheMaxSize() expected error for an invalid size")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"500MB":  500 << 20,
+		"1.5 gb": 3 << 29,
+		"2TB":    2 << 40,
+		"1024":   1024,
+		"64KB":   64 << 10,
+	}
+	for input, want := range tests {
+		if got, err := ParseSize(input); err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "GB", "-1GB", "10 PB"} {
+		if _, err := ParseSize(input); err == nil {
+			t.Errorf("ParseSize(%q) expected error", input)
+		}
+	}
+}
+
+func TestGetTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+
+	timeout, err := cfg.GetTimeout()
+	if err != nil || timeout != 0 {
+		t.Errorf("GetTimeout() = %v, %v; want 0, nil when unset", timeout, err)
+	}
+
+	cfg.Defaults.Timeout = "10m"
+	timeout, err = cfg.GetTimeout()
+	if err != nil {
+		t.Fatalf("GetTimeout() error = %v", err)
+	}
+	if timeout != 10*time.Minute {
+		t.Errorf("GetTimeout() = %v, want 10m", timeout)
+	}
+
+	cfg.Defaults.Timeout = "soon"
+	if _, err := cfg.GetTimeout(); err == nil {
+		t.Error("GetTimeout() expected error for invalid duration")
+	}
+}
+
+func TestGetOpenAIAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "env-key")
+	cfg := DefaultConfig()
+
+	if got := cfg.GetOpenAIAPIKey(); got != "env-key" {
+		t.Errorf("GetOpenAIAPIKey() = %q, want the environment key", got)
+	}
+
+	cfg.OpenAI.APIKey = "config-key"
+	if got := cfg.GetOpenAIAPIKey(); got != "config-key" {
+		t.Errorf("GetOpenAIAPIKey() = %q, want the configured key", got)
+	}
+}
+
+func TestGetGraphAPIToken(t *testing.T) {
+	t.Setenv("INSTAGRAM_ACCESS_TOKEN", "env-token")
+	cfg := DefaultConfig()
+
+	if got := cfg.GetGraphAPIToken(); got != "env-token" {
+		t.Errorf("GetGraphAPIToken() = %q, want the environment token", got)
+	}
+
+	cfg.GraphAPI.AccessToken = "config-token"
+	if got := cfg.GetGraphAPIToken(); got != "config-token" {
+		t.Errorf("GetGraphAPIToken() = %q, want the configured token", got)
+	}
+}
+
+func TestGetS3Credentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "env-id")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "env-token")
+	cfg := DefaultConfig()
+
+	if id, secret, token := cfg.GetS3Credentials(); id != "env-id" || secret != "env-secret" || token != "env-token" {
+		t.Errorf("GetS3Credentials() = %q, %q, %q; want the environment credentials", id, secret, token)
+	}
+
+	cfg.Cache.S3.AccessKeyID = "config-id"
+	cfg.Cache.S3.SecretAccessKey = "config-secret"
+	if id, secret, token := cfg.GetS3Credentials(); id != "config-id" || secret != "config-secret" || token != "" {
+		t.Errorf("GetS3Credentials() = %q, %q, %q; want the configured credentials", id, secret, token)
+	}
+}
+
+func TestGetChapterSettings(t *testing.T) {
+	cfg := DefaultConfig()
+
+	minDuration, interval, err := cfg.GetChapterSettings()
+	if err != nil {
+		t.Fatalf("GetChapterSettings() error = %v", err)
+	}
+	if minDuration != 3*time.Minute || interval != time.Minute {
+		t.Errorf("GetChapterSettings() = %v, %v; want 3m, 1m", minDuration, interval)
+	}
+
+	cfg.Output.Chapters.Interval = "0s"
+	if _, _, err := cfg.GetChapterSettings(); err == nil {
+		t.Error("GetChapterSettings() expected error for zero interval")
+	}
+
+	cfg.Output.Chapters.MinDuration = ""
+	if minDuration, _, err := cfg.GetChapterSettings(); err != nil || minDuration != 0 {
+		t.Errorf("GetChapterSettings() = %v, %v; want disabled", minDuration, err)
+	}
+}
+
+func TestGetSRTDurations(t *testing.T) {
+	cfg := DefaultConfig()
+
+	maxDuration, minDuration, err := cfg.GetSRTDurations()
+	if err != nil || maxDuration != 0 || minDuration != 0 {
+		t.Errorf("GetSRTDurations() = %v, %v, %v; want disabled by default", maxDuration, minDuration, err)
+	}
+
+	cfg.Output.SRT.MaxDuration = "6s"
+	cfg.Output.SRT.MinDuration = "800ms"
+	maxDuration, minDuration, err = cfg.GetSRTDurations()
+	if err != nil {
+		t.Fatalf("GetSRTDurations() error = %v", err)
+	}
+	if maxDuration != 6*time.Second || minDuration != 800*time.Millisecond {
+		t.Errorf("GetSRTDurations() = %v, %v; want 6s, 800ms", maxDuration, minDuration)
+	}
+
+	cfg.Output.SRT.MaxDuration = "six seconds"
+	if _, _, err := cfg.GetSRTDurations(); err == nil {
+		t.Error("GetSRTDurations() expected error for invalid max_duration")
+	}
+}
+
+func TestGetThrottleProfile(t *testing.T) {
+	cfg := DefaultConfig()
+
+	if _, ok, err := cfg.GetThrottleProfile(""); ok || err != nil {
+		t.Errorf("GetThrottleProfile(\"\") = ok %v, err %v; want no profile by default", ok, err)
+	}
+
+	profile, ok, err := cfg.GetThrottleProfile("conservative")
+	if err != nil || !ok {
+		t.Fatalf("GetThrottleProfile(conservative) = ok %v, err %v", ok, err)
+	}
+	if profile.Concurrency != 1 {
+		t.Errorf("conservative concurrency = %d, want 1", profile.Concurrency)
+	}
+
+	// The configured default applies when no name is given
+	cfg.Throttle.Profile = "aggressive"
+	if profile, ok, _ := cfg.GetThrottleProfile(""); !ok || profile.Concurrency != 10 {
+		t.Errorf("GetThrottleProfile(\"\") with default = %+v, %v; want aggressive", profile, ok)
+	}
+
+	if _, _, err := cfg.GetThrottleProfile("reckless"); err == nil {
+		t.Error("GetThrottleProfile() expected error for unknown profile")
+	}
+
+	cfg.Throttle.Profiles["broken"] = ThrottleProfile{SleepInterval: "often"}
+	if _, _, err := cfg.GetThrottleProfile("broken"); err == nil {
+		t.Error("GetThrottleProfile() expected error for invalid sleep_interval")
+	}
+}
+
+func TestGetCacheProfile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := DefaultConfig()
+	cfg.Cache.MaxSize = "10GB"
+
+	dir, maxSize, ok, err := cfg.GetCacheProfile("")
+	if err != nil || ok || dir != CacheDir() || maxSize != 10<<30 {
+		t.Errorf("GetCacheProfile(\"\") = %q, %d, %v, %v; want the default cache", dir, maxSize, ok, err)
+	}
+	if _, _, _, err := cfg.GetCacheProfile("client"); err == nil {
+		t.Error("GetCacheProfile() expected error with no profiles configured")
+	}
+
+	cfg.Cache.Profiles = map[string]CacheProfile{
+		"client":   {MaxSize: "2GB"},
+		"research": {Dir: "/data/research-cache"},
+		"a/b":      {},
+		"broken":   {MaxSize: "lots"},
+	}
+	dir, maxSize, ok, err = cfg.GetCacheProfile("client")
+	if err != nil || !ok || dir != CacheProfileDir("client") || maxSize != 2<<30 {
+		t.Errorf("GetCacheProfile(client) = %q, %d, %v, %v", dir, maxSize, ok, err)
+	}
+
+	// The configured default applies when no name is given, and inherits
+	// cache.max_size
+	cfg.Cache.Profile = "research"
+	dir, maxSize, ok, err = cfg.GetCacheProfile("")
+	if err != nil || !ok || dir != "/data/research-cache" || maxSize != 10<<30 {
+		t.Errorf("GetCacheProfile(\"\") with default = %q, %d, %v, %v; want research", dir, maxSize, ok, err)
+	}
+
+	for _, name := range []string{"personal", "a/b", "broken"} {
+		if _, _, _, err := cfg.GetCacheProfile(name); err == nil {
+			t.Errorf("GetCacheProfile(%s) expected error", name)
+		}
+	}
+}
+
+func TestLoad_ThrottleProfileOverride(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `throttle:
+  profile: night
+  profiles:
+    night:
+      concurrency: 2
+      sleep_interval: 10s
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := cfg.Throttle.Profiles["conservative"]; !ok {
+		t.Error("built-in profiles should remain alongside user profiles")
+	}
+	profile, ok, err := cfg.GetThrottleProfile("")
+	if err != nil || !ok || profile.Concurrency != 2 {
+		t.Errorf("GetThrottleProfile() = %+v, %v, %v; want user profile night", profile, ok, err)
+	}
+}
+
 func TestLoad_NonExistentReturnsDefault(t *testing.T) {
 	cfg, err := Load("/nonexistent/path/config.yaml")
 	if err != nil {
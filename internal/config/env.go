@@ -0,0 +1,54 @@
+package config
+
+import "fmt"
+
+// EnvPrefix starts the names of the environment variables that override
+// config.yaml
+const EnvPrefix = "IG2INSIGHTS_"
+
+// EnvOverride is an environment variable that replaces a setting
+type EnvOverride struct {
+	Name string // without EnvPrefix
+	Key  string // the setting it replaces
+}
+
+// EnvOverrides are the settings environment variables can replace, for CI
+// and containers where writing config.yaml is awkward. Command-line flags
+// still take precedence.
+var EnvOverrides = []EnvOverride{
+	{"MODEL", "defaults.model"},
+	{"FORMAT", "defaults.format"},
+	{"CACHE_TTL", "defaults.cache_ttl"},
+	{"TIMEOUT", "defaults.timeout"},
+	{"RETRIES", "defaults.retries"},
+	{"FALLBACK_MODEL", "defaults.fallback_model"},
+	{"SUBTITLES", "defaults.subtitles"},
+	{"MAX_DURATION", "defaults.max_duration"},
+	{"CHUNK_LENGTH", "defaults.chunk_length"},
+	{"AUDIO_FORMAT", "defaults.audio_format"},
+	{"VIDEO_QUALITY", "defaults.video_quality"},
+	{"DOWNLOAD_RETRIES", "defaults.download_retries"},
+	{"DOWNLOAD_BACKOFF", "defaults.download_backoff"},
+	{"THROTTLE_PROFILE", "throttle.profile"},
+	{"CACHE_PROFILE", "cache.profile"},
+	{"CACHE_MAX_SIZE", "cache.max_size"},
+	{"COOKIES", "cookies.file"},
+	{"PROXY", "proxy"},
+	{"DOWNLOADER", "downloader"},
+	{"LOCALE", "output.locale"},
+}
+
+// ApplyEnv replaces the settings whose environment variables are set and
+// not empty. getenv is usually os.Getenv.
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	for _, o := range EnvOverrides {
+		value := getenv(EnvPrefix + o.Name)
+		if value == "" {
+			continue
+		}
+		if err := c.Set(o.Key, value); err != nil {
+			return fmt.Errorf("$%s%s: %w", EnvPrefix, o.Name, err)
+		}
+	}
+	return nil
+}
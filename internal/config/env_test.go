@@ -0,0 +1,50 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"IG2INSIGHTS_MODEL":     "medium",
+		"IG2INSIGHTS_CACHE_TTL": "30d",
+		"IG2INSIGHTS_RETRIES":   "2",
+		"IG2INSIGHTS_SUBTITLES": "true",
+		"IG2INSIGHTS_PROXY":     "socks5://127.0.0.1:1080",
+		"IG2INSIGHTS_FORMAT":    "",
+	}
+	cfg := DefaultConfig()
+	if err := cfg.ApplyEnv(func(name string) string { return env[name] }); err != nil {
+		t.Fatalf("ApplyEnv() error = %v", err)
+	}
+
+	if cfg.Defaults.Model != "medium" || cfg.Defaults.CacheTTL != "30d" || cfg.Defaults.Retries != 2 || !cfg.Defaults.Subtitles {
+		t.Errorf("defaults = %+v", cfg.Defaults)
+	}
+	if cfg.Proxy != env["IG2INSIGHTS_PROXY"] {
+		t.Errorf("proxy = %q", cfg.Proxy)
+	}
+	if cfg.Defaults.Format != "text" {
+		t.Errorf("format = %q, want the default kept for an empty variable", cfg.Defaults.Format)
+	}
+
+	err := cfg.ApplyEnv(func(name string) string {
+		if name == "IG2INSIGHTS_DOWNLOAD_RETRIES" {
+			return "lots"
+		}
+		return ""
+	})
+	if err == nil || !strings.Contains(err.Error(), "IG2INSIGHTS_DOWNLOAD_RETRIES") {
+		t.Errorf("ApplyEnv() error = %v, want one naming the variable", err)
+	}
+}
+
+func TestEnvOverrides_Keys(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, o := range EnvOverrides {
+		if _, err := cfg.Get(o.Key); err != nil {
+			t.Errorf("%s%s overrides %s: %v", EnvPrefix, o.Name, o.Key, err)
+		}
+	}
+}
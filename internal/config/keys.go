@@ -0,0 +1,403 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keys name settings the way config.yaml nests them, joined with dots, e.g.
+// defaults.model or throttle.profiles.night.concurrency
+
+// Setting is one key and its value, as config list prints them
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Get returns the value at key: scalars as they'd be written in YAML,
+// sections and lists as a YAML block. Credentials are masked, as in
+// Settings.
+func (c *Config) Get(key string) (string, error) {
+	v, err := lookupKey(reflect.ValueOf(c).Elem(), key)
+	if err != nil {
+		return "", err
+	}
+	if isScalar(v.Type()) {
+		return maskSecret(key, fmt.Sprint(v.Interface())), nil
+	}
+	var node yaml.Node
+	if err := node.Encode(v.Interface()); err != nil {
+		return "", err
+	}
+	maskNode(&node, key)
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// secretKeys are settings holding credentials, which Get and Settings mask
+var secretKeys = map[string]bool{
+	"access_token":      true,
+	"api_key":           true,
+	"secret_access_key": true,
+}
+
+// maskSecret hides the credentials in value, the setting at key: all of a
+// secret, or the password of a URL such as a proxy or Redis address
+func maskSecret(key, value string) string {
+	if value == "" {
+		return value
+	}
+	if secretKeys[key[strings.LastIndex(key, ".")+1:]] {
+		return "********"
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return u.Redacted()
+		}
+	}
+	return value
+}
+
+// maskNode masks the credentials in the scalars of node, the section at key
+func maskNode(node *yaml.Node, key string) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		node.Value = maskSecret(key, node.Value)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			maskNode(node.Content[i+1], joinKey(key, node.Content[i].Value))
+		}
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for _, item := range node.Content {
+			maskNode(item, key)
+		}
+	}
+}
+
+// Settings returns every scalar and string-list setting in file order,
+// including unset ones, so they show what can be set. Lists of sections,
+// such as fallbacks, are summarized, and credentials are masked.
+func (c *Config) Settings() []Setting {
+	var settings []Setting
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		switch {
+		case isScalar(v.Type()):
+			settings = append(settings, Setting{Key: prefix, Value: maskSecret(prefix, fmt.Sprint(v.Interface()))})
+		case isStringList(v.Type()):
+			var items []string
+			for _, item := range v.Interface().([]string) {
+				items = append(items, maskSecret(prefix, item))
+			}
+			settings = append(settings, Setting{Key: prefix, Value: strings.Join(items, ",")})
+		case v.Kind() == reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if name := yamlName(v.Type().Field(i)); name != "" {
+					walk(joinKey(prefix, name), v.Field(i))
+				}
+			}
+		case v.Kind() == reflect.Map:
+			keys := v.MapKeys()
+			names := make([]string, len(keys))
+			for i, k := range keys {
+				names[i] = k.String()
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				walk(joinKey(prefix, name), v.MapIndex(reflect.ValueOf(name)))
+			}
+		case v.Kind() == reflect.Slice:
+			settings = append(settings, Setting{Key: prefix, Value: fmt.Sprintf("(%d entries; see config get %s)", v.Len(), prefix)})
+		}
+	}
+	walk("", reflect.ValueOf(c).Elem())
+	return settings
+}
+
+// SetKey sets key to value in the config file at path, creating the file if
+// needed and keeping its other settings and comments. value is parsed for
+// the setting's type; lists take comma-separated values.
+func SetKey(path, key, value string) error {
+	typ, err := keyType(key)
+	if err != nil {
+		return err
+	}
+	valueNode, err := scalarNode(key, typ, value)
+	if err != nil {
+		return err
+	}
+
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	node := doc.Content[0]
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(node, part)
+		if child == nil || child.Kind != yaml.MappingNode {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(node, part, child)
+		}
+		node = child
+	}
+	setMappingValue(node, parts[len(parts)-1], valueNode)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), DefaultConfig()); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	return writeConfigFile(path, buf.Bytes())
+}
+
+// readDocument parses the config file at path into a YAML document whose
+// root is a mapping, which is empty when the file is missing or empty
+func readDocument(path string) (*yaml.Node, error) {
+	empty := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return empty, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return empty, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse config: %s doesn't hold a mapping of settings", path)
+	}
+	return &doc, nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key in a mapping node, or appends it
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			// Keep comments written next to the old value
+			value.LineComment = mapping.Content[i+1].LineComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// scalarNode parses value for a setting of type typ into a YAML node
+func scalarNode(key string, typ reflect.Type, value string) (*yaml.Node, error) {
+	parsed, err := parseValue(key, typ, value)
+	if err != nil {
+		return nil, err
+	}
+	switch v := parsed.(type) {
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}, nil
+	case int:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(v)}, nil
+	case float64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(v, 'g', -1, 64)}, nil
+	default:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v.([]string) {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+		}
+		return node, nil
+	}
+}
+
+// parseValue parses value for a setting of type typ: a string, bool, int,
+// float64, or []string from comma-separated values
+func parseValue(key string, typ reflect.Type, value string) (any, error) {
+	switch typ.Kind() {
+	case reflect.String:
+		return value, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s (use true or false)", key, value)
+		}
+		return b, nil
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s (use a whole number)", key, value)
+		}
+		return n, nil
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s (use a number)", key, value)
+		}
+		return f, nil
+	case reflect.Slice:
+		if !isStringList(typ) {
+			return nil, fmt.Errorf("%s holds a list of sections; edit it with config edit", key)
+		}
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("%s is a section; set one of its keys", key)
+	}
+}
+
+// Set changes the setting at key in c, parsing value as SetKey does. Keys
+// inside maps, like profile names, aren't supported.
+func (c *Config) Set(key, value string) error {
+	typ, err := keyType(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseValue(key, typ, value)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	for _, part := range strings.Split(key, ".") {
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("%s can't be set here; use config set", key)
+		}
+		field, _ := fieldByYAMLName(v.Type(), part)
+		v = v.FieldByIndex(field.Index)
+	}
+	v.Set(reflect.ValueOf(parsed))
+	return nil
+}
+
+// keyType returns the type of the setting at key. Keys inside maps, like
+// profile names, may be anything.
+func keyType(key string) (reflect.Type, error) {
+	typ := reflect.TypeOf(Config{})
+	for _, part := range strings.Split(key, ".") {
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(typ, part)
+			if !ok {
+				return nil, unknownKey(key)
+			}
+			typ = field.Type
+		case reflect.Map:
+			if part == "" {
+				return nil, unknownKey(key)
+			}
+			typ = typ.Elem()
+		default:
+			return nil, unknownKey(key)
+		}
+	}
+	return typ, nil
+}
+
+// lookupKey returns the value at key inside v
+func lookupKey(v reflect.Value, key string) (reflect.Value, error) {
+	if key == "" {
+		return v, nil
+	}
+	for _, part := range strings.Split(key, ".") {
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(v.Type(), part)
+			if !ok {
+				return reflect.Value{}, unknownKey(key)
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			value := v.MapIndex(reflect.ValueOf(part))
+			if !value.IsValid() {
+				return reflect.Value{}, fmt.Errorf("%s is not set", key)
+			}
+			v = value
+		default:
+			return reflect.Value{}, unknownKey(key)
+		}
+	}
+	return v, nil
+}
+
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown config key: %s (see config list)", key)
+}
+
+// fieldByYAMLName finds the struct field stored under name in YAML
+func fieldByYAMLName(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); yamlName(f) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// yamlName returns the key a struct field is stored under in YAML
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+func isScalar(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isStringList(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String
+}
+
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
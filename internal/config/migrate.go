@@ -0,0 +1,175 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// Move is a file or directory MigrateAppDir moved
+type Move struct {
+	From string
+	To   string
+}
+
+// rename is os.Rename, swapped by tests
+var rename = os.Rename
+
+// MigrateAppDir moves what AppDir holds to the XDG base directories that are
+// set, so upgrading to XDG keeps the config, cache, models and history.
+// Entries already present at their new place are left alone, and AppDir is
+// removed once it's empty. Nothing is moved while no XDG directory is set.
+// An entry that can't be moved doesn't stop the others; err reports every
+// one that failed.
+func MigrateAppDir() ([]Move, error) {
+	legacy := AppDir()
+	if ConfigHome() == legacy && CacheHome() == legacy && DataHome() == legacy {
+		return nil, nil
+	}
+
+	if _, err := os.Stat(legacy); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return migrateDir(legacy, "")
+}
+
+// migrateDir moves the entries of dir to sub inside the base directories
+// they belong in, removing dir once it's empty. Profiles in AppDir, where sub
+// is "", are moved entry by entry too. Failures are collected rather than
+// stopping at the first.
+func migrateDir(dir, sub string) ([]Move, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var moves []Move
+	var errs []error
+	for _, entry := range entries {
+		if sub == "" && entry.Name() == "profiles" && entry.IsDir() {
+			profiles, err := os.ReadDir(filepath.Join(dir, "profiles"))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, profile := range profiles {
+				moved, err := migrateDir(filepath.Join(dir, "profiles", profile.Name()), filepath.Join("profiles", profile.Name()))
+				moves = append(moves, moved...)
+				if err != nil {
+					errs = append(errs, err)
+				}
+			}
+			os.Remove(filepath.Join(dir, "profiles"))
+			continue
+		}
+		move, err := migrateEntry(dir, sub, entry.Name())
+		if move != nil {
+			moves = append(moves, *move)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	os.Remove(dir)
+	return moves, errors.Join(errs...)
+}
+
+// migrateEntry moves name from dir to sub inside the base directory it
+// belongs in, unless that's dir itself or name is already there
+func migrateEntry(dir, sub, name string) (*Move, error) {
+	to := filepath.Join(migrationHome(name), sub, name)
+	from := filepath.Join(dir, name)
+	if to == from {
+		return nil, nil
+	}
+	if _, err := os.Lstat(to); err == nil {
+		return nil, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(to), err)
+	}
+	err := rename(from, to)
+	if errors.Is(err, syscall.EXDEV) {
+		// The XDG directory is on another filesystem
+		err = moveAcross(from, to)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to move %s to %s, move it by hand: %w", from, to, err)
+	}
+	return &Move{From: from, To: to}, nil
+}
+
+// moveAcross moves from to to by copying and then removing from, for moves
+// rename can't do between filesystems. A failed copy removes what it wrote.
+func moveAcross(from, to string) error {
+	if err := copyTree(from, to); err != nil {
+		os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+// copyTree copies the file, symlink or directory tree at from to to
+func copyTree(from, to string) error {
+	return filepath.WalkDir(from, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(to, rel)
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(dst, info.Mode().Perm())
+		case info.Mode()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dst)
+		default:
+			return copyFile(path, dst, info.Mode().Perm())
+		}
+	})
+}
+
+// copyFile copies the regular file src to dst with permissions perm
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// migrationHome returns the base directory an entry of AppDir belongs in
+func migrationHome(name string) string {
+	switch name {
+	case "config.yaml", "yt-dlp.conf":
+		return ConfigHome()
+	case "cache", "caches":
+		return CacheHome()
+	default:
+		return DataHome()
+	}
+}
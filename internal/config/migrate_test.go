@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestMigrateAppDir(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("HOME", base)
+	clearXDG(t)
+	for _, name := range []string{"config.yaml", "cache/a.json", "models/ggml-small.bin", "history.jsonl", "profiles/acme/config.yaml", "profiles/acme/cache/b.json"} {
+		path := filepath.Join(AppDir(), name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(name), 0644)
+	}
+
+	// Without XDG directories, nothing moves
+	if moves, err := MigrateAppDir(); err != nil || len(moves) != 0 {
+		t.Fatalf("MigrateAppDir() = %v, %v; want no moves", moves, err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(base, "conf"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(base, "cache"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(base, "data"))
+	moves, err := MigrateAppDir()
+	if err != nil {
+		t.Fatalf("MigrateAppDir() error = %v", err)
+	}
+	if len(moves) != 6 {
+		t.Errorf("MigrateAppDir() moved %d entries, want 6: %v", len(moves), moves)
+	}
+
+	for _, path := range []string{
+		filepath.Join(base, "conf", "ig2insights", "config.yaml"),
+		filepath.Join(base, "cache", "ig2insights", "cache", "a.json"),
+		filepath.Join(base, "data", "ig2insights", "models", "ggml-small.bin"),
+		filepath.Join(base, "data", "ig2insights", "history.jsonl"),
+		filepath.Join(base, "conf", "ig2insights", "profiles", "acme", "config.yaml"),
+		filepath.Join(base, "cache", "ig2insights", "profiles", "acme", "cache", "b.json"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s not migrated: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(AppDir()); !os.IsNotExist(err) {
+		t.Errorf("%s should be removed once empty", AppDir())
+	}
+}
+
+func TestMigrateAppDir_Failures(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("HOME", base)
+	clearXDG(t)
+	for _, name := range []string{"cache/a.json", "config.yaml", "history.jsonl", "models/ggml-small.bin", "session.json"} {
+		path := filepath.Join(AppDir(), name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(name), 0644)
+	}
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(base, "conf"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(base, "cache"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(base, "data"))
+
+	// The cache is on another filesystem, and the models can't be moved
+	defer func() { rename = os.Rename }()
+	rename = func(from, to string) error {
+		switch filepath.Base(from) {
+		case "cache":
+			return &os.LinkError{Op: "rename", Old: from, New: to, Err: syscall.EXDEV}
+		case "models":
+			return &os.LinkError{Op: "rename", Old: from, New: to, Err: syscall.EACCES}
+		}
+		return os.Rename(from, to)
+	}
+
+	moves, err := MigrateAppDir()
+	if err == nil || !strings.Contains(err.Error(), "models") {
+		t.Errorf("MigrateAppDir() error = %v, want the models failure", err)
+	}
+	if len(moves) != 4 {
+		t.Errorf("MigrateAppDir() moved %d entries, want 4: %v", len(moves), moves)
+	}
+
+	for _, path := range []string{
+		filepath.Join(base, "cache", "ig2insights", "cache", "a.json"),
+		filepath.Join(base, "conf", "ig2insights", "config.yaml"),
+		filepath.Join(base, "data", "ig2insights", "history.jsonl"),
+		filepath.Join(base, "data", "ig2insights", "session.json"),
+		filepath.Join(AppDir(), "models", "ggml-small.bin"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s missing: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(AppDir(), "cache")); !os.IsNotExist(err) {
+		t.Errorf("cache copied across filesystems should be removed from %s", AppDir())
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the project config, found in the working directory or
+// the nearest parent holding one
+const ProjectFileName = ".ig2insights.yaml"
+
+// projectSections are the top-level settings a project config may change:
+// output conventions, not what runs or where data goes, as the file may come
+// from a repository nobody reviewed
+var projectSections = map[string]bool{
+	"defaults": true,
+	"output":   true,
+	"pipeline": true,
+	"throttle": true,
+	"commands": true,
+}
+
+// projectFlags are the only flags a project config's commands section may
+// default: how transcripts are made and written, and batch pacing. Flags
+// that overwrite or delete files, skip safety checks, or reach credentials,
+// other files and the network settings are left to the user's own config,
+// for the same reason, including any added later.
+var projectFlags = map[string]bool{
+	"audio":            true,
+	"audio-format":     true,
+	"caption-bundle":   true,
+	"comments":         true,
+	"compare-captions": true,
+	"concurrency":      true,
+	"dir":              true,
+	"encoding":         true,
+	"format":           true,
+	"language":         true,
+	"model":            true,
+	"name":             true,
+	"output-template":  true,
+	"prompt":           true,
+	"provenance":       true,
+	"skip-existing":    true,
+	"srt-max-chars":    true,
+	"srt-max-duration": true,
+	"stats":            true,
+	"subtitles":        true,
+	"template":         true,
+	"thumbnail":        true,
+	"video":            true,
+	"video-quality":    true,
+}
+
+// FindProjectConfig returns the project config in dir or its nearest parent
+// holding one, like git finds its repository, or "" when there's none
+func FindProjectConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ProjectConfigPath returns the project config of the working directory,
+// or "" when there's none or a config file was set with SetConfigPath,
+// which is used as is
+func ProjectConfigPath() string {
+	if configFile != "" {
+		return ""
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return FindProjectConfig(dir)
+}
+
+// ApplyProject overrides c with the settings in the project config at path
+func (c *Config) ApplyProject(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	if problems := projectProblems(doc.Content[0]); len(problems) > 0 {
+		return fmt.Errorf("%s: %s", path, problems[0])
+	}
+	if err := doc.Content[0].Decode(c); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// projectProblems reports the settings in a project config's root node
+// that only the user's own config may change
+func projectProblems(root *yaml.Node) []Problem {
+	if root.Kind != yaml.MappingNode {
+		return []Problem{{Line: root.Line, Message: "doesn't hold a mapping of settings"}}
+	}
+	var problems []Problem
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if !projectSections[key.Value] {
+			problems = append(problems, Problem{Line: key.Line, Key: key.Value, Message: "can't be set in a project config; set it in your own config"})
+			continue
+		}
+		if key.Value != "commands" || value.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			command, flags := value.Content[j], value.Content[j+1]
+			if flags.Kind != yaml.MappingNode {
+				continue
+			}
+			for k := 0; k < len(flags.Content); k += 2 {
+				if flag := flags.Content[k]; !projectFlags[flag.Value] {
+					problems = append(problems, Problem{Line: flag.Line, Key: "commands." + command.Value + "." + flag.Value, Message: "can't be set in a project config; set it in your own config"})
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// isProjectFile reports whether path is named like a project config
+func isProjectFile(path string) bool {
+	return filepath.Base(path) == ProjectFileName
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "clips", "2026")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindProjectConfig(nested); got != "" {
+		t.Errorf("FindProjectConfig() = %s, want none", got)
+	}
+
+	path := filepath.Join(root, ProjectFileName)
+	os.WriteFile(path, []byte("defaults:\n  format: srt\n"), 0644)
+	if got := FindProjectConfig(nested); got != path {
+		t.Errorf("FindProjectConfig() = %s, want %s from a parent", got, path)
+	}
+}
+
+func TestApplyProject(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ProjectFileName)
+	data := "defaults:\n  format: srt\noutput:\n  caption:\n    hashtags: [ugc]\ncommands:\n  batch:\n    concurrency: 2\n"
+	os.WriteFile(path, []byte(data), 0644)
+
+	cfg := DefaultConfig()
+	cfg.Defaults.Model = "medium"
+	if err := cfg.ApplyProject(path); err != nil {
+		t.Fatalf("ApplyProject() error = %v", err)
+	}
+	if cfg.Defaults.Format != "srt" || cfg.Defaults.Model != "medium" {
+		t.Errorf("defaults = %+v, want the project's format over the user's model", cfg.Defaults)
+	}
+	if cfg.Commands["batch"]["concurrency"] != "2" || len(cfg.Output.Caption.Hashtags) != 1 {
+		t.Errorf("project settings not applied: %+v, %+v", cfg.Commands, cfg.Output.Caption)
+	}
+}
+
+func TestApplyProject_Restricted(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"ytdlp_args", "ytdlp_args: [--exec, touch pwned]\n", "line 1: ytdlp_args: can't be set in a project config"},
+		{"paths", "defaults:\n  format: srt\npaths:\n  yt_dlp: ./evil\n", "line 3: paths: can't be set"},
+		{"flag", "commands:\n  root:\n    ytdlp-args: --exec x\n", "line 3: commands.root.ytdlp-args: can't be set"},
+		{"force", "commands:\n  batch:\n    format: srt\n    force: true\n", "line 4: commands.batch.force: can't be set"},
+		{"purge", "commands:\n  cache verify:\n    purge: true\n", "line 3: commands.cache verify.purge: can't be set"},
+		{"ignore-limits", "commands:\n  batch:\n    ignore-limits: true\n", "line 3: commands.batch.ignore-limits: can't be set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ProjectFileName)
+			os.WriteFile(path, []byte(tt.data), 0644)
+
+			cfg := DefaultConfig()
+			err := cfg.ApplyProject(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ApplyProject() error = %v, want %q", err, tt.want)
+			}
+			if len(cfg.YtDlpArgs) > 0 || cfg.Paths.YtDlp != "" || cfg.Defaults.Format != DefaultConfig().Defaults.Format {
+				t.Error("a rejected project config should change nothing")
+			}
+		})
+	}
+}
+
+func TestLoadWithEnv_Project(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ProjectFileName), []byte("defaults:\n  format: srt\n"), 0644)
+	userPath := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(userPath, []byte("defaults:\n  model: medium\n  format: json\n"), 0644)
+	t.Setenv(EnvPrefix+"FORMAT", "")
+	t.Setenv(EnvPrefix+"MODEL", "")
+	t.Chdir(dir)
+
+	cfg, err := LoadWithEnv(userPath)
+	if err != nil {
+		t.Fatalf("LoadWithEnv() error = %v", err)
+	}
+	if cfg.Defaults.Model != "medium" || cfg.Defaults.Format != "srt" {
+		t.Errorf("defaults = %+v, want the user's model and the project's format", cfg.Defaults)
+	}
+
+	// A config file given explicitly is used as is
+	SetConfigPath(userPath)
+	defer SetConfigPath("")
+	if cfg, _ := LoadWithEnv(userPath); cfg.Defaults.Format != "json" {
+		t.Errorf("format = %s with --config, want json", cfg.Defaults.Format)
+	}
+}
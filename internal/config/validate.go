@@ -0,0 +1,284 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is one thing wrong with a config file
+type Problem struct {
+	Line    int    // 0 when it isn't tied to a line, e.g. an environment variable
+	Key     string // e.g. defaults.model; empty when unknown
+	Message string
+}
+
+func (p Problem) String() string {
+	var b strings.Builder
+	if p.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", p.Line)
+	}
+	if p.Key != "" {
+		b.WriteString(p.Key + ": ")
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// Report is what Check found in a config file
+type Report struct {
+	Config   *Config // with the environment's overrides applied
+	problems []Problem
+	root     *yaml.Node
+	env      map[string]string // setting -> environment variable overriding it
+}
+
+// Add records a problem with the setting at key, found by a check outside
+// this package
+func (r *Report) Add(key string, err error) {
+	if name, ok := r.env[key]; ok {
+		r.problems = append(r.problems, Problem{Key: key, Message: fmt.Sprintf("$%s: %v", name, err)})
+		return
+	}
+	r.problems = append(r.problems, Problem{Line: keyLine(r.root, key), Key: key, Message: err.Error()})
+}
+
+// Problems returns the problems found, in file order; those without a line
+// come first
+func (r *Report) Problems() []Problem {
+	problems := append([]Problem(nil), r.problems...)
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems
+}
+
+// Check reads the config file at path like LoadWithEnv, without the project
+// config, and reports its problems: unknown keys, values of the wrong type,
+// settings that don't parse, or that a project config may not change.
+// Problems other parts of the app check, such as model names, are added to
+// the report by them. err is only set when the file can't be read or isn't
+// YAML.
+func Check(path string) (*Report, error) {
+	r := &Report{Config: DefaultConfig(), root: &yaml.Node{}, env: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	var doc yaml.Node
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+	if len(doc.Content) > 0 {
+		r.root = doc.Content[0]
+		r.problems = unknownKeys(r.root, reflect.TypeOf(Config{}), "")
+
+		var typeErr *yaml.TypeError
+		if err := r.root.Decode(r.Config); errors.As(err, &typeErr) {
+			for _, msg := range typeErr.Errors {
+				r.problems = append(r.problems, typeProblem(msg))
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+
+	if isProjectFile(path) && len(doc.Content) > 0 {
+		r.problems = append(r.problems, projectProblems(r.root)...)
+	}
+
+	for _, o := range EnvOverrides {
+		if os.Getenv(EnvPrefix+o.Name) != "" {
+			r.env[o.Key] = EnvPrefix + o.Name
+		}
+	}
+	if err := r.Config.ApplyEnv(os.Getenv); err != nil {
+		r.problems = append(r.problems, Problem{Message: err.Error()})
+	}
+	r.Config.checkValues(r)
+	return r, nil
+}
+
+// unknownKeys reports the keys in node that typ has no field for,
+// suggesting the closest one. Keys inside maps, like profile names, may be
+// anything.
+func unknownKeys(node *yaml.Node, typ reflect.Type, prefix string) []Problem {
+	var problems []Problem
+	switch {
+	case node.Kind == yaml.SequenceNode && typ.Kind() == reflect.Slice:
+		// Lists of sections, such as fallbacks
+		for _, item := range node.Content {
+			problems = append(problems, unknownKeys(item, typ.Elem(), prefix)...)
+		}
+	case node.Kind == yaml.MappingNode && typ.Kind() == reflect.Struct:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode := node.Content[i]
+			key := joinKey(prefix, keyNode.Value)
+			field, ok := fieldByYAMLName(typ, keyNode.Value)
+			if !ok {
+				problems = append(problems, Problem{Line: keyNode.Line, Key: key, Message: "unknown key" + suggestField(typ, keyNode.Value)})
+				continue
+			}
+			problems = append(problems, unknownKeys(node.Content[i+1], field.Type, key)...)
+		}
+	case node.Kind == yaml.MappingNode && typ.Kind() == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			problems = append(problems, unknownKeys(node.Content[i+1], typ.Elem(), joinKey(prefix, node.Content[i].Value))...)
+		}
+	}
+	return problems
+}
+
+// suggestField returns " (did you mean x?)" for the field of typ closest to
+// name, or "" when none is close
+func suggestField(typ reflect.Type, name string) string {
+	best, bestDist := "", 3
+	for i := 0; i < typ.NumField(); i++ {
+		field := yamlName(typ.Field(i))
+		if field == "" {
+			continue
+		}
+		if d := editDistance(name, field); d < bestDist {
+			best, bestDist = field, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// typeErrorLine matches the line yaml.v3 puts before each type error
+var typeErrorLine = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// typeProblem turns one of yaml.v3's type errors, like "line 3: cannot
+// unmarshal !!str `abc` into int", into a problem
+func typeProblem(msg string) Problem {
+	m := typeErrorLine.FindStringSubmatch(msg)
+	if m == nil {
+		return Problem{Message: msg}
+	}
+	line, _ := strconv.Atoi(m[1])
+	return Problem{Line: line, Message: m[2]}
+}
+
+// checkValues adds the settings that don't parse to r
+func (c *Config) checkValues(r *Report) {
+	check := func(key string, err error) {
+		if err != nil {
+			r.Add(key, err)
+		}
+	}
+
+	_, err := c.GetTimeout()
+	check("defaults.timeout", err)
+	_, err = c.GetMaxDuration()
+	check("defaults.max_duration", err)
+	_, err = c.GetChunkLength()
+	check("defaults.chunk_length", err)
+	_, err = c.GetDownloadBackoff()
+	check("defaults.download_backoff", err)
+	if c.Cache.TTL.Transcript != "" {
+		_, err = parseTTL(c.Cache.TTL.Transcript)
+		check("cache.ttl.transcript", err)
+	} else {
+		_, err = parseTTL(c.Defaults.CacheTTL)
+		check("defaults.cache_ttl", err)
+	}
+	_, err = c.GetCacheMediaTTL()
+	check("cache.ttl.media", err)
+	_, err = c.GetCacheMaxSize()
+	check("cache.max_size", err)
+	_, _, err = c.GetChapterSettings()
+	check("output.chapters", err)
+	_, _, err = c.GetSRTDurations()
+	check("output.srt", err)
+	_, err = c.GetMirrors()
+	check("mirrors", err)
+
+	for _, name := range SortedKeys(c.Throttle.Profiles) {
+		_, _, err = c.GetThrottleProfile(name)
+		check("throttle.profiles."+name, err)
+	}
+	if c.Throttle.Profile != "" {
+		if _, ok := c.Throttle.Profiles[c.Throttle.Profile]; !ok {
+			_, _, err = c.GetThrottleProfile("")
+			check("throttle.profile", err)
+		}
+	}
+	for _, name := range SortedKeys(c.Cache.Profiles) {
+		_, _, _, err = c.GetCacheProfile(name)
+		check("cache.profiles."+name, err)
+	}
+	if c.Cache.Profile != "" {
+		if _, ok := c.Cache.Profiles[c.Cache.Profile]; !ok {
+			_, _, _, err = c.GetCacheProfile("")
+			check("cache.profile", err)
+		}
+	}
+}
+
+// keyLine returns the line of the setting at key in a config file's root
+// mapping node, or of its closest parent that's set, or 0
+func keyLine(root *yaml.Node, key string) int {
+	line := 0
+	node := root
+	for _, part := range strings.Split(key, ".") {
+		if node == nil || node.Kind != yaml.MappingNode {
+			break
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == part {
+				line = node.Content[i].Line
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
+
+// SortedKeys returns m's keys in order, for output that doesn't change
+// between runs
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	t.Setenv(EnvPrefix+"TIMEOUT", "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `defaults:
+  modle: small
+  retries: many
+cache:
+  ttl:
+    media: 3q
+throttle:
+  profiles:
+    night:
+      jitter: soon
+fallbacks:
+  - backend: whisper
+    modl: tiny
+`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Check(path)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	var got []string
+	for _, p := range report.Problems() {
+		got = append(got, p.String())
+	}
+	want := []string{
+		"line 2: defaults.modle: unknown key (did you mean model?)",
+		"line 3: cannot unmarshal !!str `many` into int",
+		"line 6: cache.ttl.media: invalid cache ttl media: 3q",
+		"line 9: throttle.profiles.night: invalid jitter in throttle profile night",
+		"line 13: fallbacks.modl: unknown key (did you mean model?)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Check() problems =\n%s\nwant %d", strings.Join(got, "\n"), len(want))
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("problem %d = %q, want it to start with %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCheck_Env(t *testing.T) {
+	t.Setenv(EnvPrefix+"TIMEOUT", "10x")
+	report, err := Check(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	problems := report.Problems()
+	if len(problems) != 1 || problems[0].Line != 0 || !strings.Contains(problems[0].Message, "$IG2INSIGHTS_TIMEOUT") {
+		t.Errorf("Check() problems = %v, want the timeout blamed on $IG2INSIGHTS_TIMEOUT", problems)
+	}
+}
+
+func TestCheck_NotYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("defaults: [unclosed"), 0644)
+	if _, err := Check(path); err == nil {
+		t.Error("Check() expected error for invalid YAML")
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// CacheMetrics counts how the cache served a run's reels and estimates the
+// time that saved
+type CacheMetrics struct {
+	Hits      int // reels whose transcript was cached
+	Misses    int // reels transcribed during the run
+	AudioHits int // misses whose audio was cached
+	TimeSaved time.Duration
+}
+
+// Add counts one reel and the time the cache saved on it
+func (m *CacheMetrics) Add(transcriptCached, audioCached bool, saved time.Duration) {
+	switch {
+	case transcriptCached:
+		m.Hits++
+	case audioCached:
+		m.Misses++
+		m.AudioHits++
+	default:
+		m.Misses++
+	}
+	m.TimeSaved += saved
+}
+
+// HitRate returns the share of reels whose transcript was cached, from 0 to 1
+func (m CacheMetrics) HitRate() float64 {
+	if m.Hits+m.Misses == 0 {
+		return 0
+	}
+	return float64(m.Hits) / float64(m.Hits+m.Misses)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheMetrics(t *testing.T) {
+	var m CacheMetrics
+	if m.HitRate() != 0 {
+		t.Errorf("empty HitRate = %v, want 0", m.HitRate())
+	}
+
+	m.Add(true, false, 10*time.Second)
+	m.Add(true, true, 20*time.Second)
+	m.Add(false, true, 5*time.Second)
+	m.Add(false, false, 0)
+
+	want := CacheMetrics{Hits: 2, Misses: 2, AudioHits: 1, TimeSaved: 35 * time.Second}
+	if m != want {
+		t.Errorf("metrics = %+v, want %+v", m, want)
+	}
+	if m.HitRate() != 0.5 {
+		t.Errorf("HitRate = %v, want 0.5", m.HitRate())
+	}
+}
+
+func TestEstimator_Saved(t *testing.T) {
+	est := Estimator{Speed: 4, TypicalReel: 40, Download: 3 * time.Second}
+
+	tests := []struct {
+		name              string
+		transcript, audio bool
+		want              time.Duration
+	}{
+		{"transcript cached", true, false, 13 * time.Second},
+		{"transcript and audio cached", true, true, 13 * time.Second},
+		{"audio cached", false, true, 3 * time.Second},
+		{"nothing cached", false, false, 0},
+	}
+	for _, tt := range tests {
+		if got := est.Saved(40, tt.transcript, tt.audio); got != tt.want {
+			t.Errorf("%s: Saved = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultCaptionLines is used when a line width is set without a line limit
+const defaultCaptionLines = 2
+
+// CaptionOptions controls how segments are reshaped into subtitle captions.
+// Zero values disable the corresponding rule.
+type CaptionOptions struct {
+	MaxLineChars int     // wrap caption text at this many characters per line
+	MaxLines     int     // split captions that would need more lines than this
+	MaxDuration  float64 // split captions longer than this many seconds
+	MinDuration  float64 // merge captions shorter than this many seconds into a neighbour
+}
+
+// IsZero reports whether no shaping rule is enabled
+func (o CaptionOptions) IsZero() bool {
+	return o.MaxLineChars <= 0 && o.MaxDuration <= 0 && o.MinDuration <= 0
+}
+
+// ShapeCaptions returns a copy of the transcript with segments split, merged
+// and line-wrapped to fit opts. Split captions share their segment's time
+// span in proportion to their length. Merges never cross speakers and never
+// produce a caption that breaks the other limits.
+func (t *Transcript) ShapeCaptions(opts CaptionOptions) *Transcript {
+	shaped := *t
+	if opts.IsZero() {
+		return &shaped
+	}
+	if opts.MaxLineChars > 0 && opts.MaxLines <= 0 {
+		opts.MaxLines = defaultCaptionLines
+	}
+
+	var segments []Segment
+	for _, seg := range t.Segments {
+		segments = append(segments, splitCaption(seg, opts)...)
+	}
+	segments = mergeCaptions(segments, opts)
+
+	for i := range segments {
+		segments[i].Text = strings.Join(wrapCaption(segments[i].Text, opts.MaxLineChars), "\n")
+	}
+
+	shaped.Segments = segments
+	return &shaped
+}
+
+// splitCaption breaks a segment into word-aligned pieces that fit opts
+func splitCaption(seg Segment, opts CaptionOptions) []Segment {
+	words := strings.Fields(seg.Text)
+	if len(words) == 0 {
+		return nil
+	}
+
+	total := utf8.RuneCountInString(strings.Join(words, " "))
+	secsPerChar := (seg.End - seg.Start) / float64(total)
+
+	var pieces []Segment
+	var current []string
+	offset := 0 // characters of text consumed by earlier pieces
+
+	emit := func() {
+		piece := strings.Join(current, " ")
+		length := utf8.RuneCountInString(piece)
+		start := seg.Start + float64(offset)*secsPerChar
+		end := start + float64(length)*secsPerChar
+		offset += length + 1
+		if offset >= total {
+			end = seg.End
+		}
+		pieces = append(pieces, Segment{Start: start, End: end, Text: piece, Speaker: seg.Speaker})
+		current = nil
+	}
+
+	for _, word := range words {
+		candidate := append(append([]string(nil), current...), word)
+		joined := strings.Join(candidate, " ")
+		if len(current) > 0 && !captionFits(joined, float64(utf8.RuneCountInString(joined))*secsPerChar, opts) {
+			emit()
+		}
+		current = append(current, word)
+	}
+	emit()
+
+	return pieces
+}
+
+// mergeCaptions joins captions shorter than MinDuration with the next one
+func mergeCaptions(segments []Segment, opts CaptionOptions) []Segment {
+	if opts.MinDuration <= 0 || len(segments) < 2 {
+		return segments
+	}
+
+	merged := []Segment{segments[0]}
+	for _, next := range segments[1:] {
+		prev := &merged[len(merged)-1]
+		short := prev.End-prev.Start < opts.MinDuration || next.End-next.Start < opts.MinDuration
+		text := prev.Text + " " + next.Text
+		if short && prev.Speaker == next.Speaker && captionFits(text, next.End-prev.Start, opts) {
+			prev.End = next.End
+			prev.Text = text
+			continue
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
+
+// captionFits reports whether text spanning duration seconds satisfies the
+// line and duration limits
+func captionFits(text string, duration float64, opts CaptionOptions) bool {
+	if opts.MaxDuration > 0 && duration > opts.MaxDuration {
+		return false
+	}
+	if opts.MaxLineChars > 0 && len(wrapCaption(text, opts.MaxLineChars)) > opts.MaxLines {
+		return false
+	}
+	return true
+}
+
+// wrapCaption greedily wraps text into lines of at most width characters.
+// Words longer than width get a line of their own.
+func wrapCaption(text string, width int) []string {
+	words := strings.Fields(text)
+	if width <= 0 || len(words) == 0 {
+		return []string{strings.Join(words, " ")}
+	}
+
+	var lines []string
+	line := words[0]
+	for _, word := range words[1:] {
+		if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line += " " + word
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShapeCaptions_ZeroOptionsKeepsSegments(t *testing.T) {
+	tr := &Transcript{Segments: []Segment{{Start: 0, End: 20, Text: "a very long segment that stays intact"}}}
+
+	shaped := tr.ShapeCaptions(CaptionOptions{})
+	if len(shaped.Segments) != 1 || shaped.Segments[0].Text != tr.Segments[0].Text {
+		t.Errorf("ShapeCaptions with zero options changed segments: %+v", shaped.Segments)
+	}
+}
+
+func TestShapeCaptions_SplitsByLength(t *testing.T) {
+	tr := &Transcript{Segments: []Segment{{
+		Start: 10,
+		End:   20,
+		Text:  "the quick brown fox jumps over the lazy dog and keeps running far away",
+	}}}
+
+	shaped := tr.ShapeCaptions(CaptionOptions{MaxLineChars: 16, MaxLines: 2})
+	if len(shaped.Segments) < 2 {
+		t.Fatalf("expected the segment to be split, got %+v", shaped.Segments)
+	}
+
+	var words []string
+	for i, seg := range shaped.Segments {
+		lines := strings.Split(seg.Text, "\n")
+		if len(lines) > 2 {
+			t.Errorf("caption %d has %d lines: %q", i, len(lines), seg.Text)
+		}
+		for _, line := range lines {
+			if len(line) > 16 {
+				t.Errorf("caption %d line %q exceeds 16 chars", i, line)
+			}
+		}
+		if i > 0 && seg.Start < shaped.Segments[i-1].End-1e-9 {
+			t.Errorf("caption %d starts before the previous one ends", i)
+		}
+		words = append(words, strings.Fields(seg.Text)...)
+	}
+
+	if got := strings.Join(words, " "); got != tr.Segments[0].Text {
+		t.Errorf("split lost or reordered words: %q", got)
+	}
+	if shaped.Segments[0].Start != 10 || shaped.Segments[len(shaped.Segments)-1].End != 20 {
+		t.Errorf("split captions should span the original segment, got %.2f-%.2f",
+			shaped.Segments[0].Start, shaped.Segments[len(shaped.Segments)-1].End)
+	}
+}
+
+func TestShapeCaptions_SplitsByDuration(t *testing.T) {
+	tr := &Transcript{Segments: []Segment{{Start: 0, End: 12, Text: "one two three four five six"}}}
+
+	shaped := tr.ShapeCaptions(CaptionOptions{MaxDuration: 5})
+	if len(shaped.Segments) < 3 {
+		t.Fatalf("expected at least 3 captions, got %d", len(shaped.Segments))
+	}
+	for i, seg := range shaped.Segments {
+		if seg.End-seg.Start > 5+1e-9 {
+			t.Errorf("caption %d lasts %.2fs, want <= 5s", i, seg.End-seg.Start)
+		}
+	}
+}
+
+func TestShapeCaptions_MergesShortCaptions(t *testing.T) {
+	tr := &Transcript{Segments: []Segment{
+		{Start: 0, End: 0.4, Text: "Hi"},
+		{Start: 0.4, End: 2, Text: "there friend"},
+		{Start: 2, End: 2.3, Text: "Yes", Speaker: "B"},
+		{Start: 2.3, End: 5, Text: "Long enough caption here"},
+	}}
+
+	shaped := tr.ShapeCaptions(CaptionOptions{MinDuration: 1})
+	if len(shaped.Segments) != 3 {
+		t.Fatalf("expected 3 captions, got %+v", shaped.Segments)
+	}
+	if shaped.Segments[0].Text != "Hi there friend" || shaped.Segments[0].End != 2 {
+		t.Errorf("first caption = %+v, want merged 'Hi there friend' ending at 2", shaped.Segments[0])
+	}
+	// Different speakers are never merged
+	if shaped.Segments[1].Speaker != "B" || shaped.Segments[1].Text != "Yes" {
+		t.Errorf("second caption = %+v, want speaker B kept separate", shaped.Segments[1])
+	}
+}
+
+func TestShapeCaptions_MergeRespectsLimits(t *testing.T) {
+	tr := &Transcript{Segments: []Segment{
+		{Start: 0, End: 0.5, Text: "short"},
+		{Start: 0.5, End: 6, Text: "a much longer caption"},
+	}}
+
+	shaped := tr.ShapeCaptions(CaptionOptions{MinDuration: 1, MaxDuration: 4})
+	for i, seg := range shaped.Segments {
+		if seg.End-seg.Start > 4+1e-9 {
+			t.Errorf("caption %d lasts %.2fs, merge broke the max duration", i, seg.End-seg.Start)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// slideSeparator joins a carousel post's shortcode and a slide number into
+// the ID of one of its videos. It is outside the shortcode alphabet, so
+// slide IDs never collide with shortcodes.
+const slideSeparator = "."
+
+// SlideID returns the ID of the video at position slide (1-based) in the
+// carousel post shortcode
+func SlideID(shortcode string, slide int) string {
+	return fmt.Sprintf("%s%s%d", shortcode, slideSeparator, slide)
+}
+
+// ParseSlideID splits a slide ID into its post's shortcode and slide number.
+// Other IDs are returned unchanged with slide 0.
+func ParseSlideID(id string) (shortcode string, slide int) {
+	i := strings.LastIndex(id, slideSeparator)
+	if _, youtube := ParseYouTubeID(id); i <= 0 || youtube || IsGenericID(id) || IsLocalFileID(id) {
+		return id, 0
+	}
+	n, err := strconv.Atoi(id[i+len(slideSeparator):])
+	if err != nil || n < 1 {
+		return id, 0
+	}
+	return id[:i], n
+}
+
+// SlideSuffix returns what output names of the reel's slide end with, or ""
+// when the reel is not a carousel slide
+func SlideSuffix(reelID string) string {
+	if _, slide := ParseSlideID(reelID); slide > 0 {
+		return fmt.Sprintf("_%d", slide)
+	}
+	return ""
+}
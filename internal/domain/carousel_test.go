@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestSlideID(t *testing.T) {
+	id := SlideID("DToLsd-EvGJ", 3)
+	if id != "DToLsd-EvGJ.3" {
+		t.Errorf("SlideID() = %q", id)
+	}
+	if shortcode, slide := ParseSlideID(id); shortcode != "DToLsd-EvGJ" || slide != 3 {
+		t.Errorf("ParseSlideID(%q) = %q, %d", id, shortcode, slide)
+	}
+	if got := SlideSuffix(id); got != "_3" {
+		t.Errorf("SlideSuffix(%q) = %q, want _3", id, got)
+	}
+
+	// A local file whose hash is all digits isn't a slide of a post named "file"
+	for _, id := range []string{"DToLsd-EvGJ", "DToLsd-EvGJ.x", "DToLsd-EvGJ.0", ".2", "file.123456789012"} {
+		if shortcode, slide := ParseSlideID(id); shortcode != id || slide != 0 {
+			t.Errorf("ParseSlideID(%q) = %q, %d, want the ID unchanged", id, shortcode, slide)
+		}
+		if got := SlideSuffix(id); got != "" {
+			t.Errorf("SlideSuffix(%q) = %q, want none", id, got)
+		}
+	}
+}
+
+func TestParseReelInput_Post(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"https://www.instagram.com/p/DToLsd-EvGJ/", true},
+		{"https://www.instagram.com/reel/DToLsd-EvGJ/", false},
+		{"DToLsd-EvGJ", false},
+	}
+	for _, tt := range tests {
+		reel, err := ParseReelInput(tt.input)
+		if err != nil {
+			t.Fatalf("ParseReelInput(%q) error = %v", tt.input, err)
+		}
+		if reel.Post != tt.want {
+			t.Errorf("ParseReelInput(%q).Post = %v, want %v", tt.input, reel.Post, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Chapter is a timestamped section of a transcript
+type Chapter struct {
+	Start float64
+	Text  string
+}
+
+// Chapters groups segments into sections of roughly interval seconds. A new
+// chapter starts at the first segment beginning at or after the previous
+// chapter's start plus interval, so sentences are never split.
+func (t *Transcript) Chapters(interval float64) []Chapter {
+	if interval <= 0 || len(t.Segments) == 0 {
+		return nil
+	}
+
+	var chapters []Chapter
+	var parts []string
+	start := t.Segments[0].Start
+
+	for _, seg := range t.Segments {
+		if len(parts) > 0 && seg.Start >= start+interval {
+			chapters = append(chapters, Chapter{Start: start, Text: strings.Join(parts, " ")})
+			parts = nil
+			start = seg.Start
+		}
+		if text := strings.TrimSpace(seg.Text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) > 0 {
+		chapters = append(chapters, Chapter{Start: start, Text: strings.Join(parts, " ")})
+	}
+
+	return chapters
+}
+
+// ToChapteredText returns plain text with a [MM:SS] marker before each chapter
+func (t *Transcript) ToChapteredText(interval float64) string {
+	chapters := t.Chapters(interval)
+	if chapters == nil {
+		return t.ToText()
+	}
+
+	blocks := make([]string, len(chapters))
+	for i, ch := range chapters {
+		blocks[i] = fmt.Sprintf("[%s]\n%s", FormatChapterTime(ch.Start), ch.Text)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// FormatChapterTime formats seconds as MM:SS, or H:MM:SS past an hour
+func FormatChapterTime(seconds float64) string {
+	total := int(seconds)
+	if total >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", total/3600, (total/60)%60, total%60)
+	}
+	return fmt.Sprintf("%02d:%02d", total/60, total%60)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+func TestTranscript_Chapters(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0, End: 25, Text: "Intro."},
+			{Start: 25, End: 55, Text: "Still intro."},
+			{Start: 62, End: 90, Text: "Part two."},
+			{Start: 90, End: 118, Text: "More."},
+			{Start: 125, End: 130, Text: "Part three."},
+		},
+	}
+
+	chapters := tr.Chapters(60)
+	expected := []Chapter{
+		{Start: 0, Text: "Intro. Still intro."},
+		{Start: 62, Text: "Part two. More."},
+		{Start: 125, Text: "Part three."},
+	}
+
+	if len(chapters) != len(expected) {
+		t.Fatalf("Chapters() returned %d chapters, want %d: %+v", len(chapters), len(expected), chapters)
+	}
+	for i, ch := range chapters {
+		if ch != expected[i] {
+			t.Errorf("chapter[%d] = %+v, want %+v", i, ch, expected[i])
+		}
+	}
+
+	text := tr.ToChapteredText(60)
+	want := "[00:00]\nIntro. Still intro.\n\n[01:02]\nPart two. More.\n\n[02:05]\nPart three."
+	if text != want {
+		t.Errorf("ToChapteredText() = %q, want %q", text, want)
+	}
+}
+
+func TestFormatChapterTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "00:00"},
+		{75.9, "01:15"},
+		{3725, "1:02:05"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatChapterTime(tt.seconds); got != tt.want {
+			t.Errorf("FormatChapterTime(%v) = %s, want %s", tt.seconds, got, tt.want)
+		}
+	}
+}
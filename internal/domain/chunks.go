@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AudioChunk is a window of a long recording that is transcribed on its own
+type AudioChunk struct {
+	Start  time.Duration
+	Length time.Duration
+}
+
+// SplitDuration divides a recording of length total into consecutive chunks
+// of at most size. It returns nil when the recording fits in one chunk, its
+// length is unknown, or size is zero. A final chunk shorter than a quarter
+// of size is folded into the one before it rather than transcribed alone.
+func SplitDuration(total, size time.Duration) []AudioChunk {
+	if size <= 0 || total <= size {
+		return nil
+	}
+
+	var chunks []AudioChunk
+	for start := time.Duration(0); start < total; start += size {
+		chunks = append(chunks, AudioChunk{Start: start, Length: min(size, total-start)})
+	}
+	if last := chunks[len(chunks)-1]; len(chunks) > 1 && last.Length < size/4 {
+		chunks = chunks[:len(chunks)-1]
+		chunks[len(chunks)-1].Length += last.Length
+	}
+	if len(chunks) == 1 {
+		return nil
+	}
+	return chunks
+}
+
+// MergeTranscripts joins the transcripts of consecutive chunks into one. The
+// chunks' segments are already timed from the start of the recording. Model
+// and language come from the first chunk that has them.
+func MergeTranscripts(parts []*Transcript) *Transcript {
+	merged := &Transcript{}
+	var texts []string
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		merged.Segments = append(merged.Segments, part.Segments...)
+		if text := strings.TrimSpace(part.ToText()); text != "" {
+			texts = append(texts, text)
+		}
+		if merged.Model == "" {
+			merged.Model = part.Model
+		}
+		if merged.Language == "" || merged.Language == "auto" {
+			merged.Language = part.Language
+		}
+		if part.TranscribedAt.After(merged.TranscribedAt) {
+			merged.TranscribedAt = part.TranscribedAt
+		}
+	}
+	merged.Text = strings.Join(texts, " ")
+	return merged
+}
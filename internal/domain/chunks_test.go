@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		total time.Duration
+		size  time.Duration
+		want  []AudioChunk
+	}{
+		{"fits", 9 * time.Minute, 10 * time.Minute, nil},
+		{"unknown length", 0, 10 * time.Minute, nil},
+		{"disabled", time.Hour, 0, nil},
+		{"even", 20 * time.Minute, 10 * time.Minute, []AudioChunk{
+			{0, 10 * time.Minute},
+			{10 * time.Minute, 10 * time.Minute},
+		}},
+		{"long tail", 25 * time.Minute, 10 * time.Minute, []AudioChunk{
+			{0, 10 * time.Minute},
+			{10 * time.Minute, 10 * time.Minute},
+			{20 * time.Minute, 5 * time.Minute},
+		}},
+		{"short tail folded", 21 * time.Minute, 10 * time.Minute, []AudioChunk{
+			{0, 10 * time.Minute},
+			{10 * time.Minute, 11 * time.Minute},
+		}},
+		{"folded into one", 11 * time.Minute, 10 * time.Minute, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitDuration(tt.total, tt.size)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SplitDuration() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("chunk %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeTranscripts(t *testing.T) {
+	early := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(time.Minute)
+
+	merged := MergeTranscripts([]*Transcript{
+		{Text: "First part.", Segments: []Segment{{Start: 0, End: 2, Text: "First part."}}, Model: "small", Language: "auto", TranscribedAt: early},
+		nil,
+		{Text: " ", Model: "small", Language: "en", TranscribedAt: late},
+		{Text: "Second part.", Segments: []Segment{{Start: 600, End: 602, Text: "Second part."}}, Model: "small", Language: "fr", TranscribedAt: early},
+	})
+
+	if merged.Text != "First part. Second part." {
+		t.Errorf("Text = %q", merged.Text)
+	}
+	if len(merged.Segments) != 2 || merged.Segments[1].Start != 600 {
+		t.Errorf("Segments = %v", merged.Segments)
+	}
+	if merged.Model != "small" || merged.Language != "en" {
+		t.Errorf("Model, Language = %q, %q, want small, en", merged.Model, merged.Language)
+	}
+	if !merged.TranscribedAt.Equal(late) {
+		t.Errorf("TranscribedAt = %v, want %v", merged.TranscribedAt, late)
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Comment is a viewer comment on a reel
+type Comment struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	LikeCount int64     `json:"likes"`
+	PostedAt  time.Time `json:"posted_at,omitzero"`
+	ReplyTo   string    `json:"reply_to,omitempty"` // ID of the comment this answers; empty for top-level comments
+}
+
+// TopComments returns the n most liked comments, keeping the original order
+// among equally liked ones. A non-positive n returns them all, ranked.
+func TopComments(comments []Comment, n int) []Comment {
+	ranked := append([]Comment(nil), comments...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].LikeCount > ranked[j].LikeCount
+	})
+	if n > 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTopComments(t *testing.T) {
+	comments := []Comment{
+		{ID: "a", LikeCount: 1},
+		{ID: "b", LikeCount: 10},
+		{ID: "c", LikeCount: 1},
+		{ID: "d", LikeCount: 5},
+	}
+
+	top := TopComments(comments, 3)
+	var ids string
+	for _, c := range top {
+		ids += c.ID
+	}
+	if ids != "bda" {
+		t.Errorf("TopComments() = %s, want bda", ids)
+	}
+	if comments[0].ID != "a" {
+		t.Error("TopComments() reordered its input")
+	}
+
+	if got := TopComments(comments, 0); len(got) != 4 || got[3].ID != "c" {
+		t.Errorf("TopComments(0) = %v, want all four ranked", got)
+	}
+}
+
+func TestComment_JSONOmitsZeroPostedAt(t *testing.T) {
+	data, err := json.Marshal(Comment{ID: "a", Text: "nice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "posted_at") {
+		t.Errorf("JSON = %s, want no posted_at for an unknown time", data)
+	}
+}
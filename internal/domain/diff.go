@@ -0,0 +1,133 @@
+package domain
+
+import "strings"
+
+// DiffOp identifies the kind of change in a word diff
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+// WordDiff is a run of words sharing the same diff operation
+type WordDiff struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffWords computes a word-level diff turning a into b.
+// Adjacent words with the same operation are merged into one run.
+func DiffWords(a, b string) []WordDiff {
+	aw := strings.Fields(a)
+	bw := strings.Fields(b)
+
+	// Longest common subsequence table over word slices
+	lcs := make([][]int, len(aw)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bw)+1)
+	}
+	for i := len(aw) - 1; i >= 0; i-- {
+		for j := len(bw) - 1; j >= 0; j-- {
+			if aw[i] == bw[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diffs []WordDiff
+	add := func(op DiffOp, word string) {
+		if n := len(diffs); n > 0 && diffs[n-1].Op == op {
+			diffs[n-1].Text += " " + word
+			return
+		}
+		diffs = append(diffs, WordDiff{Op: op, Text: word})
+	}
+
+	i, j := 0, 0
+	for i < len(aw) && j < len(bw) {
+		switch {
+		case aw[i] == bw[j]:
+			add(DiffEqual, aw[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(DiffDelete, aw[i])
+			i++
+		default:
+			add(DiffInsert, bw[j])
+			j++
+		}
+	}
+	for ; i < len(aw); i++ {
+		add(DiffDelete, aw[i])
+	}
+	for ; j < len(bw); j++ {
+		add(DiffInsert, bw[j])
+	}
+
+	return diffs
+}
+
+// WordSimilarity returns how much two texts agree at the word level,
+// from 0 (nothing in common) to 1 (identical words in the same order).
+func WordSimilarity(a, b string) float64 {
+	aw := len(strings.Fields(a))
+	bw := len(strings.Fields(b))
+	if aw+bw == 0 {
+		return 1
+	}
+
+	common := 0
+	for _, d := range DiffWords(a, b) {
+		if d.Op == DiffEqual {
+			common += len(strings.Fields(d.Text))
+		}
+	}
+	return float64(2*common) / float64(aw+bw)
+}
+
+// TranscriptAgreement summarizes how closely two transcripts of the same
+// audio agree, ignoring case and punctuation
+type TranscriptAgreement struct {
+	Similarity float64 // WordSimilarity of the normalized texts
+	WordsA     int
+	WordsB     int
+	OnlyInA    int // words of A with no match in B
+	OnlyInB    int // words of B with no match in A
+}
+
+// CompareTranscripts measures agreement between a and b at the word level
+func CompareTranscripts(a, b *Transcript) TranscriptAgreement {
+	textA := NormalizeForComparison(a.ToText())
+	textB := NormalizeForComparison(b.ToText())
+
+	agreement := TranscriptAgreement{
+		Similarity: WordSimilarity(textA, textB),
+		WordsA:     len(strings.Fields(textA)),
+		WordsB:     len(strings.Fields(textB)),
+	}
+	for _, d := range DiffWords(textA, textB) {
+		switch d.Op {
+		case DiffDelete:
+			agreement.OnlyInA += len(strings.Fields(d.Text))
+		case DiffInsert:
+			agreement.OnlyInB += len(strings.Fields(d.Text))
+		}
+	}
+	return agreement
+}
+
+// NormalizeForComparison lowercases s and drops punctuation, apostrophes and
+// bracketed sound events, so formatting differences between sources are not
+// counted as disagreements
+func NormalizeForComparison(s string) string {
+	s = soundEventPattern.ReplaceAllString(s, " ")
+	s = strings.NewReplacer("'", "", "’", "").Replace(s)
+	return strings.Join(wordPattern.FindAllString(strings.ToLower(s), -1), " ")
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "testing"
+
+func TestDiffWords(t *testing.T) {
+	diffs := DiffWords("the quick brown fox", "the quick red fox jumps")
+
+	expected := []WordDiff{
+		{Op: DiffEqual, Text: "the quick"},
+		{Op: DiffDelete, Text: "brown"},
+		{Op: DiffInsert, Text: "red"},
+		{Op: DiffEqual, Text: "fox"},
+		{Op: DiffInsert, Text: "jumps"},
+	}
+
+	if len(diffs) != len(expected) {
+		t.Fatalf("DiffWords() returned %d runs, want %d: %+v", len(diffs), len(expected), diffs)
+	}
+	for i, d := range diffs {
+		if d != expected[i] {
+			t.Errorf("DiffWords()[%d] = %+v, want %+v", i, d, expected[i])
+		}
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"identical", "hello world", "hello world", 1},
+		{"disjoint", "hello world", "goodbye moon", 0},
+		{"half", "a b c d", "a b x y", 0.5},
+		{"both empty", "", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WordSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("WordSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeForComparison(t *testing.T) {
+	got := NormalizeForComparison("[Music] Hey, I'm BACK — it’s 3.5x better!")
+	want := "hey im back its 3 5x better"
+	if got != want {
+		t.Errorf("NormalizeForComparison() = %q, want %q", got, want)
+	}
+}
+
+func TestCompareTranscripts(t *testing.T) {
+	captions := &Transcript{Segments: []Segment{{Text: "hey everyone welcome back"}, {Text: "to my channel"}}}
+	whisper := &Transcript{Segments: []Segment{{Text: "Hey everyone, welcome back to the channel."}}}
+
+	got := CompareTranscripts(captions, whisper)
+	want := TranscriptAgreement{Similarity: 12.0 / 14, WordsA: 7, WordsB: 7, OnlyInA: 1, OnlyInB: 1}
+	if got != want {
+		t.Errorf("CompareTranscripts() = %+v, want %+v", got, want)
+	}
+}
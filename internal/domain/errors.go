@@ -7,14 +7,23 @@ var (
 	ErrReelNotFound             = errors.New("reel not found or is private")
 	ErrAccountNotFound          = errors.New("account not found")
 	ErrInstagramScrapingBlocked = errors.New("Instagram is blocking profile access - browse feature temporarily unavailable")
+	ErrInvalidReelInput         = errors.New("invalid reel URL or ID")
+	ErrShareLink                = errors.New("share link must be resolved to a reel URL")
+
+	// Instagram login errors
+	ErrNoSession      = errors.New("no Instagram session found")
+	ErrSessionExpired = errors.New("Instagram session has expired")
 
 	// Network and rate limiting errors
 	ErrRateLimited    = errors.New("rate limited by Instagram")
 	ErrNetworkFailure = errors.New("network failure")
+	ErrOffline        = errors.New("needs the network, which offline mode doesn't use")
 
 	// Transcription errors
-	ErrTranscriptionFailed = errors.New("transcription failed")
-	ErrModelNotFound       = errors.New("model not found")
+	ErrTranscriptionFailed  = errors.New("transcription failed")
+	ErrTranscriptionTimeout = errors.New("transcription timed out")
+	ErrModelNotFound        = errors.New("model not found")
+	ErrReelTooLong          = errors.New("reel exceeds maximum duration")
 
 	// Cache errors
 	ErrCacheExpired = errors.New("cache expired")
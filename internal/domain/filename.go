@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxFilenameBytes keeps a base name short enough for the longest suffix
+// ig2insights appends (.captions-compare.md) within the 255-byte limit
+// shared by common filesystems
+const MaxFilenameBytes = 200
+
+// maxTitleRunes shortens captions used in filenames to their opening words
+const maxTitleRunes = 60
+
+// filenameFieldPattern matches {field} placeholders in an output template
+var filenameFieldPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// windowsReservedNames are device names Windows refuses as filenames, with
+// or without an extension
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// OutputTemplateFields lists the placeholders RenderFilename accepts
+var OutputTemplateFields = []string{"id", "author", "title", "date"}
+
+// TemplateNeedsMetadata reports whether tmpl uses fields beyond the reel ID
+func TemplateNeedsMetadata(tmpl string) bool {
+	for _, m := range filenameFieldPattern.FindAllStringSubmatch(tmpl, -1) {
+		if m[1] != "id" {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateOutputTemplate checks that tmpl only uses known fields
+func ValidateOutputTemplate(tmpl string) error {
+	for _, m := range filenameFieldPattern.FindAllStringSubmatch(tmpl, -1) {
+		known := false
+		for _, f := range OutputTemplateFields {
+			known = known || m[1] == f
+		}
+		if !known {
+			return fmt.Errorf("unknown output template field {%s} (available: {%s})", m[1], strings.Join(OutputTemplateFields, "}, {"))
+		}
+	}
+	return nil
+}
+
+// RenderFilename fills tmpl's {id}, {author}, {title} and {date} fields from
+// the reel and sanitizes the result for goos. Unknown values render empty;
+// a name that ends up empty falls back to the reel ID.
+func RenderFilename(tmpl string, reel *Reel, goos string) string {
+	name := filenameFieldPattern.ReplaceAllStringFunc(tmpl, func(field string) string {
+		switch field {
+		case "{id}":
+			return reel.ID
+		case "{author}":
+			return reel.Author
+		case "{title}":
+			return truncateWords(strings.Join(strings.Fields(reel.Title), " "), maxTitleRunes)
+		case "{date}":
+			if !reel.UploadedAt.IsZero() {
+				return reel.UploadedAt.Format("2006-01-02")
+			}
+		}
+		return ""
+	})
+
+	if name = SanitizeFilename(name, goos); name == "" {
+		return reel.ID
+	}
+	return name
+}
+
+// SanitizeFilename makes name safe to use as a base filename on goos:
+// combining marks are dropped, path separators, characters Windows
+// forbids, controls and symbols such as emoji become underscores, and the
+// result is cut to MaxFilenameBytes. Leading and
+// trailing dots, spaces and underscores are removed, so a name can't be
+// hidden or refer to a parent directory. On Windows, reserved device names
+// such as CON get an underscore.
+func SanitizeFilename(name, goos string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left over from decomposed accented letters
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '.' || r == '_':
+			sb.WriteRune(r)
+		case unicode.IsSpace(r):
+			sb.WriteRune(' ')
+		default:
+			sb.WriteRune('_')
+		}
+	}
+
+	// Collapse runs of separators left by replaced characters
+	name = strings.Join(strings.Fields(sb.String()), " ")
+	for strings.Contains(name, "__") {
+		name = strings.ReplaceAll(name, "__", "_")
+	}
+	name = strings.ReplaceAll(name, "_ _", "_")
+	name = strings.Trim(truncateBytes(name, MaxFilenameBytes), " _.")
+
+	if goos == "windows" {
+		stem, _, _ := strings.Cut(name, ".")
+		if windowsReservedNames[strings.ToUpper(stem)] {
+			name = stem + "_" + strings.TrimPrefix(name, stem)
+		}
+	}
+	return name
+}
+
+// truncateWords shortens s to at most n runes, cutting after a whole word
+// when there is one
+func truncateWords(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	cut := string([]rune(s)[:n+1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		return cut[:i]
+	}
+	return string([]rune(s)[:n])
+}
+
+// truncateBytes shortens s to at most n bytes without splitting a rune
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		want string
+	}{
+		{"cafe\u0301_cre\u0300me", "linux", "cafe_creme"},
+		{`a/b\c:d*e?f"g<h>i|j`, "linux", "a_b_c_d_e_f_g_h_i_j"},
+		{"best 🔥🔥 tips", "linux", "best _ tips"},
+		{"tab\there\x00", "linux", "tab here"},
+		{"..", "linux", ""},
+		{".hidden.", "linux", "hidden"},
+		{"東京 vlog", "linux", "東京 vlog"},
+		{"CON", "windows", "CON_"},
+		{"nul.txt", "windows", "nul_.txt"},
+		{"CON", "linux", "CON"},
+		{"CONSOLE", "windows", "CONSOLE"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeFilename(tt.name, tt.goos); got != tt.want {
+			t.Errorf("SanitizeFilename(%q, %s) = %q, want %q", tt.name, tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeFilename_Length(t *testing.T) {
+	got := SanitizeFilename(strings.Repeat("e", 150)+strings.Repeat("日", 100), "linux")
+	if len(got) > MaxFilenameBytes {
+		t.Errorf("len = %d, want at most %d", len(got), MaxFilenameBytes)
+	}
+	if !strings.HasPrefix(got, strings.Repeat("e", 150)) || !strings.HasSuffix(got, "日") {
+		t.Errorf("SanitizeFilename() = %q, want a cut between whole characters", got)
+	}
+}
+
+func TestRenderFilename(t *testing.T) {
+	reel := &Reel{
+		ID:         "ABC123",
+		Author:     "chef.maria",
+		Title:      "My  secret   pasta: 3 steps / no oven!! " + strings.Repeat("more words ", 20),
+		UploadedAt: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+	}
+
+	got := RenderFilename("{date}_{author}_{title}", reel, "windows")
+	want := "2025-03-09_chef.maria_My secret pasta_ 3 steps _ no oven_ more words more words"
+	if got != want {
+		t.Errorf("RenderFilename() = %q, want %q", got, want)
+	}
+
+	if got := RenderFilename("{author}", &Reel{ID: "ABC123"}, "linux"); got != "ABC123" {
+		t.Errorf("empty name = %q, want the reel ID", got)
+	}
+}
+
+func TestValidateOutputTemplate(t *testing.T) {
+	if err := ValidateOutputTemplate("{author}-{date}-{id}"); err != nil {
+		t.Errorf("valid template: %v", err)
+	}
+	if err := ValidateOutputTemplate("{author}-{views}"); err == nil || !strings.Contains(err.Error(), "{views}") {
+		t.Errorf("unknown field: error = %v", err)
+	}
+	if TemplateNeedsMetadata("{id}_final") || !TemplateNeedsMetadata("{id}_{title}") {
+		t.Error("TemplateNeedsMetadata() should only be true for fields beyond {id}")
+	}
+}
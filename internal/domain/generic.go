@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// genericPrefix marks videos from sites ig2insights doesn't know among reel
+// IDs. Like youtubePrefix, the '.' keeps them apart from shortcodes.
+const genericPrefix = "url."
+
+// genericHashLen is how many hex digits of the URL's hash a generic ID keeps
+const genericHashLen = 12
+
+// GenericID returns the reel ID of the video at rawURL on a site
+// ig2insights doesn't know. The ID is derived from the URL, so the same URL
+// always maps to the same cache entry and output names.
+func GenericID(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return genericPrefix + hex.EncodeToString(sum[:])[:genericHashLen]
+}
+
+// IsGenericID reports whether id was made by GenericID
+func IsGenericID(id string) bool {
+	hash, ok := strings.CutPrefix(id, genericPrefix)
+	if !ok || len(hash) != genericHashLen {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// ParseGenericURL accepts any http or https URL as a video to hand to the
+// downloader verbatim, for sites ig2insights doesn't recognize. The URL is
+// kept as given since only the downloader knows which parts matter.
+func ParseGenericURL(input string) (*Reel, error) {
+	input = strings.TrimSpace(input)
+	u, err := url.Parse(input)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReelInput, input)
+	}
+	return &Reel{ID: GenericID(input), URL: input}, nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGenericURL(t *testing.T) {
+	reel, err := ParseGenericURL(" https://vimeo.com/76979871?share=copy ")
+	if err != nil {
+		t.Fatalf("ParseGenericURL() error = %v", err)
+	}
+	if reel.URL != "https://vimeo.com/76979871?share=copy" {
+		t.Errorf("URL = %q, want the input verbatim", reel.URL)
+	}
+	if reel.ID != GenericID(reel.URL) || !IsGenericID(reel.ID) || len(reel.ID) != len("url.")+12 {
+		t.Errorf("ID = %q", reel.ID)
+	}
+	if reel.ReelURL() != reel.URL {
+		t.Errorf("ReelURL() = %q", reel.ReelURL())
+	}
+
+	for _, input := range []string{"vimeo.com/76979871", "ftp://example.com/video.mp4", "https://", "ABC123"} {
+		if _, err := ParseGenericURL(input); !errors.Is(err, ErrInvalidReelInput) {
+			t.Errorf("ParseGenericURL(%q) error = %v, want ErrInvalidReelInput", input, err)
+		}
+	}
+}
+
+func TestIsGenericID(t *testing.T) {
+	// An all-digit hash must not read as a carousel slide
+	id := "url.123456789012"
+	if !IsGenericID(id) {
+		t.Fatalf("IsGenericID(%q) = false", id)
+	}
+	if shortcode, slide := ParseSlideID(id); shortcode != id || slide != 0 {
+		t.Errorf("ParseSlideID(%q) = %q, %d", id, shortcode, slide)
+	}
+
+	for _, id := range []string{"ABC123", "yt.dQw4w9WgXcQ", "url.xyz", "url.1234567890ab1"} {
+		if IsGenericID(id) {
+			t.Errorf("IsGenericID(%q) = true", id)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// RunRecord is one transcription attempt in the local processing history
+type RunRecord struct {
+	ReelID    string        `json:"reel_id"`
+	Author    string        `json:"author,omitempty"`
+	Model     string        `json:"model"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	FromCache bool          `json:"from_cache"`
+	Success   bool          `json:"success"`
+	Error     string        `json:"error,omitempty"`
+	Backend   string        `json:"backend,omitempty"`  // fallback backend that transcribed the reel
+	Language  string        `json:"language,omitempty"` // language asked for; empty or "auto" for auto-detection
+
+	// Whisper runs record the reel's length and the time spent transcribing
+	// it alone, so each model's speed on this machine can be measured
+	AudioSeconds       int           `json:"audio_seconds,omitempty"`
+	TranscribeDuration time.Duration `json:"transcribe_duration,omitempty"`
+
+	// DownloadDuration is the time spent downloading the reel's audio; zero
+	// when it was cached
+	DownloadDuration time.Duration `json:"download_duration,omitempty"`
+}
+
+// ModelTiming aggregates transcription times for one model. Cached and
+// failed runs are excluded since they say nothing about model speed.
+type ModelTiming struct {
+	Model   string        `json:"model"`
+	Runs    int           `json:"runs"`
+	Average time.Duration `json:"average"`
+	Fastest time.Duration `json:"fastest"`
+	Slowest time.Duration `json:"slowest"`
+}
+
+// ModelTimings summarizes fresh, successful runs per model, slowest average first
+func ModelTimings(records []RunRecord) []ModelTiming {
+	byModel := make(map[string]*ModelTiming)
+	totals := make(map[string]time.Duration)
+
+	for _, r := range records {
+		if !r.Success || r.FromCache || r.Model == "" {
+			continue
+		}
+		mt, ok := byModel[r.Model]
+		if !ok {
+			mt = &ModelTiming{Model: r.Model, Fastest: r.Duration, Slowest: r.Duration}
+			byModel[r.Model] = mt
+		}
+		mt.Runs++
+		totals[r.Model] += r.Duration
+		if r.Duration < mt.Fastest {
+			mt.Fastest = r.Duration
+		}
+		if r.Duration > mt.Slowest {
+			mt.Slowest = r.Duration
+		}
+	}
+
+	timings := make([]ModelTiming, 0, len(byModel))
+	for model, mt := range byModel {
+		mt.Average = totals[model] / time.Duration(mt.Runs)
+		timings = append(timings, *mt)
+	}
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].Average != timings[j].Average {
+			return timings[i].Average > timings[j].Average
+		}
+		return timings[i].Model < timings[j].Model
+	})
+	return timings
+}
+
+// AccountStats aggregates engagement for one account's known reels
+type AccountStats struct {
+	Username      string `json:"username"`
+	Reels         int    `json:"reels"`
+	TotalViews    int64  `json:"total_views"`
+	TotalLikes    int64  `json:"total_likes"`
+	TotalComments int64  `json:"total_comments"`
+	AverageViews  int64  `json:"average_views"`
+	TopReelID     string `json:"top_reel_id"`
+}
+
+// SummarizeAccounts groups reels by author, most-viewed account first.
+// Reels without an author are skipped.
+func SummarizeAccounts(reels []*Reel) []AccountStats {
+	byAuthor := make(map[string]*AccountStats)
+	topViews := make(map[string]int64)
+
+	for _, r := range reels {
+		if r == nil || r.Author == "" {
+			continue
+		}
+		as, ok := byAuthor[r.Author]
+		if !ok {
+			as = &AccountStats{Username: r.Author}
+			byAuthor[r.Author] = as
+		}
+		as.Reels++
+		as.TotalViews += r.ViewCount
+		as.TotalLikes += r.LikeCount
+		as.TotalComments += r.CommentCount
+		if as.TopReelID == "" || r.ViewCount > topViews[r.Author] {
+			as.TopReelID = r.ID
+			topViews[r.Author] = r.ViewCount
+		}
+	}
+
+	stats := make([]AccountStats, 0, len(byAuthor))
+	for _, as := range byAuthor {
+		as.AverageViews = as.TotalViews / int64(as.Reels)
+		stats = append(stats, *as)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TotalViews != stats[j].TotalViews {
+			return stats[i].TotalViews > stats[j].TotalViews
+		}
+		return stats[i].Username < stats[j].Username
+	})
+	return stats
+}
+
+// TypicalReelSeconds is the assumed length of a reel whose duration is unknown
+// and that no run on this machine has measured
+const TypicalReelSeconds = 30
+
+// TypicalDownload is the assumed time to download a reel's audio until this
+// machine has timed a download
+const TypicalDownload = 5 * time.Second
+
+// speedSampleSize is how many recent runs of a model its measured speed covers,
+// so estimates follow hardware and whisper.cpp upgrades
+const speedSampleSize = 20
+
+// defaultSpeed is used for models missing from staticSpeeds
+const defaultSpeed = 4.0
+
+// staticSpeeds are rough seconds of audio transcribed per second of compute
+// on a typical laptop CPU, used until this machine has run a model
+var staticSpeeds = map[string]float64{
+	"tiny":                30,
+	"base":                15,
+	"small":               6,
+	"medium":              2,
+	"large":               1,
+	"large-v3-turbo":      5,
+	"large-v3-turbo-q5_0": 6,
+	"distil-large-v3":     5,
+	"small.en-tdrz":       6,
+}
+
+// Estimator predicts how long whisper takes to transcribe a reel with one model
+type Estimator struct {
+	Model       string
+	Speed       float64 // seconds of audio per second of compute
+	Runs        int     // measured runs behind Speed; 0 means the static default
+	TypicalReel int     // seconds assumed for reels of unknown length
+
+	Download time.Duration // typical time to download a reel's audio
+}
+
+// NewEstimator measures model's speed from the most recent fresh, successful
+// runs in records that know their audio length, falling back to a static
+// default when there are none
+func NewEstimator(records []RunRecord, model string) Estimator {
+	est := Estimator{Model: model, Speed: defaultSpeed, TypicalReel: TypicalReelSeconds, Download: TypicalDownload}
+	if speed, ok := staticSpeeds[model]; ok {
+		est.Speed = speed
+	}
+
+	var audio, compute float64
+	var reels, reelSeconds, downloads int
+	var downloadTotal time.Duration
+	for i := len(records) - 1; i >= 0; i-- {
+		r := records[i]
+		if r.Success && r.DownloadDuration > 0 && downloads < speedSampleSize {
+			downloads++
+			downloadTotal += r.DownloadDuration
+		}
+		if !r.Success || r.FromCache || r.AudioSeconds <= 0 || r.TranscribeDuration <= 0 {
+			continue
+		}
+		if reels < speedSampleSize {
+			reels++
+			reelSeconds += r.AudioSeconds
+		}
+		if r.Model == model && est.Runs < speedSampleSize {
+			est.Runs++
+			audio += float64(r.AudioSeconds)
+			compute += r.TranscribeDuration.Seconds()
+		}
+	}
+	if est.Runs > 0 {
+		est.Speed = audio / compute
+	}
+	if reels > 0 {
+		est.TypicalReel = reelSeconds / reels
+	}
+	if downloads > 0 {
+		est.Download = downloadTotal / time.Duration(downloads)
+	}
+	return est
+}
+
+// Estimate returns the transcription time for audioSeconds of audio, or for
+// a typical reel when the length is unknown
+func (e Estimator) Estimate(audioSeconds int) time.Duration {
+	if audioSeconds <= 0 {
+		audioSeconds = e.TypicalReel
+	}
+	return time.Duration(float64(audioSeconds) / e.Speed * float64(time.Second))
+}
+
+// Saved estimates the time the cache saved on a reel of audioSeconds: a
+// cached transcript skips downloading and transcribing it, and cached audio
+// skips the download
+func (e Estimator) Saved(audioSeconds int, transcriptCached, audioCached bool) time.Duration {
+	switch {
+	case transcriptCached:
+		return e.Download + e.Estimate(audioSeconds)
+	case audioCached:
+		return e.Download
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModelTimings(t *testing.T) {
+	records := []RunRecord{
+		{Model: "small", Duration: 10 * time.Second, Success: true},
+		{Model: "small", Duration: 20 * time.Second, Success: true},
+		{Model: "small", Duration: time.Second, Success: true, FromCache: true},
+		{Model: "small", Duration: time.Hour, Success: false},
+		{Model: "tiny", Duration: 4 * time.Second, Success: true},
+	}
+
+	timings := ModelTimings(records)
+	if len(timings) != 2 {
+		t.Fatalf("expected 2 models, got %d: %+v", len(timings), timings)
+	}
+
+	small := timings[0]
+	if small.Model != "small" || small.Runs != 2 {
+		t.Errorf("first timing = %+v, want small with 2 runs", small)
+	}
+	if small.Average != 15*time.Second || small.Fastest != 10*time.Second || small.Slowest != 20*time.Second {
+		t.Errorf("small timing = %+v, want avg 15s, fastest 10s, slowest 20s", small)
+	}
+	if timings[1].Model != "tiny" {
+		t.Errorf("second timing = %s, want tiny", timings[1].Model)
+	}
+}
+
+func TestSummarizeAccounts(t *testing.T) {
+	reels := []*Reel{
+		{ID: "a1", Author: "alice", ViewCount: 100, LikeCount: 10},
+		{ID: "a2", Author: "alice", ViewCount: 300, LikeCount: 30},
+		{ID: "b1", Author: "bob", ViewCount: 1000},
+		{ID: "x1"},
+	}
+
+	stats := SummarizeAccounts(reels)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 accounts, got %d", len(stats))
+	}
+	if stats[0].Username != "bob" {
+		t.Errorf("first account = %s, want bob (most views)", stats[0].Username)
+	}
+
+	alice := stats[1]
+	if alice.Reels != 2 || alice.TotalViews != 400 || alice.AverageViews != 200 || alice.TotalLikes != 40 {
+		t.Errorf("alice stats = %+v", alice)
+	}
+	if alice.TopReelID != "a2" {
+		t.Errorf("alice top reel = %s, want a2", alice.TopReelID)
+	}
+}
+
+func TestNewEstimator(t *testing.T) {
+	// No history: static speed and a typical reel length
+	est := NewEstimator(nil, "small")
+	if est.Runs != 0 || est.Speed != staticSpeeds["small"] || est.Download != TypicalDownload {
+		t.Errorf("static estimator = %+v", est)
+	}
+	if got := est.Estimate(0); got != 5*time.Second {
+		t.Errorf("Estimate(unknown) = %v, want 5s for a 30s reel at 6x", got)
+	}
+
+	records := []RunRecord{
+		{Model: "small", Success: true, AudioSeconds: 60, TranscribeDuration: 20 * time.Second, DownloadDuration: 2 * time.Second},
+		{Model: "small", Success: true, AudioSeconds: 40, TranscribeDuration: 5 * time.Second, DownloadDuration: 4 * time.Second},
+		{Model: "small", Success: true, AudioSeconds: 40, FromCache: true},
+		{Model: "small", Success: false, AudioSeconds: 40, TranscribeDuration: time.Hour},
+		{Model: "small", Success: true, Duration: time.Minute}, // recorded before lengths were
+		{Model: "tiny", Success: true, AudioSeconds: 20, TranscribeDuration: time.Second},
+	}
+	est = NewEstimator(records, "small")
+	if est.Runs != 2 || est.Speed != 4 {
+		t.Errorf("measured estimator = %+v, want 2 runs at 100s/25s", est)
+	}
+	if est.TypicalReel != 40 {
+		t.Errorf("TypicalReel = %d, want the 40s average of measured reels", est.TypicalReel)
+	}
+	if got := est.Estimate(120); got != 30*time.Second {
+		t.Errorf("Estimate(120) = %v, want 30s", got)
+	}
+	if est.Download != 3*time.Second {
+		t.Errorf("Download = %v, want the 3s average of timed downloads", est.Download)
+	}
+
+	if est := NewEstimator(records, "unknown-model"); est.Speed != defaultSpeed {
+		t.Errorf("unknown model speed = %v, want %v", est.Speed, defaultSpeed)
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// JobState is a reel's position in a batch run
+type JobState string
+
+const (
+	JobQueued       JobState = "queued"
+	JobDownloading  JobState = "downloading"
+	JobTranscribing JobState = "transcribing"
+	JobDone         JobState = "done"
+	JobFailed       JobState = "failed"
+)
+
+// JournalEntry records one state transition in a batch run
+type JournalEntry struct {
+	ReelID string    `json:"reel_id"`
+	State  JobState  `json:"state"`
+	At     time.Time `json:"at"`
+	Error  string    `json:"error,omitempty"`
+	Output string    `json:"output,omitempty"`
+}
+
+// ResumePlan sorts a journal's reels by what a resumed run must do with them
+type ResumePlan struct {
+	Done        []string // finished; skipped on resume
+	Interrupted []string // mid-download or mid-transcription; cached state can't be trusted
+	Pending     []string // queued or failed; processed normally
+}
+
+// LastEntries replays journal entries to each reel's last one, carrying its
+// current state and, for failed reels, the error. Reels keep the order in
+// which they were first journaled.
+func LastEntries(entries []JournalEntry) []JournalEntry {
+	var last []JournalEntry
+	index := make(map[string]int)
+	for _, e := range entries {
+		if i, seen := index[e.ReelID]; seen {
+			last[i] = e
+			continue
+		}
+		index[e.ReelID] = len(last)
+		last = append(last, e)
+	}
+	return last
+}
+
+// PlanResume replays journal entries to each reel's last state. Reels keep
+// the order in which they were first journaled.
+func PlanResume(entries []JournalEntry) ResumePlan {
+	var plan ResumePlan
+	for _, e := range LastEntries(entries) {
+		switch e.State {
+		case JobDone:
+			plan.Done = append(plan.Done, e.ReelID)
+		case JobDownloading, JobTranscribing:
+			plan.Interrupted = append(plan.Interrupted, e.ReelID)
+		default:
+			plan.Pending = append(plan.Pending, e.ReelID)
+		}
+	}
+	return plan
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanResume(t *testing.T) {
+	entries := []JournalEntry{
+		{ReelID: "A", State: JobQueued},
+		{ReelID: "B", State: JobQueued},
+		{ReelID: "C", State: JobQueued},
+		{ReelID: "D", State: JobQueued},
+		{ReelID: "E", State: JobQueued},
+		{ReelID: "A", State: JobDownloading},
+		{ReelID: "A", State: JobTranscribing},
+		{ReelID: "A", State: JobDone},
+		{ReelID: "B", State: JobDownloading},
+		{ReelID: "C", State: JobDownloading},
+		{ReelID: "C", State: JobTranscribing},
+		{ReelID: "D", State: JobFailed, Error: "reel not found"},
+	}
+
+	plan := PlanResume(entries)
+
+	check := func(name string, got []string, want string) {
+		if strings.Join(got, ",") != want {
+			t.Errorf("%s = %v, want %s", name, got, want)
+		}
+	}
+	check("Done", plan.Done, "A")
+	check("Interrupted", plan.Interrupted, "B,C")
+	check("Pending", plan.Pending, "D,E")
+}
+
+func TestPlanResume_Empty(t *testing.T) {
+	plan := PlanResume(nil)
+	if len(plan.Done)+len(plan.Interrupted)+len(plan.Pending) != 0 {
+		t.Errorf("PlanResume(nil) = %+v, want empty", plan)
+	}
+}
+
+func TestLastEntries(t *testing.T) {
+	entries := []JournalEntry{
+		{ReelID: "A", State: JobQueued},
+		{ReelID: "B", State: JobQueued},
+		{ReelID: "A", State: JobFailed, Error: "reel not found"},
+		{ReelID: "B", State: JobDone, Output: "B.txt"},
+	}
+
+	last := LastEntries(entries)
+	if len(last) != 2 || last[0].ReelID != "A" || last[1].ReelID != "B" {
+		t.Fatalf("LastEntries() = %+v, want A then B", last)
+	}
+	if last[0].State != JobFailed || last[0].Error != "reel not found" {
+		t.Errorf("A = %+v, want its failure", last[0])
+	}
+	if last[1].State != JobDone || last[1].Output != "B.txt" {
+		t.Errorf("B = %+v, want done with its output", last[1])
+	}
+}
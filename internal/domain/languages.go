@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Language is a spoken language whisper can transcribe
+type Language struct {
+	Code string // code passed to whisper, e.g. "fr"
+	Name string // English name, e.g. "French"
+}
+
+// WhisperLanguages lists the languages whisper supports, by name
+var WhisperLanguages = []Language{
+	{"af", "Afrikaans"},
+	{"sq", "Albanian"},
+	{"am", "Amharic"},
+	{"ar", "Arabic"},
+	{"hy", "Armenian"},
+	{"as", "Assamese"},
+	{"az", "Azerbaijani"},
+	{"ba", "Bashkir"},
+	{"eu", "Basque"},
+	{"be", "Belarusian"},
+	{"bn", "Bengali"},
+	{"bs", "Bosnian"},
+	{"br", "Breton"},
+	{"bg", "Bulgarian"},
+	{"yue", "Cantonese"},
+	{"ca", "Catalan"},
+	{"zh", "Chinese"},
+	{"hr", "Croatian"},
+	{"cs", "Czech"},
+	{"da", "Danish"},
+	{"nl", "Dutch"},
+	{"en", "English"},
+	{"et", "Estonian"},
+	{"fo", "Faroese"},
+	{"fi", "Finnish"},
+	{"fr", "French"},
+	{"gl", "Galician"},
+	{"ka", "Georgian"},
+	{"de", "German"},
+	{"el", "Greek"},
+	{"gu", "Gujarati"},
+	{"ht", "Haitian Creole"},
+	{"ha", "Hausa"},
+	{"haw", "Hawaiian"},
+	{"he", "Hebrew"},
+	{"hi", "Hindi"},
+	{"hu", "Hungarian"},
+	{"is", "Icelandic"},
+	{"id", "Indonesian"},
+	{"it", "Italian"},
+	{"ja", "Japanese"},
+	{"jw", "Javanese"},
+	{"kn", "Kannada"},
+	{"kk", "Kazakh"},
+	{"km", "Khmer"},
+	{"ko", "Korean"},
+	{"lo", "Lao"},
+	{"la", "Latin"},
+	{"lv", "Latvian"},
+	{"ln", "Lingala"},
+	{"lt", "Lithuanian"},
+	{"lb", "Luxembourgish"},
+	{"mk", "Macedonian"},
+	{"mg", "Malagasy"},
+	{"ms", "Malay"},
+	{"ml", "Malayalam"},
+	{"mt", "Maltese"},
+	{"mi", "Maori"},
+	{"mr", "Marathi"},
+	{"mn", "Mongolian"},
+	{"my", "Myanmar"},
+	{"ne", "Nepali"},
+	{"no", "Norwegian"},
+	{"nn", "Nynorsk"},
+	{"oc", "Occitan"},
+	{"ps", "Pashto"},
+	{"fa", "Persian"},
+	{"pl", "Polish"},
+	{"pt", "Portuguese"},
+	{"pa", "Punjabi"},
+	{"ro", "Romanian"},
+	{"ru", "Russian"},
+	{"sa", "Sanskrit"},
+	{"sr", "Serbian"},
+	{"sn", "Shona"},
+	{"sd", "Sindhi"},
+	{"si", "Sinhala"},
+	{"sk", "Slovak"},
+	{"sl", "Slovenian"},
+	{"so", "Somali"},
+	{"es", "Spanish"},
+	{"su", "Sundanese"},
+	{"sw", "Swahili"},
+	{"sv", "Swedish"},
+	{"tl", "Tagalog"},
+	{"tg", "Tajik"},
+	{"ta", "Tamil"},
+	{"tt", "Tatar"},
+	{"te", "Telugu"},
+	{"th", "Thai"},
+	{"bo", "Tibetan"},
+	{"tr", "Turkish"},
+	{"tk", "Turkmen"},
+	{"uk", "Ukrainian"},
+	{"ur", "Urdu"},
+	{"uz", "Uzbek"},
+	{"vi", "Vietnamese"},
+	{"cy", "Welsh"},
+	{"yi", "Yiddish"},
+	{"yo", "Yoruba"},
+}
+
+// LanguageName returns the English name of a whisper language code, or the
+// code itself when it isn't known
+func LanguageName(code string) string {
+	for _, lang := range WhisperLanguages {
+		if lang.Code == code {
+			return lang.Name
+		}
+	}
+	return code
+}
+
+// FilterLanguages returns the languages whose name or code contains query,
+// ignoring case. Languages whose code or name starts with query come first.
+func FilterLanguages(languages []Language, query string) []Language {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return languages
+	}
+
+	var prefix, contains []Language
+	for _, lang := range languages {
+		name := strings.ToLower(lang.Name)
+		switch {
+		case lang.Code == query || strings.HasPrefix(name, query):
+			prefix = append(prefix, lang)
+		case strings.Contains(name, query) || strings.Contains(lang.Code, query):
+			contains = append(contains, lang)
+		}
+	}
+	return append(prefix, contains...)
+}
+
+// RecentLanguages returns the languages most recently asked for in records,
+// newest first, without repeats and at most limit of them. Runs that left
+// the language to auto-detection are skipped.
+func RecentLanguages(records []RunRecord, limit int) []string {
+	sorted := make([]RunRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartedAt.After(sorted[j].StartedAt)
+	})
+
+	var recent []string
+	seen := make(map[string]bool)
+	for _, r := range sorted {
+		if len(recent) == limit {
+			break
+		}
+		if r.Language == "" || r.Language == "auto" || seen[r.Language] {
+			continue
+		}
+		seen[r.Language] = true
+		recent = append(recent, r.Language)
+	}
+	return recent
+}
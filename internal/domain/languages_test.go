@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterLanguages(t *testing.T) {
+	if got := FilterLanguages(WhisperLanguages, ""); len(got) != len(WhisperLanguages) {
+		t.Errorf("FilterLanguages(\"\") = %d languages, want all %d", len(got), len(WhisperLanguages))
+	}
+
+	got := FilterLanguages(WhisperLanguages, "Fr")
+	if len(got) == 0 || got[0].Code != "fr" {
+		t.Fatalf("FilterLanguages(Fr) = %v, want French first", got)
+	}
+	for _, lang := range got {
+		if lang.Code == "af" {
+			return // Afrikaans contains "fr"
+		}
+	}
+	t.Errorf("FilterLanguages(Fr) = %v, want names containing the query too", got)
+}
+
+func TestFilterLanguages_Code(t *testing.T) {
+	got := FilterLanguages(WhisperLanguages, "yue")
+	if len(got) != 1 || got[0].Name != "Cantonese" {
+		t.Errorf("FilterLanguages(yue) = %v, want Cantonese", got)
+	}
+	if got := FilterLanguages(WhisperLanguages, "klingon"); len(got) != 0 {
+		t.Errorf("FilterLanguages(klingon) = %v, want none", got)
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	if got := LanguageName("ja"); got != "Japanese" {
+		t.Errorf("LanguageName(ja) = %q", got)
+	}
+	if got := LanguageName("xx"); got != "xx" {
+		t.Errorf("LanguageName(xx) = %q, want the code back", got)
+	}
+}
+
+func TestRecentLanguages(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	records := []RunRecord{
+		{Language: "es", StartedAt: start},
+		{Language: "fr", StartedAt: start.Add(time.Minute)},
+		{Language: "", StartedAt: start.Add(2 * time.Minute)},
+		{Language: "es", StartedAt: start.Add(3 * time.Minute)},
+		{Language: "auto", StartedAt: start.Add(4 * time.Minute)},
+		{Language: "de", StartedAt: start.Add(5 * time.Minute)},
+	}
+
+	got := RecentLanguages(records, 2)
+	if len(got) != 2 || got[0] != "de" || got[1] != "es" {
+		t.Errorf("RecentLanguages() = %v, want [de es]", got)
+	}
+	if got := RecentLanguages(records, 10); len(got) != 3 {
+		t.Errorf("RecentLanguages() = %v, want 3 distinct languages", got)
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// localPrefix marks media files on disk among reel IDs. Like genericPrefix,
+// the '.' keeps them apart from shortcodes.
+const localPrefix = "file."
+
+// localMediaExtensions are the file types accepted as local input, and
+// whether each can hold video
+var localMediaExtensions = map[string]bool{
+	".mp4":  true,
+	".m4v":  true,
+	".mov":  true,
+	".mkv":  true,
+	".webm": true,
+	".wav":  false,
+	".mp3":  false,
+	".m4a":  false,
+	".flac": false,
+	".ogg":  false,
+	".opus": false,
+}
+
+// LocalFileID returns the reel ID of the media file at absPath. The size and
+// modification time are part of the ID, so an edited file is transcribed
+// afresh instead of served from the cache.
+func LocalFileID(absPath string, size int64, modTime time.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d", absPath, size, modTime.UnixNano())))
+	return localPrefix + hex.EncodeToString(sum[:])[:genericHashLen]
+}
+
+// IsLocalFileID reports whether id was made by LocalFileID
+func IsLocalFileID(id string) bool {
+	hash, ok := strings.CutPrefix(id, localPrefix)
+	if !ok || len(hash) != genericHashLen {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// IsLocalMediaPath reports whether input names an audio or video file rather
+// than a reel URL or ID, judging by its extension alone
+func IsLocalMediaPath(input string) bool {
+	input = strings.TrimSpace(input)
+	if strings.Contains(input, "://") {
+		return false
+	}
+	_, ok := localMediaExtensions[strings.ToLower(filepath.Ext(input))]
+	return ok
+}
+
+// IsLocalVideoPath reports whether path is a local media file that can hold
+// video, as opposed to audio only
+func IsLocalVideoPath(path string) bool {
+	return localMediaExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// LocalFileReel returns the reel for the media file at absPath, titled after
+// the file's name
+func LocalFileReel(absPath string, size int64, modTime time.Time) *Reel {
+	name := filepath.Base(absPath)
+	path := filepath.ToSlash(absPath)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive letter
+	}
+	return &Reel{
+		ID:         LocalFileID(absPath, size, modTime),
+		URL:        "file://" + path,
+		Title:      strings.TrimSuffix(name, filepath.Ext(name)),
+		UploadedAt: modTime,
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsLocalMediaPath(t *testing.T) {
+	for _, input := range []string{"./video.mp4", "clip.MOV", " /tmp/voice memo.wav ", `C:\Users\me\talk.m4a`} {
+		if !IsLocalMediaPath(input) {
+			t.Errorf("IsLocalMediaPath(%q) = false", input)
+		}
+	}
+	for _, input := range []string{"ABC123", "notes.txt", "https://example.com/video.mp4", "video"} {
+		if IsLocalMediaPath(input) {
+			t.Errorf("IsLocalMediaPath(%q) = true", input)
+		}
+	}
+
+	if !IsLocalVideoPath("a.webm") || IsLocalVideoPath("a.mp3") {
+		t.Error("IsLocalVideoPath() should tell video from audio-only files")
+	}
+}
+
+func TestLocalFileReel(t *testing.T) {
+	modTime := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	reel := LocalFileReel("/home/me/Talk Notes.mp4", 1024, modTime)
+
+	if !IsLocalFileID(reel.ID) || len(reel.ID) != len("file.")+12 {
+		t.Errorf("ID = %q", reel.ID)
+	}
+	if reel.Title != "Talk Notes" || reel.URL != "file:///home/me/Talk Notes.mp4" || !reel.UploadedAt.Equal(modTime) {
+		t.Errorf("LocalFileReel() = %+v", reel)
+	}
+
+	// Editing the file gives it a new ID
+	if LocalFileReel("/home/me/Talk Notes.mp4", 2048, modTime).ID == reel.ID {
+		t.Error("LocalFileReel() ID should change with the file's size")
+	}
+	if LocalFileReel("/home/me/Talk Notes.mp4", 1024, modTime).ID != reel.ID {
+		t.Error("LocalFileReel() ID should be stable")
+	}
+
+	for _, id := range []string{"ABC123", "url.123456789012", "file.xyz"} {
+		if IsLocalFileID(id) {
+			t.Errorf("IsLocalFileID(%q) = true", id)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultProfanity is masked by the profanity filter when no word list is configured
+var DefaultProfanity = []string{
+	"asshole", "bastard", "bitch", "bullshit", "crap", "damn", "dick", "fuck", "fucking", "motherfucker", "piss", "shit",
+}
+
+// MapText returns a copy of the transcript with fn applied to the full text
+// and to every segment
+func (t *Transcript) MapText(fn func(string) string) *Transcript {
+	mapped := *t
+	mapped.Text = fn(t.Text)
+	mapped.Segments = make([]Segment, len(t.Segments))
+	for i, seg := range t.Segments {
+		seg.Text = fn(seg.Text)
+		mapped.Segments[i] = seg
+	}
+	return &mapped
+}
+
+var (
+	spaceBeforePunct = regexp.MustCompile(`\s+([,.!?;:])`)
+	missingSpace     = regexp.MustCompile(`([,!?;])(\p{L})|(\.)(\p{Lu})`)
+	repeatedPunct    = regexp.MustCompile(`([,.!?;:])[,.;:]+`)
+)
+
+// FixPunctuation tidies whisper's punctuation: collapses whitespace, removes
+// spaces before punctuation, adds a missing space after commas and sentence
+// ends, drops doubled marks and capitalizes the start of each sentence
+func FixPunctuation(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = spaceBeforePunct.ReplaceAllString(s, "$1")
+	s = repeatedPunct.ReplaceAllString(s, "$1")
+	s = missingSpace.ReplaceAllString(s, "$1$3 $2$4")
+
+	runes := []rune(s)
+	capitalize := true
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '!' || r == '?':
+			// Only a mark followed by a space ends a sentence, so "3.5" and
+			// "instagram.com" are left alone
+			capitalize = i+1 < len(runes) && runes[i+1] == ' '
+		case capitalize && unicode.IsLetter(r):
+			runes[i] = unicode.ToUpper(r)
+			capitalize = false
+		case capitalize && !unicode.IsSpace(r):
+			capitalize = false
+		}
+	}
+	return string(runes)
+}
+
+// ApplyCorrections replaces whole-word, case-insensitive matches of each key
+// with its value. Longer keys are applied first so phrases win over the
+// single words they contain.
+func ApplyCorrections(s string, corrections map[string]string) string {
+	keys := make([]string, 0, len(corrections))
+	for k := range corrections {
+		if strings.TrimSpace(k) != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, k := range keys {
+		re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(k) + `\b`)
+		s = re.ReplaceAllLiteralString(s, corrections[k])
+	}
+	return s
+}
+
+// MaskProfanity replaces each listed word (whole word, any case) with its
+// first letter followed by asterisks
+func MaskProfanity(s string, words []string) string {
+	for _, w := range words {
+		if strings.TrimSpace(w) == "" {
+			continue
+		}
+		re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(w) + `\b`)
+		s = re.ReplaceAllStringFunc(s, func(match string) string {
+			first, size := utf8.DecodeRuneInString(match)
+			return string(first) + strings.Repeat("*", utf8.RuneCountInString(match[size:]))
+		})
+	}
+	return s
+}
+
+var (
+	sentenceEnd = regexp.MustCompile(`[^.!?]+[.!?]*`)
+	wordPattern = regexp.MustCompile(`[\p{L}\p{N}']+`)
+)
+
+// summaryStopWords are ignored when scoring sentences
+var summaryStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "from": true, "i": true, "in": true, "is": true, "it": true, "it's": true,
+	"of": true, "on": true, "or": true, "so": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "we": true, "with": true, "you": true, "your": true,
+}
+
+// Summarize returns an extractive summary: the maxSentences sentences whose
+// words occur most often across the transcript, in their original order
+func Summarize(t *Transcript, maxSentences int) string {
+	var sentences []string
+	for _, m := range sentenceEnd.FindAllString(t.ToText(), -1) {
+		if s := strings.TrimSpace(m); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+	if maxSentences <= 0 || len(sentences) <= maxSentences {
+		return strings.Join(sentences, " ")
+	}
+
+	freq := make(map[string]int)
+	for _, w := range wordPattern.FindAllString(strings.ToLower(t.ToText()), -1) {
+		if !summaryStopWords[w] {
+			freq[w]++
+		}
+	}
+
+	type scored struct {
+		index int
+		score float64
+	}
+	ranked := make([]scored, len(sentences))
+	for i, s := range sentences {
+		words := wordPattern.FindAllString(strings.ToLower(s), -1)
+		total := 0
+		for _, w := range words {
+			total += freq[w]
+		}
+		score := 0.0
+		if len(words) > 0 {
+			score = float64(total) / float64(len(words))
+		}
+		ranked[i] = scored{index: i, score: score}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	picked := ranked[:maxSentences]
+	sort.Slice(picked, func(i, j int) bool {
+		return picked[i].index < picked[j].index
+	})
+
+	parts := make([]string, len(picked))
+	for i, p := range picked {
+		parts[i] = sentences[p.index]
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFixPunctuation(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"hello , world .This is  great", "Hello, world. This is great"},
+		{"wait!! really?yes", "Wait!! Really? Yes"},
+		{"it grew 3.5 times on instagram.com. nice", "It grew 3.5 times on instagram.com. Nice"},
+		{"so,,, anyway", "So, anyway"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := FixPunctuation(tt.input); got != tt.want {
+			t.Errorf("FixPunctuation(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestApplyCorrections(t *testing.T) {
+	corrections := map[string]string{
+		"mavely":      "Mavely",
+		"u g c":       "UGC",
+		"chat gpt":    "ChatGPT",
+		"chat":        "conversation",
+		"alternative": "ignored",
+	}
+
+	got := ApplyCorrections("Join MAVELY for u g c deals, ask chat gpt in the chat", corrections)
+	want := "Join Mavely for UGC deals, ask ChatGPT in the conversation"
+	if got != want {
+		t.Errorf("ApplyCorrections() = %q, want %q", got, want)
+	}
+
+	// Partial words are not touched
+	if got := ApplyCorrections("chatting", corrections); got != "chatting" {
+		t.Errorf("ApplyCorrections() replaced inside a word: %q", got)
+	}
+}
+
+func TestMaskProfanity(t *testing.T) {
+	got := MaskProfanity("Well DAMN, that shitake was damned good", []string{"damn", "shit"})
+	want := "Well D***, that shitake was damned good"
+	if got != want {
+		t.Errorf("MaskProfanity() = %q, want %q", got, want)
+	}
+}
+
+func TestTranscript_MapText(t *testing.T) {
+	tr := &Transcript{
+		Text:     "hello world",
+		Segments: []Segment{{Start: 1, End: 2, Text: "hello"}, {Start: 2, End: 3, Text: "world"}},
+	}
+
+	upper := tr.MapText(strings.ToUpper)
+	if upper.Text != "HELLO WORLD" || upper.Segments[1].Text != "WORLD" || upper.Segments[1].Start != 2 {
+		t.Errorf("MapText() = %+v", upper)
+	}
+	if tr.Segments[0].Text != "hello" {
+		t.Error("MapText() modified the original transcript")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tr := &Transcript{Text: "Pricing matters most. I had coffee today. " +
+		"Good pricing wins customers. The weather was nice. Test your pricing often."}
+
+	got := Summarize(tr, 2)
+	if strings.Contains(got, "coffee") || strings.Contains(got, "weather") {
+		t.Errorf("Summarize() picked an off-topic sentence: %q", got)
+	}
+	if !strings.HasPrefix(got, "Pricing matters most.") && !strings.HasPrefix(got, "Good pricing") {
+		t.Errorf("Summarize() should keep original order, got %q", got)
+	}
+	if n := strings.Count(got, "."); n != 2 {
+		t.Errorf("Summarize() returned %d sentences, want 2: %q", n, got)
+	}
+
+	short := &Transcript{Text: "Only one sentence here."}
+	if got := Summarize(short, 3); got != "Only one sentence here." {
+		t.Errorf("Summarize() of a short transcript = %q", got)
+	}
+}
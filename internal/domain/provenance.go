@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Provenance records how a transcript was produced, for reproducibility
+type Provenance struct {
+	ToolVersion    string    `json:"tool_version"`
+	YtDlpVersion   string    `json:"ytdlp_version"`
+	WhisperVersion string    `json:"whisper_version"`
+	Model          string    `json:"model"`
+	ModelSHA256    string    `json:"model_sha256,omitempty"`
+	Args           []string  `json:"args"`
+	FromCache      bool      `json:"from_cache"`
+	TranscribedAt  time.Time `json:"transcribed_at"`
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+}
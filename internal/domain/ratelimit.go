@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+const (
+	// RateLimitWindow is how far back rate-limit hits count toward back-off
+	RateLimitWindow = time.Hour
+
+	baseCooldown = time.Minute
+	maxCooldown  = 30 * time.Minute
+)
+
+// BackoffStatus describes the current back-off state for a host
+type BackoffStatus struct {
+	Host          string
+	RecentHits    int       // rate-limit responses within RateLimitWindow
+	LastHit       time.Time // most recent rate-limit response
+	CooldownUntil time.Time // earliest recommended time to resume requests
+}
+
+// CoolingDown reports whether requests to the host should still be held off
+func (b BackoffStatus) CoolingDown(now time.Time) bool {
+	return now.Before(b.CooldownUntil)
+}
+
+// Wait returns the recommended time to wait before resuming, zero if none
+func (b BackoffStatus) Wait(now time.Time) time.Duration {
+	if !b.CoolingDown(now) {
+		return 0
+	}
+	return b.CooldownUntil.Sub(now)
+}
+
+// ComputeBackoff derives back-off status from rate-limit hit timestamps.
+// The cooldown doubles with each recent hit, starting at one minute and
+// capped at thirty, measured from the latest hit.
+func ComputeBackoff(host string, hits []time.Time, now time.Time) BackoffStatus {
+	status := BackoffStatus{Host: host}
+
+	cutoff := now.Add(-RateLimitWindow)
+	for _, hit := range hits {
+		if hit.Before(cutoff) {
+			continue
+		}
+		status.RecentHits++
+		if hit.After(status.LastHit) {
+			status.LastHit = hit
+		}
+	}
+
+	if status.RecentHits == 0 {
+		return status
+	}
+
+	cooldown := baseCooldown
+	for i := 1; i < status.RecentHits && cooldown < maxCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > maxCooldown {
+		cooldown = maxCooldown
+	}
+	status.CooldownUntil = status.LastHit.Add(cooldown)
+
+	return status
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeBackoff(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		hits     []time.Time
+		wantHits int
+		wantWait time.Duration
+	}{
+		{"no hits", nil, 0, 0},
+		{"single recent hit", []time.Time{now.Add(-30 * time.Second)}, 1, 30 * time.Second},
+		{"hits double cooldown", []time.Time{now.Add(-10 * time.Minute), now.Add(-time.Minute)}, 2, time.Minute},
+		{"old hits ignored", []time.Time{now.Add(-2 * time.Hour)}, 0, 0},
+		{"cooldown capped", []time.Time{now, now, now, now, now, now, now, now}, 8, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := ComputeBackoff("instagram.com", tt.hits, now)
+			if status.RecentHits != tt.wantHits {
+				t.Errorf("RecentHits = %d, want %d", status.RecentHits, tt.wantHits)
+			}
+			if got := status.Wait(now); got != tt.wantWait {
+				t.Errorf("Wait() = %v, want %v", got, tt.wantWait)
+			}
+			if status.CoolingDown(now) != (tt.wantWait > 0) {
+				t.Errorf("CoolingDown() = %v, want %v", status.CoolingDown(now), tt.wantWait > 0)
+			}
+		})
+	}
+}
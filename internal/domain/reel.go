@@ -2,7 +2,10 @@ package domain
 
 import (
 	"fmt"
+	"html"
+	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -13,12 +16,15 @@ type Reel struct {
 	URL             string
 	Author          string
 	Title           string
+	Caption         string // the post's full description, with its links and hashtags
 	DurationSeconds int
 	ViewCount       int64
 	LikeCount       int64     // Number of likes on the reel
 	CommentCount    int64     // Number of comments on the reel
 	UploadedAt      time.Time // When the reel was posted
 	FetchedAt       time.Time
+	Story           bool // an Instagram story: only visible to logged-in users, for 24 hours
+	Post            bool // linked as a /p/ post, which may be a carousel of several videos
 }
 
 // ReelURL builds the full Instagram URL for a reel
@@ -26,37 +32,165 @@ func (r *Reel) ReelURL() string {
 	if r.URL != "" {
 		return r.URL
 	}
-	return fmt.Sprintf("https://www.instagram.com/p/%s/", r.ID)
+	return CanonicalReelURL(r.ID)
 }
 
+// CheckDuration returns ErrReelTooLong when the reel is known to run longer
+// than max. A zero max or an unknown duration passes.
+func (r *Reel) CheckDuration(max time.Duration) error {
+	if r == nil || max <= 0 || r.DurationSeconds <= 0 {
+		return nil
+	}
+	if d := time.Duration(r.DurationSeconds) * time.Second; d > max {
+		return fmt.Errorf("%w: %s is longer than %s", ErrReelTooLong, d, max)
+	}
+	return nil
+}
+
+// Shortcode length bounds; real shortcodes are 10-12 characters for public
+// reels and longer for private ones
+const (
+	minShortcodeLen = 5
+	maxShortcodeLen = 64
+)
+
 var (
-	// Matches /p/ID or /reel/ID patterns
-	reelURLPattern = regexp.MustCompile(`instagram\.com/(?:p|reel)/([A-Za-z0-9_-]+)`)
 	// Valid reel ID pattern (alphanumeric, dash, underscore)
 	reelIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+	// embeddedURLPattern finds Instagram URLs in running text, stopping at
+	// the delimiters Markdown and HTML put around links
+	embeddedURLPattern = regexp.MustCompile(`(?i)(?:https?://)?(?:[a-z0-9-]+\.)*(?:instagram\.com|instagr\.am)/[^\s"'<>()\[\]]+`)
 )
 
-// ParseReelInput extracts a Reel from a URL or ID string
+// ExtractInstagramURLs returns the Instagram URLs embedded in text, such as
+// a Markdown note or a browser's bookmark export, in order and without
+// repeats. HTML entities are decoded and trailing punctuation is dropped;
+// the URLs still need ParseReelInput to tell reels from other pages.
+func ExtractInstagramURLs(text string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, match := range embeddedURLPattern.FindAllString(text, -1) {
+		u := strings.TrimRight(html.UnescapeString(match), ".,;:!?*_`")
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// reelPathKinds are the path segments that precede a shortcode in reel URLs
+var reelPathKinds = map[string]bool{"p": true, "reel": true, "reels": true, "tv": true}
+
+// ParseReelInput extracts a Reel from a URL or ID string. URLs are normalized:
+// tracking parameters (e.g. ?igsh=) and fragments are dropped and the Reel's
+// URL is set to the canonical form. Share links (instagram.com/share/...)
+// return ErrShareLink since they only reveal the reel after a redirect.
+// Story URLs and numeric story IDs give a Reel marked Story whose ID is the
+// story's shortcode. YouTube Shorts and video URLs give a Reel whose ID is
+// the video ID marked by YouTubeID.
 func ParseReelInput(input string) (*Reel, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
-		return nil, fmt.Errorf("empty input")
+		return nil, fmt.Errorf("%w: empty input", ErrInvalidReelInput)
+	}
+
+	if strings.Contains(input, "instagram.com") || strings.Contains(input, "instagr.am") {
+		return parseReelURL(input)
+	}
+	if strings.Contains(input, "youtube.com") || strings.Contains(input, "youtu.be") {
+		return parseReelURL(input)
+	}
+	if videoID, ok := ParseYouTubeID(input); ok {
+		return &Reel{ID: input, URL: YouTubeShortURL(videoID)}, nil
+	}
+
+	if isStoryID(input) {
+		return parseStory(input, "")
+	}
+	if err := validateShortcode(input); err != nil {
+		return nil, err
+	}
+	return &Reel{ID: input, URL: CanonicalReelURL(input)}, nil
+}
+
+// parseReelURL extracts the shortcode from an Instagram reel or post URL,
+// or the video ID from a YouTube one
+func parseReelURL(input string) (*Reel, error) {
+	raw := input
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReelInput, input)
+	}
+
+	if isYouTubeHost(u.Hostname()) {
+		return parseYouTubeURL(u, input)
 	}
 
-	// Try to match URL pattern
-	if matches := reelURLPattern.FindStringSubmatch(input); len(matches) > 1 {
-		return &Reel{
-			ID:  matches[1],
-			URL: input,
-		}, nil
+	host := strings.ToLower(u.Hostname())
+	if host != "instagram.com" && !strings.HasSuffix(host, ".instagram.com") && host != "instagr.am" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReelInput, input)
 	}
 
-	// Check if it's a valid reel ID
-	if reelIDPattern.MatchString(input) {
-		return &Reel{
-			ID: input,
-		}, nil
+	parts := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(parts) > 0 && parts[0] == "share" {
+		return nil, fmt.Errorf("%w: %s", ErrShareLink, input)
 	}
 
-	return nil, fmt.Errorf("invalid reel URL or ID: %s", input)
+	// Accept /stories/{username}/ID; highlights hold many stories
+	if len(parts) == 3 && parts[0] == "stories" && parts[1] != "highlights" {
+		return parseStory(parts[2], parts[1])
+	}
+
+	// Accept /p/ID, /reel/ID, and /{username}/reel/ID
+	for i := 0; i+1 < len(parts) && i < 2; i++ {
+		if reelPathKinds[parts[i]] {
+			id := parts[i+1]
+			if err := validateShortcode(id); err != nil {
+				return nil, err
+			}
+			return &Reel{ID: id, URL: CanonicalReelURL(id), Post: parts[i] == "p"}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInvalidReelInput, input)
+}
+
+// validateShortcode checks a reel shortcode's charset and length
+func validateShortcode(id string) error {
+	if !reelIDPattern.MatchString(id) {
+		return fmt.Errorf("%w: %s", ErrInvalidReelInput, id)
+	}
+	if len(id) < minShortcodeLen || len(id) > maxShortcodeLen {
+		return fmt.Errorf("%w: shortcode %q must be %d-%d characters", ErrInvalidReelInput, id, minShortcodeLen, maxShortcodeLen)
+	}
+	return nil
+}
+
+// CanonicalReelURL returns the canonical URL for a reel ID: the Shorts URL
+// of YouTube videos, the Instagram post URL of anything else
+func CanonicalReelURL(id string) string {
+	if videoID, ok := ParseYouTubeID(id); ok {
+		return YouTubeShortURL(videoID)
+	}
+	return fmt.Sprintf("https://www.instagram.com/p/%s/", id)
+}
+
+// SortReels orders reels in place: newest upload first for SortLatest,
+// highest view count first for SortMostViewed.
+func SortReels(reels []*Reel, order SortOrder) {
+	switch order {
+	case SortMostViewed:
+		sort.SliceStable(reels, func(i, j int) bool {
+			return reels[i].ViewCount > reels[j].ViewCount
+		})
+	default:
+		sort.SliceStable(reels, func(i, j int) bool {
+			return reels[i].UploadedAt.After(reels[j].UploadedAt)
+		})
+	}
 }
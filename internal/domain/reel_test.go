@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseReelInput_URL(t *testing.T) {
@@ -56,3 +59,155 @@ func TestParseReelInput_URL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReelInput_Normalization(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantID  string
+		wantErr error
+	}{
+		{"tracking params", "https://www.instagram.com/reel/DToLsd-EvGJ/?igsh=MWQ1ZGUxMzBkMA==", "DToLsd-EvGJ", nil},
+		{"fragment", "https://instagram.com/p/DToLsd-EvGJ#comments", "DToLsd-EvGJ", nil},
+		{"no scheme", "instagram.com/reel/DToLsd-EvGJ", "DToLsd-EvGJ", nil},
+		{"username path", "https://www.instagram.com/someuser/reel/DToLsd-EvGJ/", "DToLsd-EvGJ", nil},
+		{"reels path", "https://www.instagram.com/reels/DToLsd-EvGJ/", "DToLsd-EvGJ", nil},
+		{"share link", "https://www.instagram.com/share/reel/BAFg2xYz1/", "", ErrShareLink},
+		{"other host", "https://notinstagram.com.evil.io/reel/DToLsd-EvGJ/", "", ErrInvalidReelInput},
+		{"profile URL", "https://www.instagram.com/someuser/", "", ErrInvalidReelInput},
+		{"bad charset", "DToLsd$EvGJ", "", ErrInvalidReelInput},
+		{"too short", "abc", "", ErrInvalidReelInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reel, err := ParseReelInput(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseReelInput() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseReelInput() error = %v", err)
+			}
+			if reel.ID != tt.wantID {
+				t.Errorf("ParseReelInput() ID = %v, want %v", reel.ID, tt.wantID)
+			}
+			if want := "https://www.instagram.com/p/" + tt.wantID + "/"; reel.URL != want {
+				t.Errorf("ParseReelInput() URL = %v, want canonical %v", reel.URL, want)
+			}
+		})
+	}
+}
+
+func TestSortReels(t *testing.T) {
+	newReels := func() []*Reel {
+		return []*Reel{
+			{ID: "old", ViewCount: 500, UploadedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "new", ViewCount: 10, UploadedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "mid", ViewCount: 90, UploadedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		order SortOrder
+		want  []string
+	}{
+		{"latest", SortLatest, []string{"new", "mid", "old"}},
+		{"most viewed", SortMostViewed, []string{"old", "mid", "new"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reels := newReels()
+			SortReels(reels, tt.order)
+			for i, id := range tt.want {
+				if reels[i].ID != id {
+					t.Errorf("SortReels() [%d] = %s, want %s", i, reels[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestReel_CheckDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		reel    *Reel
+		max     time.Duration
+		wantErr bool
+	}{
+		{"within limit", &Reel{DurationSeconds: 90}, 10 * time.Minute, false},
+		{"exactly at limit", &Reel{DurationSeconds: 600}, 10 * time.Minute, false},
+		{"too long", &Reel{DurationSeconds: 3600}, 10 * time.Minute, true},
+		{"no limit", &Reel{DurationSeconds: 3600}, 0, false},
+		{"unknown duration", &Reel{}, time.Minute, false},
+		{"nil reel", nil, time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.reel.CheckDuration(tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrReelTooLong) {
+				t.Errorf("CheckDuration() error = %v, want ErrReelTooLong", err)
+			}
+		})
+	}
+}
+
+func TestExtractInstagramURLs(t *testing.T) {
+	text := `See [this](https://www.instagram.com/reel/ABC123/), www.instagram.com/p/DEF456/. ` +
+		`<a href="https://instagram.com/reel/GHI789/?a=1&amp;b=2">x</a> https://www.instagram.com/reel/ABC123/ https://example.com/p/NOPE12/`
+
+	got := ExtractInstagramURLs(text)
+	want := []string{
+		"https://www.instagram.com/reel/ABC123/",
+		"www.instagram.com/p/DEF456/",
+		"https://instagram.com/reel/GHI789/?a=1&b=2",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("ExtractInstagramURLs() = %q, want %q", got, want)
+	}
+}
+
+func TestParseReelInput_Story(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantURL    string
+		wantAuthor string
+	}{
+		{"story URL", "https://www.instagram.com/stories/chef/3412345678901234567/?igsh=abc", "https://www.instagram.com/stories/chef/3412345678901234567/", "chef"},
+		{"story ID", "3412345678901234567", "https://www.instagram.com/p/C9bFlDJv0uH/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reel, err := ParseReelInput(tt.input)
+			if err != nil {
+				t.Fatalf("ParseReelInput() error = %v", err)
+			}
+			if reel.ID != "C9bFlDJv0uH" || !reel.Story {
+				t.Errorf("reel = %+v, want story C9bFlDJv0uH", reel)
+			}
+			if reel.URL != tt.wantURL || reel.Author != tt.wantAuthor {
+				t.Errorf("URL, Author = %q, %q; want %q, %q", reel.URL, reel.Author, tt.wantURL, tt.wantAuthor)
+			}
+		})
+	}
+
+	for _, input := range []string{
+		"https://www.instagram.com/stories/highlights/17900000000000000/",
+		"https://www.instagram.com/stories/chef/notanid/",
+		"99999999999999999999999",
+	} {
+		if _, err := ParseReelInput(input); !errors.Is(err, ErrInvalidReelInput) {
+			t.Errorf("ParseReelInput(%q) error = %v, want ErrInvalidReelInput", input, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// InstagramCaptionLimit is the most characters Instagram accepts in a post caption
+	InstagramCaptionLimit = 2200
+
+	// InstagramHashtagLimit is the most hashtags Instagram accepts in a post caption
+	InstagramHashtagLimit = 30
+)
+
+// BuildCaption returns text as a ready-to-paste post caption: whitespace
+// collapsed, hashtags appended on their own paragraph, and the text cut at a
+// word boundary with an ellipsis so the whole caption fits in limit
+// characters. Hashtags past Instagram's limit are dropped.
+func BuildCaption(text string, hashtags []string, limit int) string {
+	tags := normalizeHashtags(hashtags)
+	text = strings.Join(strings.Fields(text), " ")
+
+	suffix := ""
+	if len(tags) > 0 {
+		suffix = strings.Join(tags, " ")
+		if text != "" {
+			suffix = "\n\n" + suffix
+		}
+	}
+
+	budget := limit - utf8.RuneCountInString(suffix)
+	if budget < 0 {
+		budget = 0
+	}
+	return ellipsize(text, budget) + suffix
+}
+
+// normalizeHashtags prefixes each tag with '#', drops empty and duplicate
+// tags, and keeps at most InstagramHashtagLimit of them
+func normalizeHashtags(hashtags []string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range hashtags {
+		tag = strings.TrimLeft(strings.Join(strings.Fields(tag), ""), "#")
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		tags = append(tags, "#"+tag)
+		if len(tags) == InstagramHashtagLimit {
+			break
+		}
+	}
+	return tags
+}
+
+// ellipsize shortens text to at most limit characters at a word boundary,
+// marking the cut with an ellipsis
+func ellipsize(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	if limit <= 1 {
+		return ""
+	}
+	return strings.TrimRight(truncateWords(text, limit-1), " ,;:.-") + "…"
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestBuildCaption(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		hashtags []string
+		limit    int
+		want     string
+	}{
+		{"fits", "Hello  there,\nfriends.", nil, 100, "Hello there, friends."},
+		{"hashtags", "Hello there.", []string{"cooking", "#pasta", " ", "Cooking"}, 100, "Hello there.\n\n#cooking #pasta"},
+		{"trimmed at word", "one two three four five", nil, 12, "one two…"},
+		{"trimmed before hashtags", "one two three four five", []string{"tag"}, 17, "one two…\n\n#tag"},
+		{"hashtags only", "", []string{"tag"}, 100, "#tag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildCaption(tt.text, tt.hashtags, tt.limit)
+			if got != tt.want {
+				t.Errorf("BuildCaption() = %q, want %q", got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > tt.limit {
+				t.Errorf("BuildCaption() is %d characters, limit %d", n, tt.limit)
+			}
+		})
+	}
+}
+
+func TestBuildCaption_HashtagLimit(t *testing.T) {
+	var tags []string
+	for i := 0; i < 40; i++ {
+		tags = append(tags, "tag"+strings.Repeat("x", i))
+	}
+	got := BuildCaption("text", tags, InstagramCaptionLimit)
+	if n := strings.Count(got, "#"); n != InstagramHashtagLimit {
+		t.Errorf("got %d hashtags, want %d", n, InstagramHashtagLimit)
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// maxRetryBackoff caps a single wait between download attempts
+const maxRetryBackoff = time.Minute
+
+// RetryPolicy controls how transient download failures are retried
+type RetryPolicy struct {
+	Retries int           // extra attempts after the first; zero disables retries
+	Backoff time.Duration // wait before the first retry, doubling for each one after
+}
+
+// Delay returns the wait before the given retry, counting from 1
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && d < maxRetryBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxRetryBackoff)
+}
+
+// IsTransient reports whether err may succeed on a later attempt: rate limits
+// and network failures, but not missing reels or missing dependencies
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNetworkFailure)
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// instagramCookieDomain matches the domain column of Instagram's cookies
+const instagramCookieDomain = "instagram.com"
+
+// Session is an Instagram login read from a Netscape-format cookie jar
+type Session struct {
+	UserID    string    // ds_user_id cookie, empty when absent
+	ExpiresAt time.Time // sessionid expiry; zero for a browser-session cookie
+}
+
+// SessionSource records where a stored session was imported from, so it can
+// be refreshed from the same place. Exactly one field is set.
+type SessionSource struct {
+	Browser string `json:"browser,omitempty"` // e.g. "firefox" or "chrome:Profile 1"
+	File    string `json:"file,omitempty"`    // cookies.txt path
+}
+
+// String describes the source for display
+func (s SessionSource) String() string {
+	if s.Browser != "" {
+		return "browser " + s.Browser
+	}
+	return s.File
+}
+
+// InstagramCookies returns the cookie jar with only the header comments and
+// the cookies of instagram.com and its subdomains, so a browser's other
+// logins are never stored or sent
+func InstagramCookies(cookieJar []byte) []byte {
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(string(cookieJar), "\n") {
+		cookie := strings.TrimPrefix(strings.TrimRight(line, "\r\n"), "#HttpOnly_")
+		if cookie == "" || strings.HasPrefix(cookie, "#") {
+			sb.WriteString(line)
+			continue
+		}
+		if fields := strings.Split(cookie, "\t"); len(fields) == 7 && isInstagramDomain(fields[0]) {
+			sb.WriteString(line)
+		}
+	}
+	return []byte(sb.String())
+}
+
+// isInstagramDomain reports whether a cookie's domain column is
+// instagram.com or one of its subdomains
+func isInstagramDomain(domain string) bool {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+	return domain == instagramCookieDomain || strings.HasSuffix(domain, "."+instagramCookieDomain)
+}
+
+// ParseSession finds the Instagram login in a Netscape-format cookie jar,
+// returning ErrNoSession when it has no Instagram sessionid cookie
+func ParseSession(cookieJar []byte) (*Session, error) {
+	var session *Session
+	userID := ""
+
+	for _, line := range strings.Split(string(cookieJar), "\n") {
+		// yt-dlp and browsers mark HttpOnly cookies with this prefix
+		line = strings.TrimPrefix(strings.TrimRight(line, "\r"), "#HttpOnly_")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 || !isInstagramDomain(fields[0]) {
+			continue
+		}
+
+		switch name, value := fields[5], fields[6]; name {
+		case "sessionid":
+			if value == "" {
+				continue
+			}
+			session = &Session{}
+			if expires, err := strconv.ParseInt(fields[4], 10, 64); err == nil && expires > 0 {
+				session.ExpiresAt = time.Unix(expires, 0)
+			}
+		case "ds_user_id":
+			userID = value
+		}
+	}
+
+	if session == nil {
+		return nil, ErrNoSession
+	}
+	session.UserID = userID
+	return session, nil
+}
+
+// Validate returns ErrSessionExpired when the session has expired at now
+func (s *Session) Validate(now time.Time) error {
+	if !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt) {
+		return ErrSessionExpired
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const testCookieJar = "# Netscape HTTP Cookie File\n" +
+	".instagram.com\tTRUE\t/\tTRUE\t1893456000\tcsrftoken\tabc\n" +
+	"#HttpOnly_.instagram.com\tTRUE\t/\tTRUE\t1893456000\tsessionid\t123%3Axyz\n" +
+	".instagram.com\tTRUE\t/\tTRUE\t1893456000\tds_user_id\t123\n" +
+	".example.com\tTRUE\t/\tFALSE\t0\tsessionid\tother\n"
+
+func TestParseSession(t *testing.T) {
+	session, err := ParseSession([]byte(testCookieJar))
+	if err != nil {
+		t.Fatalf("ParseSession() error = %v", err)
+	}
+	if session.UserID != "123" {
+		t.Errorf("UserID = %q, want 123", session.UserID)
+	}
+	if want := time.Unix(1893456000, 0); !session.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", session.ExpiresAt, want)
+	}
+
+	jar := "#HttpOnly_.evilinstagram.com\tTRUE\t/\tTRUE\t1893456000\tsessionid\tstolen\n"
+	if _, err := ParseSession([]byte(jar)); !errors.Is(err, ErrNoSession) {
+		t.Errorf("ParseSession() with a look-alike domain error = %v, want ErrNoSession", err)
+	}
+
+	jar = "# Netscape HTTP Cookie File\n.example.com\tTRUE\t/\tFALSE\t0\tsessionid\tother\n"
+	if _, err := ParseSession([]byte(jar)); !errors.Is(err, ErrNoSession) {
+		t.Errorf("ParseSession() without Instagram cookies error = %v, want ErrNoSession", err)
+	}
+}
+
+func TestInstagramCookies(t *testing.T) {
+	jar := testCookieJar +
+		"www.instagram.com\tFALSE\t/\tTRUE\t0\tig_did\tdev\n" +
+		"#HttpOnly_.evilinstagram.com\tTRUE\t/\tTRUE\t0\tsessionid\tstolen\n"
+
+	want := "# Netscape HTTP Cookie File\n" +
+		".instagram.com\tTRUE\t/\tTRUE\t1893456000\tcsrftoken\tabc\n" +
+		"#HttpOnly_.instagram.com\tTRUE\t/\tTRUE\t1893456000\tsessionid\t123%3Axyz\n" +
+		".instagram.com\tTRUE\t/\tTRUE\t1893456000\tds_user_id\t123\n" +
+		"www.instagram.com\tFALSE\t/\tTRUE\t0\tig_did\tdev\n"
+	if got := string(InstagramCookies([]byte(jar))); got != want {
+		t.Errorf("InstagramCookies() = %q, want %q", got, want)
+	}
+}
+
+func TestSession_Validate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expires time.Time
+		want    error
+	}{
+		{"browser-session cookie", time.Time{}, nil},
+		{"valid", now.Add(time.Hour), nil},
+		{"expired", now.Add(-time.Hour), ErrSessionExpired},
+	}
+
+	for _, tt := range tests {
+		s := &Session{ExpiresAt: tt.expires}
+		if err := s.Validate(now); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Validate() = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
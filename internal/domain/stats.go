@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ReadingWordsPerMinute is the average silent reading speed used for estimates
+const ReadingWordsPerMinute = 200
+
+// TranscriptStats summarizes the size of a transcript
+type TranscriptStats struct {
+	Words              int     `json:"words"`
+	ReadingTimeMinutes int     `json:"reading_time_minutes"`
+	DurationSeconds    float64 `json:"duration_seconds"`
+}
+
+// ComputeStats counts words and estimates reading time. Audio duration comes
+// from the reel metadata when known, otherwise from the last segment's end.
+func ComputeStats(t *Transcript, reel *Reel) TranscriptStats {
+	words := len(strings.Fields(t.ToText()))
+
+	var duration float64
+	if reel != nil && reel.DurationSeconds > 0 {
+		duration = float64(reel.DurationSeconds)
+	} else if n := len(t.Segments); n > 0 {
+		duration = t.Segments[n-1].End
+	}
+
+	minutes := 0
+	if words > 0 {
+		minutes = int(math.Ceil(float64(words) / ReadingWordsPerMinute))
+	}
+
+	return TranscriptStats{
+		Words:              words,
+		ReadingTimeMinutes: minutes,
+		DurationSeconds:    duration,
+	}
+}
+
+// Summary returns a one-line human-readable summary
+func (s TranscriptStats) Summary() string {
+	total := int(math.Round(s.DurationSeconds))
+	return fmt.Sprintf("Words: %d | Reading time: ~%d min | Duration: %d:%02d",
+		s.Words, s.ReadingTimeMinutes, total/60, total%60)
+}
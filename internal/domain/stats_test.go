@@ -0,0 +1,39 @@
+package domain
+
+import "testing"
+
+func TestComputeStats(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0, End: 3, Text: "one two three"},
+			{Start: 3, End: 75.4, Text: "four five"},
+		},
+	}
+
+	stats := ComputeStats(tr, nil)
+	if stats.Words != 5 {
+		t.Errorf("Words = %d, want 5", stats.Words)
+	}
+	if stats.ReadingTimeMinutes != 1 {
+		t.Errorf("ReadingTimeMinutes = %d, want 1", stats.ReadingTimeMinutes)
+	}
+	if stats.DurationSeconds != 75.4 {
+		t.Errorf("DurationSeconds = %v, want 75.4", stats.DurationSeconds)
+	}
+	if got, want := stats.Summary(), "Words: 5 | Reading time: ~1 min | Duration: 1:15"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+
+	// Reel metadata takes precedence for duration
+	stats = ComputeStats(tr, &Reel{DurationSeconds: 90})
+	if stats.DurationSeconds != 90 {
+		t.Errorf("DurationSeconds = %v, want 90 from reel", stats.DurationSeconds)
+	}
+}
+
+func TestComputeStats_Empty(t *testing.T) {
+	stats := ComputeStats(&Transcript{}, nil)
+	if stats.Words != 0 || stats.ReadingTimeMinutes != 0 || stats.DurationSeconds != 0 {
+		t.Errorf("ComputeStats(empty) = %+v, want zero", stats)
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// shortcodeAlphabet writes media IDs in base 64 to form shortcodes
+const shortcodeAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// minStoryIDDigits is the shortest numeric input taken for a story's media
+// ID. Story IDs run to 19 digits; shortcodes are never that long and all digits.
+const minStoryIDDigits = 15
+
+// MediaIDToShortcode converts a numeric media ID, as found in story URLs,
+// to the shortcode the rest of the pipeline identifies media by
+func MediaIDToShortcode(id string) (string, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n == 0 {
+		return "", fmt.Errorf("%w: story ID %q", ErrInvalidReelInput, id)
+	}
+
+	var code []byte
+	for ; n > 0; n /= 64 {
+		code = append([]byte{shortcodeAlphabet[n%64]}, code...)
+	}
+	return string(code), nil
+}
+
+// isStoryID reports whether input looks like a story's numeric media ID
+func isStoryID(input string) bool {
+	if len(input) < minStoryIDDigits {
+		return false
+	}
+	for _, r := range input {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseStory returns the story with media ID id, posted by username when known
+func parseStory(id, username string) (*Reel, error) {
+	shortcode, err := MediaIDToShortcode(id)
+	if err != nil {
+		return nil, err
+	}
+	reel := &Reel{ID: shortcode, URL: CanonicalReelURL(shortcode), Author: username, Story: true}
+	if username != "" {
+		reel.URL = fmt.Sprintf("https://www.instagram.com/stories/%s/%s/", username, id)
+	}
+	return reel, nil
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transcript sources other than whisper, recorded in Transcript.Source
+const (
+	SourceSubtitles    = "subtitles"     // captions published by the creator
+	SourceAutoCaptions = "auto-captions" // captions generated by the platform
+)
+
+var (
+	cueTimeLine = regexp.MustCompile(`^\s*([\d:.,]+)\s*-->\s*([\d:.,]+)`)
+	cueTag      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// ParseSubtitles converts a WebVTT or SRT document into a transcript, one
+// segment per cue. Styling tags are stripped, and lines repeated from the
+// previous cue (as rolling auto-captions do) are dropped.
+func ParseSubtitles(data string) (*Transcript, error) {
+	data = strings.ReplaceAll(strings.TrimPrefix(data, "\ufeff"), "\r\n", "\n")
+
+	var segments []Segment
+	var texts []string
+	lastLine := ""
+
+	for _, block := range strings.Split(data, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+
+		timing := -1
+		for i, line := range lines {
+			if cueTimeLine.MatchString(line) {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue // header, NOTE or STYLE block
+		}
+
+		m := cueTimeLine.FindStringSubmatch(lines[timing])
+		start, err := parseCueTime(m[1])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseCueTime(m[2])
+		if err != nil {
+			return nil, err
+		}
+
+		var cueLines []string
+		for _, line := range lines[timing+1:] {
+			line = strings.TrimSpace(html.UnescapeString(cueTag.ReplaceAllString(line, "")))
+			if line == "" || line == lastLine {
+				continue
+			}
+			cueLines = append(cueLines, line)
+			lastLine = line
+		}
+		if len(cueLines) == 0 {
+			continue
+		}
+
+		text := strings.Join(cueLines, " ")
+		segments = append(segments, Segment{Start: start, End: end, Text: text})
+		texts = append(texts, text)
+	}
+
+	return &Transcript{
+		Text:          strings.Join(texts, " "),
+		Segments:      segments,
+		TranscribedAt: time.Now(),
+	}, nil
+}
+
+// parseCueTime parses HH:MM:SS.mmm, MM:SS.mmm or the SRT HH:MM:SS,mmm form
+func parseCueTime(s string) (float64, error) {
+	parts := strings.Split(strings.Replace(s, ",", ".", 1), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid cue time %q", s)
+	}
+
+	seconds := 0.0
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cue time %q", s)
+		}
+		seconds = seconds*60 + v
+	}
+	return seconds, nil
+}
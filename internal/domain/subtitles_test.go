@@ -0,0 +1,56 @@
+package domain
+
+import "testing"
+
+func TestParseSubtitles_VTT(t *testing.T) {
+	vtt := "WEBVTT\nKind: captions\nLanguage: en\n\n" +
+		"NOTE generated\n\n" +
+		"00:00.000 --> 00:02.500 align:start position:0%\n<c>hello</c><00:00:01.000><c> there</c>\n\n" +
+		"00:00:02.500 --> 00:00:05.000\nhello there\nTom &amp; Jerry\n\n" +
+		"00:00:05.000 --> 00:00:06.000\n\n"
+
+	tr, err := ParseSubtitles(vtt)
+	if err != nil {
+		t.Fatalf("ParseSubtitles() error = %v", err)
+	}
+
+	want := []Segment{
+		{Start: 0, End: 2.5, Text: "hello there"},
+		{Start: 2.5, End: 5, Text: "Tom & Jerry"},
+	}
+	if len(tr.Segments) != len(want) {
+		t.Fatalf("got %d segments, want %d: %+v", len(tr.Segments), len(want), tr.Segments)
+	}
+	for i, seg := range tr.Segments {
+		if seg != want[i] {
+			t.Errorf("segment[%d] = %+v, want %+v", i, seg, want[i])
+		}
+	}
+	if tr.Text != "hello there Tom & Jerry" {
+		t.Errorf("Text = %q", tr.Text)
+	}
+}
+
+func TestParseSubtitles_SRT(t *testing.T) {
+	srt := "1\r\n00:00:01,250 --> 00:00:03,000\r\nFirst line\r\n\r\n2\r\n01:00:00,000 --> 01:00:01,500\r\nSecond\r\nline\r\n"
+
+	tr, err := ParseSubtitles(srt)
+	if err != nil {
+		t.Fatalf("ParseSubtitles() error = %v", err)
+	}
+	if len(tr.Segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(tr.Segments))
+	}
+	if tr.Segments[0].Start != 1.25 || tr.Segments[1].Start != 3600 || tr.Segments[1].End != 3601.5 {
+		t.Errorf("unexpected timings: %+v", tr.Segments)
+	}
+	if tr.Segments[1].Text != "Second line" {
+		t.Errorf("multi-line cue = %q, want %q", tr.Segments[1].Text, "Second line")
+	}
+}
+
+func TestParseSubtitles_InvalidTime(t *testing.T) {
+	if _, err := ParseSubtitles("WEBVTT\n\n1:2:3:4 --> 00:01.000\nhi\n"); err == nil {
+		t.Error("expected error for malformed cue time")
+	}
+}
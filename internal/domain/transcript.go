@@ -1,16 +1,23 @@
 package domain
 
 import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // Segment represents a timed segment of transcribed text
 type Segment struct {
-	Start float64 `json:"start"`
-	End   float64 `json:"end"`
-	Text  string  `json:"text"`
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Text    string  `json:"text"`
+	Speaker string  `json:"speaker,omitempty"` // set when speaker turns were detected
 }
 
 // Transcript represents the full transcription result
@@ -20,6 +27,9 @@ type Transcript struct {
 	Model         string    `json:"model"`
 	Language      string    `json:"language"`
 	TranscribedAt time.Time `json:"transcribed_at"`
+	Summary       string    `json:"summary,omitempty"` // set by the summarizer post-processor
+	Source        string    `json:"source,omitempty"`  // empty for whisper, otherwise SourceSubtitles or SourceAutoCaptions
+	Backend       string    `json:"backend,omitempty"` // fallback backend that produced it; empty for the primary transcriber
 }
 
 // ToText returns plain text concatenation of all segments
@@ -52,6 +62,411 @@ func (t *Transcript) ToSRT() string {
 	return strings.TrimSuffix(sb.String(), "\n")
 }
 
+// ToDelimited returns the transcript as one row per segment with a header,
+// separated by sep (',' for CSV, '\t' for TSV). Times are in seconds. A speaker
+// column is included when any segment has a speaker label.
+func (t *Transcript) ToDelimited(sep rune) string {
+	hasSpeaker := false
+	for _, seg := range t.Segments {
+		if seg.Speaker != "" {
+			hasSpeaker = true
+			break
+		}
+	}
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	w.Comma = sep
+
+	header := []string{"start", "end", "text"}
+	if hasSpeaker {
+		header = append(header, "speaker")
+	}
+	_ = w.Write(header)
+
+	for _, seg := range t.Segments {
+		row := []string{
+			strconv.FormatFloat(seg.Start, 'f', 3, 64),
+			strconv.FormatFloat(seg.End, 'f', 3, 64),
+			strings.TrimSpace(seg.Text),
+		}
+		if hasSpeaker {
+			row = append(row, seg.Speaker)
+		}
+		_ = w.Write(row)
+	}
+	w.Flush()
+
+	return sb.String()
+}
+
+// MarkdownOptions controls optional parts of Markdown output
+type MarkdownOptions struct {
+	Stats           *TranscriptStats // added to front matter when set
+	ChapterInterval float64          // seconds between timestamped headings; 0 disables
+}
+
+// ToMarkdown returns the transcript as a Markdown note with the reel's metadata
+// as YAML front matter. Unknown metadata fields are omitted.
+func (t *Transcript) ToMarkdown(reel *Reel, opts MarkdownOptions) string {
+	stats := opts.Stats
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	if reel != nil {
+		sb.WriteString(fmt.Sprintf("id: %s\n", yamlQuote(reel.ID)))
+		sb.WriteString(fmt.Sprintf("url: %s\n", yamlQuote(reel.ReelURL())))
+		if reel.Author != "" {
+			sb.WriteString(fmt.Sprintf("author: %s\n", yamlQuote(reel.Author)))
+		}
+		if reel.Title != "" {
+			sb.WriteString(fmt.Sprintf("title: %s\n", yamlQuote(reel.Title)))
+		}
+		if reel.ViewCount > 0 {
+			sb.WriteString(fmt.Sprintf("views: %d\n", reel.ViewCount))
+		}
+		if reel.LikeCount > 0 {
+			sb.WriteString(fmt.Sprintf("likes: %d\n", reel.LikeCount))
+		}
+		if reel.CommentCount > 0 {
+			sb.WriteString(fmt.Sprintf("comments: %d\n", reel.CommentCount))
+		}
+		if reel.DurationSeconds > 0 {
+			sb.WriteString(fmt.Sprintf("duration_seconds: %d\n", reel.DurationSeconds))
+		}
+		if !reel.UploadedAt.IsZero() {
+			sb.WriteString(fmt.Sprintf("uploaded: %s\n", reel.UploadedAt.Format("2006-01-02")))
+		}
+	}
+	if t.Model != "" {
+		sb.WriteString(fmt.Sprintf("model: %s\n", yamlQuote(t.Model)))
+	}
+	if t.Language != "" {
+		sb.WriteString(fmt.Sprintf("language: %s\n", yamlQuote(t.Language)))
+	}
+	if !t.TranscribedAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("transcribed: %s\n", t.TranscribedAt.UTC().Format(time.RFC3339)))
+	}
+	if stats != nil {
+		sb.WriteString(fmt.Sprintf("words: %d\n", stats.Words))
+		sb.WriteString(fmt.Sprintf("reading_time_minutes: %d\n", stats.ReadingTimeMinutes))
+		if reel == nil || reel.DurationSeconds == 0 {
+			sb.WriteString(fmt.Sprintf("duration_seconds: %s\n", strconv.FormatFloat(stats.DurationSeconds, 'f', -1, 64)))
+		}
+	}
+	sb.WriteString("---\n\n")
+
+	heading := "Transcript"
+	if reel != nil && reel.Title != "" {
+		heading = strings.TrimSpace(strings.SplitN(reel.Title, "\n", 2)[0])
+	}
+	sb.WriteString("# " + heading + "\n\n")
+
+	if t.Summary != "" {
+		sb.WriteString("> **Summary:** " + t.Summary + "\n\n")
+	}
+
+	if chapters := t.Chapters(opts.ChapterInterval); chapters != nil {
+		for i, ch := range chapters {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(fmt.Sprintf("## %s\n\n%s\n", FormatChapterTime(ch.Start), ch.Text))
+		}
+	} else {
+		sb.WriteString(t.ToText())
+		sb.WriteString("\n")
+	}
+
+	if reel != nil && reel.Caption != "" {
+		sb.WriteString("\n## Caption\n\n" + reel.Caption + "\n")
+	}
+
+	return sb.String()
+}
+
+// yamlQuote renders s as a YAML double-quoted scalar
+func yamlQuote(s string) string {
+	return strconv.Quote(s)
+}
+
+// ToJSONL returns one JSON object per segment, newline-delimited, for streaming
+// into data pipelines. Each line carries the reel ID so files can be concatenated.
+func (t *Transcript) ToJSONL(reelID string) string {
+	type line struct {
+		ReelID  string  `json:"reel_id"`
+		Index   int     `json:"index"`
+		Start   float64 `json:"start"`
+		End     float64 `json:"end"`
+		Text    string  `json:"text"`
+		Speaker string  `json:"speaker,omitempty"`
+	}
+
+	var sb strings.Builder
+	for i, seg := range t.Segments {
+		data, err := json.Marshal(line{
+			ReelID:  reelID,
+			Index:   i,
+			Start:   seg.Start,
+			End:     seg.End,
+			Text:    strings.TrimSpace(seg.Text),
+			Speaker: seg.Speaker,
+		})
+		if err != nil {
+			continue
+		}
+		sb.Write(data)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ToLRC returns the transcript in LRC (lyric) format, one timed line per segment.
+// A trailing empty line at the last segment's end clears the display.
+func (t *Transcript) ToLRC() string {
+	var sb strings.Builder
+
+	for _, seg := range t.Segments {
+		sb.WriteString(fmt.Sprintf("[%s]%s\n", formatLRCTime(seg.Start), strings.TrimSpace(seg.Text)))
+	}
+
+	if len(t.Segments) > 0 {
+		last := t.Segments[len(t.Segments)-1]
+		sb.WriteString(fmt.Sprintf("[%s]\n", formatLRCTime(last.End)))
+	}
+
+	return sb.String()
+}
+
+// ToTimestampedText returns one line per segment prefixed with its [MM:SS]
+// start time, and the speaker label when the segment has one
+func (t *Transcript) ToTimestampedText() string {
+	var sb strings.Builder
+
+	for _, seg := range t.Segments {
+		sb.WriteString(fmt.Sprintf("[%s] ", FormatChapterTime(seg.Start)))
+		if seg.Speaker != "" {
+			sb.WriteString(seg.Speaker + ": ")
+		}
+		sb.WriteString(strings.TrimSpace(seg.Text))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// formatLRCTime converts seconds to LRC timestamp format (MM:SS.xx)
+func formatLRCTime(seconds float64) string {
+	centis := int(seconds*100 + 0.5)
+	return fmt.Sprintf("%02d:%02d.%02d", centis/6000, (centis/100)%60, centis%100)
+}
+
+const (
+	// paragraphGapSeconds is the silence between segments that starts a new paragraph
+	paragraphGapSeconds = 2.0
+)
+
+// soundEventPattern matches non-speech annotations such as [Music], (laughs) or ♪
+var soundEventPattern = regexp.MustCompile(`\[[A-Za-z][A-Za-z ]*\]|\([A-Za-z][A-Za-z ]*\)|♪+`)
+
+// ToAccessible returns an accessibility-oriented transcript: speech grouped into
+// paragraphs, speaker labels when known, and sound events on their own lines.
+func (t *Transcript) ToAccessible() string {
+	var blocks []string
+	var paragraph []string
+	paragraphSpeaker := ""
+	lastLabeled := ""
+	lastEnd := -1.0
+
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		text := strings.Join(paragraph, " ")
+		if paragraphSpeaker != "" && paragraphSpeaker != lastLabeled {
+			text = paragraphSpeaker + ":\n" + text
+			lastLabeled = paragraphSpeaker
+		}
+		blocks = append(blocks, text)
+		paragraph = nil
+	}
+
+	for _, seg := range t.Segments {
+		if seg.Speaker != paragraphSpeaker || (lastEnd >= 0 && seg.Start-lastEnd >= paragraphGapSeconds) {
+			flush()
+		}
+		paragraphSpeaker = seg.Speaker
+		lastEnd = seg.End
+
+		pos := 0
+		for _, loc := range soundEventPattern.FindAllStringIndex(seg.Text, -1) {
+			if speech := strings.TrimSpace(seg.Text[pos:loc[0]]); speech != "" {
+				paragraph = append(paragraph, speech)
+			}
+			flush()
+			blocks = append(blocks, formatSoundEvent(seg.Text[loc[0]:loc[1]]))
+			pos = loc[1]
+		}
+		if speech := strings.TrimSpace(seg.Text[pos:]); speech != "" {
+			paragraph = append(paragraph, speech)
+		}
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// formatSoundEvent normalizes a sound annotation to lowercase bracket form
+func formatSoundEvent(event string) string {
+	if strings.HasPrefix(event, "♪") {
+		return "[music]"
+	}
+	inner := strings.TrimSpace(event[1 : len(event)-1])
+	return "[" + strings.ToLower(inner) + "]"
+}
+
+// TTMLStyle holds styling attributes applied to all TTML captions
+type TTMLStyle struct {
+	FontFamily      string
+	FontSize        string
+	Color           string
+	BackgroundColor string
+	TextAlign       string
+}
+
+// ToTTML returns the transcript as a TTML (IMSC-compatible) caption document
+func (t *Transcript) ToTTML(style TTMLStyle) string {
+	lang := t.Language
+	if lang == "" || lang == "auto" {
+		lang = "und"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(fmt.Sprintf(`<tt xmlns="http://www.w3.org/ns/ttml" xmlns:tts="http://www.w3.org/ns/ttml#styling" xmlns:ttp="http://www.w3.org/ns/ttml#parameter" ttp:timeBase="media" xml:lang="%s">`+"\n", xmlEscape(lang)))
+	sb.WriteString("  <head>\n")
+	sb.WriteString("    <styling>\n")
+	sb.WriteString(`      <style xml:id="default"`)
+	// Unset attributes are left out so players apply their own defaults
+	for _, attr := range []struct{ name, value string }{
+		{"fontFamily", style.FontFamily},
+		{"fontSize", style.FontSize},
+		{"color", style.Color},
+		{"backgroundColor", style.BackgroundColor},
+		{"textAlign", style.TextAlign},
+	} {
+		if attr.value != "" {
+			sb.WriteString(fmt.Sprintf(` tts:%s="%s"`, attr.name, xmlEscape(attr.value)))
+		}
+	}
+	sb.WriteString("/>\n")
+	sb.WriteString("    </styling>\n")
+	sb.WriteString("    <layout>\n")
+	sb.WriteString(`      <region xml:id="bottom" tts:origin="10% 80%" tts:extent="80% 15%" tts:displayAlign="after"/>` + "\n")
+	sb.WriteString("    </layout>\n")
+	sb.WriteString("  </head>\n")
+	sb.WriteString(`  <body style="default" region="bottom">` + "\n")
+	sb.WriteString("    <div>\n")
+
+	for _, seg := range t.Segments {
+		sb.WriteString(fmt.Sprintf(`      <p begin="%s" end="%s">%s</p>`+"\n",
+			formatTTMLTime(seg.Start), formatTTMLTime(seg.End), xmlEscape(strings.TrimSpace(seg.Text))))
+	}
+
+	sb.WriteString("    </div>\n")
+	sb.WriteString("  </body>\n")
+	sb.WriteString("</tt>\n")
+
+	return sb.String()
+}
+
+// ASSStyle configures the default style of ASS/SSA subtitle output
+type ASSStyle struct {
+	FontName     string
+	FontSize     int
+	PrimaryColor string // #RRGGBB
+	OutlineColor string // #RRGGBB
+	Bold         bool
+	Position     string // top, middle, bottom
+	MarginV      int
+}
+
+// ToASS returns the transcript as an Advanced SubStation Alpha script.
+// The canvas is sized for vertical 1080x1920 reels.
+func (t *Transcript) ToASS(style ASSStyle) string {
+	bold := 0
+	if style.Bold {
+		bold = -1
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[Script Info]\n")
+	sb.WriteString("ScriptType: v4.00+\n")
+	sb.WriteString("PlayResX: 1080\n")
+	sb.WriteString("PlayResY: 1920\n")
+	sb.WriteString("WrapStyle: 0\n")
+	sb.WriteString("ScaledBorderAndShadow: yes\n\n")
+
+	sb.WriteString("[V4+ Styles]\n")
+	sb.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	sb.WriteString(fmt.Sprintf("Style: Default,%s,%d,%s,%s,%s,&H80000000,%d,0,0,0,100,100,0,0,1,3,0,%d,60,60,%d,1\n\n",
+		style.FontName, style.FontSize, assColor(style.PrimaryColor), assColor(style.PrimaryColor),
+		assColor(style.OutlineColor), bold, assAlignment(style.Position), style.MarginV))
+
+	sb.WriteString("[Events]\n")
+	sb.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+	for _, seg := range t.Segments {
+		text := strings.ReplaceAll(strings.TrimSpace(seg.Text), "\n", "\\N")
+		sb.WriteString(fmt.Sprintf("Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+			formatASSTime(seg.Start), formatASSTime(seg.End), text))
+	}
+
+	return sb.String()
+}
+
+// formatASSTime converts seconds to ASS timestamp format (H:MM:SS.cc)
+func formatASSTime(seconds float64) string {
+	centis := int(seconds*100 + 0.5)
+	return fmt.Sprintf("%d:%02d:%02d.%02d", centis/360000, (centis/6000)%60, (centis/100)%60, centis%100)
+}
+
+// assColor converts #RRGGBB to the ASS &HAABBGGRR form. Invalid input yields opaque white.
+func assColor(color string) string {
+	color = strings.TrimPrefix(color, "#")
+	if len(color) != 6 {
+		return "&H00FFFFFF"
+	}
+	if _, err := hex.DecodeString(color); err != nil {
+		return "&H00FFFFFF"
+	}
+	color = strings.ToUpper(color)
+	return "&H00" + color[4:6] + color[2:4] + color[0:2]
+}
+
+// assAlignment maps a vertical position to a numpad-style ASS alignment (centered horizontally)
+func assAlignment(position string) int {
+	switch position {
+	case "top":
+		return 8
+	case "middle":
+		return 5
+	default:
+		return 2
+	}
+}
+
+// formatTTMLTime converts seconds to TTML clock time (HH:MM:SS.mmm)
+func formatTTMLTime(seconds float64) string {
+	return strings.Replace(formatSRTTime(seconds), ",", ".", 1)
+}
+
+// xmlEscape escapes text for use in XML content and attribute values
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
 // formatSRTTime converts seconds to SRT timestamp format (HH:MM:SS,mmm)
 func formatSRTTime(seconds float64) string {
 	hours := int(seconds) / 3600
@@ -3,6 +3,7 @@ package domain
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestTranscript_ToText(t *testing.T) {
@@ -41,3 +42,237 @@ func TestTranscript_ToSRT(t *testing.T) {
 		t.Errorf("ToSRT() missing second timestamp, got:\n%s", result)
 	}
 }
+
+func TestTranscript_ToLRC(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0.0, End: 3.5, Text: " Hello world."},
+			{Start: 3.5, End: 67.25, Text: "How are you?"},
+		},
+	}
+
+	result := tr.ToLRC()
+	expected := "[00:00.00]Hello world.\n[00:03.50]How are you?\n[01:07.25]\n"
+
+	if result != expected {
+		t.Errorf("ToLRC() = %q, want %q", result, expected)
+	}
+}
+
+func TestFormatLRCTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "00:00.00"},
+		{2.3, "00:02.30"},
+		{59.999, "01:00.00"},
+		{67.25, "01:07.25"},
+		{3599.996, "60:00.00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatLRCTime(tt.seconds); got != tt.want {
+			t.Errorf("formatLRCTime(%v) = %s, want %s", tt.seconds, got, tt.want)
+		}
+	}
+}
+
+func TestTranscript_ToTimestampedText(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0.0, End: 3.5, Text: " Hello world."},
+			{Start: 67.25, End: 70, Text: "How are you?", Speaker: "Speaker 2"},
+			{Start: 3725, End: 3730, Text: "Bye"},
+		},
+	}
+
+	result := tr.ToTimestampedText()
+	expected := "[00:00] Hello world.\n[01:07] Speaker 2: How are you?\n[1:02:05] Bye\n"
+
+	if result != expected {
+		t.Errorf("ToTimestampedText() = %q, want %q", result, expected)
+	}
+}
+
+func TestTranscript_ToDelimited(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0.0, End: 2.5, Text: " Hello, world"},
+			{Start: 2.5, End: 4.25, Text: "Say \"hi\""},
+		},
+	}
+
+	csvOut := tr.ToDelimited(',')
+	expected := "start,end,text\n" +
+		"0.000,2.500,\"Hello, world\"\n" +
+		"2.500,4.250,\"Say \"\"hi\"\"\"\n"
+	if csvOut != expected {
+		t.Errorf("ToDelimited(',') = %q, want %q", csvOut, expected)
+	}
+
+	tr.Segments[0].Speaker = "Speaker 1"
+	tsvOut := tr.ToDelimited('\t')
+	if !strings.HasPrefix(tsvOut, "start\tend\ttext\tspeaker\n0.000\t2.500\tHello, world\tSpeaker 1\n") {
+		t.Errorf("ToDelimited('\\t') = %q, want speaker column", tsvOut)
+	}
+}
+
+func TestTranscript_ToMarkdown(t *testing.T) {
+	tr := &Transcript{
+		Text:     "Hello world",
+		Model:    "small",
+		Language: "en",
+	}
+	reel := &Reel{
+		ID:         "ABC123",
+		Author:     "chef",
+		Title:      `Pasta "secrets"`,
+		ViewCount:  1500,
+		UploadedAt: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC),
+	}
+
+	result := tr.ToMarkdown(reel, MarkdownOptions{})
+	expected := "---\n" +
+		"id: \"ABC123\"\n" +
+		"url: \"https://www.instagram.com/p/ABC123/\"\n" +
+		"author: \"chef\"\n" +
+		"title: \"Pasta \\\"secrets\\\"\"\n" +
+		"views: 1500\n" +
+		"uploaded: 2025-03-14\n" +
+		"model: \"small\"\n" +
+		"language: \"en\"\n" +
+		"---\n\n" +
+		"# Pasta \"secrets\"\n\n" +
+		"Hello world\n"
+
+	if result != expected {
+		t.Errorf("ToMarkdown() = %q, want %q", result, expected)
+	}
+
+	withStats := tr.ToMarkdown(reel, MarkdownOptions{Stats: &TranscriptStats{Words: 2, ReadingTimeMinutes: 1, DurationSeconds: 4.5}})
+	if !strings.Contains(withStats, "language: \"en\"\nwords: 2\nreading_time_minutes: 1\nduration_seconds: 4.5\n---") {
+		t.Errorf("ToMarkdown() with stats missing front matter fields, got:\n%s", withStats)
+	}
+
+	tr.Segments = []Segment{
+		{Start: 0, End: 30, Text: "Intro."},
+		{Start: 65, End: 80, Text: "Next part."},
+	}
+	chaptered := tr.ToMarkdown(nil, MarkdownOptions{ChapterInterval: 60})
+	if !strings.HasSuffix(chaptered, "# Transcript\n\n## 00:00\n\nIntro.\n\n## 01:05\n\nNext part.\n") {
+		t.Errorf("ToMarkdown() with chapters, got:\n%s", chaptered)
+	}
+
+	reel.Caption = "Recipe: https://example.com #pasta"
+	captioned := tr.ToMarkdown(reel, MarkdownOptions{ChapterInterval: 60})
+	if !strings.HasSuffix(captioned, "Next part.\n\n## Caption\n\nRecipe: https://example.com #pasta\n") {
+		t.Errorf("ToMarkdown() with caption, got:\n%s", captioned)
+	}
+}
+
+func TestTranscript_ToJSONL(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0.0, End: 2.5, Text: " Hello"},
+			{Start: 2.5, End: 4.0, Text: "World", Speaker: "Speaker 1"},
+		},
+	}
+
+	result := tr.ToJSONL("ABC123")
+	expected := `{"reel_id":"ABC123","index":0,"start":0,"end":2.5,"text":"Hello"}` + "\n" +
+		`{"reel_id":"ABC123","index":1,"start":2.5,"end":4,"text":"World","speaker":"Speaker 1"}` + "\n"
+
+	if result != expected {
+		t.Errorf("ToJSONL() = %q, want %q", result, expected)
+	}
+}
+
+func TestTranscript_ToTTML(t *testing.T) {
+	tr := &Transcript{
+		Language: "en",
+		Segments: []Segment{
+			{Start: 0.0, End: 3.5, Text: "Salt & pepper <3"},
+		},
+	}
+
+	result := tr.ToTTML(TTMLStyle{FontFamily: "sansSerif", Color: "yellow"})
+
+	if !strings.Contains(result, `xml:lang="en"`) {
+		t.Errorf("ToTTML() missing language, got:\n%s", result)
+	}
+	if !strings.Contains(result, `tts:color="yellow"`) {
+		t.Errorf("ToTTML() missing style color, got:\n%s", result)
+	}
+	if !strings.Contains(result, `<p begin="00:00:00.000" end="00:00:03.500">Salt &amp; pepper &lt;3</p>`) {
+		t.Errorf("ToTTML() missing escaped cue, got:\n%s", result)
+	}
+	if !strings.Contains(result, `<style xml:id="default" tts:fontFamily="sansSerif" tts:color="yellow"/>`) {
+		t.Errorf("ToTTML() should leave unset style attributes out, got:\n%s", result)
+	}
+}
+
+func TestTranscript_ToASS(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0.0, End: 2.5, Text: " Hello world"},
+			{Start: 62.25, End: 65.0, Text: "Second line"},
+		},
+	}
+
+	result := tr.ToASS(ASSStyle{FontName: "Arial", FontSize: 64, PrimaryColor: "#FFCC00", OutlineColor: "#000000", Position: "top", MarginV: 100})
+
+	if !strings.Contains(result, "Style: Default,Arial,64,&H0000CCFF,") {
+		t.Errorf("ToASS() missing converted style color, got:\n%s", result)
+	}
+	if !strings.Contains(result, ",8,60,60,100,1") {
+		t.Errorf("ToASS() missing top alignment, got:\n%s", result)
+	}
+	if !strings.Contains(result, "Dialogue: 0,0:00:00.00,0:00:02.50,Default,,0,0,0,,Hello world") {
+		t.Errorf("ToASS() missing first dialogue, got:\n%s", result)
+	}
+	if !strings.Contains(result, "Dialogue: 0,0:01:02.25,0:01:05.00,Default,,0,0,0,,Second line") {
+		t.Errorf("ToASS() missing second dialogue, got:\n%s", result)
+	}
+}
+
+func TestAssColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  string
+	}{
+		{"#FFCC00", "&H0000CCFF"},
+		{"12ab34", "&H0034AB12"},
+		{"#12zz34", "&H00FFFFFF"},
+		{"#FFF", "&H00FFFFFF"},
+	}
+
+	for _, tt := range tests {
+		if got := assColor(tt.color); got != tt.want {
+			t.Errorf("assColor(%q) = %s, want %s", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestTranscript_ToAccessible(t *testing.T) {
+	tr := &Transcript{
+		Segments: []Segment{
+			{Start: 0.0, End: 2.0, Text: "[Music]", Speaker: ""},
+			{Start: 2.0, End: 4.0, Text: "Welcome back.", Speaker: "Speaker 1"},
+			{Start: 4.0, End: 6.0, Text: "Today we cook. (laughs)", Speaker: "Speaker 1"},
+			{Start: 6.0, End: 8.0, Text: "Sounds good.", Speaker: "Speaker 2"},
+			{Start: 12.0, End: 14.0, Text: "After a pause.", Speaker: "Speaker 2"},
+		},
+	}
+
+	result := tr.ToAccessible()
+	expected := "[music]\n\n" +
+		"Speaker 1:\nWelcome back. Today we cook.\n\n" +
+		"[laughs]\n\n" +
+		"Speaker 2:\nSounds good.\n\n" +
+		"After a pause."
+
+	if result != expected {
+		t.Errorf("ToAccessible() = %q, want %q", result, expected)
+	}
+}
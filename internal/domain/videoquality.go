@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Video qualities besides a maximum height
+const (
+	VideoQualityBest  = "best"
+	VideoQualityWorst = "worst"
+)
+
+// ParseVideoQuality normalizes a video quality: best, worst, or a maximum
+// height such as 720p (the "p" is optional). Empty means best.
+func ParseVideoQuality(input string) (string, error) {
+	quality := strings.ToLower(strings.TrimSpace(input))
+	switch quality {
+	case "", VideoQualityBest:
+		return VideoQualityBest, nil
+	case VideoQualityWorst:
+		return VideoQualityWorst, nil
+	}
+	if height, err := strconv.Atoi(strings.TrimSuffix(quality, "p")); err == nil && height > 0 {
+		return fmt.Sprintf("%dp", height), nil
+	}
+	return "", fmt.Errorf("invalid video quality %q (use best, worst or a height like 720p)", input)
+}
+
+// VideoQualityHeight returns the maximum height of a quality from
+// ParseVideoQuality, or 0 for best and worst
+func VideoQualityHeight(quality string) int {
+	height, _ := strconv.Atoi(strings.TrimSuffix(quality, "p"))
+	return height
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestParseVideoQuality(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "best"},
+		{"Best", "best"},
+		{"worst", "worst"},
+		{"720p", "720p"},
+		{" 480 ", "480p"},
+	}
+	for _, tt := range tests {
+		got, err := ParseVideoQuality(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseVideoQuality(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"hd", "0p", "-720p", "720px"} {
+		if _, err := ParseVideoQuality(input); err == nil {
+			t.Errorf("ParseVideoQuality(%q) should fail", input)
+		}
+	}
+
+	if h := VideoQualityHeight("720p"); h != 720 {
+		t.Errorf("VideoQualityHeight(720p) = %d", h)
+	}
+	if h := VideoQualityHeight("best"); h != 0 {
+		t.Errorf("VideoQualityHeight(best) = %d", h)
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// youtubePrefix marks YouTube videos among reel IDs. The '.' is outside the
+// shortcode alphabet, so YouTube IDs never collide with Instagram shortcodes.
+const youtubePrefix = "yt."
+
+// youtubeVideoIDPattern matches YouTube's 11-character video IDs
+var youtubeVideoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// YouTubeID returns the reel ID of the YouTube video videoID
+func YouTubeID(videoID string) string {
+	return youtubePrefix + videoID
+}
+
+// ParseYouTubeID returns the YouTube video ID inside a reel ID, and whether
+// the reel is a YouTube video at all
+func ParseYouTubeID(id string) (videoID string, ok bool) {
+	videoID, ok = strings.CutPrefix(id, youtubePrefix)
+	return videoID, ok && youtubeVideoIDPattern.MatchString(videoID)
+}
+
+// YouTubeShortURL returns the Shorts URL of a YouTube video ID
+func YouTubeShortURL(videoID string) string {
+	return fmt.Sprintf("https://www.youtube.com/shorts/%s", videoID)
+}
+
+// isYouTubeHost reports whether host serves YouTube videos
+func isYouTubeHost(host string) bool {
+	host = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(host), "www."), "m.")
+	return host == "youtube.com" || host == "youtu.be"
+}
+
+// parseYouTubeURL parses the URL of a single YouTube video: a Short,
+// a watch page or a youtu.be link
+func parseYouTubeURL(u *url.URL, input string) (*Reel, error) {
+	parts := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	var videoID string
+	switch {
+	case strings.EqualFold(u.Hostname(), "youtu.be") && len(parts) == 1:
+		videoID = parts[0]
+	case len(parts) == 2 && parts[0] == "shorts":
+		videoID = parts[1]
+	case len(parts) == 1 && parts[0] == "watch":
+		videoID = u.Query().Get("v")
+	}
+	if !youtubeVideoIDPattern.MatchString(videoID) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidReelInput, input)
+	}
+	return &Reel{ID: YouTubeID(videoID), URL: YouTubeShortURL(videoID)}, nil
+}
+
+// ParseYouTubePlaylist recognizes YouTube playlist and channel URLs and
+// returns the URL to list videos from: the playlist itself, or a channel's
+// Shorts tab. ok is false for anything else, including single videos.
+func ParseYouTubePlaylist(input string) (playlistURL string, ok bool) {
+	raw := strings.TrimSpace(input)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || !isYouTubeHost(u.Hostname()) || strings.EqualFold(u.Hostname(), "youtu.be") {
+		return "", false
+	}
+
+	parts := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(parts) == 0 {
+		return "", false
+	}
+
+	if parts[0] == "playlist" {
+		list := u.Query().Get("list")
+		if list == "" {
+			return "", false
+		}
+		return "https://www.youtube.com/playlist?list=" + url.QueryEscape(list), true
+	}
+
+	// Channels: /@handle, /channel/ID, /c/name and /user/name, on any tab
+	var channel string
+	switch {
+	case strings.HasPrefix(parts[0], "@") && len(parts[0]) > 1:
+		channel = parts[0]
+	case (parts[0] == "channel" || parts[0] == "c" || parts[0] == "user") && len(parts) > 1:
+		channel = parts[0] + "/" + parts[1]
+	default:
+		return "", false
+	}
+	return "https://www.youtube.com/" + channel + "/shorts", true
+}
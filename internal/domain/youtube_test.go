@@ -0,0 +1,76 @@
+package domain
+
+import "testing"
+
+func TestParseReelInput_YouTube(t *testing.T) {
+	for _, input := range []string{
+		"https://www.youtube.com/shorts/dQw4w9WgXcQ",
+		"youtube.com/shorts/dQw4w9WgXcQ?feature=share",
+		"https://m.youtube.com/watch?v=dQw4w9WgXcQ&t=10",
+		"https://youtu.be/dQw4w9WgXcQ",
+		"yt.dQw4w9WgXcQ",
+	} {
+		reel, err := ParseReelInput(input)
+		if err != nil {
+			t.Errorf("ParseReelInput(%q) error = %v", input, err)
+			continue
+		}
+		if reel.ID != "yt.dQw4w9WgXcQ" || reel.URL != "https://www.youtube.com/shorts/dQw4w9WgXcQ" {
+			t.Errorf("ParseReelInput(%q) = %s, %s", input, reel.ID, reel.URL)
+		}
+	}
+
+	for _, input := range []string{
+		"https://www.youtube.com/shorts/short",
+		"https://www.youtube.com/@creator",
+		"https://youtu.be/",
+	} {
+		if _, err := ParseReelInput(input); err == nil {
+			t.Errorf("ParseReelInput(%q) expected error", input)
+		}
+	}
+}
+
+func TestParseYouTubeID(t *testing.T) {
+	if videoID, ok := ParseYouTubeID("yt.dQw4w9WgXcQ"); !ok || videoID != "dQw4w9WgXcQ" {
+		t.Errorf("ParseYouTubeID() = %q, %v", videoID, ok)
+	}
+	for _, id := range []string{"dQw4w9WgXcQ", "yt.short", "ABC123.2"} {
+		if _, ok := ParseYouTubeID(id); ok {
+			t.Errorf("ParseYouTubeID(%q) should not be a YouTube video", id)
+		}
+	}
+
+	// Numeric video IDs are not carousel slides
+	if _, slide := ParseSlideID("yt.12345678901"); slide != 0 {
+		t.Errorf("ParseSlideID() slide = %d for a YouTube video", slide)
+	}
+
+	// Reels known only by ID link back to the right platform
+	reel := &Reel{ID: "yt.dQw4w9WgXcQ"}
+	if got := reel.ReelURL(); got != "https://www.youtube.com/shorts/dQw4w9WgXcQ" {
+		t.Errorf("ReelURL() = %q, want the Shorts URL", got)
+	}
+}
+
+func TestParseYouTubePlaylist(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"https://www.youtube.com/playlist?list=PL123&si=x", "https://www.youtube.com/playlist?list=PL123"},
+		{"https://www.youtube.com/@creator", "https://www.youtube.com/@creator/shorts"},
+		{"youtube.com/@creator/shorts", "https://www.youtube.com/@creator/shorts"},
+		{"https://www.youtube.com/channel/UC123/videos", "https://www.youtube.com/channel/UC123/shorts"},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", ""},
+		{"https://youtu.be/dQw4w9WgXcQ", ""},
+		{"https://www.youtube.com/playlist", ""},
+		{"https://www.instagram.com/creator/", ""},
+	}
+	for _, tt := range tests {
+		got, ok := ParseYouTubePlaylist(tt.input)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("ParseYouTubePlaylist(%q) = %q, %v, want %q", tt.input, got, ok, tt.want)
+		}
+	}
+}
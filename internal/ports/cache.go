@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/devbush/ig2insights/internal/domain"
@@ -14,8 +15,13 @@ type CachedItem struct {
 	AudioPath     string    // WAV audio file path (used for transcription and --audio flag)
 	VideoPath     string    // MP4 video file path (used for --video flag)
 	ThumbnailPath string    // thumbnail image path
+	RawOutputPath string    // gzip-compressed raw transcriber output, e.g. whisper JSON with tokens
 	CreatedAt     time.Time // when this item was cached
 	ExpiresAt     time.Time // when this item should be considered stale
+
+	// MediaExpiresAt is when the audio, video and thumbnail go stale, ahead
+	// of the rest of the item. Zero when they last as long as the item.
+	MediaExpiresAt time.Time
 }
 
 // CacheStore handles persistent caching of reels and transcripts.
@@ -23,6 +29,10 @@ type CacheStore interface {
 	// Get retrieves a cached item by reel ID, returning nil if not found.
 	Get(ctx context.Context, reelID string) (*CachedItem, error)
 
+	// Peek retrieves a cached item by reel ID even when it has expired,
+	// without counting as a use. Returns domain.ErrCacheMiss if not found.
+	Peek(ctx context.Context, reelID string) (*CachedItem, error)
+
 	// Set stores an item in the cache.
 	Set(ctx context.Context, reelID string, item *CachedItem) error
 
@@ -38,6 +48,90 @@ type CacheStore interface {
 	// GetCacheDir returns the cache directory path for a given reel ID.
 	GetCacheDir(reelID string) string
 
-	// Stats returns cache statistics: item count and total size in bytes.
-	Stats(ctx context.Context) (itemCount int, totalSize int64, err error)
+	// Stats returns cache statistics: item count, total size in bytes, and
+	// their breakdown by kind of file.
+	Stats(ctx context.Context) (*CacheStats, error)
+
+	// List returns every readable cached item, including expired ones.
+	List(ctx context.Context) ([]*CachedItem, error)
+}
+
+// CacheUsage is how many files of one kind are cached and their total size.
+type CacheUsage struct {
+	Count int
+	Size  int64 // bytes
+}
+
+// CacheItemSize is the total size of one cached item's files.
+type CacheItemSize struct {
+	ReelID string
+	Size   int64 // bytes
+}
+
+// CacheStats summarizes a cache's contents.
+type CacheStats struct {
+	ItemCount int
+	TotalSize int64 // bytes
+
+	Transcripts CacheUsage // metadata holding transcripts, and raw transcriber output
+	Audio       CacheUsage
+	Video       CacheUsage
+	Thumbnails  CacheUsage
+
+	Largest []CacheItemSize // the largest items, largest first
+}
+
+// CacheArchiver is implemented by cache stores that can be moved between
+// machines as a single archive.
+type CacheArchiver interface {
+	// Export writes every cached item to w and returns how many it wrote.
+	Export(ctx context.Context, w io.Writer) (int, error)
+
+	// Import reads an archive written by Export into the cache, replacing
+	// cached items for the same reels, and returns how many it read.
+	Import(ctx context.Context, r io.Reader) (int, error)
+}
+
+// CacheProblem is a damaged file in a cached item.
+type CacheProblem struct {
+	ReelID string
+	File   string // path of the damaged file, or where its metadata is kept
+	Reason string // e.g. "checksum mismatch"
+}
+
+// CacheVerifier is implemented by cache stores that record checksums of
+// cached files.
+type CacheVerifier interface {
+	// Verify checks every cached item and returns the damaged files found.
+	Verify(ctx context.Context) ([]CacheProblem, error)
+}
+
+// Kinds of cached files
+const (
+	CacheFileAudio     = "audio"
+	CacheFileVideo     = "video"
+	CacheFileThumbnail = "thumbnail"
+	CacheFileRaw       = "raw" // raw transcriber output
+)
+
+// CachePruneFilter selects cached files to delete.
+type CachePruneFilter struct {
+	Kinds      []string      // CacheFileAudio, CacheFileVideo, ...
+	OlderThan  time.Duration // only items cached longer ago than this; 0 for any age
+	LargerThan int64         // only files larger than this many bytes; 0 for any size
+}
+
+// CachePruner is implemented by cache stores that can delete some of a
+// cached item's files while keeping the item.
+type CachePruner interface {
+	// Prune deletes the files filter selects, keeping transcripts and
+	// metadata, and returns how many files it deleted and their total size.
+	Prune(ctx context.Context, filter CachePruneFilter) (files int, freed int64, err error)
+}
+
+// CacheMigrator is implemented by cache stores that version their entries.
+type CacheMigrator interface {
+	// Migrate upgrades items cached in an older format and returns how many
+	// it upgraded.
+	Migrate(ctx context.Context) (int, error)
 }
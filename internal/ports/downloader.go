@@ -53,3 +53,66 @@ type VideoDownloader interface {
 	// FFmpegInstructions returns platform-specific installation instructions.
 	FFmpegInstructions() string
 }
+
+// SubtitleResult contains subtitles published with a reel.
+type SubtitleResult struct {
+	Transcript *domain.Transcript // Source is SourceSubtitles or SourceAutoCaptions
+	Reel       *domain.Reel       // reel metadata fetched alongside the subtitles
+}
+
+// SubtitleFetcher retrieves subtitles the platform already has for a reel.
+// Downloaders implement it optionally.
+type SubtitleFetcher interface {
+	// DownloadSubtitles fetches subtitles in language ("auto" for any),
+	// preferring creator-provided tracks over automatic captions. It returns
+	// a nil result when the reel has none.
+	DownloadSubtitles(ctx context.Context, reelID string, destDir string, language string) (*SubtitleResult, error)
+}
+
+// CarouselLister enumerates the videos of a carousel post. Downloaders
+// implement it optionally.
+type CarouselLister interface {
+	// ListSlides returns the post's videos with IDs from domain.SlideID, or
+	// nil when the post is a single video.
+	ListSlides(ctx context.Context, shortcode string) ([]*domain.Reel, error)
+}
+
+// PlaylistLister enumerates the videos of a YouTube playlist or channel.
+// Downloaders implement it optionally.
+type PlaylistLister interface {
+	// ListPlaylist returns the playlist's videos with IDs from
+	// domain.YouTubeID, in the playlist's order.
+	ListPlaylist(ctx context.Context, playlistURL string) ([]*domain.Reel, error)
+}
+
+// URLRegistrar downloads videos from URLs on sites ig2insights doesn't know.
+// Downloaders implement it optionally.
+type URLRegistrar interface {
+	// RegisterURL makes the downloader fetch reelID, an ID from
+	// domain.GenericID, from rawURL.
+	RegisterURL(reelID, rawURL string)
+}
+
+// FileRegistrar reads audio and video files already on disk. Downloaders
+// implement it optionally.
+type FileRegistrar interface {
+	// RegisterFile makes the downloader read reelID, an ID from
+	// domain.LocalFileID, from the file at path instead of downloading it.
+	RegisterFile(reelID, path string)
+}
+
+// CommentFetcher retrieves a reel's viewer comments. Downloaders implement it
+// optionally.
+type CommentFetcher interface {
+	// FetchComments returns up to limit of the reel's most liked comments,
+	// most liked first.
+	FetchComments(ctx context.Context, reelID string, limit int) ([]domain.Comment, error)
+}
+
+// VideoQualityDownloader downloads videos at a chosen quality. Downloaders
+// implement it optionally; others always download their one version.
+type VideoQualityDownloader interface {
+	// DownloadVideoQuality downloads the video like DownloadVideo, at a
+	// quality from domain.ParseVideoQuality.
+	DownloadVideoQuality(ctx context.Context, reelID string, destPath string, quality string) error
+}
@@ -13,4 +13,7 @@ type AccountFetcher interface {
 
 	// ListReels fetches reels from an account with the specified sort order and limit.
 	ListReels(ctx context.Context, username string, sort domain.SortOrder, limit int) ([]*domain.Reel, error)
+
+	// GetReel fetches metadata for a single reel without downloading media.
+	GetReel(ctx context.Context, reelID string) (*domain.Reel, error)
 }
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// HistoryStore persists a local log of transcription runs.
+type HistoryStore interface {
+	// Append records a single run.
+	Append(ctx context.Context, record domain.RunRecord) error
+
+	// List returns all recorded runs, oldest first.
+	List(ctx context.Context) ([]domain.RunRecord, error)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// BatchJournal is an append-only log of batch state transitions that
+// survives crashes.
+type BatchJournal interface {
+	// Append durably records a transition before returning.
+	Append(ctx context.Context, entry domain.JournalEntry) error
+
+	// Entries returns all recorded transitions in order.
+	Entries(ctx context.Context) ([]domain.JournalEntry, error)
+
+	// Reset discards the journal to start a new run.
+	Reset(ctx context.Context) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitStore persists rate-limit responses so back-off survives across runs.
+type RateLimitStore interface {
+	// Record stores a rate-limit response from host at the given time.
+	Record(ctx context.Context, host string, at time.Time) error
+
+	// Hits returns recorded rate-limit timestamps keyed by host.
+	Hits(ctx context.Context) (map[string][]time.Time, error)
+
+	// Reset forgets all recorded rate-limit responses.
+	Reset(ctx context.Context) error
+}
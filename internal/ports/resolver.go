@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// LinkResolver follows redirecting links, such as Instagram share links,
+// to the URL they point at.
+type LinkResolver interface {
+	// Resolve returns the final URL reached from rawURL.
+	Resolve(ctx context.Context, rawURL string) (string, error)
+}
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/devbush/ig2insights/internal/domain"
+)
+
+// SessionStore persists the Instagram login used for downloads.
+type SessionStore interface {
+	// Load returns the stored cookie jar and where it was imported from,
+	// or domain.ErrNoSession when none is stored.
+	Load(ctx context.Context) (cookieJar []byte, source domain.SessionSource, err error)
+
+	// Save replaces the stored session.
+	Save(ctx context.Context, cookieJar []byte, source domain.SessionSource) error
+
+	// Delete removes the stored session.
+	Delete(ctx context.Context) error
+
+	// CookiesPath returns the Netscape-format cookie jar handed to the downloader.
+	CookiesPath() string
+}
+
+// CookieExporter reads Instagram cookies from a local browser profile.
+// Downloaders implement it optionally.
+type CookieExporter interface {
+	// ExportCookies returns the browser's cookies as a Netscape-format cookie jar.
+	ExportCookies(ctx context.Context, browser string) ([]byte, error)
+}
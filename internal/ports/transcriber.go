@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/devbush/ig2insights/internal/domain"
 )
@@ -16,8 +17,20 @@ type Model struct {
 
 // TranscribeOpts configures transcription behavior.
 type TranscribeOpts struct {
-	Model    string
-	Language string // empty string enables auto-detection
+	Model       string
+	Language    string  // empty string enables auto-detection
+	Prompt      string  // initial prompt to bias vocabulary (names, jargon)
+	Temperature float64 // sampling temperature; zero uses the backend default
+
+	// RawOutputPath, when set, is where the backend saves its full raw output
+	// (tokens, probabilities), gzip-compressed. Failing to save it is not an error.
+	RawOutputPath string
+
+	// Offset and Duration limit transcription to a window of the file; a
+	// zero Duration runs to the end. Segment times stay relative to the
+	// start of the file.
+	Offset   time.Duration
+	Duration time.Duration
 }
 
 // Transcriber handles speech-to-text conversion.